@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BankEntry is one file of BankSet virtual filesystem.
+type BankEntry struct {
+	// Path is game virtual path ("\dz\scripts\3_game\x.c").
+	Path string `json:"path" yaml:"path"`
+	// Archive is path of archive providing the file.
+	Archive string `json:"archive" yaml:"archive"`
+	// Entry is archive entry metadata.
+	Entry EntryInfo `json:"entry" yaml:"entry"`
+}
+
+// BankSet is merged virtual filesystem over many archives (a whole @mod or game install).
+// It is built on MultiReader and resolves virtual paths by the same rules: prefix header
+// plus entry path, case-insensitively, with later archives overriding earlier ones.
+// BankSet is safe for concurrent reads.
+type BankSet struct {
+	multi *MultiReader
+}
+
+// OpenBankSet opens archives in load order and builds merged virtual path index.
+// Already opened readers are closed when one archive fails to open or load entries.
+func OpenBankSet(paths []string, opts ReaderOptions) (*BankSet, error) {
+	multi, err := OpenMulti(paths, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range multi.readers {
+		if err := r.LoadEntries(); err != nil {
+			_ = multi.Close()
+			return nil, fmt.Errorf("index %s: %w", multi.paths[i], err)
+		}
+	}
+	multi.indexOnce.Do(multi.buildIndex)
+
+	return &BankSet{multi: multi}, nil
+}
+
+// OpenBankSetDir opens all .pbo files under dir in lexical walk order as BankSet.
+func OpenBankSetDir(dir string, opts ReaderOptions) (*BankSet, error) {
+	paths, err := findArchivesUnder(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenBankSet(paths, opts)
+}
+
+// OpenBankSetMods opens addons of mods as BankSet; mod order is load order.
+func OpenBankSetMods(mods []ModLayout, opts ReaderOptions) (*BankSet, error) {
+	var paths []string
+	for _, mod := range mods {
+		paths = append(paths, mod.Addons...)
+	}
+
+	return OpenBankSet(paths, opts)
+}
+
+// findArchivesUnder returns regular ".pbo" files (case-insensitive) under dir in walk order.
+func findArchivesUnder(dir string) ([]string, error) {
+	var paths []string
+	walkErr := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && strings.EqualFold(filepath.Ext(filePath), ".pbo") {
+			paths = append(paths, filePath)
+		}
+
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("walk %s: %w", dir, walkErr)
+	}
+
+	return paths, nil
+}
+
+// Len returns number of virtual files.
+func (b *BankSet) Len() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.multi.index)
+}
+
+// Archives returns archive paths in load order.
+func (b *BankSet) Archives() []string {
+	if b == nil {
+		return nil
+	}
+
+	return append([]string(nil), b.multi.paths...)
+}
+
+// Shadowed returns number of archive entries hidden by later archives or duplicate paths.
+func (b *BankSet) Shadowed() int {
+	if b == nil {
+		return 0
+	}
+
+	return b.multi.shadowed
+}
+
+// Lookup resolves virtual path ("\dz\scripts\x.c" or "dz/scripts/x.c") to winning file.
+func (b *BankSet) Lookup(virtualPath string) (BankEntry, bool) {
+	if b == nil {
+		return BankEntry{}, false
+	}
+
+	file, ok := b.multi.lookup(virtualPath)
+	if !ok {
+		return BankEntry{}, false
+	}
+
+	return b.bankEntry(file), true
+}
+
+// Files returns all virtual files sorted by virtual path.
+func (b *BankSet) Files() []BankEntry {
+	if b == nil {
+		return nil
+	}
+
+	keys := b.sortedKeys()
+	out := make([]BankEntry, len(keys))
+	for i, key := range keys {
+		out[i] = b.bankEntry(b.multi.index[key])
+	}
+
+	return out
+}
+
+// sortedKeys returns index keys in virtual path order.
+func (b *BankSet) sortedKeys() []string {
+	keys := make([]string, 0, len(b.multi.index))
+	for key := range b.multi.index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// bankEntry builds exported view of located file.
+func (b *BankSet) bankEntry(file multiFile) BankEntry {
+	r := b.multi.readers[file.archive]
+	entry := r.entries[file.entry]
+
+	return BankEntry{
+		Path:    ResolveVirtualPath(r.Prefix(), entry.Path),
+		Archive: b.multi.paths[file.archive],
+		Entry:   entry,
+	}
+}
+
+// Open opens winning file of virtual path for reading.
+func (b *BankSet) Open(virtualPath string) (io.ReadCloser, error) {
+	if b == nil {
+		return nil, ErrNilReader
+	}
+
+	return b.multi.OpenEntry(virtualPath)
+}
+
+// ReadFile reads full content of winning file of virtual path.
+func (b *BankSet) ReadFile(virtualPath string) ([]byte, error) {
+	if b == nil {
+		return nil, ErrNilReader
+	}
+
+	return b.multi.ReadEntry(virtualPath)
+}
+
+// Close closes all archives and returns first close error.
+func (b *BankSet) Close() error {
+	if b == nil {
+		return nil
+	}
+
+	return b.multi.Close()
+}
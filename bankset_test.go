@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBankSet_LoadOrderAndPrefixes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "a_base.pbo")
+	if err := createTestPBO(basePath, map[string][]byte{
+		"3_game/x.c":   []byte("base"),
+		"3_game/y.c":   []byte("only base"),
+		"data/tex.paa": []byte("texture"),
+	}, PackOptions{Headers: []HeaderPair{{Key: HeaderKeyPrefix, Value: `dz\scripts`}}}); err != nil {
+		t.Fatalf("createTestPBO base: %v", err)
+	}
+
+	modPath := filepath.Join(dir, "b_mod.pbo")
+	if err := createTestPBO(modPath, map[string][]byte{
+		"scripts/3_Game/X.c": []byte("override"),
+	}, PackOptions{Headers: []HeaderPair{{Key: HeaderKeyPrefix, Value: "dz"}}}); err != nil {
+		t.Fatalf("createTestPBO mod: %v", err)
+	}
+
+	b, err := OpenBankSetDir(dir, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenBankSetDir: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	if b.Len() != 3 || b.Shadowed() != 1 || len(b.Archives()) != 2 {
+		t.Fatalf("Len=%d Shadowed=%d Archives=%v", b.Len(), b.Shadowed(), b.Archives())
+	}
+
+	data, err := b.ReadFile(`\dz\scripts\3_game\x.c`)
+	if err != nil || string(data) != "override" {
+		t.Fatalf("ReadFile override=%q err=%v", data, err)
+	}
+	if data, err := b.ReadFile("DZ/Scripts/3_game/y.c"); err != nil || string(data) != "only base" {
+		t.Fatalf("ReadFile base=%q err=%v", data, err)
+	}
+
+	entry, ok := b.Lookup(`\dz\scripts\3_game\x.c`)
+	if !ok || entry.Archive != modPath || entry.Path != `\dz\scripts\3_Game\X.c` {
+		t.Fatalf("Lookup=%+v ok=%v", entry, ok)
+	}
+
+	files := b.Files()
+	if len(files) != 3 || files[0].Path != `\dz\scripts\3_Game\X.c` || files[2].Path != `\dz\scripts\data\tex.paa` {
+		t.Fatalf("Files=%+v", files)
+	}
+
+	if _, err := b.Open(`\dz\missing.c`); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("Open missing err=%v", err)
+	}
+
+	// MultiReader resolves the same path by the same load-order rule.
+	m, err := OpenMulti([]string{basePath, modPath}, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenMulti: %v", err)
+	}
+	defer func() { _ = m.Close() }()
+
+	if idx, _, ok := m.Find(`\dz\scripts\3_game\x.c`); !ok || m.Path(idx) != modPath {
+		t.Fatalf("MultiReader.Find idx=%d ok=%v, want %s", idx, ok, modPath)
+	}
+}
+
+func TestOpenBankSet_ClosesOnError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	okPath := filepath.Join(dir, "ok.pbo")
+	if err := createTestPBO(okPath, map[string][]byte{"a.txt": []byte("a")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	_, err := OpenBankSet([]string{okPath, filepath.Join(dir, "missing.pbo")}, ReaderOptions{})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("OpenBankSet err=%v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import "fmt"
+
+// BatchFailure is one failed path of batch operation.
+type BatchFailure struct {
+	// Err is failure cause.
+	Err error `json:"-" yaml:"-"`
+	// Path is entry path that failed.
+	Path string `json:"path" yaml:"path"`
+}
+
+// BatchError reports every failure of batch operation instead of only the first one.
+// Extract with ExtractErrorCollectAll (through errors.As on ExtractError) and
+// PackOptions.VerifyAfterWrite return it, so automation can act on complete failure set.
+type BatchError struct {
+	// Op names failed operation ("extract", "verify").
+	Op string `json:"op" yaml:"op"`
+	// Failures are failed paths in operation order.
+	Failures []BatchFailure `json:"failures" yaml:"failures"`
+}
+
+// Error implements error.
+func (e *BatchError) Error() string {
+	switch len(e.Failures) {
+	case 0:
+		return e.Op + " failed"
+	case 1:
+		return e.Failures[0].Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %d entries failed; first: %v", e.Op, len(e.Failures), e.Failures[0].Err)
+}
+
+// Unwrap returns failure causes for errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// Paths returns failed paths in operation order.
+func (e *BatchError) Paths() []string {
+	paths := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		paths = append(paths, f.Path)
+	}
+
+	return paths
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -56,6 +57,26 @@ func BenchmarkOpenParseLargeIndex(b *testing.B) {
 	}
 }
 
+func BenchmarkOpenParseLargeIndexInternPaths(b *testing.B) {
+	path := createBenchLargeIndexPBO(b, benchLargeIndexEntries)
+	opts := ReaderOptions{InternPaths: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := OpenWithOptions(path, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if len(r.Entries()) == 0 {
+			b.Fatal("empty entries")
+		}
+
+		_ = r.Close()
+	}
+}
+
 func BenchmarkExtract(b *testing.B) {
 	benchmarkExtractWithSanitize(b, false)
 }
@@ -275,6 +296,34 @@ func BenchmarkPackWithCompress(b *testing.B) {
 	}
 }
 
+func BenchmarkPackWithCompressWorkers(b *testing.B) {
+	data := bytes.Repeat([]byte("class CfgPatches { units[] = {}; };\n"), 4000)
+	inputs := make([]Input, 32)
+	for i := range inputs {
+		inputs[i] = Input{
+			Path: filepath.Join("scripts", fmt.Sprintf("f%d.c", i)),
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+			SizeHint: int64(len(data)),
+		}
+	}
+	opts := PackOptions{Compress: includeRules("*"), CompressWorkers: runtime.GOMAXPROCS(0)}
+	dir := b.TempDir()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := filepath.Join(dir, fmt.Sprintf("out%d.pbo", i))
+		f, _ := os.Create(out)
+		_, err := Pack(context.Background(), f, inputs, opts)
+		_ = f.Close()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkPackWithCompressNoMatch(b *testing.B) {
 	data := bytes.Repeat([]byte("x"), 2000)
 	inputs := make([]Input, 10)
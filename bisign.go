@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+)
+
+const (
+	// biPublicKeyBlobHeaderSize is PUBLICKEYBLOB header size: blob header, "RSA1", bit length, exponent.
+	biPublicKeyBlobHeaderSize = 20
+	// biMaxKeyBits bounds accepted RSA key size.
+	biMaxKeyBits = 16384
+)
+
+// sha1DigestInfoPrefix is DER DigestInfo prefix of SHA1 in PKCS#1 v1.5 signature padding.
+var sha1DigestInfoPrefix = []byte{0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14}
+
+// BiKey is RSA public key of .bikey file or embedded into .bisign file.
+type BiKey struct {
+	// N is RSA modulus.
+	N *big.Int `json:"-" yaml:"-"`
+	// Name is key authority name.
+	Name string `json:"name" yaml:"name"`
+	// Bits is RSA modulus bit length.
+	Bits uint32 `json:"bits" yaml:"bits"`
+	// Exponent is RSA public exponent.
+	Exponent uint32 `json:"exponent" yaml:"exponent"`
+}
+
+// Bisign is parsed .bisign signature of one PBO.
+type Bisign struct {
+	// Key is signer public key embedded into signature file.
+	Key BiKey `json:"key" yaml:"key"`
+	// Sig1 is signature of hash1 as stored (little-endian).
+	Sig1 []byte `json:"-" yaml:"-"`
+	// Sig2 is signature of hash2 as stored (little-endian).
+	Sig2 []byte `json:"-" yaml:"-"`
+	// Sig3 is signature of hash3 as stored (little-endian).
+	Sig3 []byte `json:"-" yaml:"-"`
+	// Version is signature hash policy version.
+	Version SignVersion `json:"version" yaml:"version"`
+}
+
+// ReadBiKey reads and parses .bikey file.
+func ReadBiKey(path string) (*BiKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ParseBiKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+// ParseBiKey parses .bikey content.
+func ParseBiKey(data []byte) (*BiKey, error) {
+	p := biParser{data: data}
+	key, err := p.key()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBiKey, err)
+	}
+
+	return key, nil
+}
+
+// ReadBisign reads and parses .bisign file.
+func ReadBisign(path string) (*Bisign, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := ParseBisign(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return sig, nil
+}
+
+// ParseBisign parses .bisign content.
+func ParseBisign(data []byte) (*Bisign, error) {
+	p := biParser{data: data}
+	key, err := p.key()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBisign, err)
+	}
+
+	sig := &Bisign{Key: *key}
+	if sig.Sig1, err = p.block(); err != nil {
+		return nil, fmt.Errorf("%w: sig1: %w", ErrInvalidBisign, err)
+	}
+
+	version, err := p.uint32()
+	if err != nil {
+		return nil, fmt.Errorf("%w: version: %w", ErrInvalidBisign, err)
+	}
+	sig.Version = SignVersion(version)
+
+	if sig.Sig2, err = p.block(); err != nil {
+		return nil, fmt.Errorf("%w: sig2: %w", ErrInvalidBisign, err)
+	}
+	if sig.Sig3, err = p.block(); err != nil {
+		return nil, fmt.Errorf("%w: sig3: %w", ErrInvalidBisign, err)
+	}
+
+	return sig, nil
+}
+
+// RecoverHash decodes signature stored little-endian with public key and returns
+// SHA1 digest from its PKCS#1 v1.5 padding.
+func (k *BiKey) RecoverHash(sig []byte) ([]byte, error) {
+	if k == nil || k.N == nil || k.N.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: missing modulus", ErrInvalidBiKey)
+	}
+
+	size := (k.N.BitLen() + 7) / 8
+	if len(sig) != size {
+		return nil, fmt.Errorf("%w: signature is %d bytes, key needs %d", ErrSignatureMismatch, len(sig), size)
+	}
+
+	s := new(big.Int).SetBytes(reversedBytes(sig))
+	if s.Cmp(k.N) >= 0 {
+		return nil, fmt.Errorf("%w: signature exceeds modulus", ErrSignatureMismatch)
+	}
+
+	em := s.Exp(s, big.NewInt(int64(k.Exponent)), k.N).FillBytes(make([]byte, size))
+
+	// EM = 0x00 0x01 0xFF.. 0x00 DigestInfo(SHA1) hash.
+	tail := len(sha1DigestInfoPrefix) + 20
+	if size < tail+11 || em[0] != 0x00 || em[1] != 0x01 {
+		return nil, fmt.Errorf("%w: bad padding", ErrSignatureMismatch)
+	}
+	for _, b := range em[2 : size-tail-1] {
+		if b != 0xff {
+			return nil, fmt.Errorf("%w: bad padding", ErrSignatureMismatch)
+		}
+	}
+	if em[size-tail-1] != 0x00 || !bytes.Equal(em[size-tail:size-20], sha1DigestInfoPrefix) {
+		return nil, fmt.Errorf("%w: bad digest info", ErrSignatureMismatch)
+	}
+
+	return em[size-20:], nil
+}
+
+// Verify checks that all three signatures were made by key over hs.
+func (k *BiKey) Verify(sig *Bisign, hs HashSet) error {
+	if sig == nil {
+		return fmt.Errorf("%w: signature is nil", ErrInvalidBisign)
+	}
+
+	for i, pair := range [3]struct {
+		sig  []byte
+		hash []byte
+	}{{sig.Sig1, hs.Hash1[:]}, {sig.Sig2, hs.Hash2[:]}, {sig.Sig3, hs.Hash3[:]}} {
+		got, err := k.RecoverHash(pair.sig)
+		if err != nil {
+			return fmt.Errorf("sig%d: %w", i+1, err)
+		}
+		if !bytes.Equal(got, pair.hash) {
+			return fmt.Errorf("%w: sig%d signs %x, archive hash%d is %x", ErrSignatureMismatch, i+1, got, i+1, pair.hash)
+		}
+	}
+
+	return nil
+}
+
+// SameKey reports whether both keys have equal modulus and exponent.
+func (k *BiKey) SameKey(other *BiKey) bool {
+	if k == nil || other == nil || k.N == nil || other.N == nil {
+		return false
+	}
+
+	return k.Exponent == other.Exponent && k.N.Cmp(other.N) == 0
+}
+
+// VerifyAgainstKeys verifies PBO signature against every .bikey in keysDir like game
+// server does and returns path of the first accepting key. v3 signatures are checked
+// with DayZ and Arma filehash policies. Fails with ErrNoMatchingKey when no key accepts.
+func VerifyAgainstKeys(pboPath string, bisignPath string, keysDir string) (string, error) {
+	sig, err := ReadBisign(bisignPath)
+	if err != nil {
+		return "", err
+	}
+
+	hashSets, err := bisignHashSets(pboPath, sig.Version)
+	if err != nil {
+		return "", err
+	}
+
+	keyPaths, err := listFilesByExt(keysDir, BikeyExtension)
+	if err != nil {
+		return "", err
+	}
+
+	var lastErr error
+	for _, keyPath := range keyPaths {
+		key, err := ReadBiKey(keyPath)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if !key.SameKey(&sig.Key) {
+			continue
+		}
+
+		for _, hs := range hashSets {
+			if lastErr = key.Verify(sig, hs); lastErr == nil {
+				return keyPath, nil
+			}
+		}
+	}
+
+	if lastErr != nil {
+		return "", fmt.Errorf("%w: %s in %s: %w", ErrNoMatchingKey, sig.Key.Name, keysDir, lastErr)
+	}
+
+	return "", fmt.Errorf("%w: %s in %s", ErrNoMatchingKey, sig.Key.Name, keysDir)
+}
+
+// bisignHashSets computes candidate archive hash sets for signature version.
+func bisignHashSets(pboPath string, version SignVersion) ([]HashSet, error) {
+	gameTypes := []GameType{GameTypeAny}
+	if version == SignVersionV3 {
+		gameTypes = []GameType{GameTypeDayZ, GameTypeArma}
+	}
+
+	out := make([]HashSet, 0, len(gameTypes))
+	for _, gameType := range gameTypes {
+		hs, err := ComputeHashSet(pboPath, version, gameType)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(out, hs) {
+			out = append(out, hs)
+		}
+	}
+
+	return out, nil
+}
+
+// biParser reads little-endian BI key and signature structures.
+type biParser struct {
+	data []byte
+	pos  int
+}
+
+// key reads authority name and PUBLICKEYBLOB.
+func (p *biParser) key() (*BiKey, error) {
+	end := bytes.IndexByte(p.data[p.pos:], 0)
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated key name")
+	}
+	name := string(p.data[p.pos : p.pos+end])
+	p.pos += end + 1
+
+	blob, err := p.block()
+	if err != nil {
+		return nil, fmt.Errorf("key blob: %w", err)
+	}
+	if len(blob) < biPublicKeyBlobHeaderSize || blob[0] != 0x06 || string(blob[8:12]) != "RSA1" {
+		return nil, fmt.Errorf("not an RSA PUBLICKEYBLOB")
+	}
+
+	bits := binary.LittleEndian.Uint32(blob[12:16])
+	exponent := binary.LittleEndian.Uint32(blob[16:20])
+	modulus := blob[biPublicKeyBlobHeaderSize:]
+	if bits == 0 || bits > biMaxKeyBits || bits%8 != 0 || int(bits/8) != len(modulus) || exponent == 0 {
+		return nil, fmt.Errorf("bad key size %d bits, modulus %d bytes, exponent %d", bits, len(modulus), exponent)
+	}
+
+	return &BiKey{
+		N:        new(big.Int).SetBytes(reversedBytes(modulus)),
+		Name:     name,
+		Bits:     bits,
+		Exponent: exponent,
+	}, nil
+}
+
+// block reads uint32 length-prefixed byte block.
+func (p *biParser) block() ([]byte, error) {
+	n, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(n) > uint64(len(p.data)-p.pos) {
+		return nil, fmt.Errorf("block of %d bytes exceeds remaining %d", n, len(p.data)-p.pos)
+	}
+
+	out := p.data[p.pos : p.pos+int(n)]
+	p.pos += int(n)
+	return out, nil
+}
+
+// uint32 reads little-endian uint32.
+func (p *biParser) uint32() (uint32, error) {
+	if len(p.data)-p.pos < 4 {
+		return 0, fmt.Errorf("unexpected end of data at %d", p.pos)
+	}
+
+	v := binary.LittleEndian.Uint32(p.data[p.pos:])
+	p.pos += 4
+	return v, nil
+}
+
+// reversedBytes returns reversed copy of b (little-endian to big-endian).
+func reversedBytes(b []byte) []byte {
+	out := slices.Clone(b)
+	slices.Reverse(out)
+	return out
+}
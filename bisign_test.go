@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyAgainstKeys(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": []byte("void main() {}"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "addon"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	signer := newTestBiSigner(t, "author")
+	other := newTestBiSigner(t, "other")
+	hs, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+
+	bisignPath := pboPath + ".author.bisign"
+	writeTestFile(t, bisignPath, signer.bisign(t, SignVersionV3, hs))
+
+	keysDir := filepath.Join(dir, "keys")
+	if err := os.MkdirAll(keysDir, 0o750); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeTestFile(t, filepath.Join(keysDir, "other.bikey"), other.bikey())
+	if _, err := VerifyAgainstKeys(pboPath, bisignPath, keysDir); !errors.Is(err, ErrNoMatchingKey) {
+		t.Fatalf("without signer key err=%v, want ErrNoMatchingKey", err)
+	}
+
+	keyPath := filepath.Join(keysDir, "author.BIKEY")
+	writeTestFile(t, keyPath, signer.bikey())
+	got, err := VerifyAgainstKeys(pboPath, bisignPath, keysDir)
+	if err != nil || got != keyPath {
+		t.Fatalf("VerifyAgainstKeys=%q err=%v, want %q", got, err, keyPath)
+	}
+
+	key, err := ReadBiKey(keyPath)
+	if err != nil || key.Name != "author" || key.Bits != 1024 || key.Exponent != 65537 {
+		t.Fatalf("ReadBiKey=%+v err=%v", key, err)
+	}
+
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": []byte("void main() { tampered(); }"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "addon"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+	if _, err := VerifyAgainstKeys(pboPath, bisignPath, keysDir); !errors.Is(err, ErrNoMatchingKey) || !errors.Is(err, ErrSignatureMismatch) {
+		t.Fatalf("tampered err=%v, want ErrNoMatchingKey and ErrSignatureMismatch", err)
+	}
+
+	if _, err := ParseBisign([]byte("author\x00\x04\x00")); !errors.Is(err, ErrInvalidBisign) {
+		t.Fatalf("truncated bisign err=%v, want ErrInvalidBisign", err)
+	}
+}
+
+// testBiSigner produces BI key and signature files for tests.
+type testBiSigner struct {
+	key  *rsa.PrivateKey
+	name string
+}
+
+// newTestBiSigner generates 1024-bit RSA signer.
+func newTestBiSigner(t *testing.T, name string) *testBiSigner {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	return &testBiSigner{key: key, name: name}
+}
+
+// bikey encodes public key as .bikey content.
+func (s *testBiSigner) bikey() []byte {
+	size := s.key.Size()
+	out := append([]byte(s.name), 0)
+	out = binary.LittleEndian.AppendUint32(out, uint32(biPublicKeyBlobHeaderSize+size))
+	out = append(out, 0x06, 0x02, 0x00, 0x00, 0x00, 0x24, 0x00, 0x00, 'R', 'S', 'A', '1')
+	out = binary.LittleEndian.AppendUint32(out, uint32(size*8))
+	out = binary.LittleEndian.AppendUint32(out, uint32(s.key.E))
+	return append(out, reversedBytes(s.key.N.FillBytes(make([]byte, size)))...)
+}
+
+// bisign signs hash set and encodes .bisign content.
+func (s *testBiSigner) bisign(t *testing.T, version SignVersion, hs HashSet) []byte {
+	t.Helper()
+
+	out := s.bikey()
+	for i, hash := range [3][20]byte{hs.Hash1, hs.Hash2, hs.Hash3} {
+		sig, err := rsa.SignPKCS1v15(nil, s.key, crypto.SHA1, hash[:])
+		if err != nil {
+			t.Fatalf("SignPKCS1v15: %v", err)
+		}
+		if i == 1 {
+			out = binary.LittleEndian.AppendUint32(out, uint32(version))
+		}
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(sig)))
+		out = append(out, reversedBytes(sig)...)
+	}
+
+	return out
+}
+
+// writeTestFile writes data to path or fails test.
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile(%s): %v", path, err)
+	}
+}
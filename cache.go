@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultCacheArchives is default number of archives kept in Cache.
+const DefaultCacheArchives = 256
+
+// CacheOptions configures Cache.
+type CacheOptions struct {
+	// MaxArchives is max number of archives kept in LRU cache.
+	MaxArchives int `json:"max_archives,omitempty" yaml:"max_archives,omitempty"`
+	// ContentKey identifies archives with SHA1 trailer by size and trailer hash instead of
+	// size and modification time, so rewritten files with equal content stay cached.
+	// Archives without trailer fall back to modification time.
+	ContentKey bool `json:"content_key,omitempty" yaml:"content_key,omitempty"`
+}
+
+// applyDefaults fills zero-value cache options.
+func (opts *CacheOptions) applyDefaults() {
+	if opts.MaxArchives <= 0 {
+		opts.MaxArchives = DefaultCacheArchives
+	}
+}
+
+// Cache keeps parsed entry tables and signature hash sets of archive files in LRU order,
+// keyed by absolute path and validated against file identity (size plus modification time
+// or trailer hash) on every lookup. Cache is safe for concurrent use.
+type Cache struct {
+	items map[string]*list.Element
+	lru   *list.List
+	opts  CacheOptions
+	mu    sync.Mutex
+}
+
+// cacheIdentity is file identity cached data is valid for.
+type cacheIdentity struct {
+	size    int64
+	modTime int64
+	trailer [shaSize]byte
+}
+
+// cacheHashKey selects cached hash set.
+type cacheHashKey struct {
+	version  SignVersion
+	gameType GameType
+}
+
+// cacheItem is cached state of one archive path.
+type cacheItem struct {
+	// parsed is reader template without source handle.
+	parsed   *Reader
+	hashSets map[cacheHashKey]HashSet
+	path     string
+	identity cacheIdentity
+}
+
+// NewCache creates empty archive cache.
+func NewCache(opts CacheOptions) *Cache {
+	opts.applyDefaults()
+
+	return &Cache{
+		items: make(map[string]*list.Element, opts.MaxArchives),
+		lru:   list.New(),
+		opts:  opts,
+	}
+}
+
+// Len returns number of cached archives.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Remove drops cached state of archive at path.
+func (c *Cache) Remove(path string) {
+	key := cacheKey(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.items, key)
+	}
+}
+
+// OpenCached opens PBO file like Open, reusing entry table parsed by earlier call for the
+// same unchanged file. Nil cache falls back to Open.
+func OpenCached(cache *Cache, path string) (*Reader, error) {
+	if cache == nil {
+		return Open(path)
+	}
+
+	f, key, id, err := cache.openFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := cache.newReader(f, key, id)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ComputeHashSetCached is ComputeHashSet reusing hash set and entry table cached for the
+// same unchanged file. Nil cache falls back to ComputeHashSet.
+func ComputeHashSetCached(cache *Cache, path string, version SignVersion, gameType GameType) (HashSet, error) {
+	if cache == nil {
+		return ComputeHashSet(path, version, gameType)
+	}
+
+	spec, policy, err := signPolicyFor(version, gameType)
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	f, key, id, err := cache.openFile(path)
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	hashKey := cacheHashKey{version: version, gameType: gameType}
+	if hs, ok := cache.hashSet(key, id, hashKey); ok {
+		_ = f.Close()
+		return hs, nil
+	}
+
+	r, err := cache.newReader(f, key, id)
+	if err != nil {
+		_ = f.Close()
+		return HashSet{}, err
+	}
+	defer func() { _ = r.Close() }()
+
+	details, err := computeHashDetailsFromPackedParts(r.ra, r.size, r.hasTrailer, r.Headers(), r.entries, version, spec, policy)
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	hs, err := details.HashSet()
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	cache.storeHashSet(key, id, hashKey, hs)
+	return hs, nil
+}
+
+// cacheKey returns absolute clean path used as cache key.
+func cacheKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(path)
+}
+
+// openFile opens archive and resolves its cache key and identity.
+func (c *Cache) openFile(path string) (*os.File, string, cacheIdentity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", cacheIdentity{}, fmt.Errorf("open PBO: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, "", cacheIdentity{}, fmt.Errorf("stat: %w", err)
+	}
+
+	id := cacheIdentity{size: fi.Size(), modTime: fi.ModTime().UnixNano()}
+	if c.opts.ContentKey && id.size >= 21 {
+		var tail [21]byte
+		if _, err := f.ReadAt(tail[:], id.size-21); err == nil && tail[0] == 0x00 {
+			copy(id.trailer[:], tail[1:])
+			id.modTime = 0
+		}
+	}
+
+	return f, cacheKey(path), id, nil
+}
+
+// newReader returns reader over f from cached entry table, parsing and caching it on miss.
+func (c *Cache) newReader(f *os.File, key string, id cacheIdentity) (*Reader, error) {
+	if parsed := c.parsed(key, id); parsed != nil {
+		r := parsed.shareParsed()
+		r.ra = f
+		r.file = f
+		r.decompressSem = newDecompressSem(0)
+		return r, nil
+	}
+
+	r, err := NewReaderFromReaderAtWithOptions(f, id.size, ReaderOptions{BuildIndex: true})
+	if err != nil {
+		return nil, err
+	}
+	r.file = f
+	if r.decrypted {
+		return r, nil
+	}
+
+	parsed := r.shareParsed()
+	parsed.ra = nil
+	parsed.decompressSem = nil
+	c.storeParsed(key, id, parsed)
+
+	return r, nil
+}
+
+// lookup returns cached item of key matching id and marks it recently used.
+// Stale item is dropped. Caller holds c.mu.
+func (c *Cache) lookup(key string, id cacheIdentity) *cacheItem {
+	elem, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+
+	item := elem.Value.(*cacheItem)
+	if item.identity != id {
+		c.lru.Remove(elem)
+		delete(c.items, key)
+		return nil
+	}
+
+	c.lru.MoveToFront(elem)
+	return item
+}
+
+// item returns cached item of key matching id, inserting empty one and evicting least
+// recently used items over limit. Caller holds c.mu.
+func (c *Cache) item(key string, id cacheIdentity) *cacheItem {
+	if item := c.lookup(key, id); item != nil {
+		return item
+	}
+
+	item := &cacheItem{path: key, identity: id}
+	c.items[key] = c.lru.PushFront(item)
+	for c.lru.Len() > c.opts.MaxArchives {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheItem).path)
+	}
+
+	return item
+}
+
+// parsed returns cached reader template of key matching id.
+func (c *Cache) parsed(key string, id cacheIdentity) *Reader {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item := c.lookup(key, id); item != nil {
+		return item.parsed
+	}
+
+	return nil
+}
+
+// storeParsed caches reader template of key with identity id.
+func (c *Cache) storeParsed(key string, id cacheIdentity, parsed *Reader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.item(key, id).parsed = parsed
+}
+
+// hashSet returns cached hash set of key matching id.
+func (c *Cache) hashSet(key string, id cacheIdentity, hashKey cacheHashKey) (HashSet, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if item := c.lookup(key, id); item != nil {
+		hs, ok := item.hashSets[hashKey]
+		return hs, ok
+	}
+
+	return HashSet{}, false
+}
+
+// storeHashSet caches hash set of key with identity id.
+func (c *Cache) storeHashSet(key string, id cacheIdentity, hashKey cacheHashKey, hs HashSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := c.item(key, id)
+	if item.hashSets == nil {
+		item.hashSets = make(map[cacheHashKey]HashSet, 1)
+	}
+	item.hashSets[hashKey] = hs
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenCached_ReusesEntryTable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "cached.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("alpha"), "b.txt": []byte("beta")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	cache := NewCache(CacheOptions{MaxArchives: 1})
+	first, err := OpenCached(cache, pboPath)
+	if err != nil {
+		t.Fatalf("OpenCached: %v", err)
+	}
+	_ = first.Close()
+
+	second, err := OpenCached(cache, pboPath)
+	if err != nil {
+		t.Fatalf("OpenCached hit: %v", err)
+	}
+	defer func() { _ = second.Close() }()
+
+	if &second.entries[0] != &first.entries[0] || cache.Len() != 1 {
+		t.Fatalf("entry table not reused, len=%d", cache.Len())
+	}
+	if data, err := second.ReadEntry("b.txt"); err != nil || string(data) != "beta" {
+		t.Fatalf("ReadEntry=%q err=%v", data, err)
+	}
+
+	want, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+	for range 2 {
+		got, err := ComputeHashSetCached(cache, pboPath, SignVersionV3, GameTypeDayZ)
+		if err != nil || got != want {
+			t.Fatalf("ComputeHashSetCached=%x err=%v, want %x", got, err, want)
+		}
+	}
+
+	// Rewritten file with new mtime invalidates cached table.
+	if err := createTestPBO(pboPath, map[string][]byte{"c.txt": []byte("gamma")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO rewrite: %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(pboPath, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	third, err := OpenCached(cache, pboPath)
+	if err != nil {
+		t.Fatalf("OpenCached after rewrite: %v", err)
+	}
+	defer func() { _ = third.Close() }()
+
+	if entries := third.Entries(); len(entries) != 1 || entries[0].Path != "c.txt" {
+		t.Fatalf("stale entries after rewrite: %+v", entries)
+	}
+
+	// Second archive evicts first with MaxArchives=1.
+	otherPath := filepath.Join(dir, "other.pbo")
+	if err := createTestPBO(otherPath, map[string][]byte{"d.txt": []byte("delta")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO other: %v", err)
+	}
+	other, err := OpenCached(cache, otherPath)
+	if err != nil {
+		t.Fatalf("OpenCached other: %v", err)
+	}
+	_ = other.Close()
+
+	cache.mu.Lock()
+	_, kept := cache.items[cacheKey(pboPath)]
+	cache.mu.Unlock()
+	if kept || cache.Len() != 1 {
+		t.Fatalf("LRU did not evict oldest archive, len=%d", cache.Len())
+	}
+}
+
+func TestOpenCached_ContentKeyIgnoresModTime(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "content.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	cache := NewCache(CacheOptions{ContentKey: true})
+	first, err := OpenCached(cache, pboPath)
+	if err != nil {
+		t.Fatalf("OpenCached: %v", err)
+	}
+	_ = first.Close()
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(pboPath, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	second, err := OpenCached(cache, pboPath)
+	if err != nil {
+		t.Fatalf("OpenCached touched: %v", err)
+	}
+	defer func() { _ = second.Close() }()
+
+	if &second.entries[0] != &first.entries[0] {
+		t.Fatal("touched archive with same trailer was re-parsed")
+	}
+}
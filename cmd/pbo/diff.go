@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"fmt"
+	"sort"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runDiff compares headers and decoded entry contents of two archives.
+// Output lines are prefixed with "-" (only in first), "+" (only in second), or "~" (changed).
+func runDiff(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "diff")
+	var rf readerFlags
+	rf.register(fs)
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	a, err := openForDiff(&rf, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = a.Close() }()
+
+	b, err := openForDiff(&rf, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = b.Close() }()
+
+	different := diffHeaders(env, a.Headers(), b.Headers())
+
+	entriesA := entriesByPath(a.Entries())
+	entriesB := entriesByPath(b.Entries())
+	paths := make([]string, 0, len(entriesA)+len(entriesB))
+	for path := range entriesA {
+		paths = append(paths, path)
+	}
+	for path := range entriesB {
+		if _, ok := entriesA[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, inA := entriesA[path]
+		_, inB := entriesB[path]
+		switch {
+		case !inB:
+			_, _ = fmt.Fprintf(env.stdout, "- %s\n", path)
+			different = true
+		case !inA:
+			_, _ = fmt.Fprintf(env.stdout, "+ %s\n", path)
+			different = true
+		default:
+			same, err := sameEntryContent(a, b, path)
+			if err != nil {
+				return err
+			}
+			if !same {
+				_, _ = fmt.Fprintf(env.stdout, "~ %s\n", path)
+				different = true
+			}
+		}
+	}
+
+	if different {
+		return errDifferent
+	}
+
+	return nil
+}
+
+// openForDiff opens archive with reader flags resolved for path.
+func openForDiff(rf *readerFlags, path string) (*pbo.Reader, error) {
+	opts, err := rf.options(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pbo.OpenWithOptions(path, opts)
+}
+
+// diffHeaders prints header differences and reports whether any were found.
+// Repeated keys are compared by occurrence order.
+func diffHeaders(env *cmdEnv, a []pbo.HeaderPair, b []pbo.HeaderPair) bool {
+	slotsA, valuesA := headerSlots(a)
+	slotsB, valuesB := headerSlots(b)
+
+	different := false
+	for i, h := range a {
+		value, ok := valuesB[slotsA[i]]
+		switch {
+		case !ok:
+			_, _ = fmt.Fprintf(env.stdout, "- header %s=%s\n", h.Key, h.Value)
+			different = true
+		case value != h.Value:
+			_, _ = fmt.Fprintf(env.stdout, "~ header %s=%s -> %s\n", h.Key, h.Value, value)
+			different = true
+		}
+	}
+	for i, h := range b {
+		if _, ok := valuesA[slotsB[i]]; !ok {
+			_, _ = fmt.Fprintf(env.stdout, "+ header %s=%s\n", h.Key, h.Value)
+			different = true
+		}
+	}
+
+	return different
+}
+
+// headerSlot identifies n-th occurrence of header key.
+type headerSlot struct {
+	key string
+	n   int
+}
+
+// headerSlots returns slot of every header in order and values indexed by slot.
+func headerSlots(headers []pbo.HeaderPair) ([]headerSlot, map[headerSlot]string) {
+	slots := make([]headerSlot, len(headers))
+	values := make(map[headerSlot]string, len(headers))
+	seen := make(map[string]int, len(headers))
+	for i, h := range headers {
+		slots[i] = headerSlot{key: h.Key, n: seen[h.Key]}
+		seen[h.Key]++
+		values[slots[i]] = h.Value
+	}
+
+	return slots, values
+}
+
+// entriesByPath indexes entries by stored path.
+func entriesByPath(entries []pbo.EntryInfo) map[string]pbo.EntryInfo {
+	out := make(map[string]pbo.EntryInfo, len(entries))
+	for _, e := range entries {
+		out[e.Path] = e
+	}
+
+	return out
+}
+
+// sameEntryContent compares decoded content of path in both archives by SHA256.
+func sameEntryContent(a *pbo.Reader, b *pbo.Reader, path string) (bool, error) {
+	sumA, err := a.HashEntry(path, crypto.SHA256)
+	if err != nil {
+		return false, err
+	}
+
+	sumB, err := b.HashEntry(path, crypto.SHA256)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(sumA, sumB), nil
+}
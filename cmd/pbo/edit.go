@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runEdit stages add/replace/delete operations and commits them to archive in place.
+func runEdit(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "edit")
+	var pf packFlags
+	pf.register(fs)
+	var adds, replaces, deletes, deleteDirs stringList
+	fs.Var(&adds, "add", "add entry from file as entry=src (repeatable)")
+	fs.Var(&replaces, "replace", "replace entry from file as entry=src (repeatable)")
+	fs.Var(&deletes, "delete", "delete entry path (repeatable)")
+	fs.Var(&deleteDirs, "delete-dir", "delete entries under directory prefix (repeatable)")
+	syncDir := fs.String("sync-dir", "", "stage differences between directory and archive as dir[=prefix]")
+	syncMTime := fs.Bool("sync-mtime", false, "compare -sync-dir files by size and mtime instead of content hash")
+	syncKeep := fs.Bool("sync-keep-extra", false, "keep archive entries missing in -sync-dir")
+	var setHeaders, deleteHeaders stringList
+	fs.Var(&setHeaders, "set-header", "set header as key=value (repeatable)")
+	fs.Var(&deleteHeaders, "delete-header", "delete header key (repeatable)")
+	prefix := fs.String("prefix", "", "set prefix header")
+	var bf backupFlags
+	bf.register(fs)
+	dryRun := fs.Bool("dry-run", false, "print resolved plan as JSON without writing")
+	appendOnly := fs.Bool("append", false, "append added entries in place with stored offsets instead of full rewrite")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	packOpts, err := pf.options()
+	if err != nil {
+		return err
+	}
+
+	editOpts := pbo.EditOptions{PackOptions: packOpts}
+	bf.apply(&editOpts)
+	editor, err := pbo.OpenEditor(fs.Arg(0), editOpts)
+	if err != nil {
+		return err
+	}
+
+	addInputs, err := fileInputs(adds)
+	if err != nil {
+		return err
+	}
+
+	replaceInputs, err := fileInputs(replaces)
+	if err != nil {
+		return err
+	}
+
+	if *syncDir != "" {
+		dir, archivePrefix, _ := strings.Cut(*syncDir, "=")
+		syncOpts := pbo.SyncOptions{KeepExtra: *syncKeep}
+		if *syncMTime {
+			syncOpts.Compare = pbo.SyncCompareMTime
+		}
+
+		res, err := editor.SyncDir(dir, archivePrefix, syncOpts)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(env.stderr, "sync: %d added, %d replaced, %d deleted, %d unchanged\n",
+			len(res.Added), len(res.Replaced), len(res.Deleted), res.Unchanged)
+		if res.Prefix != "" {
+			_, _ = fmt.Fprintf(env.stderr, "sync: prefix %s\n", res.Prefix)
+		}
+	}
+	if err := editor.Delete(deletes...); err != nil {
+		return err
+	}
+	if err := editor.DeleteDir(deleteDirs...); err != nil {
+		return err
+	}
+	if err := editor.Replace(replaceInputs...); err != nil {
+		return err
+	}
+	if err := editor.Add(addInputs...); err != nil {
+		return err
+	}
+	if err := stageHeaderEdits(editor, setHeaders, deleteHeaders, *prefix); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		plan, err := editor.Plan(ctx)
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(env.stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(plan); err != nil {
+			return err
+		}
+		if plan.HasConflicts() {
+			return errDifferent
+		}
+
+		return nil
+	}
+
+	commit := editor.Commit
+	if *appendOnly {
+		commit = editor.CommitAppend
+	}
+
+	res, err := commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.stdout, "wrote %d entries in %s\n", res.WrittenEntries, res.Duration)
+	return nil
+}
+
+// stageHeaderEdits stages header deletions, key=value assignments, and prefix change.
+func stageHeaderEdits(editor *pbo.Editor, sets []string, deletes []string, prefix string) error {
+	for _, key := range deletes {
+		if err := editor.DeleteHeader(key); err != nil {
+			return err
+		}
+	}
+
+	for _, spec := range sets {
+		key, value, ok := strings.Cut(spec, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("%w: %q must be key=value", errUsage, spec)
+		}
+
+		if err := editor.SetHeader(key, value); err != nil {
+			return err
+		}
+	}
+
+	if prefix == "" {
+		return nil
+	}
+
+	return editor.SetPrefix(prefix)
+}
+
+// fileInputs converts entry=src flag values to lazily opened file inputs.
+func fileInputs(specs []string) ([]pbo.Input, error) {
+	inputs := make([]pbo.Input, 0, len(specs))
+	for _, spec := range specs {
+		entryPath, srcPath, ok := strings.Cut(spec, "=")
+		if !ok || entryPath == "" || srcPath == "" {
+			return nil, fmt.Errorf("%w: %q must be entry=src", errUsage, spec)
+		}
+
+		info, err := os.Stat(srcPath)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, pbo.Input{
+			Path:     entryPath,
+			ModTime:  info.ModTime(),
+			SizeHint: info.Size(),
+			Open: func() (io.ReadCloser, error) {
+				return os.Open(srcPath) //nolint:gosec // path is provided by command line user
+			},
+		})
+	}
+
+	return inputs, nil
+}
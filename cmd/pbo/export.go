@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runExport writes archive into compressed container with uncompressed index.
+func runExport(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "export")
+	format := fs.String("format", string(pbo.ExportFormatGzip), "container format: gzip")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	if err := pbo.ExportCompressed(ctx, fs.Arg(0), fs.Arg(1), pbo.ExportFormat(*format)); err != nil {
+		return err
+	}
+
+	idx, err := pbo.ReadExportIndex(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.stdout, "exported %d entries, %d bytes archive as %s\n", len(idx.Entries), idx.Size, idx.Format)
+	return nil
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runExtract extracts archive entries to destination directory.
+func runExtract(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "extract")
+	var rf readerFlags
+	rf.register(fs)
+	fileMode := fs.String("file-mode", string(pbo.ExtractFileModeAuto),
+		"existing file handling: auto, overwrite_smart, truncate, create_only, skip_unchanged")
+	workers := fs.Int("workers", 0, "parallel extract workers (0 = GOMAXPROCS)")
+	readahead := fs.Int("readahead", 0, "payload bytes to read ahead of workers (0 = disabled)")
+	bytesPerSecond := fs.Int64("bytes-per-second", 0, "limit extract rate (0 = unlimited)")
+	entryTimeout := fs.Duration("entry-timeout", 0, "fail entry that makes no progress this long (0 = disabled)")
+	continueOnError := fs.Bool("continue", false, "continue after per-entry errors")
+	errorPolicy := fs.String("on-error", "",
+		"per-entry error handling: fail_fast, collect_all, skip_and_report (default fail_fast, collect_all with -continue)")
+	rawNames := fs.Bool("raw-names", false, "do not sanitize output file names")
+	caseCollision := fs.String("case-collision", string(pbo.ExtractCollisionSuffix),
+		"raw names differing only by case: suffix, error, merge")
+	atomic := fs.Bool("atomic", false, "extract into staging dir and replace destination on success")
+	checkSpace := fs.Bool("check-space", false, "fail early when destination lacks free space")
+	strictSizes := fs.Bool("strict-sizes", false, "fail when decoded entry size differs from original size")
+	dryRun := fs.Bool("dry-run", false, "print planned output paths without writing (implies -v)")
+	verbose := fs.Bool("v", false, "print extracted paths")
+	filePerm := fs.String("file-perm", "", "octal permission of written files, ignoring umask (default 0600 with umask)")
+	dirPerm := fs.String("dir-perm", "", "octal permission of output directories, ignoring umask (default 0750 with umask)")
+	owner := fs.String("owner", "", "numeric uid:gid owner of written files and directories")
+	longPaths := fs.Bool("long-paths", false, `write through Windows extended-length \\?\ paths`)
+	winAttrs := fs.Bool("win-attrs", false, "clear read-only/hidden before overwrite and hide dot names on Windows")
+	objects := fs.Bool("objects", false, "write content-addressed objects/<sha1> and <archive>.json manifest into dst-dir")
+	var only stringList
+	fs.Var(&only, "entry", "extract only this entry path (repeatable)")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	filePermValue, err := parsePermFlag("file-perm", *filePerm)
+	if err != nil {
+		return err
+	}
+	dirPermValue, err := parsePermFlag("dir-perm", *dirPerm)
+	if err != nil {
+		return err
+	}
+	chown, err := parseOwnerFlag(*owner)
+	if err != nil {
+		return err
+	}
+	if *objects && (len(only) > 0 || *atomic || *dryRun) {
+		return fmt.Errorf("%w: -objects cannot be combined with -entry, -atomic, or -dry-run", errUsage)
+	}
+
+	path := fs.Arg(0)
+	opts, err := rf.options(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := pbo.OpenWithOptions(path, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	extractOpts := pbo.ExtractOptions{
+		FileMode:          pbo.ExtractFileMode(*fileMode),
+		CaseCollision:     pbo.ExtractCollisionPolicy(*caseCollision),
+		MaxWorkers:        *workers,
+		Readahead:         *readahead,
+		BytesPerSecond:    *bytesPerSecond,
+		PerEntryTimeout:   *entryTimeout,
+		ErrorPolicy:       pbo.ExtractErrorPolicy(*errorPolicy),
+		ContinueOnError:   *continueOnError,
+		RawNames:          *rawNames,
+		Atomic:            *atomic,
+		DryRun:            *dryRun,
+		CheckDiskSpace:    *checkSpace,
+		StrictSizes:       *strictSizes,
+		FilePerm:          filePermValue,
+		DirPerm:           dirPermValue,
+		Chown:             chown,
+		LongPaths:         *longPaths,
+		WindowsAttributes: *winAttrs,
+		OnSizeMismatch: func(entry pbo.EntryInfo, written int64, _ string) {
+			_, _ = fmt.Fprintf(env.stderr, "pbo extract: warning: %s: wrote %d bytes, original size %d\n", entry.Path, written, entry.OriginalSize)
+		},
+		OnEntryFailed: func(entry pbo.EntryInfo, err error) {
+			_, _ = fmt.Fprintf(env.stderr, "pbo extract: %s: %v\n", entry.Path, err)
+		},
+	}
+	if *verbose || *dryRun {
+		extractOpts.OnEntryDone = func(_ pbo.EntryInfo, written int64, outputPath string) {
+			_, _ = fmt.Fprintf(env.stdout, "%d\t%s\n", written, outputPath)
+		}
+		extractOpts.OnEntrySkipped = func(_ pbo.EntryInfo, outputPath string) {
+			_, _ = fmt.Fprintf(env.stdout, "skip\t%s\n", outputPath)
+		}
+	}
+
+	if *objects {
+		return extractObjects(ctx, r, path, fs.Arg(1), extractOpts)
+	}
+
+	if len(only) > 0 {
+		return r.ExtractEntries(ctx, only, fs.Arg(1), extractOpts)
+	}
+
+	return r.Extract(ctx, fs.Arg(1), extractOpts)
+}
+
+// extractObjects writes archive content into object store and saves manifest
+// named after archive next to objects dir.
+func extractObjects(ctx context.Context, r *pbo.Reader, archivePath string, storeDir string, opts pbo.ExtractOptions) error {
+	manifest, err := r.ExtractObjects(ctx, storeDir, opts)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(archivePath), filepath.Ext(archivePath)) + ".json"
+	return pbo.WriteObjectManifest(filepath.Join(storeDir, name), manifest)
+}
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"crypto"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/woozymasta/pathrules"
+	"github.com/woozymasta/pbo"
+)
+
+// Command line errors.
+var (
+	// errUsage marks invalid command line arguments.
+	errUsage = errors.New("invalid usage")
+	// errFlagParse marks flag parse errors already reported by flag package.
+	errFlagParse = errors.New("flag parse failed")
+)
+
+// stringList is repeatable string flag.
+type stringList []string
+
+// String returns comma-joined values.
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set appends one flag value.
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// readerFlags are shared archive read flags mapped to pbo.ReaderOptions.
+type readerFlags struct {
+	sealedKey       string
+	offsetMode      string
+	prefix          string
+	unicodeForm     string
+	nameEncoding    string
+	minOriginalSize uint
+	minDataSize     uint
+	maxEntrySize    uint
+	junkFilter      bool
+	asciiOnly       bool
+	sanitizeControl bool
+	sanitizeNames   bool
+	recover         bool
+	auto            bool
+}
+
+// packFlags are shared pack flags mapped to pbo.PackOptions.
+type packFlags struct {
+	headers         stringList
+	compressExts    stringList
+	compressRules   stringList
+	sealedKey       string
+	entryHash       string
+	streamMode      string
+	order           string
+	pathCase        string
+	unicodeForm     string
+	nameEncoding    string
+	spoolDir        string
+	bytesPerSecond  int64
+	entryTimeout    time.Duration
+	openBackoff     time.Duration
+	openAttempts    int
+	align           int
+	compressWorkers int
+	minCompressSize uint
+	maxCompressSize uint
+	spoolCompress   uint
+	dupHeaders      bool
+	verify          bool
+	dedup           bool
+	storedOffsets   bool
+	alignEntries    bool
+	relOffsets      bool
+	noPrefixFile    bool
+}
+
+// backupFlags are shared commit backup flags mapped to pbo.EditOptions.
+type backupFlags struct {
+	dir      string
+	template string
+	keep     int
+	copy     bool
+}
+
+// newFlagSet creates subcommand flag set writing errors and help to env stderr.
+func newFlagSet(env *cmdEnv, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("pbo "+name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(env.stderr, "usage: pbo %s\n\nflags:\n", env.usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseFlags parses args and checks positional argument count.
+func parseFlags(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return fmt.Errorf("%w: %w", errFlagParse, err)
+	}
+
+	if fs.NArg() != positional {
+		return fmt.Errorf("%w: expected %d arguments, got %d", errUsage, positional, fs.NArg())
+	}
+
+	return nil
+}
+
+// register binds reader flags to fs.
+func (f *readerFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.sealedKey, "sealed-key", "", "32 hex chars sealed archive key")
+	fs.StringVar(&f.offsetMode, "offset-mode", "", "entry offset mode: sequential (default), stored_compat, stored_strict")
+	fs.StringVar(&f.prefix, "entry-prefix", "", "only entries under this path prefix")
+	fs.StringVar(&f.unicodeForm, "unicode-form", "", "normalize entry paths: nfc, nfd")
+	fs.StringVar(&f.nameEncoding, "name-encoding", "", "decode legacy entry names: auto, windows-1251, windows-1252")
+	fs.UintVar(&f.minOriginalSize, "min-original-size", 0, "skip entries with smaller original size")
+	fs.UintVar(&f.minDataSize, "min-data-size", 0, "skip entries with smaller stored size")
+	fs.UintVar(&f.maxEntrySize, "max-entry-size", 0, "fail on entries decompressing above this size (0 = unlimited)")
+	fs.BoolVar(&f.junkFilter, "junk-filter", false, "skip junk entries (empty, broken, invalid paths)")
+	fs.BoolVar(&f.asciiOnly, "ascii-only", false, "skip entries with non-ASCII paths")
+	fs.BoolVar(&f.sanitizeControl, "sanitize-control", false, "replace control characters in entry paths")
+	fs.BoolVar(&f.sanitizeNames, "sanitize-names", false, "sanitize reserved and non-portable entry names")
+	fs.BoolVar(&f.recover, "recover", false, "salvage entries from damaged entry table")
+	fs.BoolVar(&f.auto, "auto", false, "detect index obfuscation and enable matching filters")
+}
+
+// options converts flags to reader options for archive at path.
+func (f *readerFlags) options(path string) (pbo.ReaderOptions, error) {
+	opts := pbo.ReaderOptions{}
+	if f.auto {
+		report, err := pbo.AnalyzeObfuscation(path)
+		if err != nil {
+			return opts, err
+		}
+
+		opts = report.ReaderOptions()
+	}
+
+	switch mode := pbo.OffsetMode(f.offsetMode); mode {
+	case "":
+	case pbo.OffsetModeSequential, pbo.OffsetModeStoredCompat, pbo.OffsetModeStoredStrict:
+		opts.OffsetMode = mode
+	default:
+		return opts, fmt.Errorf("%w: unknown offset mode %q", errUsage, f.offsetMode)
+	}
+
+	key, err := parseSealedKey(f.sealedKey)
+	if err != nil {
+		return opts, err
+	}
+
+	minOriginal, err := uint32Flag("min-original-size", f.minOriginalSize)
+	if err != nil {
+		return opts, err
+	}
+
+	minData, err := uint32Flag("min-data-size", f.minDataSize)
+	if err != nil {
+		return opts, err
+	}
+
+	maxEntry, err := uint32Flag("max-entry-size", f.maxEntrySize)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.SealedKey = key
+	opts.EntryPathPrefix = f.prefix
+	opts.UnicodeForm = pbo.UnicodeForm(f.unicodeForm)
+	opts.NameEncoding = pbo.NameEncoding(f.nameEncoding)
+	opts.MinEntryOriginalSize = minOriginal
+	opts.MinEntryDataSize = minData
+	opts.MaxEntryDecompressedSize = maxEntry
+	opts.EnableJunkFilter = opts.EnableJunkFilter || f.junkFilter
+	opts.FilterASCIIOnly = f.asciiOnly
+	opts.SanitizeControlChars = opts.SanitizeControlChars || f.sanitizeControl
+	opts.SanitizeNames = opts.SanitizeNames || f.sanitizeNames
+	opts.RecoverMode = f.recover
+
+	return opts, nil
+}
+
+// register binds pack flags to fs.
+func (f *packFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.headers, "header", "archive header key=value (repeatable)")
+	fs.BoolVar(&f.dupHeaders, "allow-duplicate-headers", false, "allow repeated -header keys")
+	fs.BoolVar(&f.verify, "verify", false, "re-read written payloads and compare with sources")
+	fs.BoolVar(&f.storedOffsets, "stored-offsets", false, "write payload offsets into index instead of zero")
+	fs.BoolVar(&f.relOffsets, "relative-offsets", false, "with -stored-offsets, write offsets relative to payload start")
+	fs.BoolVar(&f.dedup, "dedup", false, "store identical payloads once (needs stored-offset readers)")
+	fs.BoolVar(&f.noPrefixFile, "ignore-prefix-file", false, "pack $PBOPREFIX$-style files as entries instead of reading prefix")
+	fs.Var(&f.compressExts, "compress-ext", "compress files with comma-separated extensions (repeatable)")
+	fs.Var(&f.compressRules, "compress-rules", "compress files matched by rules file (repeatable)")
+	fs.StringVar(&f.sealedKey, "sealed-key", "", "32 hex chars key to write sealed archive")
+	fs.StringVar(&f.entryHash, "entry-hash", "", "record per-entry digests: sha1, sha256, xxh64")
+	fs.StringVar(&f.order, "order", "", "entry order: path, preserve_input")
+	fs.StringVar(&f.pathCase, "path-case", "", "duplicate path detection: insensitive, sensitive")
+	fs.StringVar(&f.unicodeForm, "unicode-form", "", "normalize input paths: nfc, nfd")
+	fs.StringVar(&f.nameEncoding, "name-encoding", "", "write entry names in legacy code page: windows-1251, windows-1252")
+	fs.StringVar(&f.streamMode, "stream-mode", "", "stream pack mode: auto, size_hint, buffered")
+	fs.StringVar(&f.spoolDir, "spool-dir", "", "directory for spooled compression temp files")
+	fs.IntVar(&f.align, "align", 0, "pad payload start to multiple of bytes, e.g. 4096")
+	fs.BoolVar(&f.alignEntries, "align-entries", false, "with -align, pad every entry payload (needs stored-offset readers)")
+	fs.Int64Var(&f.bytesPerSecond, "bytes-per-second", 0, "limit payload write rate (0 = unlimited)")
+	fs.DurationVar(&f.entryTimeout, "entry-timeout", 0, "fail when one input makes no progress this long (0 = disabled)")
+	fs.IntVar(&f.openAttempts, "open-attempts", 0, "open each input up to N times on failure (0 = once)")
+	fs.DurationVar(&f.openBackoff, "open-backoff", time.Second, "delay before second open attempt, doubled per retry")
+	fs.UintVar(&f.minCompressSize, "min-compress-size", 0, "minimum entry size for compression (0 = default)")
+	fs.UintVar(&f.maxCompressSize, "max-compress-size", 0, "maximum entry size for in-memory compression (0 = default)")
+	fs.IntVar(&f.compressWorkers, "compress-workers", 0, "compress entries in parallel goroutines (0 = in writer)")
+	fs.UintVar(&f.spoolCompress, "spool-compress-size", 0, "compress larger entries through temp files (0 = disabled)")
+}
+
+// register binds backup flags to fs.
+func (f *backupFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.dir, "backup-dir", "", "directory for commit backups (default archive directory)")
+	fs.StringVar(&f.template, "backup-name", "", "backup name template with {name}, {stem}, {timestamp}, {unix} (default {name}.bak)")
+	fs.IntVar(&f.keep, "backup-keep", 0, "backup generations to keep after commit")
+	fs.BoolVar(&f.copy, "backup-copy", false, "copy archive to backup instead of renaming it")
+}
+
+// apply sets backup fields of edit options.
+func (f *backupFlags) apply(opts *pbo.EditOptions) {
+	opts.BackupDir = f.dir
+	opts.BackupNameTemplate = f.template
+	opts.BackupKeep = f.keep
+	opts.BackupCopy = f.copy
+}
+
+// options converts flags to pack options.
+func (f *packFlags) options() (pbo.PackOptions, error) {
+	opts := pbo.PackOptions{
+		StreamMode:            pbo.PackStreamMode(f.streamMode),
+		Order:                 pbo.PackOrder(f.order),
+		PathCaseSensitivity:   pbo.PathCaseSensitivity(f.pathCase),
+		UnicodeForm:           pbo.UnicodeForm(f.unicodeForm),
+		NameEncoding:          pbo.NameEncoding(f.nameEncoding),
+		SpoolDir:              f.spoolDir,
+		BytesPerSecond:        f.bytesPerSecond,
+		PerEntryTimeout:       f.entryTimeout,
+		PayloadAlignment:      f.align,
+		CompressWorkers:       f.compressWorkers,
+		AlignEntries:          f.alignEntries,
+		AllowDuplicateHeaders: f.dupHeaders,
+		VerifyAfterWrite:      f.verify,
+		WriteStoredOffsets:    f.storedOffsets,
+		RelativeStoredOffsets: f.relOffsets,
+		DedupEqualPayloads:    f.dedup,
+		IgnorePrefixFile:      f.noPrefixFile,
+	}
+
+	if f.openAttempts > 1 {
+		opts.OpenRetry = &pbo.OpenRetry{Attempts: f.openAttempts, Backoff: f.openBackoff}
+	}
+
+	for _, raw := range f.headers {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok || key == "" {
+			return opts, fmt.Errorf("%w: header %q must be key=value", errUsage, raw)
+		}
+
+		opts.Headers = append(opts.Headers, pbo.HeaderPair{Key: key, Value: value})
+	}
+
+	for _, raw := range f.compressExts {
+		opts.Compress = append(opts.Compress, pathrules.ParseExtensions(strings.Split(raw, ","))...)
+	}
+
+	for _, path := range f.compressRules {
+		rules, err := pathrules.LoadRulesFile(path)
+		if err != nil {
+			return opts, fmt.Errorf("load compress rules: %w", err)
+		}
+
+		opts.Compress = append(opts.Compress, rules...)
+	}
+
+	if len(opts.Compress) > 0 {
+		opts.CompressMatcherOptions = pathrules.MatcherOptions{
+			CaseInsensitive: true,
+			DefaultAction:   pathrules.ActionExclude,
+		}
+	}
+
+	key, err := parseSealedKey(f.sealedKey)
+	if err != nil {
+		return opts, err
+	}
+	opts.SealedKey = key
+
+	if opts.EntryHash, err = parseHashName(f.entryHash); err != nil {
+		return opts, err
+	}
+
+	if opts.MinCompressSize, err = uint32Flag("min-compress-size", f.minCompressSize); err != nil {
+		return opts, err
+	}
+
+	if opts.MaxCompressSize, err = uint32Flag("max-compress-size", f.maxCompressSize); err != nil {
+		return opts, err
+	}
+
+	if opts.SpoolCompressSize, err = uint32Flag("spool-compress-size", f.spoolCompress); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// parseSealedKey decodes optional hex sealed key.
+func parseSealedKey(raw string) (*pbo.SealedKey, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	decoded, err := hex.DecodeString(raw)
+	if err != nil || len(decoded) != len(pbo.SealedKey{}) {
+		return nil, fmt.Errorf("%w: sealed key must be 32 hex chars", errUsage)
+	}
+
+	var key pbo.SealedKey
+	copy(key[:], decoded)
+
+	return &key, nil
+}
+
+// parseHashName maps digest name to crypto.Hash; empty name disables hashing.
+func parseHashName(raw string) (crypto.Hash, error) {
+	switch strings.ToLower(raw) {
+	case "":
+		return 0, nil
+	case "sha1":
+		return crypto.SHA1, nil
+	case "sha256":
+		return crypto.SHA256, nil
+	case "xxh64", "xxhash":
+		return pbo.XXH64, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported hash %q", errUsage, raw)
+	}
+}
+
+// parsePermFlag parses optional octal permission flag value ("0644").
+func parsePermFlag(name string, raw string) (fs.FileMode, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	perm, err := strconv.ParseUint(raw, 8, 32)
+	if err != nil || perm == 0 || perm > uint64(fs.ModePerm) {
+		return 0, fmt.Errorf("%w: -%s %q is not octal permission", errUsage, name, raw)
+	}
+
+	return fs.FileMode(perm), nil
+}
+
+// parseOwnerFlag parses optional "uid:gid" owner flag value into chown callback.
+func parseOwnerFlag(raw string) (func(string, bool) (int, int), error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	uidRaw, gidRaw, ok := strings.Cut(raw, ":")
+	uid, uidErr := strconv.Atoi(uidRaw)
+	gid, gidErr := strconv.Atoi(gidRaw)
+	if !ok || uidErr != nil || gidErr != nil || uid < 0 || gid < 0 {
+		return nil, fmt.Errorf("%w: -owner %q must be uid:gid", errUsage, raw)
+	}
+
+	return func(string, bool) (int, int) { return uid, gid }, nil
+}
+
+// uint32Flag checks that unsigned flag value fits uint32.
+func uint32Flag(name string, value uint) (uint32, error) {
+	if uint64(value) > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("%w: -%s %d exceeds uint32", errUsage, name, value)
+	}
+
+	return uint32(value), nil
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/woozymasta/pbo"
+)
+
+// signFlags select signature hash policy.
+type signFlags struct {
+	game     string
+	allowExt string
+	denyExt  string
+	version  uint
+}
+
+// register binds signature policy flags to fs.
+func (f *signFlags) register(fs *flag.FlagSet) {
+	fs.UintVar(&f.version, "sign-version", uint(pbo.SignVersionV3), "signature hash policy version: 2, 3")
+	fs.StringVar(&f.game, "game", string(pbo.GameTypeDayZ), "game type for v3 policy: arma, dayz")
+	fs.StringVar(&f.allowExt, "sign-allow-ext", "", "comma-separated extensions hashed into filehash (replaces game policy)")
+	fs.StringVar(&f.denyExt, "sign-deny-ext", "", "comma-separated extensions excluded from filehash (replaces game policy)")
+}
+
+// policy returns custom extension policy, or nil when built-in game policy applies.
+func (f *signFlags) policy() pbo.SignPolicy {
+	if f.allowExt == "" && f.denyExt == "" {
+		return nil
+	}
+
+	return pbo.SignExtensionPolicy{
+		Allow: splitExtList(f.allowExt),
+		Deny:  splitExtList(f.denyExt),
+	}
+}
+
+// hashSet computes signature hash set of archive at path.
+func (f *signFlags) hashSet(path string) (pbo.HashSet, error) {
+	version, err := uint32Flag("sign-version", f.version)
+	if err != nil {
+		return pbo.HashSet{}, err
+	}
+
+	if policy := f.policy(); policy != nil {
+		return pbo.ComputeHashSetWithPolicy(path, pbo.SignVersion(version), policy)
+	}
+
+	return pbo.ComputeHashSet(path, pbo.SignVersion(version), pbo.GameType(f.game))
+}
+
+// hashDetails computes signature hash set with intermediate digests of archive at path.
+func (f *signFlags) hashDetails(path string) (*pbo.HashDetails, error) {
+	version, err := uint32Flag("sign-version", f.version)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy := f.policy(); policy != nil {
+		return pbo.ComputeHashDetailsWithPolicy(path, pbo.SignVersion(version), policy)
+	}
+
+	return pbo.ComputeHashDetails(path, pbo.SignVersion(version), pbo.GameType(f.game))
+}
+
+// splitExtList splits comma-separated extension list dropping empty items.
+func splitExtList(raw string) []string {
+	var out []string
+	for item := range strings.SplitSeq(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+// runHash prints signature hash set and optional per-entry digests.
+func runHash(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "hash")
+	var sf signFlags
+	sf.register(fs)
+	var rf readerFlags
+	rf.register(fs)
+	entries := fs.String("entries", "", "also print per-entry digests: sha1, sha256, xxh64")
+	dirPattern := fs.String("dir", "", "treat argument as directory and hash every file matching glob (for example **/*.pbo)")
+	workers := fs.Int("workers", 0, "parallel archives for -dir (0 = GOMAXPROCS)")
+	details := fs.Bool("details", false, "also print prefix, namehash, filehash and entries hashed into filehash")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	if *dirPattern != "" {
+		return runHashDir(ctx, env, sf, path, *dirPattern, *workers)
+	}
+
+	entryHash, err := parseHashName(*entries)
+	if err != nil {
+		return err
+	}
+
+	d, err := sf.hashDetails(path)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.stdout, "hash1 %x\nhash2 %x\nhash3 %x\n", d.Hash1, d.Hash2, d.Hash3)
+	if *details {
+		_, _ = fmt.Fprintf(env.stdout, "prefix %s\nnamehash %x\nfilehash %x\n", d.Prefix, d.NameHash, d.FileHash)
+		for _, name := range d.FileHashEntries {
+			_, _ = fmt.Fprintf(env.stdout, "filehash-entry %s\n", name)
+		}
+	}
+	if entryHash == 0 {
+		return nil
+	}
+
+	opts, err := rf.options(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := pbo.OpenWithOptions(path, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, e := range r.Entries() {
+		sum, err := r.HashEntry(e.Path, entryHash)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(env.stdout, "%s  %s\n", hex.EncodeToString(sum), e.Path)
+	}
+
+	return nil
+}
+
+// runHashDir prints hash sets of all archives under dir matching pattern sorted by path.
+func runHashDir(ctx context.Context, env *cmdEnv, sf signFlags, dir string, pattern string, workers int) error {
+	version, err := uint32Flag("sign-version", sf.version)
+	if err != nil {
+		return err
+	}
+
+	var sets map[string]pbo.HashSet
+	if policy := sf.policy(); policy != nil {
+		sets, err = pbo.ComputeHashSetsWithPolicy(ctx, dir, pattern, pbo.SignVersion(version), policy, workers)
+	} else {
+		sets, err = pbo.ComputeHashSets(ctx, dir, pattern, pbo.SignVersion(version), pbo.GameType(sf.game), workers)
+	}
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(sets))
+	for p := range sets {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		hs := sets[p]
+		_, _ = fmt.Fprintf(env.stdout, "%x %x %x  %s\n", hs.Hash1, hs.Hash2, hs.Hash3, p)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/woozymasta/pbo"
+)
+
+// listOutput is JSON form of list command output.
+type listOutput struct {
+	Recovery    *pbo.RecoveryReport `json:"recovery,omitempty"`
+	Headers     []pbo.HeaderPair    `json:"headers,omitempty"`
+	Diagnostics []pbo.ParseIssue    `json:"diagnostics,omitempty"`
+	Entries     []pbo.EntryInfo     `json:"entries"`
+}
+
+// runList prints archive headers and entry table.
+func runList(_ context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "list")
+	var rf readerFlags
+	rf.register(fs)
+	asJSON := fs.Bool("json", false, "print JSON instead of table")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	opts, err := rf.options(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := pbo.OpenWithOptions(path, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	if *asJSON {
+		enc := json.NewEncoder(env.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(listOutput{
+			Recovery:    r.RecoveryReport(),
+			Headers:     r.Headers(),
+			Diagnostics: r.Diagnostics(),
+			Entries:     r.Entries(),
+		})
+	}
+
+	for _, h := range r.Headers() {
+		_, _ = fmt.Fprintf(env.stdout, "%s=%s\n", h.Key, h.Value)
+	}
+
+	tw := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "SIZE\tSTORED\tOFFSET\t PATH")
+	for _, e := range r.Entries() {
+		size := e.DataSize
+		if e.IsCompressed() {
+			size = e.OriginalSize
+		}
+
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t %s\n", size, e.DataSize, e.Offset, e.Path)
+	}
+
+	return tw.Flush()
+}
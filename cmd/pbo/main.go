@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+// Command pbo lists, extracts, packs, hashes, verifies, diffs, and edits PBO archives.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+)
+
+// Process exit codes.
+const (
+	// exitOK means command succeeded.
+	exitOK = 0
+	// exitFailure means command failed or found differences.
+	exitFailure = 1
+	// exitUsage means invalid command line.
+	exitUsage = 2
+)
+
+// errDifferent marks successful comparison that found differences or mismatches.
+var errDifferent = errors.New("differences found")
+
+// command is one pbo subcommand.
+type command struct {
+	run     func(ctx context.Context, env *cmdEnv, args []string) error
+	usage   string
+	summary string
+}
+
+// cmdEnv carries output streams and usage line for one command invocation.
+type cmdEnv struct {
+	stdout io.Writer
+	stderr io.Writer
+	usage  string
+}
+
+// commands maps subcommand name to implementation.
+var commands = map[string]command{
+	"list":        {run: runList, usage: "list [flags] <archive.pbo>", summary: "list headers and entries"},
+	"extract":     {run: runExtract, usage: "extract [flags] <archive.pbo> <dst-dir>", summary: "extract entries to directory"},
+	"pack":        {run: runPack, usage: "pack [flags] <src-dir> <archive.pbo>", summary: "pack directory into archive"},
+	"hash":        {run: runHash, usage: "hash [flags] <archive.pbo>", summary: "print signature hash set and entry digests"},
+	"sign-verify": {run: runSignVerify, usage: "sign-verify [flags] <archive.pbo>", summary: "verify SHA1 trailer, expected signature hashes, and bisign"},
+	"diff":        {run: runDiff, usage: "diff [flags] <a.pbo> <b.pbo>", summary: "compare headers and entry contents of two archives"},
+	"edit":        {run: runEdit, usage: "edit [flags] <archive.pbo>", summary: "add, replace, or delete entries in place"},
+	"export":      {run: runExport, usage: "export [flags] <archive.pbo> <out.pbo.gz>", summary: "write compressed container with listable index"},
+	"repack":      {run: runRepack, usage: "repack [flags] <archive.pbo>", summary: "rewrite archive sequentially dropping payload gaps"},
+	"serve":       {run: runServe, usage: "serve [flags] <archive.pbo>", summary: "serve archive entries over HTTP"},
+	"watch":       {run: runWatch, usage: "watch [flags] <src-dir> <archive.pbo>", summary: "rebuild archive when source directory changes"},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run dispatches args to subcommand and returns process exit code.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "pbo: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	env := &cmdEnv{stdout: stdout, stderr: stderr, usage: cmd.usage}
+	err := cmd.run(ctx, env, args[1:])
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errFlagParse):
+		return exitUsage
+	case errors.Is(err, errUsage):
+		_, _ = fmt.Fprintf(stderr, "pbo %s: %v\nusage: pbo %s\n", args[0], err, cmd.usage)
+		return exitUsage
+	case errors.Is(err, errDifferent):
+		return exitFailure
+	default:
+		_, _ = fmt.Fprintf(stderr, "pbo %s: %v\n", args[0], err)
+		return exitFailure
+	}
+}
+
+// printUsage writes command overview.
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintln(w, "usage: pbo <command> [flags] [args]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].summary)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "run 'pbo <command> -h' for command flags")
+}
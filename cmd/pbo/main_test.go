@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCLI runs command line and returns exit code with captured stdout.
+func runCLI(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	if code == exitUsage {
+		t.Logf("stderr: %s", stderr.String())
+	}
+
+	return code, stdout.String()
+}
+
+// writeCLITestTree writes files map under dir.
+func writeCLITestTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+}
+
+func TestRunPackListVerifyDiffEdit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	writeCLITestTree(t, src, map[string]string{
+		"config.cpp":        "class CfgPatches {};",
+		"scripts/init.sqf":  strings.Repeat("hint \"hello\";\n", 200),
+		"data/readme.txt":   "readme",
+		"data/notes/a.txt":  "notes",
+		"data/notes/b.txt":  "more notes",
+		"scripts/extra.sqf": "extra",
+	})
+
+	archive := filepath.Join(dir, "addon.pbo")
+	if code, out := runCLI(t, "pack", "-compress-ext", "sqf", "-header", "prefix=test", src, archive); code != exitOK {
+		t.Fatalf("pack exit=%d out=%q", code, out)
+	}
+
+	code, out := runCLI(t, "list", archive)
+	if code != exitOK {
+		t.Fatalf("list exit=%d", code)
+	}
+	if !strings.Contains(out, "prefix=test") || !strings.Contains(out, `scripts\init.sqf`) {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if code, out := runCLI(t, "sign-verify", archive); code != exitOK || !strings.Contains(out, "OK") {
+		t.Fatalf("sign-verify exit=%d out=%q", code, out)
+	}
+
+	if code, _ := runCLI(t, "sign-verify", "-hash1", strings.Repeat("00", 20), archive); code != exitFailure {
+		t.Fatalf("sign-verify mismatch exit=%d, want %d", code, exitFailure)
+	}
+
+	copyPath := filepath.Join(dir, "copy.pbo")
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := os.WriteFile(copyPath, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if code, out := runCLI(t, "diff", archive, copyPath); code != exitOK || out != "" {
+		t.Fatalf("diff identical exit=%d out=%q", code, out)
+	}
+
+	replacement := filepath.Join(dir, "readme.txt")
+	writeCLITestTree(t, dir, map[string]string{"readme.txt": "changed"})
+	code, out = runCLI(t, "edit",
+		"-replace", "data/readme.txt="+replacement,
+		"-delete-dir", "data/notes",
+		copyPath,
+	)
+	if code != exitOK {
+		t.Fatalf("edit exit=%d out=%q", code, out)
+	}
+
+	code, out = runCLI(t, "diff", archive, copyPath)
+	if code != exitFailure {
+		t.Fatalf("diff changed exit=%d, want %d", code, exitFailure)
+	}
+	for _, want := range []string{`~ data\readme.txt`, `- data\notes\a.txt`, `- data\notes\b.txt`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("diff output %q missing %q", out, want)
+		}
+	}
+
+	dst := filepath.Join(dir, "out")
+	if code, _ := runCLI(t, "extract", copyPath, dst); code != exitOK {
+		t.Fatalf("extract exit=%d", code)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "data", "readme.txt"))
+	if err != nil || string(got) != "changed" {
+		t.Fatalf("extracted readme=%q err=%v", got, err)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := [][]string{
+		nil,
+		{"unknown"},
+		{"list"},
+		{"list", "-no-such-flag", "a.pbo"},
+		{"list", "-offset-mode", "bogus", "a.pbo"},
+		{"pack", "-header", "novalue", "src", "out.pbo"},
+		{"extract", "-file-perm", "rw", "a.pbo", "out"},
+		{"extract", "-owner", "root", "a.pbo", "out"},
+		{"extract", "-objects", "-atomic", "a.pbo", "out"},
+	}
+
+	for _, args := range cases {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != exitUsage {
+			t.Fatalf("run(%q) exit=%d, want %d", args, code, exitUsage)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runPack packs source directory into archive with SHA1 trailer.
+func runPack(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "pack")
+	var pf packFlags
+	pf.register(fs)
+	verbose := fs.Bool("v", false, "print written entries")
+	extStats := fs.Bool("ext-stats", false, "print per-extension compression breakdown")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	opts, err := pf.options()
+	if err != nil {
+		return err
+	}
+
+	if *verbose {
+		opts.OnEntryDone = func(entry pbo.PackEntryProgress) {
+			_, _ = fmt.Fprintf(env.stdout, "%d\t%d\t%s\n", entry.DataSize, entry.OriginalSize, entry.Path)
+		}
+	}
+
+	res, err := pbo.PackDir(ctx, fs.Arg(0), fs.Arg(1), opts)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.stdout, "packed %d entries (%d compressed), data %d bytes, index %d bytes in %s\n",
+		res.WrittenEntries, res.CompressedEntries, res.DataSize, res.IndexSize, res.Duration)
+	for _, d := range res.EntryDigests {
+		_, _ = fmt.Fprintf(env.stdout, "%s  %s\n", d.Digest, d.Path)
+	}
+
+	if !*extStats {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "ENTRIES\tCOMPRESSED\tNOT_SMALLER\tORIGINAL\tPACKED\tRATIO\t EXT")
+	for _, e := range res.Extensions {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%.3f\t %s\n",
+			e.Entries, e.CompressedEntries, e.SkippedNotSmaller, e.OriginalBytes, e.PackedBytes, e.Ratio, e.Extension)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runRepack rewrites archive sequentially and reports reclaimed bytes.
+func runRepack(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "repack")
+	offsetMode := fs.String("offset-mode", string(pbo.OffsetModeStoredCompat), "source offset mode: sequential, stored_compat, stored_strict")
+	var bf backupFlags
+	bf.register(fs)
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	editOpts := pbo.EditOptions{
+		ReaderOptions: pbo.ReaderOptions{OffsetMode: pbo.OffsetMode(*offsetMode)},
+	}
+	bf.apply(&editOpts)
+
+	res, err := pbo.Repack(ctx, fs.Arg(0), editOpts)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(env.stdout, "%d -> %d bytes, reclaimed %d\n", res.SizeBefore, res.SizeAfter, res.ReclaimedBytes)
+	return nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runServe serves archive entries over HTTP until interrupted.
+func runServe(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "serve")
+	var rf readerFlags
+	rf.register(fs)
+	addr := fs.String("addr", "127.0.0.1:8080", "listen address")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	opts, err := rf.options(path)
+	if err != nil {
+		return err
+	}
+
+	r, err := pbo.OpenWithOptions(path, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Handler: pbo.FileServer(r), ReadHeaderTimeout: 10 * time.Second}
+	stop := context.AfterFunc(ctx, func() { _ = srv.Close() })
+	defer stop()
+
+	_, _ = fmt.Fprintf(env.stdout, "serving %s on http://%s/\n", path, ln.Addr())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
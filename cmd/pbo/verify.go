@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runSignVerify checks SHA1 trailer, compares signature hashes with expected values,
+// and optionally verifies .bisign against server keys.
+func runSignVerify(_ context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "sign-verify")
+	var sf signFlags
+	sf.register(fs)
+	var expected [3]string
+	fs.StringVar(&expected[0], "hash1", "", "expected hash1 hex")
+	fs.StringVar(&expected[1], "hash2", "", "expected hash2 hex")
+	fs.StringVar(&expected[2], "hash3", "", "expected hash3 hex")
+	bisign := fs.String("bisign", "", "verify .bisign signature against -keys directory")
+	keysDir := fs.String("keys", "", "directory with .bikey files for -bisign")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	trailer, err := pbo.VerifySHA1Trailer(path)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(env.stdout, "trailer %x OK\n", trailer)
+
+	hs, err := sf.hashSet(path)
+	if err != nil {
+		return err
+	}
+
+	mismatch := false
+	for i, actual := range [3][20]byte{hs.Hash1, hs.Hash2, hs.Hash3} {
+		if expected[i] == "" {
+			continue
+		}
+
+		want, err := hex.DecodeString(expected[i])
+		if err != nil || len(want) != len(actual) {
+			return fmt.Errorf("%w: -hash%d must be 40 hex chars", errUsage, i+1)
+		}
+
+		status := "OK"
+		if !bytes.Equal(want, actual[:]) {
+			status = "MISMATCH"
+			mismatch = true
+		}
+
+		_, _ = fmt.Fprintf(env.stdout, "hash%d %x %s\n", i+1, actual, status)
+	}
+
+	if *bisign != "" {
+		if *keysDir == "" {
+			return fmt.Errorf("%w: -bisign requires -keys", errUsage)
+		}
+
+		keyPath, err := pbo.VerifyAgainstKeys(path, *bisign, *keysDir)
+		switch {
+		case errors.Is(err, pbo.ErrNoMatchingKey):
+			_, _ = fmt.Fprintf(env.stdout, "bisign MISMATCH: %v\n", err)
+			mismatch = true
+			if err := printBisignMismatch(env, path, *bisign, pbo.GameType(sf.game)); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			_, _ = fmt.Fprintf(env.stdout, "bisign OK %s\n", keyPath)
+		}
+	}
+
+	if mismatch {
+		return errDifferent
+	}
+
+	return nil
+}
+
+// printBisignMismatch prints which signature hash components differ from bisign.
+func printBisignMismatch(env *cmdEnv, path string, bisign string, gameType pbo.GameType) error {
+	report, err := pbo.ExplainBisignMismatch(path, bisign, gameType)
+	if err != nil {
+		return err
+	}
+
+	if report.OK() {
+		_, _ = fmt.Fprintln(env.stdout, "  archive hashes match bisign; signer key is not in keys directory")
+		return nil
+	}
+
+	for _, note := range report.Notes {
+		_, _ = fmt.Fprintf(env.stdout, "  %s\n", note)
+	}
+	for _, name := range report.Details.FileHashEntries {
+		_, _ = fmt.Fprintf(env.stdout, "  filehash-entry %s\n", name)
+	}
+
+	return nil
+}
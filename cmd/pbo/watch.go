@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/woozymasta/pbo"
+)
+
+// runWatch rebuilds archive from source directory on every change until interrupted.
+func runWatch(ctx context.Context, env *cmdEnv, args []string) error {
+	fs := newFlagSet(env, "watch")
+	var pf packFlags
+	pf.register(fs)
+	interval := fs.Duration("interval", 0, "source tree poll interval (default 500ms)")
+	debounce := fs.Duration("debounce", 0, "quiet period before rebuild (default 300ms)")
+	if err := parseFlags(fs, args, 2); err != nil {
+		return err
+	}
+
+	packOpts, err := pf.options()
+	if err != nil {
+		return err
+	}
+
+	w, err := pbo.NewWatcher(fs.Arg(0), fs.Arg(1), pbo.WatchOptions{
+		EditOptions:  pbo.EditOptions{PackOptions: packOpts},
+		PollInterval: *interval,
+		Debounce:     *debounce,
+		OnCommit: func(e pbo.WatchEvent) {
+			mode := "updated"
+			if e.Full {
+				mode = "packed"
+			}
+			_, _ = fmt.Fprintf(env.stdout, "%s %s: %d added, %d changed, %d removed in %s\n",
+				mode, fs.Arg(1), len(e.Added), len(e.Changed), len(e.Removed), e.Duration)
+		},
+		OnError: func(err error) {
+			_, _ = fmt.Fprintf(env.stderr, "pbo watch: %v\n", err)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Run(ctx)
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+var (
+	// entryCodecsMu guards entryCodecs registry.
+	entryCodecsMu sync.RWMutex
+	// entryCodecs maps stored mime marker to registered payload decoder.
+	entryCodecs = make(map[MimeType]EntryCodec)
+)
+
+// EntryCodec decodes stored payload of entries with custom mime marker (for example MimeEncoded).
+type EntryCodec interface {
+	// Decode returns decoded content stream for one stored payload.
+	// key is ReaderOptions.EntryKey of reader that opened the entry.
+	Decode(payload io.Reader, info EntryInfo, key []byte) (io.Reader, error)
+}
+
+// EntryCodecFunc adapts plain function to EntryCodec.
+type EntryCodecFunc func(payload io.Reader, info EntryInfo, key []byte) (io.Reader, error)
+
+// Decode calls f(payload, info, key).
+func (f EntryCodecFunc) Decode(payload io.Reader, info EntryInfo, key []byte) (io.Reader, error) {
+	return f(payload, info, key)
+}
+
+// RegisterEntryCodec registers process-wide decoder for entries stored with mime marker.
+// Raw, compressed, and header markers are handled by package and cannot be overridden.
+func RegisterEntryCodec(mime MimeType, codec EntryCodec) error {
+	switch mime {
+	case MimeNil, MimeCompress, MimeHeader:
+		return fmt.Errorf("%w: mime 0x%08x is reserved", ErrInvalidEntryCodec, uint32(mime))
+	}
+
+	if codec == nil {
+		return fmt.Errorf("%w: codec is nil", ErrInvalidEntryCodec)
+	}
+
+	entryCodecsMu.Lock()
+	entryCodecs[mime] = codec
+	entryCodecsMu.Unlock()
+
+	return nil
+}
+
+// UnregisterEntryCodec removes decoder registered for mime marker.
+func UnregisterEntryCodec(mime MimeType) {
+	entryCodecsMu.Lock()
+	delete(entryCodecs, mime)
+	entryCodecsMu.Unlock()
+}
+
+// lookupEntryCodec returns registered decoder for mime marker or nil.
+func lookupEntryCodec(mime MimeType) EntryCodec {
+	entryCodecsMu.RLock()
+	defer entryCodecsMu.RUnlock()
+
+	return entryCodecs[mime]
+}
+
+// openCodecEntry decodes payload through registered codec; ok is false when no codec applies.
+func (r *Reader) openCodecEntry(info *EntryInfo, name string, payload io.Reader) (io.ReadCloser, bool, error) {
+	if info.MimeType == MimeNil || info.MimeType == MimeCompress {
+		return nil, false, nil
+	}
+
+	codec := lookupEntryCodec(info.MimeType)
+	if codec == nil {
+		if info.MimeType == MimeEncoded {
+			return nil, true, fmt.Errorf("%w: %s", ErrEntryCodecNotFound, name)
+		}
+
+		return nil, false, nil
+	}
+
+	decoded, err := codec.Decode(payload, *info, r.entryKey)
+	if err != nil {
+		return nil, true, fmt.Errorf("decode entry %s: %w", name, err)
+	}
+
+	if rc, ok := decoded.(io.ReadCloser); ok {
+		return rc, true, nil
+	}
+
+	return nopCloser{Reader: decoded}, true, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// xorTestCodec decodes payload by XOR with repeating key.
+func xorTestCodec(payload io.Reader, _ EntryInfo, key []byte) (io.Reader, error) {
+	if len(key) == 0 {
+		return nil, errors.New("key is required")
+	}
+
+	data, err := io.ReadAll(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range data {
+		data[i] ^= key[i%len(key)]
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+func TestEntryCodec_DecodesEncodedEntries(t *testing.T) {
+	// Not parallel: codec registry is process-wide.
+	key := []byte{0x5a, 0xa5}
+	plain := []byte("class CfgPatches {};")
+	encoded := make([]byte, len(plain))
+	for i := range plain {
+		encoded[i] = plain[i] ^ key[i%len(key)]
+	}
+
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: "plain.txt", data: []byte("plain")},
+		{name: "config.cpp", data: encoded, mime: MimeEncoded, originalSize: uint32(len(plain))}, //nolint:gosec // small
+	})
+
+	r, err := OpenWithOptions(path, ReaderOptions{EntryKey: key})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if _, err := r.ReadEntry("config.cpp"); !errors.Is(err, ErrEntryCodecNotFound) {
+		t.Fatalf("expected ErrEntryCodecNotFound, got %v", err)
+	}
+
+	if err := RegisterEntryCodec(MimeCompress, EntryCodecFunc(xorTestCodec)); !errors.Is(err, ErrInvalidEntryCodec) {
+		t.Fatalf("expected ErrInvalidEntryCodec for reserved mime, got %v", err)
+	}
+
+	if err := RegisterEntryCodec(MimeEncoded, EntryCodecFunc(xorTestCodec)); err != nil {
+		t.Fatalf("RegisterEntryCodec: %v", err)
+	}
+	t.Cleanup(func() { UnregisterEntryCodec(MimeEncoded) })
+
+	got, err := r.ReadEntry("config.cpp")
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if string(got) != string(plain) {
+		t.Fatalf("decoded=%q, want %q", got, plain)
+	}
+
+	raw, err := r.ReadEntry("plain.txt")
+	if err != nil || string(raw) != "plain" {
+		t.Fatalf("plain entry=%q err=%v", raw, err)
+	}
+}
+
+func TestEntryCodec_MaxEntryDecompressedSize(t *testing.T) {
+	// Not parallel: codec registry is process-wide.
+	const expandMime MimeType = 0x7e570001
+	expand := func(payload io.Reader, _ EntryInfo, _ []byte) (io.Reader, error) {
+		data, err := io.ReadAll(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.NewReader(bytes.Repeat(data, 1000)), nil
+	}
+	if err := RegisterEntryCodec(expandMime, EntryCodecFunc(expand)); err != nil {
+		t.Fatalf("RegisterEntryCodec: %v", err)
+	}
+	t.Cleanup(func() { UnregisterEntryCodec(expandMime) })
+
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: "liar.bin", data: []byte("boom"), mime: expandMime},
+		{name: "honest.bin", data: []byte("boom"), mime: expandMime, originalSize: 4000},
+	})
+
+	r, err := OpenWithOptions(path, ReaderOptions{MaxEntryDecompressedSize: 100})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	got, err := r.ReadEntry("liar.bin")
+	if !errors.Is(err, ErrEntryTooLarge) || len(got) != 100 {
+		t.Fatalf("liar.bin len=%d err=%v, want limit error after 100 bytes", len(got), err)
+	}
+
+	if _, err := r.OpenEntry("honest.bin"); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("honest.bin err=%v, want ErrEntryTooLarge before decode", err)
+	}
+}
@@ -6,6 +6,7 @@ package pbo
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/woozymasta/lzss"
 	"github.com/woozymasta/pathrules"
@@ -85,6 +86,49 @@ func shouldCompressBySize(opts PackOptions, size uint32) bool {
 	return true
 }
 
+// Compressor compresses one whole entry payload stored with MimeCompress marker.
+// Readers decode such payloads as LZSS, so non-LZSS output is only readable by matching tooling.
+type Compressor interface {
+	// Compress returns compressed form of data. Output not smaller than data is stored raw.
+	Compress(data []byte) ([]byte, error)
+}
+
+// CompressorFunc adapts plain function to Compressor.
+type CompressorFunc func(data []byte) ([]byte, error)
+
+// Compress calls f(data).
+func (f CompressorFunc) Compress(data []byte) ([]byte, error) {
+	return f(data)
+}
+
+// LZSSCompressor is default Compressor producing game-compatible LZSS payloads.
+type LZSSCompressor struct {
+	// Options tune LZSS encoder. Nil means lzss.DefaultCompressOptions.
+	Options *lzss.CompressOptions
+}
+
+// Compress compresses data using LZSS.
+func (c LZSSCompressor) Compress(data []byte) ([]byte, error) {
+	if c.Options == nil {
+		return compressLZSS(data)
+	}
+
+	return lzss.Compress(data, c.Options)
+}
+
+// StreamCompressor is Compressor that can also compress unbounded streams with bounded memory.
+// It enables spooled compression of large entries (see PackOptions.SpoolCompressSize).
+type StreamCompressor interface {
+	Compressor
+	// CompressStream compresses src into dst and returns consumed and written byte counts.
+	CompressStream(dst io.Writer, src io.Reader) (int64, int64, error)
+}
+
+// CompressStream compresses src into dst using LZSS with bounded memory.
+func (c LZSSCompressor) CompressStream(dst io.Writer, src io.Reader) (int64, int64, error) {
+	return lzss.CompressToWriter(dst, src, c.Options)
+}
+
 // compressLZSS compresses the data using LZSS.
 func compressLZSS(data []byte) ([]byte, error) {
 	return lzss.Compress(data, lzss.DefaultCompressOptions())
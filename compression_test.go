@@ -222,3 +222,206 @@ func TestPack_CompressUnknownSizeHintBranch(t *testing.T) {
 		t.Fatalf("payload mismatch after roundtrip")
 	}
 }
+
+func TestPack_CustomCompressor(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "out.pbo")
+	payload := bytes.Repeat([]byte("abcdef"), 256)
+	calls := 0
+	opts := PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+		Compressor: CompressorFunc(func(data []byte) ([]byte, error) {
+			calls++
+			return LZSSCompressor{}.Compress(data)
+		}),
+	}
+
+	res, err := PackFile(t.Context(), outPath, streamTestInputs(map[string][]byte{"a.txt": payload}), opts)
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if calls != 1 || res.CompressedEntries != 1 {
+		t.Fatalf("calls=%d compressed=%d", calls, res.CompressedEntries)
+	}
+
+	got, err := readEntryFromFile(outPath, "a.txt")
+	if err != nil {
+		t.Fatalf("readEntryFromFile: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatal("payload mismatch after roundtrip")
+	}
+}
+
+func TestPack_PreCompressedInput(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("precompressed "), 128)
+	compressed, err := compressLZSS(payload)
+	if err != nil {
+		t.Fatalf("compressLZSS: %v", err)
+	}
+
+	input := Input{
+		Path:         "data/cfg.bin",
+		SizeHint:     int64(len(compressed)),
+		OriginalSize: uint32(len(payload)), //nolint:gosec // small test payload
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(compressed)), nil
+		},
+	}
+
+	outPath := filepath.Join(t.TempDir(), "out.pbo")
+	if _, err := PackFile(t.Context(), outPath, []Input{input}, PackOptions{}); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	var streamed memoryBuffer
+	res, err := PackToWriter(t.Context(), &streamed, []Input{input}, PackOptions{StreamMode: PackStreamModeSizeHint})
+	if err != nil {
+		t.Fatalf("PackToWriter: %v", err)
+	}
+	if res.CompressedEntries != 1 {
+		t.Fatalf("stream compressed entries=%d, want 1", res.CompressedEntries)
+	}
+
+	fileData, err := readEntryFromFile(outPath, "data/cfg.bin")
+	if err != nil {
+		t.Fatalf("readEntryFromFile: %v", err)
+	}
+
+	r, err := NewReaderFromBytes(streamed.data, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("NewReaderFromBytes: %v", err)
+	}
+	streamData, err := r.ReadEntry("data/cfg.bin")
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+
+	for _, got := range [][]byte{fileData, streamData} {
+		if !bytes.Equal(got, payload) {
+			t.Fatal("pre-compressed payload mismatch after roundtrip")
+		}
+	}
+
+	entries := r.Entries()
+	if len(entries) != 1 || entries[0].MimeType != MimeCompress || entries[0].DataSize != uint32(len(compressed)) { //nolint:gosec // small
+		t.Fatalf("entries=%+v", entries)
+	}
+}
+
+func TestPack_CompressOptionsTuneLZSS(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("texture block "), 512)
+	pack := func(opts *lzss.CompressOptions) (*PackResult, []byte) {
+		t.Helper()
+
+		outPath := filepath.Join(t.TempDir(), "out.pbo")
+		res, err := PackFile(t.Context(), outPath, streamTestInputs(map[string][]byte{"a.txt": payload}), PackOptions{
+			Compress:        includeRules("*.txt"),
+			MinCompressSize: 1,
+			CompressOptions: opts,
+		})
+		if err != nil {
+			t.Fatalf("PackFile: %v", err)
+		}
+
+		got, err := readEntryFromFile(outPath, "a.txt")
+		if err != nil {
+			t.Fatalf("readEntryFromFile: %v", err)
+		}
+
+		return res, got
+	}
+
+	fast, fastData := pack(&lzss.CompressOptions{Checksum: lzss.ChecksumUnsigned, SearchLimit: 64})
+	def, defData := pack(nil)
+	literal, literalData := pack(&lzss.CompressOptions{Checksum: lzss.ChecksumUnsigned})
+
+	for _, got := range [][]byte{fastData, defData, literalData} {
+		if !bytes.Equal(got, payload) {
+			t.Fatal("payload mismatch after roundtrip")
+		}
+	}
+
+	if fast.CompressedEntries != 1 || def.CompressedEntries != 1 {
+		t.Fatalf("fast=%+v default=%+v", fast, def)
+	}
+	if literal.CompressedEntries != 0 || literal.SkippedCompressionEntries != 1 {
+		t.Fatalf("literals-only result=%+v, want raw fallback", literal)
+	}
+}
+
+func TestPack_SpoolCompressLargeKnownSize(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("large texture payload "), 4096)
+	spoolDir := t.TempDir()
+	outPath := filepath.Join(t.TempDir(), "out.pbo")
+	opts := PackOptions{
+		Compress:          includeRules("*.paa"),
+		MinCompressSize:   1,
+		MaxCompressSize:   1024,
+		SpoolCompressSize: uint32(len(payload)), //nolint:gosec // small test payload
+		SpoolDir:          spoolDir,
+	}
+
+	res, err := PackFile(t.Context(), outPath, streamTestInputs(map[string][]byte{"big.paa": payload}), opts)
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if res.CompressedEntries != 1 {
+		t.Fatalf("compressed entries=%d, want 1", res.CompressedEntries)
+	}
+
+	got, err := readEntryFromFile(outPath, "big.paa")
+	if err != nil {
+		t.Fatalf("readEntryFromFile: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatal("payload mismatch after roundtrip")
+	}
+
+	leftovers, err := filepath.Glob(filepath.Join(spoolDir, "*"))
+	if err != nil {
+		t.Fatalf("glob spool dir: %v", err)
+	}
+	if len(leftovers) != 0 {
+		t.Fatalf("spool files left: %v", leftovers)
+	}
+}
+
+func TestPack_SpoolCompressFallsBackToRaw(t *testing.T) {
+	t.Parallel()
+
+	payload := make([]byte, 8192)
+	rand.New(rand.NewSource(7)).Read(payload)
+	outPath := filepath.Join(t.TempDir(), "out.pbo")
+	opts := PackOptions{
+		Compress:          includeRules("*.bin"),
+		MinCompressSize:   1,
+		MaxCompressSize:   1024,
+		SpoolCompressSize: 1 << 20,
+		SpoolDir:          t.TempDir(),
+	}
+
+	res, err := PackFile(t.Context(), outPath, streamTestInputs(map[string][]byte{"noise.bin": payload}), opts)
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if res.CompressedEntries != 0 || res.SkippedCompressionEntries != 1 {
+		t.Fatalf("result=%+v, want raw fallback", res)
+	}
+
+	got, err := readEntryFromFile(outPath, "noise.bin")
+	if err != nil {
+		t.Fatalf("readEntryFromFile: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatal("payload mismatch after roundtrip")
+	}
+}
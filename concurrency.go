@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/woozymasta/lzss"
+)
+
+// newDecompressSem creates background decompression slot semaphore; zero limit means GOMAXPROCS.
+func newDecompressSem(limit int) chan struct{} {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+
+	return make(chan struct{}, limit)
+}
+
+// tryAcquireDecompress takes one background decompression slot without blocking.
+// Readers without semaphore (internal temporary readers) always get a slot.
+func (r *Reader) tryAcquireDecompress() bool {
+	if r.decompressSem == nil {
+		return true
+	}
+
+	select {
+	case r.decompressSem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseDecompress returns slot taken by tryAcquireDecompress.
+func (r *Reader) releaseDecompress() {
+	if r.decompressSem != nil {
+		<-r.decompressSem
+	}
+}
+
+// errInlineStopped aborts inline decoder coroutine after Close.
+var errInlineStopped = errors.New("inline decompression stopped")
+
+// inlineDecompressReader decodes compressed entry in caller goroutine while it reads.
+// It is used when all background decompression slots are busy: lzss.DecompressToWriter
+// runs as pull coroutine that advances only inside Read, one decoded chunk at a time,
+// so memory stays bounded by LZSS window and chunk buffers.
+type inlineDecompressReader struct {
+	next    func() ([]byte, bool)
+	stop    func()
+	err     error
+	pending []byte
+	mu      sync.Mutex
+	// closeReq defers Close to in-flight Read; coroutine must not be stopped concurrently.
+	closeReq atomic.Bool
+	finished bool
+	closed   bool
+}
+
+// newInlineDecompressReader returns reader decoding outLen bytes of LZSS payload src.
+func newInlineDecompressReader(src io.Reader, name string, outLen int) *inlineDecompressReader {
+	d := &inlineDecompressReader{}
+	d.next, d.stop = iter.Pull(func(yield func([]byte) bool) {
+		_, err := lzss.DecompressToWriter(yieldWriter(yield), src, outLen, nil)
+		switch {
+		case err == nil:
+			d.err = io.EOF
+		case errors.Is(err, errInlineStopped):
+			d.err = ErrClosed
+		default:
+			d.err = fmt.Errorf("decompress entry %s: %w", name, err)
+		}
+	})
+
+	return d
+}
+
+// yieldWriter passes decoded chunks to pull coroutine consumer.
+type yieldWriter func([]byte) bool
+
+// Write hands p to consumer; p is valid until consumer pulls next chunk.
+func (w yieldWriter) Write(p []byte) (int, error) {
+	if !w(p) {
+		return 0, errInlineStopped
+	}
+
+	return len(p), nil
+}
+
+// Read copies decoded bytes into p, decoding next chunk when none are pending.
+func (d *inlineDecompressReader) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	n, err := d.readLocked(p)
+	d.mu.Unlock()
+
+	if d.closeReq.Load() {
+		d.tryClose()
+	}
+
+	return n, err
+}
+
+// readLocked implements Read under d.mu.
+func (d *inlineDecompressReader) readLocked(p []byte) (int, error) {
+	if d.closed {
+		return 0, ErrClosed
+	}
+
+	for len(d.pending) == 0 {
+		if d.finished {
+			return 0, d.err
+		}
+
+		chunk, ok := d.next()
+		if !ok {
+			d.finished = true
+			continue
+		}
+		d.pending = chunk
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+
+	return n, nil
+}
+
+// Close stops decoder. Close during Read is deferred until that Read returns.
+func (d *inlineDecompressReader) Close() error {
+	d.closeReq.Store(true)
+	d.tryClose()
+
+	return nil
+}
+
+// tryClose stops decoder coroutine unless Read holds it.
+func (d *inlineDecompressReader) tryClose() {
+	if !d.mu.TryLock() {
+		return
+	}
+	defer d.mu.Unlock()
+
+	if !d.closed {
+		d.closed = true
+		d.pending = nil
+		d.stop()
+	}
+}
+
+// Clone returns Reader sharing parsed metadata with r and using its own source handle.
+// Readers created by Open/OpenWithOptions reopen the archive file; other readers, including
+// ones over ArchiveDecryptor view, share underlying io.ReaderAt. Clone is closed independently of r, has its own
+// Limits.MaxTotalDecompressed budget, and shares background decompression slots with r.
+func (r *Reader) Clone() (*Reader, error) {
+	if r == nil || r.ra == nil {
+		return nil, ErrNilReader
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	c := r.shareParsed()
+	if r.file == nil || r.decrypted {
+		return c, nil
+	}
+
+	f, err := os.Open(r.file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("open PBO: %w", err)
+	}
+
+	c.file = f
+	c.ra = f
+	if sealed, ok := r.ra.(*sealedReaderAt); ok {
+		s := *sealed
+		s.source = f
+		c.ra = &s
+	}
+
+	return c, nil
+}
+
+// shareParsed returns Reader sharing parsed metadata, source, and decompression slots
+// with r; entries must be loaded. Caller replaces source handle when needed.
+func (r *Reader) shareParsed() *Reader {
+	r.entryIndexOnce.Do(r.buildEntryIndex)
+	c := &Reader{
+		ra:              r.ra,
+		entryIndex:      r.entryIndex,
+		header:          r.header,
+		headers:         r.headers,
+		entries:         r.entries,
+		size:            r.size,
+		dataStart:       r.dataStart,
+		entryKey:        r.entryKey,
+		decompressSem:   r.decompressSem,
+		maxTotal:        r.maxTotal,
+		maxDecompressed: r.maxDecompressed,
+		recovery:        r.recovery,
+		hidden:          r.hidden,
+		diagnostics:     r.diagnostics,
+		sha1Trailer:     r.sha1Trailer,
+		hasTrailer:      r.hasTrailer,
+		data:            r.data,
+	}
+	c.entryIndexOnce.Do(func() {})
+
+	return c
+}
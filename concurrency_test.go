@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/woozymasta/lzss"
+)
+
+func TestOpenEntry_DecompressSlotsBounded(t *testing.T) {
+	t.Parallel()
+
+	a := bytes.Repeat([]byte("alpha "), 4096)
+	b := bytes.Repeat([]byte("bravo "), 4096)
+	pboPath := filepath.Join(t.TempDir(), "slots.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": a,
+		"b.txt": b,
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{MaxDecompressStreams: 1})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	rcA, err := r.OpenEntry("a.txt")
+	if err != nil {
+		t.Fatalf("OpenEntry a.txt: %v", err)
+	}
+	rcB, err := r.OpenEntry("b.txt")
+	if err != nil {
+		t.Fatalf("OpenEntry b.txt: %v", err)
+	}
+	if _, ok := rcB.(*inlineDecompressReader); !ok {
+		t.Fatalf("second stream type %T, want inline decoder while slot is busy", rcB)
+	}
+
+	// Read second stream first: inline decoding must not wait for busy slot.
+	gotB, err := io.ReadAll(rcB)
+	if err != nil || !bytes.Equal(gotB, b) {
+		t.Fatalf("read b.txt err=%v equal=%v", err, bytes.Equal(gotB, b))
+	}
+	gotA, err := io.ReadAll(rcA)
+	if err != nil || !bytes.Equal(gotA, a) {
+		t.Fatalf("read a.txt err=%v equal=%v", err, bytes.Equal(gotA, a))
+	}
+	_ = rcA.Close()
+	_ = rcB.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := range 16 {
+		wg.Go(func() {
+			name, want := "a.txt", a
+			if i%2 == 1 {
+				name, want = "b.txt", b
+			}
+
+			got, err := r.ReadEntry(name)
+			if err != nil || !bytes.Equal(got, want) {
+				errs <- fmt.Errorf("ReadEntry %s err=%v equal=%v", name, err, bytes.Equal(got, want))
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestReader_Clone(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "clone.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":  []byte("class CfgPatches {};"),
+		"scripts.txt": bytes.Repeat([]byte("x"), 1024),
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	c, err := r.Clone()
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if c.file == nil || c.file == r.file {
+		t.Fatal("clone must own separate file handle")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got, err := c.ReadEntry("scripts.txt")
+	if err != nil || !bytes.Equal(got, bytes.Repeat([]byte("x"), 1024)) {
+		t.Fatalf("clone ReadEntry after source close err=%v", err)
+	}
+	if len(c.Entries()) != 2 {
+		t.Fatalf("clone entries=%d, want 2", len(c.Entries()))
+	}
+
+	if _, err := r.Clone(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Clone of closed reader err=%v, want ErrClosed", err)
+	}
+}
+
+func TestInlineDecompressReader(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewPCG(3820, 1))
+	random := make([]byte, 50000)
+	for i := range random {
+		random[i] = byte(rng.Uint32())
+	}
+	compressible := bytes.Repeat([]byte("inline decoder keeps memory bounded; "), 2048)
+	compressible = append(compressible, []byte("tail without repeats 0123456789")...)
+
+	payloads := map[string][]byte{
+		"short":        []byte("ab"),
+		"random":       random,
+		"compressible": compressible,
+		"zeros":        make([]byte, 70000),
+	}
+	for name, data := range payloads {
+		packed, err := lzss.Compress(data, nil)
+		if err != nil {
+			t.Fatalf("%s: Compress: %v", name, err)
+		}
+
+		inputs := map[string][]byte{
+			"valid":     packed,
+			"corrupt":   flipByte(packed, len(packed)-1),
+			"mangled":   flipByte(packed, len(packed)/2),
+			"truncated": packed[:len(packed)/2],
+			"no_sum":    packed[:max(len(packed)-4, 0)],
+		}
+		for kind, input := range inputs {
+			var want bytes.Buffer
+			_, wantErr := lzss.DecompressToWriter(&want, bytes.NewReader(input), len(data), nil)
+
+			for _, chunk := range []int{1, 7, 4096, 32<<10 + 1, len(data) + 100} {
+				d := newInlineDecompressReader(iotest.HalfReader(bytes.NewReader(input)), "a.txt", len(data))
+				got, err := readAllChunked(d, chunk)
+				_ = d.Close()
+
+				if (err == nil) != (wantErr == nil) {
+					t.Fatalf("%s/%s chunk %d: err=%v, lzss err=%v", name, kind, chunk, err, wantErr)
+				}
+				if wantErr != nil {
+					if !strings.Contains(err.Error(), wantErr.Error()) {
+						t.Fatalf("%s/%s chunk %d: err=%v, lzss err=%v", name, kind, chunk, err, wantErr)
+					}
+					continue
+				}
+				if !bytes.Equal(got, want.Bytes()) || !bytes.Equal(got, data) {
+					t.Fatalf("%s/%s chunk %d: decoded %d bytes, differs from lzss", name, kind, chunk, len(got))
+				}
+			}
+		}
+	}
+}
+
+func TestInlineDecompressReader_Close(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("close inline decoder mid stream "), 8192)
+	packed, err := lzss.Compress(data, nil)
+	if err != nil {
+		t.Fatalf("Compress: %v", err)
+	}
+
+	d := newInlineDecompressReader(bytes.NewReader(packed), "a.txt", len(data))
+	if _, err := d.Read(make([]byte, 10)); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := d.Read(make([]byte, 10)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Read after Close err=%v, want ErrClosed", err)
+	}
+
+	// Close racing with Read is deferred to Read and never stops decoder concurrently.
+	for range 16 {
+		d := newInlineDecompressReader(bytes.NewReader(packed), "a.txt", len(data))
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			buf := make([]byte, 1000)
+			for {
+				if _, err := d.Read(buf); err != nil {
+					return
+				}
+			}
+		})
+		_ = d.Close()
+		wg.Wait()
+	}
+}
+
+// flipByte returns copy of data with byte at i inverted.
+func flipByte(data []byte, i int) []byte {
+	out := bytes.Clone(data)
+	if i >= 0 && i < len(out) {
+		out[i] ^= 0xFF
+	}
+
+	return out
+}
+
+// readAllChunked reads r to EOF with reads of at most chunk bytes.
+func readAllChunked(r io.Reader, chunk int) ([]byte, error) {
+	var out []byte
+	buf := make([]byte, chunk)
+	for {
+		n, err := r.Read(buf)
+		out = append(out, buf[:n]...)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+	}
+}
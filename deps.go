@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AddonPatch is one CfgPatches class declared by archive config.
+type AddonPatch struct {
+	// Name is CfgPatches class name other addons list in requiredAddons.
+	Name string `json:"name" yaml:"name"`
+	// Config is entry path of declaring config.cpp or config.bin.
+	Config string `json:"config" yaml:"config"`
+	// RequiredAddons lists requiredAddons[] values in declaration order.
+	RequiredAddons []string `json:"required_addons,omitempty" yaml:"required_addons,omitempty"`
+}
+
+// AddonNode is one addon of BankSet dependency graph.
+type AddonNode struct {
+	// Name is CfgPatches class name.
+	Name string `json:"name" yaml:"name"`
+	// Archive is path of archive declaring the addon.
+	Archive string `json:"archive" yaml:"archive"`
+	// RequiredAddons lists requiredAddons[] values in declaration order.
+	RequiredAddons []string `json:"required_addons,omitempty" yaml:"required_addons,omitempty"`
+}
+
+// MissingAddon is required addon not declared by any archive of BankSet.
+type MissingAddon struct {
+	// Name is required addon name.
+	Name string `json:"name" yaml:"name"`
+	// RequiredBy lists addons requiring it, sorted.
+	RequiredBy []string `json:"required_by" yaml:"required_by"`
+}
+
+// DuplicateAddon is addon declared by more than one archive of BankSet.
+type DuplicateAddon struct {
+	// Name is addon name.
+	Name string `json:"name" yaml:"name"`
+	// Archives lists declaring archive paths in load order.
+	Archives []string `json:"archives" yaml:"archives"`
+}
+
+// DependencyGraph is requiredAddons graph of BankSet archives.
+type DependencyGraph struct {
+	// Addons lists declared addons in archive load order.
+	Addons []AddonNode `json:"addons,omitempty" yaml:"addons,omitempty"`
+	// Missing lists required addons without declaration, sorted by name.
+	Missing []MissingAddon `json:"missing,omitempty" yaml:"missing,omitempty"`
+	// Duplicates lists addons declared by several archives, sorted by name.
+	Duplicates []DuplicateAddon `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+}
+
+// Dependencies opens archive at path and returns addons required by its CfgPatches
+// classes, deduplicated case-insensitively in declaration order. Addons declared by
+// the archive itself are omitted.
+func Dependencies(path string) ([]string, error) {
+	r, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	patches, err := r.CfgPatches()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(patches))
+	for _, patch := range patches {
+		seen[strings.ToLower(patch.Name)] = struct{}{}
+	}
+
+	var out []string
+	for _, patch := range patches {
+		for _, addon := range patch.RequiredAddons {
+			key := strings.ToLower(addon)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			out = append(out, addon)
+		}
+	}
+
+	return out, nil
+}
+
+// CfgPatches parses CfgPatches classes of config.cpp and binarized config.bin entries.
+// Only top-level CfgPatches is read; preprocessor directives are not evaluated.
+// Patch names repeated by several configs of archive keep first declaration.
+func (r *Reader) CfgPatches() ([]AddonPatch, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	var patches []AddonPatch
+	seen := make(map[string]struct{})
+	for _, entry := range r.entries {
+		base := strings.ToLower(path.Base(NormalizePath(entry.Path)))
+		if base != "config.cpp" && base != "config.bin" {
+			continue
+		}
+
+		found, err := r.entryCfgPatches(entry)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", entry.Path, err)
+		}
+
+		for _, patch := range found {
+			key := strings.ToLower(patch.Name)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			patch.Config = entry.Path
+			patches = append(patches, patch)
+		}
+	}
+
+	return patches, nil
+}
+
+// entryCfgPatches reads one config entry and parses it as raP or text config.
+func (r *Reader) entryCfgPatches(entry EntryInfo) ([]AddonPatch, error) {
+	if int64(filterOriginalSizeOrDataSize(entry)) > DefaultSearchMaxEntrySize {
+		return nil, fmt.Errorf("%w: config larger than %d bytes", ErrInvalidConfig, DefaultSearchMaxEntrySize)
+	}
+
+	rc, err := r.OpenEntryInfo(entry)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, rapSignature) {
+		return rapCfgPatches(data)
+	}
+
+	return textCfgPatches(data), nil
+}
+
+// textCfgPatches returns CfgPatches classes of text config. Parsing is token based and
+// tolerant: unknown syntax is skipped rather than reported.
+func textCfgPatches(data []byte) []AddonPatch {
+	tokens := configTokens(data)
+
+	var (
+		patches []AddonPatch
+		// stack holds class names of open braces; array and unnamed braces push "".
+		stack   []string
+		pending string
+	)
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.text == "{" && !tok.quoted:
+			stack = append(stack, pending)
+			pending = ""
+			if len(stack) == 2 && strings.EqualFold(stack[0], "CfgPatches") && stack[1] != "" {
+				patches = append(patches, AddonPatch{Name: stack[1]})
+			}
+
+		case tok.text == "}" && !tok.quoted:
+			if len(stack) != 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+		case tok.text == "class" && !tok.quoted && i+1 < len(tokens):
+			i++
+			pending = tokens[i].text
+			// Skip ": Base" so base name is not taken for next class.
+			if i+2 < len(tokens) && tokens[i+1].text == ":" {
+				i += 2
+			}
+
+		case tok.text == ";" && !tok.quoted:
+			pending = ""
+
+		case len(stack) == 2 && len(patches) != 0 && strings.EqualFold(stack[0], "CfgPatches") &&
+			!tok.quoted && strings.EqualFold(tok.text, "requiredAddons"):
+			values, next, ok := configArrayValues(tokens, i+1)
+			if !ok {
+				continue
+			}
+
+			last := &patches[len(patches)-1]
+			last.RequiredAddons = append(last.RequiredAddons, values...)
+			i = next - 1
+		}
+	}
+
+	return patches
+}
+
+// configArrayValues reads "[] = {...}" or "[] += {...}" at tokens[i] and returns
+// string and word values plus index after closing brace.
+func configArrayValues(tokens []configToken, i int) ([]string, int, bool) {
+	for _, want := range []string{"[", "]"} {
+		if i >= len(tokens) || tokens[i].text != want {
+			return nil, i, false
+		}
+		i++
+	}
+	if i < len(tokens) && tokens[i].text == "+" {
+		i++
+	}
+	if i+1 >= len(tokens) || tokens[i].text != "=" || tokens[i+1].text != "{" {
+		return nil, i, false
+	}
+
+	var values []string
+	depth := 0
+	for i++; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.quoted:
+			values = append(values, tok.text)
+		case tok.text == "{":
+			depth++
+		case tok.text == "}":
+			depth--
+			if depth == 0 {
+				return values, i + 1, true
+			}
+		case tok.text != ",":
+			values = append(values, tok.text)
+		}
+	}
+
+	return values, i, true
+}
+
+// configToken is one token of text config.
+type configToken struct {
+	text string
+	// quoted marks string literal token.
+	quoted bool
+}
+
+// configTokens splits text config into words, string literals, and punctuation,
+// dropping comments and preprocessor lines.
+func configTokens(data []byte) []configToken {
+	var tokens []configToken
+	lineStart := true
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '\n':
+			lineStart = true
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+
+		case c == '#' && lineStart:
+			// Preprocessor directive, with backslash line continuations.
+			for i < len(data) && data[i] != '\n' {
+				if data[i] == '\\' && i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+				i++
+			}
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+
+		case c == '"':
+			// Config strings escape quote by doubling it.
+			var sb strings.Builder
+			for i++; i < len(data); i++ {
+				if data[i] == '"' {
+					if i+1 < len(data) && data[i+1] == '"' {
+						sb.WriteByte('"')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteByte(data[i])
+			}
+			tokens = append(tokens, configToken{text: sb.String(), quoted: true})
+			lineStart = false
+
+		case strings.IndexByte("{}[];=,:+", c) >= 0:
+			tokens = append(tokens, configToken{text: string(c)})
+			lineStart = false
+			i++
+
+		default:
+			start := i
+			for i < len(data) && !isConfigDelimiter(data[i]) {
+				i++
+			}
+			tokens = append(tokens, configToken{text: string(data[start:i])})
+			lineStart = false
+		}
+	}
+
+	return tokens
+}
+
+// isConfigDelimiter reports whether c ends word token of text config.
+func isConfigDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '"' || c == '/' ||
+		strings.IndexByte("{}[];=,:+", c) >= 0
+}
+
+// DependencyGraph collects CfgPatches of all archives and reports required addons
+// missing from the set and addons declared by several archives.
+func (b *BankSet) DependencyGraph() (*DependencyGraph, error) {
+	if b == nil {
+		return nil, ErrNilReader
+	}
+
+	graph := &DependencyGraph{}
+	declared := make(map[string][]string)
+	names := make(map[string]string)
+	for i, r := range b.multi.readers {
+		patches, err := r.CfgPatches()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.multi.paths[i], err)
+		}
+
+		for _, patch := range patches {
+			key := strings.ToLower(patch.Name)
+			if _, ok := names[key]; !ok {
+				names[key] = patch.Name
+			}
+
+			declared[key] = append(declared[key], b.multi.paths[i])
+			graph.Addons = append(graph.Addons, AddonNode{
+				Name:           patch.Name,
+				Archive:        b.multi.paths[i],
+				RequiredAddons: patch.RequiredAddons,
+			})
+		}
+	}
+
+	missing := make(map[string]*MissingAddon)
+	for _, node := range graph.Addons {
+		for _, addon := range node.RequiredAddons {
+			key := strings.ToLower(addon)
+			if _, ok := declared[key]; ok {
+				continue
+			}
+
+			m := missing[key]
+			if m == nil {
+				m = &MissingAddon{Name: addon}
+				missing[key] = m
+			}
+			if !containsFold(m.RequiredBy, node.Name) {
+				m.RequiredBy = append(m.RequiredBy, node.Name)
+			}
+		}
+	}
+
+	for _, m := range missing {
+		sort.Strings(m.RequiredBy)
+		graph.Missing = append(graph.Missing, *m)
+	}
+	sort.Slice(graph.Missing, func(i, j int) bool {
+		return strings.ToLower(graph.Missing[i].Name) < strings.ToLower(graph.Missing[j].Name)
+	})
+
+	for key, archives := range declared {
+		if len(archives) > 1 {
+			graph.Duplicates = append(graph.Duplicates, DuplicateAddon{Name: names[key], Archives: archives})
+		}
+	}
+	sort.Slice(graph.Duplicates, func(i, j int) bool {
+		return strings.ToLower(graph.Duplicates[i].Name) < strings.ToLower(graph.Duplicates[j].Name)
+	})
+
+	return graph, nil
+}
+
+// containsFold reports whether values contain s case-insensitively.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
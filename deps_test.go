@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// testRapConfig builds binarized config with CfgPatches classes and their requiredAddons.
+// Second patch stores requiredAddons as array extend entry to cover "+=" form.
+func testRapConfig(patches []AddonPatch) []byte {
+	var buf bytes.Buffer
+	buf.Write(rapSignature)
+	buf.Write(make([]byte, 12))
+
+	// Root body: one class entry pointing to CfgPatches body.
+	buf.WriteByte(0)
+	buf.WriteByte(1)
+	buf.WriteByte(rapEntryClass)
+	buf.WriteString("CfgPatches\x00")
+	patchesOffsetAt := buf.Len()
+	buf.Write(make([]byte, 4))
+
+	binary.LittleEndian.PutUint32(buf.Bytes()[patchesOffsetAt:], uint32(buf.Len()))
+	buf.WriteByte(0)
+	buf.WriteByte(byte(len(patches)))
+	offsetsAt := make([]int, len(patches))
+	for i, patch := range patches {
+		buf.WriteByte(rapEntryClass)
+		buf.WriteString(patch.Name + "\x00")
+		offsetsAt[i] = buf.Len()
+		buf.Write(make([]byte, 4))
+	}
+
+	for i, patch := range patches {
+		binary.LittleEndian.PutUint32(buf.Bytes()[offsetsAt[i]:], uint32(buf.Len()))
+		buf.WriteByte(0)
+		buf.WriteByte(3)
+		buf.WriteByte(rapEntryValue)
+		buf.WriteByte(rapElemString)
+		buf.WriteString("author\x00tester\x00")
+		buf.WriteByte(rapEntryArray)
+		buf.WriteString("units\x00")
+		buf.Write([]byte{1, rapElemFloat, 0, 0, 0, 0})
+
+		if i%2 == 1 {
+			buf.WriteByte(rapEntryArrayExtend)
+			buf.Write([]byte{1, 0, 0, 0})
+		} else {
+			buf.WriteByte(rapEntryArray)
+		}
+		buf.WriteString("requiredAddons\x00")
+		buf.WriteByte(byte(len(patch.RequiredAddons)))
+		for _, addon := range patch.RequiredAddons {
+			buf.WriteByte(rapElemString)
+			buf.WriteString(addon + "\x00")
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func TestDependencies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	aPath := filepath.Join(dir, "a.pbo")
+	if err := createTestPBO(aPath, map[string][]byte{
+		"config.cpp": []byte(`#include "macros.hpp"
+// class CfgPatches { class Commented {}; };
+class CfgPatches
+{
+	class Mod_A
+	{
+		units[] = {};
+		requiredAddons[] = {"DZ_Data", "Mod_B"}; /* "Mod_Commented" */
+	};
+	class Mod_A2: Mod_A
+	{
+		requiredAddons[] = {"Mod_A", "dz_data"};
+	};
+};
+class CfgVehicles
+{
+	class Item { requiredAddons[] = {"NotAPatch"}; };
+};
+`),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO a: %v", err)
+	}
+
+	bPath := filepath.Join(dir, "b.pbo")
+	if err := createTestPBO(bPath, map[string][]byte{
+		"config.bin": testRapConfig([]AddonPatch{
+			{Name: "Mod_B", RequiredAddons: []string{"DZ_Data", "Mod_C"}},
+			{Name: "Mod_A"},
+		}),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO b: %v", err)
+	}
+
+	deps, err := Dependencies(aPath)
+	if err != nil {
+		t.Fatalf("Dependencies a: %v", err)
+	}
+	if !slices.Equal(deps, []string{"DZ_Data", "Mod_B"}) {
+		t.Fatalf("Dependencies a=%v", deps)
+	}
+
+	deps, err = Dependencies(bPath)
+	if err != nil {
+		t.Fatalf("Dependencies b: %v", err)
+	}
+	if !slices.Equal(deps, []string{"DZ_Data", "Mod_C"}) {
+		t.Fatalf("Dependencies b=%v", deps)
+	}
+
+	b, err := OpenBankSet([]string{aPath, bPath}, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenBankSet: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	graph, err := b.DependencyGraph()
+	if err != nil {
+		t.Fatalf("DependencyGraph: %v", err)
+	}
+
+	var addons []string
+	for _, node := range graph.Addons {
+		addons = append(addons, node.Name+"@"+filepath.Base(node.Archive))
+	}
+	if !slices.Equal(addons, []string{"Mod_A@a.pbo", "Mod_A2@a.pbo", "Mod_B@b.pbo", "Mod_A@b.pbo"}) {
+		t.Fatalf("addons=%v", addons)
+	}
+
+	wantMissing := []MissingAddon{
+		{Name: "DZ_Data", RequiredBy: []string{"Mod_A", "Mod_A2", "Mod_B"}},
+		{Name: "Mod_C", RequiredBy: []string{"Mod_B"}},
+	}
+	if !reflect.DeepEqual(graph.Missing, wantMissing) {
+		t.Fatalf("missing=%+v", graph.Missing)
+	}
+
+	wantDuplicates := []DuplicateAddon{{Name: "Mod_A", Archives: []string{aPath, bPath}}}
+	if !reflect.DeepEqual(graph.Duplicates, wantDuplicates) {
+		t.Fatalf("duplicates=%+v", graph.Duplicates)
+	}
+}
+
+func TestRapCfgPatches_Malformed(t *testing.T) {
+	t.Parallel()
+
+	data := testRapConfig([]AddonPatch{{Name: "Mod_A", RequiredAddons: []string{"DZ_Data"}}})
+	patches, err := rapCfgPatches(data)
+	if err != nil || len(patches) != 1 || !slices.Equal(patches[0].RequiredAddons, []string{"DZ_Data"}) {
+		t.Fatalf("rapCfgPatches=%+v err=%v", patches, err)
+	}
+
+	for _, bad := range [][]byte{data[:len(data)-3], data[:20], []byte("\x00raP")} {
+		if _, err := rapCfgPatches(bad); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("rapCfgPatches malformed err=%v", err)
+		}
+	}
+}
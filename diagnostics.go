@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// suspiciousNameLen is entry name length above which names are reported as suspicious.
+// Longer names cannot be extracted on default Windows path limits.
+const suspiciousNameLen = 260
+
+// ParseIssueKind identifies one kind of non-fatal parse observation.
+type ParseIssueKind string
+
+// Parse issue kinds reported by Reader.Diagnostics.
+const (
+	// ParseIssueStoredOffsetsIgnored means index has non-zero stored offsets that were not used.
+	ParseIssueStoredOffsetsIgnored ParseIssueKind = "stored_offsets_ignored"
+	// ParseIssueTrailerMissing means archive has no 0x00 + SHA1 trailer.
+	ParseIssueTrailerMissing ParseIssueKind = "trailer_missing"
+	// ParseIssueSuspiciousNameLength means entry name is empty or unusually long.
+	ParseIssueSuspiciousNameLength ParseIssueKind = "suspicious_name_length"
+	// ParseIssueZeroMime means MimeNil entry carries original size different from data size.
+	ParseIssueZeroMime ParseIssueKind = "zero_mime"
+	// ParseIssueUnknownMime means entry mime type is not a known PBO marker.
+	ParseIssueUnknownMime ParseIssueKind = "unknown_mime"
+	// ParseIssueTableRecovered means RecoverMode salvaged entries from damaged table.
+	ParseIssueTableRecovered ParseIssueKind = "table_recovered"
+	// ParseIssueStoredOffsetsOverlap means ignored stored offsets point back into earlier
+	// payload (shared payloads of DedupEqualPayloads), so sequential offsets misread entries.
+	ParseIssueStoredOffsetsOverlap ParseIssueKind = "stored_offsets_overlap"
+)
+
+// ParseIssue is one non-fatal observation made while parsing archive.
+type ParseIssue struct {
+	// Kind is issue kind.
+	Kind ParseIssueKind `json:"kind" yaml:"kind"`
+	// Path is raw entry path for entry-level issues; empty for archive-level ones.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Detail is human-readable description.
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// Diagnostics returns non-fatal parse observations in detection order.
+// Entry-level issues are collected from raw index table before reader filters.
+func (r *Reader) Diagnostics() []ParseIssue {
+	if r == nil {
+		return nil
+	}
+
+	_ = r.LoadEntries()
+
+	return slices.Clone(r.diagnostics)
+}
+
+// addIssue appends one parse issue.
+func (r *Reader) addIssue(kind ParseIssueKind, path string, detail string) {
+	r.diagnostics = append(r.diagnostics, ParseIssue{Kind: kind, Path: path, Detail: detail})
+}
+
+// collectEntryIssues records suspicious raw index rows.
+func (r *Reader) collectEntryIssues() {
+	for _, entry := range r.entries {
+		switch n := len(entry.Path); {
+		case n == 0:
+			r.addIssue(ParseIssueSuspiciousNameLength, entry.Path, "empty entry name")
+		case n > suspiciousNameLen:
+			r.addIssue(ParseIssueSuspiciousNameLength, entry.Path, fmt.Sprintf("entry name is %d bytes", n))
+		}
+
+		switch entry.MimeType {
+		case MimeNil:
+			if entry.OriginalSize != 0 && entry.OriginalSize != entry.DataSize {
+				r.addIssue(ParseIssueZeroMime, entry.Path, fmt.Sprintf("original size %d with data size %d",
+					entry.OriginalSize, entry.DataSize))
+			}
+		case MimeCompress, MimeEncoded:
+		default:
+			r.addIssue(ParseIssueUnknownMime, entry.Path, fmt.Sprintf("mime %#08x", uint32(entry.MimeType)))
+		}
+	}
+}
+
+// overlappingStoredOffset returns path of first entry whose non-zero stored offset points
+// before end of previous stored payload; empty when stored offsets are absent or ascending.
+// Zero offsets mean "not stored" and are skipped.
+func overlappingStoredOffset(entries []EntryInfo) string {
+	var end uint64
+	for i := range entries {
+		if entries[i].Offset == 0 {
+			continue
+		}
+		if uint64(entries[i].Offset) < end {
+			return entries[i].Path
+		}
+
+		end = uint64(entries[i].Offset) + uint64(entries[i].DataSize)
+	}
+
+	return ""
+}
+
+// hasStoredOffsets reports whether any parsed index row has non-zero stored offset.
+func hasStoredOffsets(entries []EntryInfo) bool {
+	for i := range entries {
+		if entries[i].Offset != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReaderDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	cleanPath := filepath.Join(t.TempDir(), "clean.pbo")
+	if err := createTestPBO(cleanPath, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	clean, err := Open(cleanPath)
+	if err != nil {
+		t.Fatalf("Open clean: %v", err)
+	}
+	defer func() { _ = clean.Close() }()
+
+	if issues := clean.Diagnostics(); len(issues) != 0 {
+		t.Fatalf("clean archive issues=%+v", issues)
+	}
+
+	longName := strings.Repeat("n", suspiciousNameLen+1)
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: `a.txt`, data: []byte("alpha"), offset: 0},
+		{name: longName, data: []byte("beta"), offset: 5},
+		{name: `odd.bin`, data: []byte("odd"), mime: MimeType(0x1234)},
+		{name: `sized.txt`, data: []byte("sized"), originalSize: 99},
+	})
+
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	got := make(map[ParseIssueKind]string)
+	for _, issue := range r.Diagnostics() {
+		got[issue.Kind] = issue.Path
+	}
+
+	want := map[ParseIssueKind]string{
+		ParseIssueStoredOffsetsIgnored: "",
+		ParseIssueSuspiciousNameLength: longName,
+		ParseIssueUnknownMime:          "odd.bin",
+		ParseIssueZeroMime:             "sized.txt",
+	}
+	for kind, wantPath := range want {
+		gotPath, ok := got[kind]
+		if !ok || gotPath != wantPath {
+			t.Fatalf("issue %s path=%q present=%v, want %q; all=%+v", kind, gotPath, ok, wantPath, r.Diagnostics())
+		}
+	}
+}
+
+func TestReaderDiagnostics_DedupOverlap(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{"a.txt": []byte("first"), "b.txt": []byte("second"), "c.txt": []byte("first")}
+	path := filepath.Join(t.TempDir(), "dedup.pbo")
+	if err := createTestPBO(path, files, PackOptions{DedupEqualPayloads: true}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var found bool
+	for _, issue := range r.Diagnostics() {
+		if issue.Kind == ParseIssueStoredOffsetsOverlap && issue.Path == "c.txt" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("default reader issues=%+v, want stored offsets overlap on c.txt", r.Diagnostics())
+	}
+
+	stored, err := OpenWithOptions(path, ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = stored.Close() }()
+
+	for _, issue := range stored.Diagnostics() {
+		if issue.Kind == ParseIssueStoredOffsetsOverlap {
+			t.Fatalf("stored offset reader reported overlap: %+v", issue)
+		}
+	}
+	if data, err := stored.ReadEntry("c.txt"); err != nil || string(data) != "first" {
+		t.Fatalf("stored ReadEntry(c.txt)=%q err=%v", data, err)
+	}
+}
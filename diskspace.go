@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiskSpaceError reports destination filesystem without enough free space for extraction.
+type DiskSpaceError struct {
+	// Path is existing directory used to query filesystem free space.
+	Path string `json:"path" yaml:"path"`
+	// Required is total expected size of extracted entries in bytes.
+	Required int64 `json:"required" yaml:"required"`
+	// Available is free space available to current user in bytes.
+	Available int64 `json:"available" yaml:"available"`
+}
+
+// Error implements error.
+func (e *DiskSpaceError) Error() string {
+	return fmt.Sprintf("%v: %s needs %d bytes, %d available", ErrInsufficientSpace, e.Path, e.Required, e.Available)
+}
+
+// Unwrap returns ErrInsufficientSpace.
+func (e *DiskSpaceError) Unwrap() error {
+	return ErrInsufficientSpace
+}
+
+// checkDiskSpace fails with DiskSpaceError when filesystem holding dir has less than required free bytes.
+// Platforms without free space query skip the check.
+func checkDiskSpace(dir string, required int64) error {
+	probe := nearestExistingDir(dir)
+	available, ok, err := availableDiskSpace(probe)
+	if err != nil {
+		return fmt.Errorf("query free space of %s: %w", probe, err)
+	}
+
+	if !ok || available >= uint64(max(required, 0)) {
+		return nil
+	}
+
+	return &DiskSpaceError{
+		Path:      probe,
+		Required:  required,
+		Available: int64(min(available, uint64(1<<63-1))), //nolint:gosec // clamped to int64 range
+	}
+}
+
+// nearestExistingDir returns dir or its closest existing parent.
+func nearestExistingDir(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
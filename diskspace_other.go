@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+//go:build !linux && !darwin && !freebsd && !dragonfly && !windows
+
+package pbo
+
+// availableDiskSpace reports unknown free space on platforms without supported query.
+func availableDiskSpace(string) (uint64, bool, error) {
+	return 0, false, nil
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+//go:build linux || darwin || freebsd || dragonfly
+
+package pbo
+
+import "syscall"
+
+// availableDiskSpace returns free bytes available to unprivileged user on filesystem of path.
+func availableDiskSpace(path string) (uint64, bool, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, false, err
+	}
+
+	return uint64(st.Bavail) * uint64(st.Bsize), true, nil //nolint:gosec,unconvert // field types differ per platform
+}
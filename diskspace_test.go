@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckDiskSpace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, ok, err := availableDiskSpace(dir); err != nil || !ok {
+		t.Skipf("free space query unsupported: ok=%v err=%v", ok, err)
+	}
+
+	missing := filepath.Join(dir, "not", "created", "yet")
+	if err := checkDiskSpace(missing, 1); err != nil {
+		t.Fatalf("checkDiskSpace small: %v", err)
+	}
+
+	err := checkDiskSpace(missing, 1<<62)
+	var spaceErr *DiskSpaceError
+	if !errors.As(err, &spaceErr) || !errors.Is(err, ErrInsufficientSpace) {
+		t.Fatalf("expected DiskSpaceError, got %v", err)
+	}
+	if spaceErr.Path != dir || spaceErr.Required != 1<<62 || spaceErr.Available <= 0 {
+		t.Fatalf("unexpected error fields: %+v", spaceErr)
+	}
+
+	pboPath := filepath.Join(dir, "small.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("a")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := r.Extract(context.Background(), filepath.Join(dir, "out"), ExtractOptions{CheckDiskSpace: true}); err != nil {
+		t.Fatalf("Extract with disk space check: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+//go:build windows
+
+package pbo
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// procGetDiskFreeSpaceExW queries free space of volume holding directory.
+var procGetDiskFreeSpaceExW = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// availableDiskSpace returns free bytes available to current user on volume of path.
+func availableDiskSpace(path string) (uint64, bool, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var freeToCaller uint64
+	ok, _, callErr := procGetDiskFreeSpaceExW.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeToCaller)),
+		0,
+		0,
+	)
+	if ok == 0 {
+		return 0, false, callErr
+	}
+
+	return freeToCaller, true, nil
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EditSession stages edits across several archives and commits them as one transaction:
+// when any archive fails, every archive already rewritten in this commit is restored from backup.
+type EditSession struct {
+	byPath  map[string]*Editor
+	editors []*Editor
+	opts    EditOptions
+}
+
+// NewEditSession creates empty session; opts apply to every archive editor.
+func NewEditSession(opts EditOptions) *EditSession {
+	return &EditSession{
+		byPath: make(map[string]*Editor, 8),
+		opts:   opts,
+	}
+}
+
+// Editor returns staging editor for archive path, creating it on first use.
+// Repeated calls with the same cleaned path return the same Editor.
+func (s *EditSession) Editor(path string) (*Editor, error) {
+	if s == nil {
+		return nil, ErrNilReader
+	}
+
+	trimmedPath := strings.TrimSpace(path)
+	if trimmedPath == "" {
+		return nil, ErrInvalidEntryPath
+	}
+
+	key := filepath.Clean(trimmedPath)
+	if e, ok := s.byPath[key]; ok {
+		return e, nil
+	}
+
+	e, err := OpenEditor(key, s.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.byPath[key] = e
+	s.editors = append(s.editors, e)
+	return e, nil
+}
+
+// Paths returns archive paths in the order their editors were created.
+func (s *EditSession) Paths() []string {
+	if s == nil {
+		return nil
+	}
+
+	out := make([]string, len(s.editors))
+	for i, e := range s.editors {
+		out[i] = e.path
+	}
+
+	return out
+}
+
+// Commit rewrites every staged archive in creation order and returns pack results keyed by
+// archive path. On failure all archives of this commit are restored from their backups.
+func (s *EditSession) Commit(ctx context.Context) (map[string]*PackResult, error) {
+	if s == nil {
+		return nil, ErrNilReader
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	now := time.Now()
+	results := make(map[string]*PackResult, len(s.editors))
+	backups := make([]*editBackup, 0, len(s.editors))
+	for _, e := range s.editors {
+		if err := ctx.Err(); err != nil {
+			return nil, s.rollback(backups, err)
+		}
+
+		backup, err := e.newBackup(now)
+		if err != nil {
+			return nil, s.rollback(backups, fmt.Errorf("%s: %w", e.path, err))
+		}
+
+		if err := e.createBackup(backup); err != nil {
+			return nil, s.rollback(backups, fmt.Errorf("%s: %w", e.path, err))
+		}
+		backups = append(backups, backup)
+
+		res, err := e.commitFromBackup(ctx, backup.path)
+		if err != nil {
+			return nil, s.rollback(backups, fmt.Errorf("commit %s: %w", e.path, err))
+		}
+
+		results[e.path] = res
+	}
+
+	for i, e := range s.editors {
+		if err := e.finishBackup(backups[i]); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// rollback restores backed up archives in reverse order and returns cause
+// joined with any restore failures. backups[i] belongs to s.editors[i].
+func (s *EditSession) rollback(backups []*editBackup, cause error) error {
+	var rollbackErrs []error
+	for i := len(backups) - 1; i >= 0; i-- {
+		e := s.editors[i]
+		if err := e.restoreBackup(backups[i]); err != nil {
+			rollbackErrs = append(rollbackErrs, fmt.Errorf("%s: %w", e.path, err))
+		}
+	}
+
+	if len(rollbackErrs) == 0 {
+		return cause
+	}
+
+	return fmt.Errorf("%w (rollback failed: %v)", cause, errors.Join(rollbackErrs...))
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditSession_CommitAll(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.pbo"), filepath.Join(dir, "b.pbo")}
+	for _, p := range paths {
+		if err := createTestPBO(p, map[string][]byte{"data.txt": []byte("orig")}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+	}
+
+	s := NewEditSession(EditOptions{})
+	for _, p := range paths {
+		e, err := s.Editor(p)
+		if err != nil {
+			t.Fatalf("Editor: %v", err)
+		}
+		if err := e.Replace(sessionInput("data.txt", "new")); err != nil {
+			t.Fatalf("Replace: %v", err)
+		}
+	}
+
+	if again, _ := s.Editor(paths[0]); again != s.editors[0] {
+		t.Fatal("Editor must return same editor for repeated path")
+	}
+
+	results, err := s.Commit(context.Background())
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results=%d, want 2", len(results))
+	}
+
+	for _, p := range paths {
+		got, err := readEntryFromFile(p, "data.txt")
+		if err != nil || string(got) != "new" {
+			t.Fatalf("%s data=%q err=%v, want new", p, got, err)
+		}
+		if _, err := os.Stat(p + ".bak"); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("%s backup must be removed, stat err=%v", p, err)
+		}
+	}
+}
+
+func TestEditSession_FailureRollsBackAll(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.pbo"), filepath.Join(dir, "b.pbo"), filepath.Join(dir, "c.pbo")}
+	for _, p := range paths {
+		if err := createTestPBO(p, map[string][]byte{"data.txt": []byte("orig")}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+	}
+
+	s := NewEditSession(EditOptions{BackupKeep: 1})
+	for i, p := range paths {
+		e, err := s.Editor(p)
+		if err != nil {
+			t.Fatalf("Editor: %v", err)
+		}
+
+		in := sessionInput("data.txt", "new")
+		if i == 2 {
+			in.Path = "missing.txt"
+		}
+		if err := e.Replace(in); err != nil {
+			t.Fatalf("Replace: %v", err)
+		}
+	}
+
+	if _, err := s.Commit(context.Background()); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("Commit err=%v, want ErrEntryNotFound", err)
+	}
+
+	for _, p := range paths {
+		got, err := readEntryFromFile(p, "data.txt")
+		if err != nil || string(got) != "orig" {
+			t.Fatalf("%s data=%q err=%v, want restored orig", p, got, err)
+		}
+		if _, err := os.Stat(p + ".bak"); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("%s backup must be moved back, stat err=%v", p, err)
+		}
+	}
+}
+
+// sessionInput returns in-memory input with known size.
+func sessionInput(path string, data string) Input {
+	return Input{
+		Path:     path,
+		SizeHint: int64(len(data)),
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte(data))), nil
+		},
+	}
+}
@@ -11,13 +11,15 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Editor accumulates archive edit operations and applies them on Commit.
 type Editor struct {
-	path string
-	ops  []editOperation
-	opts EditOptions
+	path      string
+	ops       []editOperation
+	headerOps []headerOperation
+	opts      EditOptions
 }
 
 // editOperation stores one staged editor operation.
@@ -27,6 +29,13 @@ type editOperation struct {
 	kind   editOperationKind
 }
 
+// headerOperation stores one staged header change.
+type headerOperation struct {
+	key    string
+	value  string
+	delete bool
+}
+
 // editOperationKind identifies staged edit action type.
 type editOperationKind uint8
 
@@ -47,6 +56,9 @@ func OpenEditor(path string, opts EditOptions) (*Editor, error) {
 	if trimmedPath == "" {
 		return nil, ErrInvalidEntryPath
 	}
+	if err := validatePathCaseSensitivity(opts.PackOptions.PathCaseSensitivity); err != nil {
+		return nil, err
+	}
 
 	opts.applyDefaults()
 
@@ -149,6 +161,45 @@ func (e *Editor) DeleteDir(prefixes ...string) error {
 	return nil
 }
 
+// SetHeader schedules setting header key to value. Existing key keeps its position
+// and later duplicates are dropped; missing key is appended. Keys match case-insensitively.
+func (e *Editor) SetHeader(key string, value string) error {
+	if e == nil {
+		return ErrNilReader
+	}
+
+	if err := validateHeaderPair(key, value); err != nil {
+		return err
+	}
+
+	e.headerOps = append(e.headerOps, headerOperation{key: key, value: value})
+	return nil
+}
+
+// DeleteHeader schedules removing every header with key (case-insensitive).
+func (e *Editor) DeleteHeader(key string) error {
+	if e == nil {
+		return ErrNilReader
+	}
+
+	if err := validateHeaderPair(key, ""); err != nil {
+		return err
+	}
+
+	e.headerOps = append(e.headerOps, headerOperation{key: key, delete: true})
+	return nil
+}
+
+// SetPrefix schedules setting normalized "prefix" header; empty value removes it.
+func (e *Editor) SetPrefix(value string) error {
+	prefix := NormalizePrefixHeader(value)
+	if prefix == "" {
+		return e.DeleteHeader(HeaderKeyPrefix)
+	}
+
+	return e.SetHeader(HeaderKeyPrefix, prefix)
+}
+
 // Commit applies all staged operations in one rewrite transaction.
 func (e *Editor) Commit(ctx context.Context) (*PackResult, error) {
 	if e == nil {
@@ -159,18 +210,18 @@ func (e *Editor) Commit(ctx context.Context) (*PackResult, error) {
 		ctx = context.Background()
 	}
 
-	backupPath := e.path + ".bak"
-	if err := prepareBackupSlot(backupPath, e.opts.BackupKeep); err != nil {
+	backup, err := e.newBackup(time.Now())
+	if err != nil {
 		return nil, err
 	}
 
-	if err := os.Rename(e.path, backupPath); err != nil {
-		return nil, fmt.Errorf("move archive to backup: %w", err)
+	if err := e.createBackup(backup); err != nil {
+		return nil, err
 	}
 
-	res, err := e.commitFromBackup(ctx, backupPath)
+	res, err := e.commitFromBackup(ctx, backup.path)
 	if err != nil {
-		rollbackErr := rollbackFromBackup(e.path, backupPath)
+		rollbackErr := e.restoreBackup(backup)
 		if rollbackErr != nil {
 			return nil, fmt.Errorf("%v (rollback failed: %v)", err, rollbackErr)
 		}
@@ -178,10 +229,8 @@ func (e *Editor) Commit(ctx context.Context) (*PackResult, error) {
 		return nil, err
 	}
 
-	if e.opts.BackupKeep == 0 {
-		if err := removeIfExists(backupPath); err != nil {
-			return nil, fmt.Errorf("remove backup: %w", err)
-		}
+	if err := e.finishBackup(backup); err != nil {
+		return nil, err
 	}
 
 	return res, nil
@@ -201,28 +250,28 @@ func (e *Editor) commitFromBackup(ctx context.Context, backupPath string) (*Pack
 	}
 
 	packOpts := e.opts.PackOptions
-	srcReader, err := NewReaderFromReaderAtWithOptions(srcFile, srcInfo.Size(), ReaderOptions{
-		SealedKey: packOpts.SealedKey,
-	})
+	srcReader, err := NewReaderFromReaderAtWithOptions(srcFile, srcInfo.Size(), e.sourceReaderOptions())
 	if err != nil {
 		return nil, fmt.Errorf("parse backup: %w", err)
 	}
 
-	plan, err := buildEditPlan(srcReader.entries, e.ops)
+	plan, err := buildEditPlan(srcReader.entries, e.ops, packOpts.PathCaseSensitivity)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(packOpts.Headers) == 0 {
 		packOpts.Headers = srcReader.Headers()
+		packOpts.AllowDuplicateHeaders = true
 	}
+	packOpts.Headers = applyHeaderOperations(packOpts.Headers, e.headerOps)
 
 	dstFile, err := os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
 	if err != nil {
 		return nil, fmt.Errorf("create destination archive: %w", err)
 	}
 
-	res, writeErr := rewriteArchive(ctx, dstFile, srcReader.ra, plan, packOpts)
+	res, writeErr := rewriteArchive(ctx, dstFile, srcReader, plan, packOpts)
 	if writeErr != nil {
 		_ = dstFile.Close()
 		return nil, writeErr
@@ -244,6 +293,56 @@ func (e *Editor) commitFromBackup(ctx context.Context, backupPath string) (*Pack
 	return res, nil
 }
 
+// sourceReaderOptions returns options for parsing source archive.
+func (e *Editor) sourceReaderOptions() ReaderOptions {
+	opts := e.opts.ReaderOptions
+	opts.LazyEntries = false
+	if opts.SealedKey == nil {
+		opts.SealedKey = e.opts.PackOptions.SealedKey
+	}
+	if opts.NameEncoding == NameEncodingNone {
+		opts.NameEncoding = e.opts.PackOptions.NameEncoding
+	}
+	if opts.UnicodeForm == UnicodeFormNone {
+		opts.UnicodeForm = e.opts.PackOptions.UnicodeForm
+	}
+
+	return opts
+}
+
+// applyHeaderOperations returns copy of headers with staged operations applied in order.
+func applyHeaderOperations(headers []HeaderPair, ops []headerOperation) []HeaderPair {
+	if len(ops) == 0 {
+		return headers
+	}
+
+	out := append([]HeaderPair(nil), headers...)
+	for _, op := range ops {
+		kept := out[:0]
+		found := false
+		for _, h := range out {
+			if !strings.EqualFold(h.Key, op.key) {
+				kept = append(kept, h)
+				continue
+			}
+
+			if op.delete || found {
+				continue
+			}
+
+			found = true
+			kept = append(kept, HeaderPair{Key: h.Key, Value: op.value})
+		}
+
+		out = kept
+		if !op.delete && !found {
+			out = append(out, HeaderPair{Key: op.key, Value: op.value})
+		}
+	}
+
+	return out
+}
+
 // normalizeEditorInputs validates and canonicalizes editor input list.
 func normalizeEditorInputs(inputs []Input) ([]Input, error) {
 	if len(inputs) == 0 {
@@ -286,11 +385,29 @@ func normalizeEditorPaths(paths []string) ([]string, error) {
 
 // normalizeEditorArchivePath converts path to canonical archive path form.
 func normalizeEditorArchivePath(raw string) (string, error) {
-	return normalizeArchiveEntryPath(raw)
+	return NormalizeEntryPath(raw)
 }
 
 // buildEditPlan applies staged operations to source entries and builds final write plan.
-func buildEditPlan(sourceEntries []EntryInfo, ops []editOperation) ([]rewriteEntry, error) {
+func buildEditPlan(sourceEntries []EntryInfo, ops []editOperation, pathCase PathCaseSensitivity) ([]rewriteEntry, error) {
+	state, err := resolveEditState(sourceEntries, ops, pathCase, func(conflict EditConflict) error {
+		return conflict.Err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedEditPlan(state), nil
+}
+
+// resolveEditState applies staged operations to source entries and returns final state keyed by path.
+// onConflict decides whether conflicting operation aborts resolution (non-nil error) or is skipped.
+func resolveEditState(
+	sourceEntries []EntryInfo,
+	ops []editOperation,
+	pathCase PathCaseSensitivity,
+	onConflict func(conflict EditConflict) error,
+) (map[string]rewriteEntry, error) {
 	state := make(map[string]rewriteEntry, len(sourceEntries))
 	for i := range sourceEntries {
 		path, err := normalizeEditorArchivePath(sourceEntries[i].Path)
@@ -298,7 +415,7 @@ func buildEditPlan(sourceEntries []EntryInfo, ops []editOperation) ([]rewriteEnt
 			return nil, fmt.Errorf("%w: source entry path %q", ErrInvalidEntryPath, sourceEntries[i].Path)
 		}
 
-		key := editorPathKey(path)
+		key := editorPathKey(path, pathCase)
 		if _, exists := state[key]; exists {
 			return nil, fmt.Errorf("%w: %q", ErrDuplicateEntryPath, path)
 		}
@@ -314,22 +431,27 @@ func buildEditPlan(sourceEntries []EntryInfo, ops []editOperation) ([]rewriteEnt
 	for _, op := range ops {
 		switch op.kind {
 		case editOperationAdd:
-			if err := applyEditAdd(state, op.inputs); err != nil {
+			if err := applyEditAdd(state, op.inputs, pathCase, onConflict); err != nil {
 				return nil, err
 			}
 		case editOperationReplace:
-			if err := applyEditReplace(state, op.inputs); err != nil {
+			if err := applyEditReplace(state, op.inputs, pathCase, onConflict); err != nil {
 				return nil, err
 			}
 		case editOperationDelete:
-			applyEditDelete(state, op.paths)
+			applyEditDelete(state, op.paths, pathCase)
 		case editOperationDeleteDir:
-			applyEditDeleteDir(state, op.paths)
+			applyEditDeleteDir(state, op.paths, pathCase)
 		default:
 			return nil, fmt.Errorf("unknown edit operation kind: %d", op.kind)
 		}
 	}
 
+	return state, nil
+}
+
+// sortedEditPlan flattens edit state into deterministic path-sorted write plan.
+func sortedEditPlan(state map[string]rewriteEntry) []rewriteEntry {
 	plan := make([]rewriteEntry, 0, len(state))
 	for _, item := range state {
 		plan = append(plan, item)
@@ -337,15 +459,28 @@ func buildEditPlan(sourceEntries []EntryInfo, ops []editOperation) ([]rewriteEnt
 
 	sort.Slice(plan, func(i, j int) bool { return plan[i].path < plan[j].path })
 
-	return plan, nil
+	return plan
 }
 
-// applyEditAdd adds new entries and fails on existing paths.
-func applyEditAdd(state map[string]rewriteEntry, inputs []Input) error {
+// applyEditAdd adds new entries and reports conflicts for existing paths.
+func applyEditAdd(
+	state map[string]rewriteEntry,
+	inputs []Input,
+	pathCase PathCaseSensitivity,
+	onConflict func(conflict EditConflict) error,
+) error {
 	for _, in := range inputs {
-		key := editorPathKey(in.Path)
+		key := editorPathKey(in.Path, pathCase)
 		if _, exists := state[key]; exists {
-			return fmt.Errorf("%w: %q", ErrDuplicateEntryPath, in.Path)
+			if err := onConflict(EditConflict{
+				Path: in.Path,
+				Kind: EditConflictAddExisting,
+				Err:  fmt.Errorf("%w: %q", ErrDuplicateEntryPath, in.Path),
+			}); err != nil {
+				return err
+			}
+
+			continue
 		}
 
 		item := in
@@ -358,12 +493,25 @@ func applyEditAdd(state map[string]rewriteEntry, inputs []Input) error {
 	return nil
 }
 
-// applyEditReplace replaces existing entries and fails on missing paths.
-func applyEditReplace(state map[string]rewriteEntry, inputs []Input) error {
+// applyEditReplace replaces existing entries and reports conflicts for missing paths.
+func applyEditReplace(
+	state map[string]rewriteEntry,
+	inputs []Input,
+	pathCase PathCaseSensitivity,
+	onConflict func(conflict EditConflict) error,
+) error {
 	for _, in := range inputs {
-		key := editorPathKey(in.Path)
+		key := editorPathKey(in.Path, pathCase)
 		if _, exists := state[key]; !exists {
-			return fmt.Errorf("%w: %q", ErrEntryNotFound, in.Path)
+			if err := onConflict(EditConflict{
+				Path: in.Path,
+				Kind: EditConflictReplaceMissing,
+				Err:  fmt.Errorf("%w: %q", ErrEntryNotFound, in.Path),
+			}); err != nil {
+				return err
+			}
+
+			continue
 		}
 
 		item := in
@@ -377,17 +525,17 @@ func applyEditReplace(state map[string]rewriteEntry, inputs []Input) error {
 }
 
 // applyEditDelete removes exact paths from state.
-func applyEditDelete(state map[string]rewriteEntry, paths []string) {
+func applyEditDelete(state map[string]rewriteEntry, paths []string, pathCase PathCaseSensitivity) {
 	for _, path := range paths {
-		delete(state, editorPathKey(path))
+		delete(state, editorPathKey(path, pathCase))
 	}
 }
 
 // applyEditDeleteDir removes entries matching directory prefixes.
-func applyEditDeleteDir(state map[string]rewriteEntry, prefixes []string) {
+func applyEditDeleteDir(state map[string]rewriteEntry, prefixes []string, pathCase PathCaseSensitivity) {
 	for _, prefix := range prefixes {
 		for key, item := range state {
-			if hasEditorDirPrefix(item.path, prefix) {
+			if hasEditorDirPrefix(item.path, prefix, pathCase) {
 				delete(state, key)
 			}
 		}
@@ -395,15 +543,19 @@ func applyEditDeleteDir(state map[string]rewriteEntry, prefixes []string) {
 }
 
 // hasEditorDirPrefix reports whether path is equal to prefix or inside prefixed directory.
-func hasEditorDirPrefix(path string, prefix string) bool {
-	pathKey := editorPathKey(path)
-	prefixKey := editorPathKey(prefix)
+func hasEditorDirPrefix(path string, prefix string, pathCase PathCaseSensitivity) bool {
+	pathKey := editorPathKey(path, pathCase)
+	prefixKey := editorPathKey(prefix, pathCase)
 
 	return pathKey == prefixKey || strings.HasPrefix(pathKey, prefixKey+`\`)
 }
 
-// editorPathKey returns case-insensitive map key for archive path.
-func editorPathKey(path string) string {
+// editorPathKey returns map key for archive path under pathCase (case-insensitive by default).
+func editorPathKey(path string, pathCase PathCaseSensitivity) string {
+	if pathCase == PathCaseSensitive {
+		return path
+	}
+
 	return strings.ToLower(path)
 }
 
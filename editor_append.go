@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// CommitAppend applies staged Add operations in place: new payloads are appended at
+// archive end and only header and entry table are rewritten, with absolute stored offsets.
+// Existing payloads overlapped by the grown entry table are moved to archive end.
+// Resulting archive must be read with OffsetModeStoredCompat; Repack restores sequential
+// layout expected by game tooling. No backup is made; on error archive is truncated to
+// its original size and overwritten table and trailer bytes are restored, but a crash
+// mid-commit can still leave archive without trailer or with orphan tail bytes.
+// Fails with ErrNotAppendOnly when Replace or Delete operations are staged or archive is sealed.
+func (e *Editor) CommitAppend(ctx context.Context) (*PackResult, error) {
+	if e == nil {
+		return nil, ErrNilReader
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for _, op := range e.ops {
+		if op.kind != editOperationAdd {
+			return nil, fmt.Errorf("%w: replace and delete need full Commit", ErrNotAppendOnly)
+		}
+	}
+
+	readerOpts := e.sourceReaderOptions()
+	if readerOpts.SealedKey != nil {
+		return nil, fmt.Errorf("%w: sealed archive", ErrNotAppendOnly)
+	}
+	if readerOpts.OffsetMode != OffsetModeStoredStrict {
+		readerOpts.OffsetMode = OffsetModeStoredCompat
+	}
+
+	f, err := os.OpenFile(e.path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	rollback := &appendRollback{f: f, size: -1}
+	res, err := e.appendToFile(ctx, f, readerOpts, rollback)
+	if err == nil {
+		if trailerErr := writeSHA1Trailer(e.path); trailerErr != nil {
+			err = fmt.Errorf("write SHA1 trailer: %w", trailerErr)
+		}
+	}
+	if err != nil {
+		return nil, errors.Join(err, rollback.restore())
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return res, nil
+}
+
+// appendRollback holds archive bytes overwritten by CommitAppend so failed commit can
+// put archive back into its original state.
+type appendRollback struct {
+	f *os.File
+	// head is original content under rewritten header and entry table; nil until table write.
+	head []byte
+	// tail is original content from tailOff to size (trailer and orphan bytes).
+	tail    []byte
+	tailOff int64
+	// size is original archive size; negative when nothing was changed yet.
+	size int64
+}
+
+// restore writes back saved bytes and truncates archive to original size.
+func (rb *appendRollback) restore() error {
+	if rb.size < 0 {
+		return nil
+	}
+
+	if rb.head != nil {
+		if _, err := rb.f.WriteAt(rb.head, 0); err != nil {
+			return fmt.Errorf("restore entry table: %w", err)
+		}
+	}
+	if _, err := rb.f.WriteAt(rb.tail, rb.tailOff); err != nil {
+		return fmt.Errorf("restore archive tail: %w", err)
+	}
+	if err := rb.f.Truncate(rb.size); err != nil {
+		return fmt.Errorf("restore archive size: %w", err)
+	}
+	if err := rb.f.Sync(); err != nil {
+		return fmt.Errorf("sync restored archive: %w", err)
+	}
+
+	return nil
+}
+
+// checkAppendBound fails when entry of size written at pos does not fit 32-bit PBO offsets.
+func checkAppendBound(path string, pos int64, size int64) error {
+	if pos >= maxPBOData || pos+size > maxPBOData {
+		return fmt.Errorf("%w: entry %s at offset %d with size %d exceeds 4 GiB", ErrSizeOverflow, path, pos, size)
+	}
+
+	return nil
+}
+
+// appendToFile relocates overlapped payloads, appends new payloads, and rewrites entry table.
+// Original bytes it overwrites are saved into rollback before write.
+func (e *Editor) appendToFile(
+	ctx context.Context,
+	f *os.File,
+	readerOpts ReaderOptions,
+	rollback *appendRollback,
+) (*PackResult, error) {
+	startedAt := time.Now()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat archive: %w", err)
+	}
+
+	src, err := NewReaderFromReaderAtWithOptions(f, fi.Size(), ReaderOptions{
+		EntryKey:     readerOpts.EntryKey,
+		Logger:       readerOpts.Logger,
+		OffsetMode:   readerOpts.OffsetMode,
+		Limits:       readerOpts.Limits,
+		NameEncoding: readerOpts.NameEncoding,
+		UnicodeForm:  readerOpts.UnicodeForm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse archive: %w", err)
+	}
+
+	state, err := resolveEditState(src.entries, e.ops, e.opts.PackOptions.PathCaseSensitivity, func(conflict EditConflict) error {
+		return conflict.Err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var added []rewriteEntry
+	for _, item := range sortedEditPlan(state) {
+		if item.input != nil {
+			added = append(added, item)
+		}
+	}
+
+	packOpts := e.opts.PackOptions
+	if len(packOpts.Headers) == 0 {
+		packOpts.Headers = src.Headers()
+		packOpts.AllowDuplicateHeaders = true
+	}
+	packOpts.Headers = applyHeaderOperations(packOpts.Headers, e.headerOps)
+
+	var headerBuf bytes.Buffer
+	hw := bufio.NewWriter(&headerBuf)
+	if _, err := writeHeaderSection(hw, packOpts.Headers, packOpts.AllowDuplicateHeaders); err != nil {
+		return nil, err
+	}
+	if err := hw.Flush(); err != nil {
+		return nil, fmt.Errorf("flush headers: %w", err)
+	}
+
+	existing := make([]EntryInfo, len(src.entries))
+	copy(existing, src.entries)
+	sort.SliceStable(existing, func(i, j int) bool { return existing[i].Offset < existing[j].Offset })
+
+	// Existing names are resolved decoded but written back as stored bytes;
+	// only added names take PackOptions.NameEncoding.
+	addedNames, err := encodeEntryNames(added, packOpts.NameEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	indexSize := int64(1 + 20)
+	for i := range existing {
+		indexSize += int64(len(storedEntryName(existing[i])) + 1 + 20)
+	}
+	for _, name := range addedNames {
+		indexSize += int64(len(name) + 1 + 20)
+	}
+	dataStart := int64(headerBuf.Len()) + indexSize
+
+	appendPos := src.dataStart
+	for i := range existing {
+		appendPos = max(appendPos, int64(existing[i].Offset)+int64(existing[i].DataSize))
+	}
+
+	// Small archives can end inside grown entry table; append past it.
+	appendPos = max(appendPos, dataStart)
+
+	// Everything from appendPos on (trailer, orphan bytes) is overwritten by appended payloads.
+	tail := make([]byte, max(fi.Size()-appendPos, 0))
+	if _, err := f.ReadAt(tail, appendPos); err != nil {
+		return nil, fmt.Errorf("read archive tail: %w", err)
+	}
+	rollback.tail, rollback.tailOff, rollback.size = tail, appendPos, fi.Size()
+
+	copyBuf, releaseCopyBuffer := acquirePackCopyBuffer()
+	defer releaseCopyBuffer()
+
+	// Entries starting inside new table area move to archive end, after kept entries.
+	kept := make([]EntryInfo, 0, len(existing))
+	var moved []EntryInfo
+	for _, entry := range existing {
+		if int64(entry.Offset) >= dataStart {
+			kept = append(kept, entry)
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := checkAppendBound(entry.Path, appendPos, int64(entry.DataSize)); err != nil {
+			return nil, err
+		}
+
+		payload := io.NewSectionReader(f, int64(entry.Offset), int64(entry.DataSize))
+		if _, err := copyPayloadBounded(io.NewOffsetWriter(f, appendPos), payload, int64(entry.DataSize), copyBuf); err != nil {
+			return nil, fmt.Errorf("move entry %s: %w", entry.Path, err)
+		}
+
+		entry.Offset = uint32(appendPos) //nolint:gosec // checked by checkAppendBound
+		moved = append(moved, entry)
+		appendPos += int64(entry.DataSize)
+	}
+
+	packOpts.applyDefaults()
+	matcher, err := newCompressMatcher(packOpts.Compress, packOpts.CompressMatcherOptions)
+	if err != nil {
+		return nil, fmt.Errorf("compile compress rules: %w", err)
+	}
+
+	hasher, err := newEntryHasher(packOpts.EntryHash)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &PackResult{}
+	extStats := make(extensionStatsCollector)
+	appended := make([]EntryInfo, 0, len(added))
+	for i, item := range added {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Payload write itself rejects sizes past 4 GiB from running offset (checkedDataSize).
+		if err := checkAppendBound(item.path, appendPos, max(item.input.SizeHint, 0)); err != nil {
+			return nil, err
+		}
+
+		cw := &countingWriter{w: io.NewOffsetWriter(f, appendPos)}
+		w := bufio.NewWriter(cw)
+		record, err := writeRewriteInputPayload(ctx, w, item, packOpts, matcher, hasher, uint32(appendPos), copyBuf) //nolint:gosec // checked by checkAppendBound
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Flush(); err != nil {
+			return nil, fmt.Errorf("flush entry %s: %w", item.path, err)
+		}
+
+		record.timestamp = packOpts.entryTimestamp(record.timestamp)
+		appended = append(appended, EntryInfo{
+			Path:         addedNames[i],
+			Offset:       uint32(appendPos), //nolint:gosec // checked by checkAppendBound
+			DataSize:     record.dataSize,
+			OriginalSize: record.originalSize,
+			TimeStamp:    record.timestamp,
+			MimeType:     record.mime,
+		})
+		extStats.add(item.path, record)
+
+		res.WrittenEntries++
+		if record.mime == MimeCompress {
+			res.CompressedEntries++
+			res.CompressedBytes += int64(record.dataSize)
+		} else {
+			res.RawBytes += int64(record.dataSize)
+		}
+		if record.compressionCandidate && record.mime != MimeCompress {
+			res.SkippedCompressionEntries++
+		}
+		if hasher != nil {
+			res.EntryDigests = append(res.EntryDigests, EntryDigest{Path: item.path, Digest: hexDigest(hasher)})
+		}
+
+		appendPos += int64(record.dataSize)
+	}
+
+	if err := f.Truncate(appendPos); err != nil {
+		return nil, fmt.Errorf("truncate archive: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return nil, fmt.Errorf("sync payloads: %w", err)
+	}
+
+	table := headerBuf.Bytes()
+	var fields [20]byte
+	for _, group := range [][]EntryInfo{kept, moved, appended} {
+		for i := range group {
+			encodeStoredEntryFields(&fields, group[i])
+			table = append(table, storedEntryName(group[i])...)
+			table = append(table, 0)
+			table = append(table, fields[:]...)
+		}
+	}
+	table = append(table, make([]byte, 1+20)...)
+
+	head := make([]byte, len(table))
+	if _, err := f.ReadAt(head, 0); err != nil {
+		return nil, fmt.Errorf("read entry table: %w", err)
+	}
+	rollback.head = head
+
+	if _, err := f.WriteAt(table, 0); err != nil {
+		return nil, fmt.Errorf("write entry table: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return nil, fmt.Errorf("sync entry table: %w", err)
+	}
+
+	res.DataSize = appendPos - dataStart
+	res.IndexSize = indexSize
+	res.Extensions = extStats.result()
+	res.Duration = time.Since(startedAt)
+
+	return res, nil
+}
+
+// storedEntryName returns entry name as stored in archive index before decoding.
+func storedEntryName(info EntryInfo) string {
+	if info.OriginalPath != "" {
+		return info.OriginalPath
+	}
+
+	return info.Path
+}
+
+// encodeStoredEntryFields encodes entry index fields with absolute stored offset.
+func encodeStoredEntryFields(dst *[20]byte, info EntryInfo) {
+	binary.LittleEndian.PutUint32(dst[0:4], uint32(info.MimeType))
+	binary.LittleEndian.PutUint32(dst[4:8], info.OriginalSize)
+	binary.LittleEndian.PutUint32(dst[8:12], info.Offset)
+	binary.LittleEndian.PutUint32(dst[12:16], info.TimeStamp)
+	binary.LittleEndian.PutUint32(dst[16:20], info.DataSize)
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorCommitAppend(t *testing.T) {
+	t.Parallel()
+
+	want := map[string][]byte{
+		"a.txt":  bytes.Repeat([]byte("alpha "), 64),
+		"b.bin":  []byte("raw payload"),
+		"c.txt":  bytes.Repeat([]byte("charlie "), 512),
+		"zz.bin": []byte("tail"),
+	}
+	pboPath := filepath.Join(t.TempDir(), "append.pbo")
+	if err := createTestPBO(pboPath, want, PackOptions{
+		Headers:         []HeaderPair{{Key: "prefix", Value: "mod"}},
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+	}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	appendOnce := func(inputs map[string][]byte) {
+		t.Helper()
+
+		editor, err := OpenEditor(pboPath, EditOptions{
+			PackOptions: PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1},
+		})
+		if err != nil {
+			t.Fatalf("OpenEditor: %v", err)
+		}
+		for name, data := range inputs {
+			if err := editor.Add(sessionInput(name, string(data))); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			want[name] = data
+		}
+		if err := editor.SetHeader("version", "2"); err != nil {
+			t.Fatalf("SetHeader: %v", err)
+		}
+
+		res, err := editor.CommitAppend(context.Background())
+		if err != nil {
+			t.Fatalf("CommitAppend: %v", err)
+		}
+		if res.WrittenEntries != len(inputs) {
+			t.Fatalf("WrittenEntries=%d, want %d", res.WrittenEntries, len(inputs))
+		}
+	}
+
+	appendOnce(map[string][]byte{
+		"scripts/new_module_with_long_name.txt": bytes.Repeat([]byte("delta "), 256),
+		"data/new.bin":                          []byte("new raw"),
+	})
+	appendOnce(map[string][]byte{"later.bin": []byte("second append")})
+
+	if _, err := VerifySHA1Trailer(pboPath); err != nil {
+		t.Fatalf("VerifySHA1Trailer: %v", err)
+	}
+
+	verify := func(opts ReaderOptions) {
+		t.Helper()
+
+		r, err := OpenWithOptions(pboPath, opts)
+		if err != nil {
+			t.Fatalf("OpenWithOptions: %v", err)
+		}
+		defer func() { _ = r.Close() }()
+
+		if len(r.Entries()) != len(want) {
+			t.Fatalf("entries=%d, want %d", len(r.Entries()), len(want))
+		}
+		for name, data := range want {
+			got, err := r.ReadEntry(name)
+			if err != nil || !bytes.Equal(got, data) {
+				t.Fatalf("%s err=%v equal=%v", name, err, bytes.Equal(got, data))
+			}
+		}
+
+		headers := r.Headers()
+		if len(headers) != 2 || headers[1] != (HeaderPair{Key: "version", Value: "2"}) {
+			t.Fatalf("headers=%+v", headers)
+		}
+	}
+
+	verify(ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+
+	if _, err := Repack(context.Background(), pboPath, EditOptions{}); err != nil {
+		t.Fatalf("Repack: %v", err)
+	}
+	verify(ReaderOptions{})
+}
+
+func TestEditorCommitAppend_Rejects(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "append.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("A")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Replace(sessionInput("a.txt", "B")); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if _, err := editor.CommitAppend(context.Background()); !errors.Is(err, ErrNotAppendOnly) {
+		t.Fatalf("Replace err=%v, want ErrNotAppendOnly", err)
+	}
+
+	editor, err = OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(sessionInput("A.TXT", "B")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := editor.CommitAppend(context.Background()); !errors.Is(err, ErrDuplicateEntryPath) {
+		t.Fatalf("Add existing err=%v, want ErrDuplicateEntryPath", err)
+	}
+
+	got, err := readEntryFromFile(pboPath, "a.txt")
+	if err != nil || string(got) != "A" {
+		t.Fatalf("archive changed after rejected append: %q err=%v", got, err)
+	}
+}
+
+func TestEditorCommitAppend_RestoresOnFailure(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "append.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("A")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+	original, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	errBroken := errors.New("broken input")
+	failing := []Input{
+		{
+			Path: "zz_broken.bin",
+			Open: func() (io.ReadCloser, error) { return nil, errBroken },
+		},
+		{
+			Path:     "zz_huge.bin",
+			SizeHint: 5 << 30,
+			Open:     func() (io.ReadCloser, error) { return nil, errBroken },
+		},
+	}
+	wantErrs := []error{errBroken, ErrSizeOverflow}
+
+	for i, bad := range failing {
+		editor, err := OpenEditor(pboPath, EditOptions{})
+		if err != nil {
+			t.Fatalf("OpenEditor: %v", err)
+		}
+		if err := editor.Add(sessionInput("scripts/first_added_entry_with_long_name.c", "ok")); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := editor.Add(bad); err != nil {
+			t.Fatalf("Add %s: %v", bad.Path, err)
+		}
+
+		if _, err := editor.CommitAppend(context.Background()); !errors.Is(err, wantErrs[i]) {
+			t.Fatalf("CommitAppend %s err=%v, want %v", bad.Path, err, wantErrs[i])
+		}
+
+		got, err := os.ReadFile(pboPath)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if !bytes.Equal(got, original) {
+			t.Fatalf("archive not restored after failed append of %s", bad.Path)
+		}
+	}
+
+	// Archive smaller than grown entry table still appends past new table.
+	editor, err := OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(sessionInput("scripts/first_added_entry_with_long_name.c", "ok")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := editor.CommitAppend(context.Background()); err != nil {
+		t.Fatalf("CommitAppend: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{OffsetMode: OffsetModeStoredCompat})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for name, want := range map[string]string{"a.txt": "A", `scripts\first_added_entry_with_long_name.c`: "ok"} {
+		if data, err := r.ReadEntry(name); err != nil || string(data) != want {
+			t.Fatalf("ReadEntry %s = %q, %v", name, data, err)
+		}
+	}
+}
+
+func TestEditorCommitAppend_NameEncoding(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "cp1251.pbo")
+	opts := PackOptions{NameEncoding: NameEncodingWindows1251}
+	if err := createTestPBO(pboPath, map[string][]byte{"тест.txt": []byte("old")}, opts); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{PackOptions: opts})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(sessionInput("тест.txt", "dup")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := editor.CommitAppend(context.Background()); !errors.Is(err, ErrDuplicateEntryPath) {
+		t.Fatalf("Add existing encoded name err=%v, want ErrDuplicateEntryPath", err)
+	}
+
+	editor, err = OpenEditor(pboPath, EditOptions{PackOptions: opts})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(sessionInput("новый.txt", "new")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := editor.CommitAppend(context.Background()); err != nil {
+		t.Fatalf("CommitAppend: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{
+		OffsetMode:   OffsetModeStoredCompat,
+		NameEncoding: NameEncodingWindows1251,
+	})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for name, want := range map[string]string{"тест.txt": "old", "новый.txt": "new"} {
+		if data, err := r.ReadEntry(name); err != nil || string(data) != want {
+			t.Fatalf("ReadEntry %s = %q, %v", name, data, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultBackupNameTemplate is backup file name used when EditOptions.BackupNameTemplate is empty.
+	defaultBackupNameTemplate = "{name}.bak"
+	// backupTimestampLayout formats {timestamp} placeholder (UTC).
+	backupTimestampLayout = "20060102T150405Z"
+)
+
+// editBackup describes backup location of one commit.
+type editBackup struct {
+	// path is backup file path.
+	path string
+	// pattern matches backup names of earlier commits for timestamped templates.
+	pattern *regexp.Regexp
+}
+
+// newBackup resolves backup path for commit started at now.
+func (e *Editor) newBackup(now time.Time) (*editBackup, error) {
+	template := e.opts.BackupNameTemplate
+	if template == "" {
+		template = defaultBackupNameTemplate
+	}
+
+	base := filepath.Base(e.path)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	replacer := strings.NewReplacer(
+		"{name}", base,
+		"{stem}", stem,
+		"{timestamp}", now.UTC().Format(backupTimestampLayout),
+		"{unix}", strconv.FormatInt(now.Unix(), 10),
+	)
+
+	name := replacer.Replace(template)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBackupTemplate, template)
+	}
+
+	dir := e.opts.BackupDir
+	if dir == "" {
+		dir = filepath.Dir(e.path)
+	}
+
+	b := &editBackup{path: filepath.Join(dir, name)}
+	if filepath.Clean(b.path) == filepath.Clean(e.path) {
+		return nil, fmt.Errorf("%w: %q resolves to archive path", ErrInvalidBackupTemplate, template)
+	}
+
+	if strings.Contains(template, "{timestamp}") || strings.Contains(template, "{unix}") {
+		expr := regexp.QuoteMeta(template)
+		expr = strings.NewReplacer(
+			regexp.QuoteMeta("{name}"), regexp.QuoteMeta(base),
+			regexp.QuoteMeta("{stem}"), regexp.QuoteMeta(stem),
+			regexp.QuoteMeta("{timestamp}"), `\d{8}T\d{6}Z`,
+			regexp.QuoteMeta("{unix}"), `\d+`,
+		).Replace(expr)
+		b.pattern = regexp.MustCompile("^" + expr + "$")
+	}
+
+	return b, nil
+}
+
+// createBackup moves or copies archive to backup path, rotating fixed-name generations first.
+func (e *Editor) createBackup(b *editBackup) error {
+	if e.opts.BackupDir != "" {
+		if err := os.MkdirAll(e.opts.BackupDir, 0o750); err != nil {
+			return fmt.Errorf("create backup dir: %w", err)
+		}
+	}
+
+	if b.pattern == nil {
+		if err := prepareBackupSlot(b.path, e.opts.BackupKeep); err != nil {
+			return err
+		}
+	} else if err := removeIfExists(b.path); err != nil {
+		return err
+	}
+
+	if e.opts.BackupCopy {
+		if err := copyFileSynced(e.path, b.path); err != nil {
+			_ = os.Remove(b.path)
+			return fmt.Errorf("copy archive to backup: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.Rename(e.path, b.path); err != nil {
+		return fmt.Errorf("move archive to backup: %w", err)
+	}
+
+	return nil
+}
+
+// restoreBackup puts backup back in place of archive after failed commit.
+func (e *Editor) restoreBackup(b *editBackup) error {
+	if !e.opts.BackupCopy {
+		return rollbackFromBackup(e.path, b.path)
+	}
+
+	if err := copyFileSynced(b.path, e.path); err != nil {
+		return fmt.Errorf("restore backup: %w", err)
+	}
+
+	return removeIfExists(b.path)
+}
+
+// finishBackup applies BackupKeep policy after successful commit.
+func (e *Editor) finishBackup(b *editBackup) error {
+	if e.opts.BackupKeep <= 0 {
+		if err := removeIfExists(b.path); err != nil {
+			return fmt.Errorf("remove backup: %w", err)
+		}
+
+		return nil
+	}
+
+	if b.pattern == nil {
+		return nil
+	}
+
+	dir := filepath.Dir(b.path)
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("list backups: %w", err)
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Type().IsRegular() && b.pattern.MatchString(item.Name()) {
+			names = append(names, item.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names[:max(len(names)-e.opts.BackupKeep, 0)] {
+		if err := removeIfExists(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("remove old backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyFileSynced copies src to dst (truncating dst) and syncs written data.
+func copyFileSynced(src string, dst string) error {
+	in, err := os.Open(src) //nolint:gosec // path is editor archive or its backup
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // path is editor archive or its backup
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
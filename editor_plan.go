@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// EditPlanAction identifies how one entry is produced by editor commit.
+type EditPlanAction string
+
+// Editor plan actions.
+const (
+	// EditPlanKeep copies already packed payload from source archive.
+	EditPlanKeep EditPlanAction = "keep"
+	// EditPlanAdd writes new entry from staged input.
+	EditPlanAdd EditPlanAction = "add"
+	// EditPlanReplace rewrites existing entry from staged input.
+	EditPlanReplace EditPlanAction = "replace"
+)
+
+// EditConflictKind identifies staged operation conflict type.
+type EditConflictKind string
+
+// Editor conflict kinds.
+const (
+	// EditConflictAddExisting means Add targets path that already exists.
+	EditConflictAddExisting EditConflictKind = "add_existing"
+	// EditConflictReplaceMissing means Replace targets path that does not exist.
+	EditConflictReplaceMissing EditConflictKind = "replace_missing"
+)
+
+// EditConflict describes one staged operation that Commit would reject.
+type EditConflict struct {
+	// Err is the error Commit would return for this conflict.
+	Err error `json:"-" yaml:"-"`
+	// Path is conflicting archive path.
+	Path string `json:"path" yaml:"path"`
+	// Kind is conflict type.
+	Kind EditConflictKind `json:"kind" yaml:"kind"`
+}
+
+// EditPlanEntry describes one entry of resolved editor plan.
+type EditPlanEntry struct {
+	// Path is final archive entry path.
+	Path string `json:"path" yaml:"path"`
+	// Action is how entry payload is produced.
+	Action EditPlanAction `json:"action" yaml:"action"`
+	// SizeHint is staged input size hint (zero for kept entries or unknown size).
+	SizeHint int64 `json:"size_hint,omitempty" yaml:"size_hint,omitempty"`
+	// DataSize is stored payload size of kept entry.
+	DataSize uint32 `json:"data_size,omitempty" yaml:"data_size,omitempty"`
+	// OriginalSize is original size of kept compressed entry.
+	OriginalSize uint32 `json:"original_size,omitempty" yaml:"original_size,omitempty"`
+	// CompressionCandidate reports whether staged input would enter compression path.
+	CompressionCandidate bool `json:"compression_candidate,omitempty" yaml:"compression_candidate,omitempty"`
+	// Compressed reports whether kept entry payload is stored compressed.
+	Compressed bool `json:"compressed,omitempty" yaml:"compressed,omitempty"`
+}
+
+// EditPlan is resolved result of staged editor operations without writing archive.
+type EditPlan struct {
+	// Entries is final entry list in write order.
+	Entries []EditPlanEntry `json:"entries" yaml:"entries"`
+	// Deleted lists source entry paths removed by staged operations.
+	Deleted []string `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	// Conflicts lists staged operations that make Commit fail.
+	Conflicts []EditConflict `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	// Headers are headers Commit would write.
+	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// HasConflicts reports whether Commit would fail on staged operation conflicts.
+func (p *EditPlan) HasConflicts() bool {
+	return p != nil && len(p.Conflicts) > 0
+}
+
+// Plan resolves staged operations against current archive and returns final entry list
+// with compression decisions and conflicts. Nothing is written.
+func (e *Editor) Plan(ctx context.Context) (*EditPlan, error) {
+	if e == nil {
+		return nil, ErrNilReader
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	packOpts := e.opts.PackOptions
+	srcReader, err := OpenWithOptions(e.path, e.sourceReaderOptions())
+	if err != nil {
+		return nil, fmt.Errorf("parse archive: %w", err)
+	}
+	defer func() { _ = srcReader.Close() }()
+
+	plan := &EditPlan{}
+	state, err := resolveEditState(srcReader.entries, e.ops, packOpts.PathCaseSensitivity, func(conflict EditConflict) error {
+		plan.Conflicts = append(plan.Conflicts, conflict)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	compressMatcher, err := newCompressMatcher(packOpts.Compress, packOpts.CompressMatcherOptions)
+	if err != nil {
+		return nil, fmt.Errorf("compile compress rules: %w", err)
+	}
+
+	sourceKeys := make(map[string]string, len(srcReader.entries))
+	for i := range srcReader.entries {
+		path, err := normalizeEditorArchivePath(srcReader.entries[i].Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: source entry path %q", ErrInvalidEntryPath, srcReader.entries[i].Path)
+		}
+
+		sourceKeys[editorPathKey(path, packOpts.PathCaseSensitivity)] = path
+	}
+
+	for key, path := range sourceKeys {
+		if _, exists := state[key]; !exists {
+			plan.Deleted = append(plan.Deleted, path)
+		}
+	}
+	sort.Strings(plan.Deleted)
+
+	rewritePlan := sortedEditPlan(state)
+	plan.Entries = make([]EditPlanEntry, 0, len(rewritePlan))
+	for _, item := range rewritePlan {
+		entry := EditPlanEntry{Path: item.path}
+		switch {
+		case item.source != nil:
+			entry.Action = EditPlanKeep
+			entry.DataSize = item.source.DataSize
+			entry.OriginalSize = item.source.OriginalSize
+			entry.Compressed = item.source.IsCompressed()
+		case item.input != nil:
+			entry.Action = EditPlanAdd
+			if _, existed := sourceKeys[editorPathKey(item.path, packOpts.PathCaseSensitivity)]; existed {
+				entry.Action = EditPlanReplace
+			}
+
+			entry.SizeHint = item.input.SizeHint
+			entry.CompressionCandidate = shouldUseCompressionForInput(packOpts, compressMatcher, *item.input)
+		}
+
+		plan.Entries = append(plan.Entries, entry)
+	}
+
+	plan.Headers = packOpts.Headers
+	if len(plan.Headers) == 0 {
+		plan.Headers = srcReader.Headers()
+	}
+	plan.Headers = applyHeaderOperations(plan.Headers, e.headerOps)
+
+	return plan, nil
+}
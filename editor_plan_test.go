@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditorPlan_ResolvesWithoutWriting(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "archive.pbo")
+	err := createTestPBO(pboPath, map[string][]byte{
+		"dir/a.txt":     []byte("old-a"),
+		"dir/sub/b.txt": []byte("old-b"),
+		"keep.cfg":      []byte("keep"),
+	}, PackOptions{})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	before, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{
+		PackOptions: PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1},
+	})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+
+	payload := bytes.Repeat([]byte("x"), 128)
+	openPayload := func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(payload)), nil }
+	if err := editor.Replace(Input{Path: "dir/a.txt", Open: openPayload, SizeHint: 128}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if err := editor.Add(Input{Path: "new.txt", Open: openPayload, SizeHint: 128}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := editor.Add(Input{Path: "keep.cfg", Open: openPayload}); err != nil {
+		t.Fatalf("Add conflicting: %v", err)
+	}
+	if err := editor.Replace(Input{Path: "missing.txt", Open: openPayload}); err != nil {
+		t.Fatalf("Replace missing: %v", err)
+	}
+	if err := editor.DeleteDir("dir/sub"); err != nil {
+		t.Fatalf("DeleteDir: %v", err)
+	}
+
+	plan, err := editor.Plan(context.Background())
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	after, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Fatal("Plan modified archive")
+	}
+
+	want := []struct {
+		path      string
+		action    EditPlanAction
+		candidate bool
+	}{
+		{path: `dir\a.txt`, action: EditPlanReplace, candidate: true},
+		{path: "keep.cfg", action: EditPlanKeep},
+		{path: "new.txt", action: EditPlanAdd, candidate: true},
+	}
+	if len(plan.Entries) != len(want) {
+		t.Fatalf("entries=%+v", plan.Entries)
+	}
+	for i := range want {
+		got := plan.Entries[i]
+		if got.Path != want[i].path || got.Action != want[i].action || got.CompressionCandidate != want[i].candidate {
+			t.Fatalf("entry[%d]=%+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if len(plan.Deleted) != 1 || plan.Deleted[0] != `dir\sub\b.txt` {
+		t.Fatalf("deleted=%v", plan.Deleted)
+	}
+
+	if !plan.HasConflicts() || len(plan.Conflicts) != 2 {
+		t.Fatalf("conflicts=%+v", plan.Conflicts)
+	}
+	if plan.Conflicts[0].Kind != EditConflictAddExisting || plan.Conflicts[1].Kind != EditConflictReplaceMissing {
+		t.Fatalf("conflict kinds=%+v", plan.Conflicts)
+	}
+
+	if _, err := editor.Commit(context.Background()); err == nil {
+		t.Fatal("expected Commit to fail on conflicts reported by Plan")
+	}
+}
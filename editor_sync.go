@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SyncCompareMode selects how SyncDir detects changed files.
+type SyncCompareMode string
+
+// SyncDir compare modes.
+const (
+	// SyncCompareHash compares size, then SHA256 of file and decoded entry content.
+	SyncCompareHash SyncCompareMode = "hash"
+	// SyncCompareMTime compares size and file modification time with entry timestamp (seconds).
+	// Archives packed with SourceDateEpoch or ZeroTimestamps always look changed in this mode.
+	SyncCompareMTime SyncCompareMode = "mtime"
+)
+
+// SyncOptions configures Editor.SyncDir.
+type SyncOptions struct {
+	// Compare selects change detection (default SyncCompareHash).
+	Compare SyncCompareMode `json:"compare,omitempty" yaml:"compare,omitempty"`
+	// KeepExtra keeps archive entries under prefix that have no source file instead of deleting them.
+	KeepExtra bool `json:"keep_extra,omitempty" yaml:"keep_extra,omitempty"`
+	// IgnorePrefixFile syncs $PBOPREFIX$-style files as regular entries instead of
+	// staging prefix header from them.
+	IgnorePrefixFile bool `json:"ignore_prefix_file,omitempty" yaml:"ignore_prefix_file,omitempty"`
+}
+
+// SyncDirResult lists operations staged by Editor.SyncDir.
+type SyncDirResult struct {
+	// Prefix is prefix header staged from source prefix file when it differs from archive.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Added lists archive paths of source files missing in archive.
+	Added []string `json:"added,omitempty" yaml:"added,omitempty"`
+	// Replaced lists archive paths of entries whose source file differs.
+	Replaced []string `json:"replaced,omitempty" yaml:"replaced,omitempty"`
+	// Deleted lists archive paths of entries under prefix without source file.
+	Deleted []string `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	// Unchanged is number of source files equal to archive entries.
+	Unchanged int `json:"unchanged,omitempty" yaml:"unchanged,omitempty"`
+}
+
+// SyncDir compares srcDir with archive entries under archivePrefix and stages only
+// differences as Add, Replace, and Delete operations; empty prefix maps srcDir to archive root.
+// Comparison uses archive as currently stored on disk, not previously staged operations.
+// With empty archivePrefix, prefix file in srcDir root is not synced as entry and its
+// prefix is staged with SetPrefix unless IgnorePrefixFile is set.
+func (e *Editor) SyncDir(srcDir string, archivePrefix string, opts SyncOptions) (*SyncDirResult, error) {
+	if e == nil {
+		return nil, ErrNilReader
+	}
+
+	compare := opts.Compare
+	if compare == "" {
+		compare = SyncCompareHash
+	}
+	if compare != SyncCompareHash && compare != SyncCompareMTime {
+		return nil, fmt.Errorf("unknown sync compare mode %q", compare)
+	}
+
+	prefix := ""
+	if NormalizePath(archivePrefix) != "" {
+		var err error
+		prefix, err = normalizeEditorArchivePath(archivePrefix)
+		if err != nil {
+			return nil, fmt.Errorf("%w: archive prefix %q", ErrInvalidEntryPath, archivePrefix)
+		}
+	}
+
+	inputs, err := InputsFromDir(srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var pf *PrefixFile
+	if prefix == "" && !opts.IgnorePrefixFile {
+		if pf, err = ReadPrefixFile(srcDir); err != nil {
+			return nil, err
+		}
+		if pf != nil {
+			inputs = withoutPrefixFileInputs(inputs)
+		}
+	}
+
+	src, err := OpenWithOptions(e.path, e.sourceReaderOptions())
+	if err != nil {
+		return nil, fmt.Errorf("parse archive: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	pathCase := e.opts.PackOptions.PathCaseSensitivity
+	existing := make(map[string]*EntryInfo, len(src.entries))
+	for i := range src.entries {
+		entryPath, err := normalizeEditorArchivePath(src.entries[i].Path)
+		if err != nil {
+			continue
+		}
+		if prefix != "" && !hasEditorDirPrefix(entryPath, prefix, pathCase) {
+			continue
+		}
+		if pf != nil && IsPrefixFileName(entryPath) {
+			continue
+		}
+		if _, ok := existing[editorPathKey(entryPath, pathCase)]; !ok {
+			existing[editorPathKey(entryPath, pathCase)] = &src.entries[i]
+		}
+	}
+
+	res := &SyncDirResult{}
+	var added, replaced []Input
+	for _, in := range inputs {
+		filePath := filepath.Join(srcDir, filepath.FromSlash(in.Path))
+		if prefix != "" {
+			in.Path = prefix + `\` + in.Path
+		}
+		if in.Path, err = normalizeEditorArchivePath(in.Path); err != nil {
+			return nil, fmt.Errorf("%w: input path %q", ErrInvalidEntryPath, in.Path)
+		}
+
+		key := editorPathKey(in.Path, pathCase)
+		entry, ok := existing[key]
+		delete(existing, key)
+		if !ok {
+			added = append(added, in)
+			res.Added = append(res.Added, in.Path)
+			continue
+		}
+
+		same, err := syncEntryUnchanged(src, entry, in, filePath, compare)
+		if err != nil {
+			return nil, err
+		}
+		if same {
+			res.Unchanged++
+			continue
+		}
+
+		replaced = append(replaced, in)
+		res.Replaced = append(res.Replaced, in.Path)
+	}
+
+	if !opts.KeepExtra {
+		for _, entry := range existing {
+			entryPath, _ := normalizeEditorArchivePath(entry.Path)
+			res.Deleted = append(res.Deleted, entryPath)
+		}
+	}
+
+	sort.Strings(res.Added)
+	sort.Strings(res.Replaced)
+	sort.Strings(res.Deleted)
+
+	if err := e.Delete(res.Deleted...); err != nil {
+		return nil, err
+	}
+	if err := e.Replace(replaced...); err != nil {
+		return nil, err
+	}
+	if err := e.Add(added...); err != nil {
+		return nil, err
+	}
+
+	if pf != nil && pf.Prefix != src.Prefix() {
+		if err := e.SetPrefix(pf.Prefix); err != nil {
+			return nil, err
+		}
+		res.Prefix = pf.Prefix
+	}
+
+	return res, nil
+}
+
+// syncEntryUnchanged reports whether source file matches archive entry under compare mode.
+func syncEntryUnchanged(src *Reader, entry *EntryInfo, in Input, filePath string, compare SyncCompareMode) (bool, error) {
+	if int64(filterOriginalSizeOrDataSize(*entry)) != in.SizeHint {
+		return false, nil
+	}
+
+	if compare == SyncCompareMTime {
+		return int64(entry.TimeStamp) == in.ModTime.Unix(), nil
+	}
+
+	fileSum, err := syncFileSHA256(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	rc, err := src.openEntryByInfo(entry, entry.Path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return false, fmt.Errorf("hash entry %s: %w", entry.Path, err)
+	}
+
+	return bytes.Equal(fileSum, h.Sum(nil)), nil
+}
+
+// syncFileSHA256 returns SHA256 of local file content.
+func syncFileSHA256(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath) //nolint:gosec // path comes from caller-selected source tree walk
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hash %s: %w", filePath, err)
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestEditorSyncDir(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "sync.pbo")
+	if err := createTestPBO(archivePath, map[string][]byte{
+		"config.cpp":     []byte("cfg"),
+		"scripts/a.c":    []byte("A"),
+		"scripts/b.c":    []byte("B"),
+		"scripts/gone.c": []byte("G"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	src := t.TempDir()
+	for name, data := range map[string]string{"a.c": "A", "b.c": "B2", "sub/new.c": "N"} {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	editor, err := OpenEditor(archivePath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+
+	res, err := editor.SyncDir(src, "scripts", SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncDir: %v", err)
+	}
+	if !slices.Equal(res.Added, []string{`scripts\sub\new.c`}) ||
+		!slices.Equal(res.Replaced, []string{`scripts\b.c`}) ||
+		!slices.Equal(res.Deleted, []string{`scripts\gone.c`}) ||
+		res.Unchanged != 1 {
+		t.Fatalf("SyncDir result=%+v", res)
+	}
+
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	r, err := Open(archivePath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for name, want := range map[string]string{
+		"config.cpp":        "cfg",
+		"scripts/a.c":       "A",
+		"scripts/b.c":       "B2",
+		"scripts/sub/new.c": "N",
+	} {
+		got, err := r.ReadEntry(name)
+		if err != nil || string(got) != want {
+			t.Fatalf("%s=%q err=%v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := r.ReadEntry("scripts/gone.c"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("gone.c err=%v, want ErrEntryNotFound", err)
+	}
+}
+
+func TestEditorSyncDir_KeepExtraAndMTime(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "sync.pbo")
+	if err := createTestPBO(archivePath, map[string][]byte{
+		"a.c":     []byte("A"),
+		"extra.c": []byte("X"),
+	}, PackOptions{ZeroTimestamps: true}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "a.c"), []byte("A"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	editor, err := OpenEditor(archivePath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+
+	res, err := editor.SyncDir(src, "", SyncOptions{Compare: SyncCompareMTime, KeepExtra: true})
+	if err != nil {
+		t.Fatalf("SyncDir: %v", err)
+	}
+	if !slices.Equal(res.Replaced, []string{"a.c"}) || len(res.Deleted) != 0 || len(res.Added) != 0 {
+		t.Fatalf("SyncDir result=%+v, want a.c replaced by mtime and extra.c kept", res)
+	}
+
+	if _, err := editor.SyncDir(src, "", SyncOptions{Compare: "size"}); err == nil {
+		t.Fatal("expected error for unknown compare mode")
+	}
+}
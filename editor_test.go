@@ -100,6 +100,77 @@ func TestEditorCommit_AddReplaceDeleteDir(t *testing.T) {
 	}
 }
 
+func TestEditorCommit_HeaderOperations(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "archive.pbo")
+	err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": []byte("data"),
+	}, PackOptions{
+		Headers: []HeaderPair{
+			{Key: "prefix", Value: `old\addon`},
+			{Key: "product", Value: "dayz"},
+			{Key: "author", Value: "someone"},
+			{Key: "version", Value: "1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{BackupKeep: 0})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+
+	if err := editor.SetPrefix("/new/addon/"); err != nil {
+		t.Fatalf("SetPrefix: %v", err)
+	}
+	if err := editor.SetHeader("VERSION", "2"); err != nil {
+		t.Fatalf("SetHeader: %v", err)
+	}
+	if err := editor.DeleteHeader("Author"); err != nil {
+		t.Fatalf("DeleteHeader: %v", err)
+	}
+	if err := editor.SetHeader("license", "MIT"); err != nil {
+		t.Fatalf("SetHeader: %v", err)
+	}
+	if err := editor.SetHeader("", "x"); !errors.Is(err, ErrInvalidHeaderPair) {
+		t.Fatalf("expected ErrInvalidHeaderPair, got %v", err)
+	}
+
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	want := []HeaderPair{
+		{Key: "prefix", Value: `new\addon`},
+		{Key: "product", Value: "dayz"},
+		{Key: "version", Value: "2"},
+		{Key: "license", Value: "MIT"},
+	}
+	got := r.Headers()
+	if len(got) != len(want) {
+		t.Fatalf("headers=%v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("headers=%v, want %v", got, want)
+		}
+	}
+
+	data, err := r.ReadEntry("a.txt")
+	if err != nil || string(data) != "data" {
+		t.Fatalf("entry after header edit=%q, err=%v", data, err)
+	}
+}
+
 func TestEditorCommit_ReplaceMissingPathFailsAndRestoresSource(t *testing.T) {
 	t.Parallel()
 
@@ -314,3 +385,119 @@ func readEntryFromFile(path string, entryPath string) ([]byte, error) {
 func stringsEqualFold(left string, right string) bool {
 	return strings.EqualFold(left, right)
 }
+
+func TestEditorCommit_BackupLocationOptions(t *testing.T) {
+	t.Parallel()
+
+	replaceInput := func(path string, value string) Input {
+		return Input{
+			Path: path,
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte(value))), nil
+			},
+			SizeHint: int64(len(value)),
+		}
+	}
+
+	t.Run("copy into backup dir", func(t *testing.T) {
+		t.Parallel()
+
+		pboPath := filepath.Join(t.TempDir(), "archive.pbo")
+		if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("v0")}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		opts := EditOptions{BackupDir: backupDir, BackupCopy: true, BackupKeep: 1}
+		editor, err := OpenEditor(pboPath, opts)
+		if err != nil {
+			t.Fatalf("OpenEditor: %v", err)
+		}
+		if err := editor.Replace(replaceInput("a.txt", "v1")); err != nil {
+			t.Fatalf("Replace: %v", err)
+		}
+		if _, err := editor.Commit(context.Background()); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+
+		if got, err := readEntryFromFile(filepath.Join(backupDir, "archive.pbo.bak"), "a.txt"); err != nil || string(got) != "v0" {
+			t.Fatalf("backup payload=%q err=%v, want v0", got, err)
+		}
+		if got, err := readEntryFromFile(pboPath, "a.txt"); err != nil || string(got) != "v1" {
+			t.Fatalf("archive payload=%q err=%v, want v1", got, err)
+		}
+
+		editor, err = OpenEditor(pboPath, opts)
+		if err != nil {
+			t.Fatalf("OpenEditor: %v", err)
+		}
+		if err := editor.Replace(replaceInput("missing.txt", "x")); err != nil {
+			t.Fatalf("Replace: %v", err)
+		}
+		if _, err := editor.Commit(context.Background()); !errors.Is(err, ErrEntryNotFound) {
+			t.Fatalf("Commit err=%v, want ErrEntryNotFound", err)
+		}
+		if got, err := readEntryFromFile(pboPath, "a.txt"); err != nil || string(got) != "v1" {
+			t.Fatalf("restored payload=%q err=%v, want v1", got, err)
+		}
+	})
+
+	t.Run("timestamped template keeps newest", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		pboPath := filepath.Join(dir, "archive.pbo")
+		if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("v0")}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+		for _, name := range []string{"archive-20000101T000000Z.bak", "archive-20000102T000000Z.bak", "other.bak"} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("old"), 0o600); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+		}
+
+		editor, err := OpenEditor(pboPath, EditOptions{BackupNameTemplate: "{stem}-{timestamp}.bak", BackupKeep: 2})
+		if err != nil {
+			t.Fatalf("OpenEditor: %v", err)
+		}
+		if err := editor.Replace(replaceInput("a.txt", "v1")); err != nil {
+			t.Fatalf("Replace: %v", err)
+		}
+		if _, err := editor.Commit(context.Background()); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "archive-20000101T000000Z.bak")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("oldest backup must be pruned, stat err=%v", err)
+		}
+		for _, name := range []string{"archive-20000102T000000Z.bak", "other.bak"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Fatalf("%s must be kept: %v", name, err)
+			}
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, "archive-*.bak"))
+		if err != nil || len(matches) != 2 {
+			t.Fatalf("timestamped backups=%v err=%v, want 2", matches, err)
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		pboPath := filepath.Join(t.TempDir(), "archive.pbo")
+		if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("v0")}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+
+		for _, template := range []string{"{name}", "../{name}.bak"} {
+			editor, err := OpenEditor(pboPath, EditOptions{BackupNameTemplate: template})
+			if err != nil {
+				t.Fatalf("OpenEditor: %v", err)
+			}
+			if _, err := editor.Commit(context.Background()); !errors.Is(err, ErrInvalidBackupTemplate) {
+				t.Fatalf("template %q err=%v, want ErrInvalidBackupTemplate", template, err)
+			}
+		}
+	})
+}
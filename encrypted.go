@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// EncryptionKind identifies encrypted archive variant reported by EncryptedArchiveError.
+type EncryptionKind string
+
+// Detected encrypted archive variants.
+const (
+	// EncryptionEBO is bank with plain header pairs and unreadable entry table, like Arma
+	// encrypted .ebo files and archives packed with PackOptions.SealedKey.
+	EncryptionEBO EncryptionKind = "ebo"
+	// EncryptionEnco is bank whose header record carries "Enco" marker instead of "Vers".
+	EncryptionEnco EncryptionKind = "enco"
+)
+
+// encryptedTableProbeSize is number of entry table bytes inspected by looksEncryptedTable.
+const encryptedTableProbeSize = maxNameLen + 21
+
+var (
+	// archiveDecryptorsMu guards archiveDecryptors registry.
+	archiveDecryptorsMu sync.RWMutex
+	// archiveDecryptors maps encryption kind to registered decryption provider.
+	archiveDecryptors = make(map[EncryptionKind]ArchiveDecryptor)
+)
+
+// EncryptedArchiveError reports archive detected as encrypted bank.
+type EncryptedArchiveError struct {
+	// Kind is detected encryption variant.
+	Kind EncryptionKind `json:"kind" yaml:"kind"`
+	// Err is parse failure that led to detection; nil when marker identified bank.
+	Err error `json:"-" yaml:"-"`
+}
+
+// Error implements error.
+func (e *EncryptedArchiveError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%v: %s bank", ErrEncryptedArchive, e.Kind)
+	}
+
+	return fmt.Sprintf("%v: %s bank (%v)", ErrEncryptedArchive, e.Kind, e.Err)
+}
+
+// Unwrap returns ErrEncryptedArchive and parse failure.
+func (e *EncryptedArchiveError) Unwrap() []error {
+	return []error{ErrEncryptedArchive, e.Err}
+}
+
+// ArchiveDecryptor turns encrypted bank into plain PBO view for reader and listing functions.
+type ArchiveDecryptor interface {
+	// Decrypt returns plain archive bytes of encrypted source and their size.
+	// key is ReaderOptions.EntryKey of caller.
+	Decrypt(src io.ReaderAt, size int64, kind EncryptionKind, key []byte) (io.ReaderAt, int64, error)
+}
+
+// ArchiveDecryptorFunc adapts plain function to ArchiveDecryptor.
+type ArchiveDecryptorFunc func(src io.ReaderAt, size int64, kind EncryptionKind, key []byte) (io.ReaderAt, int64, error)
+
+// Decrypt calls f(src, size, kind, key).
+func (f ArchiveDecryptorFunc) Decrypt(src io.ReaderAt, size int64, kind EncryptionKind, key []byte) (io.ReaderAt, int64, error) {
+	return f(src, size, kind, key)
+}
+
+// RegisterArchiveDecryptor registers process-wide decryption provider for encryption kind.
+// Readers and listing functions retry archives detected as kind through it.
+func RegisterArchiveDecryptor(kind EncryptionKind, dec ArchiveDecryptor) error {
+	if kind == "" || dec == nil {
+		return fmt.Errorf("%w: decryptor for %q", ErrInvalidArchiveDecryptor, kind)
+	}
+
+	archiveDecryptorsMu.Lock()
+	archiveDecryptors[kind] = dec
+	archiveDecryptorsMu.Unlock()
+
+	return nil
+}
+
+// UnregisterArchiveDecryptor removes decryption provider registered for kind.
+func UnregisterArchiveDecryptor(kind EncryptionKind) {
+	archiveDecryptorsMu.Lock()
+	delete(archiveDecryptors, kind)
+	archiveDecryptorsMu.Unlock()
+}
+
+// lookupArchiveDecryptor returns registered decryption provider for kind or nil.
+func lookupArchiveDecryptor(kind EncryptionKind) ArchiveDecryptor {
+	archiveDecryptorsMu.RLock()
+	defer archiveDecryptorsMu.RUnlock()
+
+	return archiveDecryptors[kind]
+}
+
+// withArchiveDecryptor runs parse over src and, when it reports encrypted bank with
+// registered decryptor, runs it again over decrypted view. decrypted tells parse which pass runs.
+func withArchiveDecryptor[T any](
+	src io.ReaderAt,
+	size int64,
+	key []byte,
+	parse func(ra io.ReaderAt, size int64, decrypted bool) (T, error),
+) (T, error) {
+	out, err := parse(src, size, false)
+
+	var encErr *EncryptedArchiveError
+	if err == nil || !errors.As(err, &encErr) {
+		return out, err
+	}
+
+	dec := lookupArchiveDecryptor(encErr.Kind)
+	if dec == nil {
+		return out, err
+	}
+
+	plain, plainSize, decErr := dec.Decrypt(src, size, encErr.Kind, key)
+	if decErr != nil {
+		return out, fmt.Errorf("decrypt %s bank: %w", encErr.Kind, decErr)
+	}
+	if plain == nil {
+		return out, fmt.Errorf("decrypt %s bank: %w", encErr.Kind, ErrNilReader)
+	}
+
+	return parse(plain, plainSize, true)
+}
+
+// encryptedTableError wraps entry table parse error into EncryptedArchiveError when
+// table bytes do not look like PBO entry records. Limit errors are kept as is.
+func encryptedTableError(ra io.ReaderAt, tableOffset int64, size int64, err error) error {
+	if errors.Is(err, ErrLimitExceeded) || !looksEncryptedTable(ra, tableOffset, size) {
+		return err
+	}
+
+	return &EncryptedArchiveError{Kind: EncryptionEBO, Err: err}
+}
+
+// looksEncryptedTable reports whether first entry record at tableOffset is implausible:
+// name without terminator, name with control bytes, or payload larger than archive.
+func looksEncryptedTable(ra io.ReaderAt, tableOffset int64, size int64) bool {
+	if tableOffset >= size {
+		return false
+	}
+
+	probe := make([]byte, min(int64(encryptedTableProbeSize), size-tableOffset))
+	n, _ := ra.ReadAt(probe, tableOffset)
+	probe = probe[:n]
+
+	nameEnd := bytes.IndexByte(probe, 0)
+	if nameEnd < 0 {
+		return len(probe) == encryptedTableProbeSize
+	}
+	for _, c := range probe[:nameEnd] {
+		if c < 0x20 || c == 0x7f {
+			return true
+		}
+	}
+
+	fields := probe[nameEnd+1:]
+	if len(fields) < 20 {
+		return false
+	}
+
+	return int64(binary.LittleEndian.Uint32(fields[16:20])) > size
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpen_DetectsEncryptedBanks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string][]byte{"config.cpp": []byte("class CfgPatches {};"), "data/a.paa": []byte("texture")}
+	key := sealedTestKey()
+
+	sealedPath := filepath.Join(dir, "sealed.ebo")
+	if err := createTestPBO(sealedPath, files, PackOptions{SealedKey: &key}); err != nil {
+		t.Fatalf("createTestPBO sealed: %v", err)
+	}
+
+	_, err := Open(sealedPath)
+	var encErr *EncryptedArchiveError
+	if !errors.As(err, &encErr) || encErr.Kind != EncryptionEBO || !errors.Is(err, ErrEncryptedArchive) {
+		t.Fatalf("Open sealed err=%v", err)
+	}
+	if _, err := ListEntries(sealedPath); !errors.Is(err, ErrEncryptedArchive) {
+		t.Fatalf("ListEntries sealed err=%v", err)
+	}
+
+	plainPath := filepath.Join(dir, "plain.pbo")
+	if err := createTestPBO(plainPath, files, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO plain: %v", err)
+	}
+	data, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	encoPath := filepath.Join(dir, "enco.pbo")
+	binary.LittleEndian.PutUint32(data[1:5], uint32(MimeEncoded))
+	writeTestFile(t, encoPath, data)
+	if _, err := Open(encoPath); !errors.As(err, &encErr) || encErr.Kind != EncryptionEnco {
+		t.Fatalf("Open enco err=%v", err)
+	}
+	if _, err := ReadHeaders(encoPath); !errors.Is(err, ErrEncryptedArchive) {
+		t.Fatalf("ReadHeaders enco err=%v", err)
+	}
+
+	// Plain corruption keeps reporting parse error, not encryption.
+	truncPath := filepath.Join(dir, "trunc.pbo")
+	binary.LittleEndian.PutUint32(data[1:5], uint32(MimeHeader))
+	writeTestFile(t, truncPath, data[:40])
+	if _, err := Open(truncPath); err == nil || errors.Is(err, ErrEncryptedArchive) {
+		t.Fatalf("Open truncated err=%v", err)
+	}
+}
+
+func TestRegisterArchiveDecryptor(t *testing.T) {
+	key := sealedTestKey()
+	pboPath := filepath.Join(t.TempDir(), "sealed.ebo")
+	if err := createTestPBO(pboPath, map[string][]byte{"config.cpp": []byte("class CfgPatches {};")}, PackOptions{
+		Headers:   []HeaderPair{{Key: "prefix", Value: "bank"}},
+		SealedKey: &key,
+	}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	if err := RegisterArchiveDecryptor(EncryptionEBO, nil); !errors.Is(err, ErrInvalidArchiveDecryptor) {
+		t.Fatalf("RegisterArchiveDecryptor nil err=%v", err)
+	}
+
+	var calls int
+	err := RegisterArchiveDecryptor(EncryptionEBO, ArchiveDecryptorFunc(
+		func(src io.ReaderAt, size int64, kind EncryptionKind, entryKey []byte) (io.ReaderAt, int64, error) {
+			calls++
+			if kind != EncryptionEBO || string(entryKey) != string(key[:]) {
+				return nil, 0, errors.New("unexpected decrypt arguments")
+			}
+
+			var sealed SealedKey
+			copy(sealed[:], entryKey)
+			plain, err := prepareReaderAtWithSealedOptions(src, size, &sealed)
+			return plain, size, err
+		},
+	))
+	if err != nil {
+		t.Fatalf("RegisterArchiveDecryptor: %v", err)
+	}
+	t.Cleanup(func() { UnregisterArchiveDecryptor(EncryptionEBO) })
+
+	opts := ReaderOptions{EntryKey: key[:]}
+	r, err := OpenWithOptions(pboPath, opts)
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if data, err := r.ReadEntry("config.cpp"); err != nil || string(data) != "class CfgPatches {};" || r.Prefix() != "bank" {
+		t.Fatalf("ReadEntry=%q prefix=%q err=%v", data, r.Prefix(), err)
+	}
+
+	c, err := r.Clone()
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if _, err := c.ReadEntry("config.cpp"); err != nil {
+		t.Fatalf("Clone ReadEntry: %v", err)
+	}
+	_ = c.Close()
+
+	if entries, err := ListEntriesWithOptions(pboPath, opts); err != nil || len(entries) != 1 {
+		t.Fatalf("ListEntriesWithOptions=%v err=%v", entries, err)
+	}
+	if calls != 2 {
+		t.Fatalf("decryptor calls=%d, want 2", calls)
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"crypto"
+	_ "crypto/sha1"   //nolint:gosec // SHA1 is registered for entry manifests, not for security.
+	_ "crypto/sha256" // registers SHA256 for entry digests
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// EntryDigest is content digest of one packed entry.
+type EntryDigest struct {
+	// Path is entry path written to archive.
+	Path string `json:"path" yaml:"path"`
+	// Digest is lower-case hex digest of original (uncompressed) entry content.
+	Digest string `json:"digest" yaml:"digest"`
+}
+
+// HashEntry computes digest of decompressed content of named entry.
+func (r *Reader) HashEntry(name string, h crypto.Hash) ([]byte, error) {
+	hasher, err := newEntryHasher(h)
+	if err != nil {
+		return nil, err
+	}
+	if hasher == nil {
+		return nil, fmt.Errorf("%w: hash is not set", ErrUnsupportedHash)
+	}
+
+	rc, err := r.OpenEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := io.Copy(hasher, rc); err != nil {
+		return nil, fmt.Errorf("hash entry %s: %w", name, err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// HashEntries returns copy of entries with Checksum set to hex digest of decompressed
+// content, in entry table order. It reads every entry.
+func (r *Reader) HashEntries(h crypto.Hash) ([]EntryInfo, error) {
+	hasher, err := newEntryHasher(h)
+	if err != nil {
+		return nil, err
+	}
+	if hasher == nil {
+		return nil, fmt.Errorf("%w: hash is not set", ErrUnsupportedHash)
+	}
+
+	entries := r.Entries()
+	for i := range entries {
+		entries[i].Checksum, err = hashStoredEntry(hasher, r, entries[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// newEntryHasher returns hasher for selected algorithm or nil when hashing is disabled.
+func newEntryHasher(h crypto.Hash) (hash.Hash, error) {
+	if h == 0 {
+		return nil, nil
+	}
+	if h == XXH64 {
+		return newXXH64(), nil
+	}
+
+	if !h.Available() {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedHash, h)
+	}
+
+	return h.New(), nil
+}
+
+// hashStoredEntry hashes decoded content of already packed source entry through src
+// entry open path, so its entry key, codecs, and limits apply.
+func hashStoredEntry(hasher hash.Hash, src *Reader, info EntryInfo) (string, error) {
+	hasher.Reset()
+
+	rc, err := src.openEntryByInfo(&info, info.Path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if _, err := io.Copy(hasher, rc); err != nil {
+		return "", fmt.Errorf("hash entry %s: %w", info.Path, err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashingReader wraps src so consumed bytes are fed to hasher; hasher may be nil.
+func hashingReader(hasher hash.Hash, src io.Reader) io.Reader {
+	if hasher == nil {
+		return src
+	}
+
+	hasher.Reset()
+	return io.TeeReader(src, hasher)
+}
+
+// hexDigest returns current hasher digest as hex or empty string for nil hasher.
+func hexDigest(hasher hash.Hash) string {
+	if hasher == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackEntryHash_DigestsMatchContent(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"a.txt":     bytes.Repeat([]byte("compressible "), 128),
+		"dir/b.bin": []byte("raw payload"),
+	}
+	pboPath := filepath.Join(t.TempDir(), "hash.pbo")
+
+	var progressDigests []string
+	opts := PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+		EntryHash:       crypto.SHA256,
+		OnEntryDone: func(entry PackEntryProgress) {
+			progressDigests = append(progressDigests, entry.Digest)
+		},
+	}
+
+	res, err := PackFile(context.Background(), pboPath, streamTestInputs(files), opts)
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if res.CompressedEntries != 1 {
+		t.Fatalf("compressed entries=%d, want 1", res.CompressedEntries)
+	}
+	if len(res.EntryDigests) != len(files) || len(progressDigests) != len(files) {
+		t.Fatalf("digests=%+v progress=%v", res.EntryDigests, progressDigests)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for i, d := range res.EntryDigests {
+		sum := sha256.Sum256(files[NormalizePath(d.Path)])
+		want := hex.EncodeToString(sum[:])
+		if d.Digest != want || progressDigests[i] != want {
+			t.Fatalf("digest %s=%s progress=%s, want %s", d.Path, d.Digest, progressDigests[i], want)
+		}
+
+		got, err := r.HashEntry(d.Path, crypto.SHA256)
+		if err != nil {
+			t.Fatalf("HashEntry: %v", err)
+		}
+		if hex.EncodeToString(got) != want {
+			t.Fatalf("HashEntry %s=%x, want %s", d.Path, got, want)
+		}
+	}
+}
+
+func TestPackEntryHash_KeptEntriesOnEdit(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "edit.pbo")
+	payload := bytes.Repeat([]byte("kept "), 200)
+	err := createTestPBO(pboPath, map[string][]byte{"kept.txt": payload}, PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{PackOptions: PackOptions{EntryHash: crypto.SHA256}})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(streamTestInputs(map[string][]byte{"new.bin": []byte("new")})[0]); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	res, err := editor.Commit(context.Background())
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	sum := sha256.Sum256(payload)
+	if len(res.EntryDigests) != 2 || res.EntryDigests[0].Digest != hex.EncodeToString(sum[:]) {
+		t.Fatalf("digests=%+v", res.EntryDigests)
+	}
+}
+
+func TestReaderHashEntry_UnsupportedHash(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "x.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("a")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if _, err := r.HashEntry("a.txt", crypto.BLAKE2b_256); !errors.Is(err, ErrUnsupportedHash) {
+		t.Fatalf("expected ErrUnsupportedHash, got %v", err)
+	}
+}
+
+func TestXXH64_KnownVectors(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{
+		"":    "ef46db3751d8e999",
+		"a":   "d24ec4f1a98c6e5b",
+		"abc": "44bc2cf5ad770999",
+		"Nobody inspects the spammish repetition": "fbcea83c8a378bf1",
+	} {
+		h := newXXH64()
+		_, _ = h.Write([]byte(input))
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Fatalf("xxh64(%q)=%s, want %s", input, got, want)
+		}
+	}
+
+	data := bytes.Repeat([]byte("0123456789abcdef-"), 13)
+	whole := newXXH64()
+	_, _ = whole.Write(data)
+	split := newXXH64()
+	for _, b := range data {
+		_, _ = split.Write([]byte{b})
+	}
+	if whole.Sum64() != split.Sum64() {
+		t.Fatalf("byte-wise writes digest %x, want %x", split.Sum64(), whole.Sum64())
+	}
+}
+
+func TestReaderHashEntries_MatchPackDigests(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "xxh.pbo")
+	files := map[string][]byte{
+		"a.txt": bytes.Repeat([]byte("alpha "), 100),
+		"b.bin": []byte("bravo"),
+	}
+	f, err := os.Create(pboPath)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	res, err := Pack(context.Background(), f, streamTestInputs(files), PackOptions{
+		EntryHash:       XXH64,
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+	})
+	_ = f.Close()
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries, err := r.HashEntries(XXH64)
+	if err != nil {
+		t.Fatalf("HashEntries: %v", err)
+	}
+	if len(entries) != len(res.EntryDigests) {
+		t.Fatalf("entries=%d digests=%d", len(entries), len(res.EntryDigests))
+	}
+	for i, d := range res.EntryDigests {
+		if entries[i].Path != d.Path || entries[i].Checksum != d.Digest || len(d.Digest) != 16 {
+			t.Fatalf("entry %s checksum=%q, pack digest %+v", entries[i].Path, entries[i].Checksum, d)
+		}
+	}
+	if r.Entries()[0].Checksum != "" {
+		t.Fatal("HashEntries must not modify reader entries")
+	}
+}
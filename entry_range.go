@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// OpenEntryRange opens stream of up to length decoded bytes of named entry starting at off.
+// Stored entries seek directly; compressed and codec entries are decoded and skipped up to
+// off and decoding stops when stream is closed. Range past entry end is truncated.
+func (r *Reader) OpenEntryRange(name string, off int64, length int64) (io.ReadCloser, error) {
+	if r == nil || r.ra == nil {
+		return nil, ErrNilReader
+	}
+	if off < 0 || length < 0 {
+		return nil, fmt.Errorf("%w: offset %d, length %d", ErrInvalidEntryRange, off, length)
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	info := r.findEntryByName(name)
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, name)
+	}
+
+	if (info.MimeType == MimeNil || info.MimeType == MimeCompress) && !info.IsCompressed() {
+		size := int64(info.DataSize)
+		start := min(off, size)
+		return nopCloser{Reader: io.NewSectionReader(r.ra, int64(info.Offset)+start, min(length, size-start))}, nil
+	}
+
+	rc, err := r.openEntryByInfo(info, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.CopyN(io.Discard, rc, off); err != nil && !errors.Is(err, io.EOF) {
+		_ = rc.Close()
+		return nil, fmt.Errorf("skip to offset %d in %s: %w", off, name, err)
+	}
+
+	return readCloser{Reader: io.LimitReader(rc, length), Closer: rc}, nil
+}
+
+// ReadEntryRange reads up to length decoded bytes of named entry starting at off.
+// Result is shorter than length when range passes entry end.
+func (r *Reader) ReadEntryRange(name string, off int64, length int64) ([]byte, error) {
+	rc, err := r.OpenEntryRange(name, off, length)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	return io.ReadAll(rc)
+}
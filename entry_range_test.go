@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadEntryRange(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 512)
+	pboPath := filepath.Join(t.TempDir(), "range.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"packed.txt": content,
+		"plain.bin":  content,
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if info := r.findEntryByName("packed.txt"); info == nil || !info.IsCompressed() {
+		t.Fatalf("packed.txt must be compressed: %+v", info)
+	}
+
+	tests := []struct {
+		off, length int64
+		want        []byte
+	}{
+		{off: 0, length: 16, want: content[:16]},
+		{off: 4000, length: 100, want: content[4000:4100]},
+		{off: int64(len(content)) - 10, length: 100, want: content[len(content)-10:]},
+		{off: int64(len(content)) + 5, length: 10, want: nil},
+	}
+
+	for _, name := range []string{"packed.txt", "plain.bin"} {
+		for _, tt := range tests {
+			got, err := r.ReadEntryRange(name, tt.off, tt.length)
+			if err != nil {
+				t.Fatalf("%s [%d,+%d]: %v", name, tt.off, tt.length, err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Fatalf("%s [%d,+%d]: got %d bytes, want %d", name, tt.off, tt.length, len(got), len(tt.want))
+			}
+		}
+	}
+
+	if _, err := r.ReadEntryRange("plain.bin", -1, 1); !errors.Is(err, ErrInvalidEntryRange) {
+		t.Fatalf("negative offset err=%v, want ErrInvalidEntryRange", err)
+	}
+	if _, err := r.ReadEntryRange("missing", 0, 1); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("missing entry err=%v, want ErrEntryNotFound", err)
+	}
+}
@@ -5,6 +5,7 @@
 package pbo
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math"
@@ -24,6 +25,10 @@ func (nopCloser) Close() error {
 
 // findEntryByName resolves one entry by normalized path.
 func (r *Reader) findEntryByName(name string) *EntryInfo {
+	if r.LoadEntries() != nil {
+		return nil
+	}
+
 	lookupName := NormalizePath(name)
 	r.entryIndexOnce.Do(r.buildEntryIndex)
 
@@ -35,6 +40,34 @@ func (r *Reader) findEntryByName(name string) *EntryInfo {
 	return &r.entries[idx]
 }
 
+// Has reports whether archive contains entry at path using the same normalized
+// indexed lookup as OpenEntry.
+func (r *Reader) Has(path string) bool {
+	if r == nil {
+		return false
+	}
+
+	return r.findEntryByName(path) != nil
+}
+
+// Stat returns metadata of entry at path using the same lookup as Has; missing
+// entries fail with ErrEntryNotFound.
+func (r *Reader) Stat(path string) (EntryInfo, error) {
+	if r == nil {
+		return EntryInfo{}, ErrNilReader
+	}
+	if err := r.LoadEntries(); err != nil {
+		return EntryInfo{}, err
+	}
+
+	info := r.findEntryByName(path)
+	if info == nil {
+		return EntryInfo{}, fmt.Errorf("%w: %s", ErrEntryNotFound, path)
+	}
+
+	return *info, nil
+}
+
 // buildEntryIndex builds normalized path lookup index for parsed entries.
 func (r *Reader) buildEntryIndex() {
 	index := make(map[string]int, len(r.entries))
@@ -47,6 +80,18 @@ func (r *Reader) buildEntryIndex() {
 		index[key] = i
 	}
 
+	// Stored names of sanitized entries resolve too unless they collide with visible paths.
+	for i := range r.entries {
+		if r.entries[i].OriginalPath == "" {
+			continue
+		}
+
+		key := NormalizePath(r.entries[i].OriginalPath)
+		if _, exists := index[key]; !exists {
+			index[key] = i
+		}
+	}
+
 	r.entryIndex = index
 }
 
@@ -56,24 +101,152 @@ func (r *Reader) openEntryByInfo(info *EntryInfo, name string) (io.ReadCloser, e
 		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, name)
 	}
 
-	sr := io.NewSectionReader(r.ra, int64(info.Offset), int64(info.DataSize))
+	return r.openEntryPayload(info, name, io.NewSectionReader(r.ra, int64(info.Offset), int64(info.DataSize)))
+}
+
+// openEntryPayload opens decoded stream over stored payload of entry.
+func (r *Reader) openEntryPayload(info *EntryInfo, name string, sr io.Reader) (io.ReadCloser, error) {
+	if rc, ok, err := r.openCodecEntry(info, name, sr); ok {
+		if err != nil {
+			return rc, err
+		}
+		if err := r.checkDeclaredSize(info, name); err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+
+		return r.limitDecoded(rc, name), nil
+	}
+
 	if !info.IsCompressed() {
 		return nopCloser{Reader: sr}, nil
 	}
 
+	if err := r.checkDeclaredSize(info, name); err != nil {
+		return nil, err
+	}
+
 	outLen, err := checkedUint32ToInt(info.OriginalSize)
 	if err != nil {
 		return nil, fmt.Errorf("resolve output size for %s: %w", name, err)
 	}
 
+	if payload, ok := r.payloadBytes(info); ok {
+		out, _, err := lzss.DecompressBlock(payload, outLen, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompress entry %s: %w", name, err)
+		}
+
+		return r.limitDecoded(nopCloser{Reader: bytes.NewReader(out)}, name), nil
+	}
+
+	if !r.tryAcquireDecompress() {
+		return r.limitDecoded(newInlineDecompressReader(sr, name, outLen), name), nil
+	}
+
 	pr, pw := io.Pipe()
-	go streamDecompressEntry(name, pw, sr, outLen)
+	go func() {
+		defer r.releaseDecompress()
+		streamDecompressEntry(name, pw, sr, outLen)
+	}()
 
-	return pr, nil
+	return r.limitDecoded(pr, name), nil
+}
+
+// payloadBytes returns stored payload slice of entry for readers created by NewReaderFromBytes.
+func (r *Reader) payloadBytes(info *EntryInfo) ([]byte, bool) {
+	end := uint64(info.Offset) + uint64(info.DataSize)
+	if r.data == nil || end > uint64(len(r.data)) {
+		return nil, false
+	}
+
+	return r.data[info.Offset:end], true
+}
+
+// checkDeclaredSize fails entry whose declared original size exceeds per-entry or remaining total limit.
+func (r *Reader) checkDeclaredSize(info *EntryInfo, name string) error {
+	if r.maxDecompressed != 0 && info.OriginalSize > r.maxDecompressed {
+		return &EntrySizeError{Path: name, Size: int64(info.OriginalSize), Limit: r.maxDecompressed}
+	}
+
+	return checkLimit(LimitMaxTotalDecompressed, r.maxTotal, r.decompressed.Load()+int64(info.OriginalSize))
+}
+
+// limitDecoded wraps decoded entry stream with configured decompression limits.
+func (r *Reader) limitDecoded(rc io.ReadCloser, name string) io.ReadCloser {
+	if r.maxDecompressed == 0 && r.maxTotal <= 0 {
+		return rc
+	}
+
+	return &entryLimitReader{rc: rc, r: r, name: name}
+}
+
+// EntrySizeError reports entry whose decoded content exceeds ReaderOptions.MaxEntryDecompressedSize.
+type EntrySizeError struct {
+	// Path is entry path.
+	Path string `json:"path" yaml:"path"`
+	// Size is declared original size or decoded bytes read before limit was hit.
+	Size int64 `json:"size" yaml:"size"`
+	// Limit is configured MaxEntryDecompressedSize.
+	Limit uint32 `json:"limit" yaml:"limit"`
+}
+
+// Error implements error.
+func (e *EntrySizeError) Error() string {
+	return fmt.Sprintf("%v: %s has %d bytes, limit %d", ErrEntryTooLarge, e.Path, e.Size, e.Limit)
+}
+
+// Unwrap returns ErrEntryTooLarge.
+func (e *EntrySizeError) Unwrap() error {
+	return ErrEntryTooLarge
+}
+
+// entryLimitReader fails decoded stream once it exceeds per-entry or reader-wide limits.
+type entryLimitReader struct {
+	rc   io.ReadCloser
+	r    *Reader
+	err  error
+	name string
+	n    int64
+}
+
+// Read reads decoded bytes and returns EntrySizeError or LimitError past limits.
+func (l *entryLimitReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	n, err := l.rc.Read(p)
+	l.n += int64(n)
+	keep := n
+	if limit := int64(l.r.maxDecompressed); limit != 0 && l.n > limit {
+		keep -= int(l.n - limit)
+		l.err = &EntrySizeError{Path: l.name, Size: l.n, Limit: l.r.maxDecompressed}
+	}
+
+	if l.r.maxTotal > 0 {
+		total := l.r.decompressed.Add(int64(keep))
+		if total > l.r.maxTotal && l.err == nil {
+			keep -= int(total - l.r.maxTotal)
+			l.err = &LimitError{Limit: LimitMaxTotalDecompressed, Max: l.r.maxTotal, Actual: total}
+		}
+	}
+
+	if l.err != nil {
+		return max(keep, 0), l.err
+	}
+
+	return n, err
+}
+
+// Close closes decoded stream.
+func (l *entryLimitReader) Close() error {
+	return l.rc.Close()
 }
 
 // OpenEntry opens named entry for reading.
 // Returned stream yields decompressed content for LZSS-compressed entries.
+// Streams must be closed: an abandoned compressed stream holds its decompression slot.
 func (r *Reader) OpenEntry(name string) (io.ReadCloser, error) {
 	if r == nil || r.ra == nil {
 		return nil, ErrNilReader
@@ -85,6 +258,9 @@ func (r *Reader) OpenEntry(name string) (io.ReadCloser, error) {
 	if closed {
 		return nil, ErrClosed
 	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
 
 	return r.openEntryByInfo(r.findEntryByName(name), name)
 }
@@ -122,6 +298,66 @@ func (r *Reader) ReadEntry(name string) ([]byte, error) {
 	return io.ReadAll(rc)
 }
 
+// WriteEntryTo streams full (decompressed) content of the named entry into w and returns
+// bytes written. Compressed entries are decoded straight into w without intermediate pipe.
+func (r *Reader) WriteEntryTo(w io.Writer, name string) (int64, error) {
+	if r == nil || r.ra == nil {
+		return 0, ErrNilReader
+	}
+	if w == nil {
+		return 0, ErrNilWriter
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return 0, ErrClosed
+	}
+	if err := r.LoadEntries(); err != nil {
+		return 0, err
+	}
+
+	info := r.findEntryByName(name)
+	if info == nil {
+		return 0, fmt.Errorf("%w: %s", ErrEntryNotFound, name)
+	}
+
+	sr := io.NewSectionReader(r.ra, int64(info.Offset), int64(info.DataSize))
+	if info.MimeType != MimeNil && info.MimeType != MimeCompress {
+		rc, err := r.openEntryPayload(info, name, sr)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = rc.Close() }()
+
+		return io.Copy(w, rc)
+	}
+	if !info.IsCompressed() {
+		return io.Copy(w, sr)
+	}
+
+	if err := r.checkDeclaredSize(info, name); err != nil {
+		return 0, err
+	}
+
+	outLen, err := checkedUint32ToInt(info.OriginalSize)
+	if err != nil {
+		return 0, fmt.Errorf("resolve output size for %s: %w", name, err)
+	}
+
+	cw := &countingWriter{w: w}
+	_, err = lzss.DecompressToWriter(cw, sr, outLen, nil)
+	if r.maxTotal > 0 {
+		r.decompressed.Add(cw.n)
+	}
+	if err != nil {
+		return cw.n, fmt.Errorf("decompress entry %s: %w", name, err)
+	}
+
+	return cw.n, nil
+}
+
 // streamDecompressEntry decodes one compressed entry stream into pipe writer.
 func streamDecompressEntry(name string, dst *io.PipeWriter, src io.Reader, outLen int) {
 	_, err := lzss.DecompressToWriter(dst, src, outLen, nil)
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// deadlineChunkSize is read size of background source reads behind deadline reader.
+const deadlineChunkSize = 32 << 10
+
+// openWithDeadline opens entry stream with open and guards it with per-entry stall
+// timeout. Zero timeout returns open result unchanged. Open call itself and every
+// later Read must make progress within timeout, otherwise they fail with ErrEntryTimeout.
+func openWithDeadline(path string, timeout time.Duration, open func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if timeout <= 0 {
+		return open()
+	}
+
+	type openResult struct {
+		rc  io.ReadCloser
+		err error
+	}
+
+	// Buffered so late Open result can be delivered and closed after timeout.
+	opened := make(chan openResult, 1)
+	go func() {
+		rc, err := open()
+		opened <- openResult{rc: rc, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-opened:
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		return newDeadlineReader(res.rc, path, timeout), nil
+	case <-timer.C:
+		go func() {
+			if res := <-opened; res.rc != nil {
+				_ = res.rc.Close()
+			}
+		}()
+
+		return nil, entryTimeoutError(path, "open", timeout)
+	}
+}
+
+// entryTimeoutError wraps ErrEntryTimeout and context.DeadlineExceeded for stalled entry.
+func entryTimeoutError(path string, op string, timeout time.Duration) error {
+	return fmt.Errorf("%w: %s %s made no progress in %s: %w", ErrEntryTimeout, op, path, timeout, context.DeadlineExceeded)
+}
+
+// deadlineChunk is one result of background source read.
+type deadlineChunk struct {
+	err  error
+	data []byte
+}
+
+// deadlineReader reads source in background goroutine so stalled Read can be abandoned.
+// Each chunk re-arms timeout, so slow but moving streams are not interrupted. Source is
+// closed only after background read returns, unless that read is stalled past timeout;
+// then source is closed concurrently to unblock it.
+type deadlineReader struct {
+	src      io.ReadCloser
+	err      error
+	closeErr error
+	chunks   chan deadlineChunk
+	done     chan struct{}
+	exited   chan struct{}
+	path     string
+	pending  []byte
+	// bufs alternate between background read and chunk being consumed by Read.
+	bufs      [2][]byte
+	timeout   time.Duration
+	closeOnce sync.Once
+}
+
+// newDeadlineReader starts background reads of src.
+func newDeadlineReader(src io.ReadCloser, path string, timeout time.Duration) *deadlineReader {
+	d := &deadlineReader{
+		src:     src,
+		chunks:  make(chan deadlineChunk),
+		done:    make(chan struct{}),
+		exited:  make(chan struct{}),
+		path:    path,
+		timeout: timeout,
+		bufs:    [2][]byte{make([]byte, deadlineChunkSize), make([]byte, deadlineChunkSize)},
+	}
+	go d.pump()
+
+	return d
+}
+
+// pump forwards source chunks until error or Close. Unbuffered chunks channel means
+// next chunk is taken only after previous one is consumed, so buffer of previous
+// chunk is free when pump reads into it again.
+func (d *deadlineReader) pump() {
+	defer close(d.exited)
+
+	for i := 0; ; i ^= 1 {
+		n, err := d.src.Read(d.bufs[i])
+		select {
+		case d.chunks <- deadlineChunk{data: d.bufs[i][:n], err: err}:
+		case <-d.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Read returns buffered source bytes, waiting at most timeout for next chunk.
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		timer := time.NewTimer(d.timeout)
+		select {
+		case chunk := <-d.chunks:
+			timer.Stop()
+			d.pending, d.err = chunk.data, chunk.err
+		case <-timer.C:
+			d.err = entryTimeoutError(d.path, "read", d.timeout)
+			// Closing source unblocks most network streams stuck in Read.
+			d.closeSource(false)
+		}
+	}
+
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+// Close stops background reads and closes source once.
+func (d *deadlineReader) Close() error {
+	d.closeSource(true)
+	return d.closeErr
+}
+
+// closeSource stops pump and closes source once. With wait, source is closed after
+// in-flight background read returns or stays stalled for timeout.
+func (d *deadlineReader) closeSource(wait bool) {
+	d.closeOnce.Do(func() {
+		close(d.done)
+		if wait {
+			timer := time.NewTimer(d.timeout)
+			select {
+			case <-d.exited:
+			case <-timer.C:
+			}
+			timer.Stop()
+		}
+		d.closeErr = d.src.Close()
+	})
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stallReader returns prefix and then blocks in Read until closed.
+type stallReader struct {
+	closed chan struct{}
+	prefix []byte
+}
+
+func newStallReader(prefix string) *stallReader {
+	return &stallReader{closed: make(chan struct{}), prefix: []byte(prefix)}
+}
+
+func (s *stallReader) Read(p []byte) (int, error) {
+	if len(s.prefix) > 0 {
+		n := copy(p, s.prefix)
+		s.prefix = s.prefix[n:]
+		return n, nil
+	}
+
+	<-s.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (s *stallReader) Close() error {
+	close(s.closed)
+	return nil
+}
+
+// slowReader returns one byte per delay.
+type slowReader struct {
+	data  []byte
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	if len(s.data) == 0 {
+		return 0, io.EOF
+	}
+
+	time.Sleep(s.delay)
+	p[0] = s.data[0]
+	s.data = s.data[1:]
+	return 1, nil
+}
+
+func TestPack_PerEntryTimeout(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.pbo")
+	opts := PackOptions{PerEntryTimeout: 50 * time.Millisecond}
+
+	stalled := newStallReader("partial")
+	_, err := PackFile(context.Background(), out, []Input{{
+		Path: "stalled.bin",
+		Open: func() (io.ReadCloser, error) { return stalled, nil },
+	}}, opts)
+	if !errors.Is(err, ErrEntryTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("stalled read err = %v, want ErrEntryTimeout", err)
+	}
+	select {
+	case <-stalled.closed:
+	default:
+		t.Fatal("stalled input was not closed")
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	_, err = PackFile(context.Background(), out, []Input{{
+		Path: "open.bin",
+		Open: func() (io.ReadCloser, error) {
+			<-release
+			return io.NopCloser(bytes.NewReader(nil)), nil
+		},
+	}}, opts)
+	if !errors.Is(err, ErrEntryTimeout) {
+		t.Fatalf("stalled open err = %v, want ErrEntryTimeout", err)
+	}
+
+	// Slow but moving stream re-arms timeout on every chunk.
+	_, err = PackFile(context.Background(), out, []Input{{
+		Path: "slow.bin",
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(&slowReader{data: []byte("abcdef"), delay: 20 * time.Millisecond}), nil
+		},
+	}}, opts)
+	if err != nil {
+		t.Fatalf("slow input: %v", err)
+	}
+
+	r, err := Open(out)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	data, err := r.ReadEntry("slow.bin")
+	if err != nil || string(data) != "abcdef" {
+		t.Fatalf("ReadEntry = %q, %v", data, err)
+	}
+}
+
+func TestExtract_PerEntryTimeout(t *testing.T) {
+	t.Parallel()
+
+	archive := filepath.Join(t.TempDir(), "in.pbo")
+	if err := createTestPBO(archive, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(archive)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dst := t.TempDir()
+	if err := r.Extract(context.Background(), dst, ExtractOptions{PerEntryTimeout: time.Second}); err != nil {
+		t.Fatalf("Extract with timeout: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	if err != nil || string(got) != "alpha" {
+		t.Fatalf("extracted = %q, %v", got, err)
+	}
+}
+
+func TestOpenWithDeadline_Disabled(t *testing.T) {
+	t.Parallel()
+
+	src := io.NopCloser(bytes.NewReader([]byte("x")))
+	rc, err := openWithDeadline("x", 0, func() (io.ReadCloser, error) { return src, nil })
+	if err != nil || rc != src {
+		t.Fatalf("openWithDeadline zero timeout = %v, %v; want source unchanged", rc, err)
+	}
+}
+
+// closeCheckReader fails test when Close runs while Read is in progress.
+type closeCheckReader struct {
+	t      *testing.T
+	src    io.ReadCloser
+	inRead atomic.Bool
+}
+
+func (c *closeCheckReader) Read(p []byte) (int, error) {
+	c.inRead.Store(true)
+	defer c.inRead.Store(false)
+
+	time.Sleep(time.Millisecond)
+	return c.src.Read(p)
+}
+
+func (c *closeCheckReader) Close() error {
+	if c.inRead.Load() {
+		c.t.Error("source closed during Read")
+	}
+
+	return c.src.Close()
+}
+
+func TestDeadlineReader_InlineDecompress(t *testing.T) {
+	t.Parallel()
+
+	big := bytes.Repeat([]byte("inline entry behind deadline reader "), 64<<10)
+	pboPath := filepath.Join(t.TempDir(), "inline.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": []byte("holds the only decompression slot"),
+		"b.txt": big,
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{MaxDecompressStreams: 1})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	busy, err := r.OpenEntry("a.txt")
+	if err != nil {
+		t.Fatalf("OpenEntry a.txt: %v", err)
+	}
+	defer func() { _ = busy.Close() }()
+
+	// Large healthy inline entry is decoded per chunk and never trips short timeout.
+	rc, err := openWithDeadline("b.txt", 200*time.Millisecond, func() (io.ReadCloser, error) { return r.OpenEntry("b.txt") })
+	if err != nil {
+		t.Fatalf("openWithDeadline: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil || !bytes.Equal(got, big) {
+		t.Fatalf("read inline entry err=%v equal=%v", err, bytes.Equal(got, big))
+	}
+	if err := rc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Early close while background read runs must not race with source Read.
+	for range 8 {
+		inline, err := r.OpenEntry("b.txt")
+		if err != nil {
+			t.Fatalf("OpenEntry b.txt: %v", err)
+		}
+		d := newDeadlineReader(&closeCheckReader{t: t, src: inline}, "b.txt", time.Second)
+		if _, err := d.Read(make([]byte, 10)); err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if err := d.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+}
@@ -34,6 +34,24 @@ var (
 	ErrUnsupportedSignVersion = errors.New("unsupported signature version")
 	// ErrUnsupportedGameTypeV3 means the game type is not supported for v3.
 	ErrUnsupportedGameTypeV3 = errors.New("unsupported game type for v3")
+	// ErrNilSignPolicy means custom signature hash policy is nil.
+	ErrNilSignPolicy = errors.New("sign policy is nil")
+	// ErrInvalidSignVersionSpec means RegisterSignVersion got reserved version or incomplete spec.
+	ErrInvalidSignVersionSpec = errors.New("invalid sign version spec")
+	// ErrInvalidPrefixFile means $PBOPREFIX$-style prefix file content is malformed.
+	ErrInvalidPrefixFile = errors.New("invalid prefix file")
+	// ErrInvalidBiKey means .bikey content is malformed.
+	ErrInvalidBiKey = errors.New("invalid bikey")
+	// ErrInvalidBisign means .bisign content is malformed.
+	ErrInvalidBisign = errors.New("invalid bisign")
+	// ErrSignatureMismatch means signature does not decode to archive hash with given key.
+	ErrSignatureMismatch = errors.New("signature mismatch")
+	// ErrNoMatchingKey means no key of keys directory accepts the signature.
+	ErrNoMatchingKey = errors.New("no matching key")
+	// ErrVerifyMismatch means written payload read back differs from its source (see PackOptions.VerifyAfterWrite).
+	ErrVerifyMismatch = errors.New("written payload does not match source")
+	// ErrEntrySizeMismatch means extracted entry size differs from its OriginalSize (see ExtractOptions.StrictSizes).
+	ErrEntrySizeMismatch = errors.New("extracted size does not match original size")
 	// ErrTrailerTooShort means the file is too short for the trailer.
 	ErrTrailerTooShort = errors.New("file too short for trailer")
 	// ErrInvalidTrailerPrefix means the trailer does not start with 0x00.
@@ -44,12 +62,84 @@ var (
 	ErrInvalidSHA1DigestLength = errors.New("invalid SHA1 digest length")
 	// ErrInvalidEntryPath means one of input entry paths is empty or invalid after normalization.
 	ErrInvalidEntryPath = errors.New("invalid entry path")
+	// ErrInvalidPathCaseSensitivity means PackOptions.PathCaseSensitivity is unknown.
+	ErrInvalidPathCaseSensitivity = errors.New("invalid path case sensitivity")
+	// ErrInvalidPayloadAlignment means PackOptions.PayloadAlignment is out of range.
+	ErrInvalidPayloadAlignment = errors.New("invalid payload alignment")
 	// ErrDuplicateEntryPath means two inputs resolve to the same path (case-insensitive).
 	ErrDuplicateEntryPath = errors.New("duplicate entry path")
 	// ErrInvalidExtractPath means archive entry path is invalid for extraction destination.
 	ErrInvalidExtractPath = errors.New("invalid extract path")
+	// ErrExtractPathCollision means two entries map to output paths differing only by case.
+	ErrExtractPathCollision = errors.New("extract path case collision")
+	// ErrInvalidExtractErrorPolicy means ExtractOptions.ErrorPolicy is unknown.
+	ErrInvalidExtractErrorPolicy = errors.New("invalid extract error policy")
+	// ErrInsufficientSpace means destination filesystem lacks free space for extraction (see DiskSpaceError).
+	ErrInsufficientSpace = errors.New("insufficient disk space")
+	// ErrUnsupportedExportFormat means export format is unknown or has no registered encoder.
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+	// ErrInvalidExport means compressed container has no readable index part.
+	ErrInvalidExport = errors.New("invalid compressed export")
+	// ErrInvalidEntryFields means PackOptions.EntryFields changed entry data size field.
+	ErrInvalidEntryFields = errors.New("invalid entry fields")
+	// ErrInvalidEntryRange means entry range has negative offset or length.
+	ErrInvalidEntryRange = errors.New("invalid entry range")
+	// ErrEntryTooLarge means entry decoded size exceeds ReaderOptions.MaxEntryDecompressedSize (see EntrySizeError).
+	ErrEntryTooLarge = errors.New("entry decompressed size exceeds limit")
+	// ErrLimitExceeded means archive exceeds one of ReaderOptions.Limits (see LimitError).
+	ErrLimitExceeded = errors.New("archive resource limit exceeded")
+	// ErrInvalidPackOrder means PackOptions.Order is unknown or custom order has no OrderLess.
+	ErrInvalidPackOrder = errors.New("invalid pack order")
 	// ErrExtractPathOutsideRoot means resolved extraction path escapes destination root.
 	ErrExtractPathOutsideRoot = errors.New("extract path escapes destination root")
+	// ErrAtomicSkipUnchanged means ExtractOptions.Atomic is combined with ExtractFileModeSkipUnchanged.
+	ErrAtomicSkipUnchanged = errors.New("atomic extract cannot skip unchanged files")
 	// ErrInvalidEntryOffset means one or more entry offsets are malformed for selected reader policy.
 	ErrInvalidEntryOffset = errors.New("invalid entry offset")
+	// ErrSizeHintRequired means streamed pack mode requires positive SizeHint on every input.
+	ErrSizeHintRequired = errors.New("size hint is required")
+	// ErrSizeHintMismatch means input stream length differs from declared SizeHint.
+	ErrSizeHintMismatch = errors.New("input size does not match size hint")
+	// ErrModNotFound means requested mod directory is missing in server layout.
+	ErrModNotFound = errors.New("mod not found")
+	// ErrInvalidMission means mission folder has no recognized mission marker file.
+	ErrInvalidMission = errors.New("invalid mission folder")
+	// ErrInvalidBackupTemplate means EditOptions.BackupNameTemplate yields empty, nested, or archive path.
+	ErrInvalidBackupTemplate = errors.New("invalid backup name template")
+	// ErrNotAppendOnly means Editor.CommitAppend cannot apply staged operations in place.
+	ErrNotAppendOnly = errors.New("edit is not append-only")
+	// ErrOutputIsSource means output archive path points to one of the source archives.
+	ErrOutputIsSource = errors.New("output archive is also a source")
+	// ErrUnsupportedHash means requested hash algorithm is not set or not linked into binary.
+	ErrUnsupportedHash = errors.New("unsupported hash algorithm")
+	// ErrInvalidEntryCodec means entry codec registration is rejected.
+	ErrInvalidEntryCodec = errors.New("invalid entry codec")
+	// ErrEntryCodecNotFound means entry uses encoded mime marker without registered codec.
+	ErrEntryCodecNotFound = errors.New("entry codec not registered")
+	// ErrRangeNotSupported means remote server does not serve consistent byte ranges.
+	ErrRangeNotSupported = errors.New("HTTP range requests not supported")
+	// ErrInvalidHeaderPair means header key is empty or key/value contains NUL byte.
+	ErrInvalidHeaderPair = errors.New("invalid header key or value")
+	// ErrDuplicateHeaderKey means header key repeats without PackOptions.AllowDuplicateHeaders.
+	ErrDuplicateHeaderKey = errors.New("duplicate header key")
+	// ErrInvalidIndexSidecar means .pboidx sidecar is malformed or has unsupported version.
+	ErrInvalidIndexSidecar = errors.New("invalid index sidecar")
+	// ErrStaleIndexSidecar means archive size or modification time differs from sidecar record.
+	ErrStaleIndexSidecar = errors.New("stale index sidecar")
+	// ErrEncryptedArchive means archive is encrypted bank (see EncryptedArchiveError), not corrupted PBO.
+	ErrEncryptedArchive = errors.New("archive is encrypted")
+	// ErrInvalidArchiveDecryptor means archive decryptor registration is rejected.
+	ErrInvalidArchiveDecryptor = errors.New("invalid archive decryptor")
+	// ErrInvalidConfig means config entry cannot be parsed for CfgPatches.
+	ErrInvalidConfig = errors.New("invalid config")
+	// ErrMissingModTime means input has zero ModTime while PackOptions.RequireModTime is set.
+	ErrMissingModTime = errors.New("input modification time is not set")
+	// ErrPathTooLong means output path exceeds platform path length limit during extract.
+	ErrPathTooLong = errors.New("output path too long")
+	// ErrInvalidUnicodeForm means UnicodeForm is not one of supported normalization forms.
+	ErrInvalidUnicodeForm = errors.New("invalid unicode form")
+	// ErrInvalidNameEncoding means NameEncoding is unknown or not usable for operation.
+	ErrInvalidNameEncoding = errors.New("invalid name encoding")
+	// ErrEntryTimeout means entry stream made no progress within PerEntryTimeout.
+	ErrEntryTimeout = errors.New("entry timeout")
 )
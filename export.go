@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ExportFormat selects compressed container written by ExportCompressed.
+type ExportFormat string
+
+// Compressed container formats.
+const (
+	// ExportFormatGzip writes .pbo.gz: stored gzip member with header and index,
+	// then compressed member with payloads. gunzip restores original archive.
+	ExportFormatGzip ExportFormat = "gzip"
+	// ExportFormatZstd writes .pbo.zst: zstd skippable frame with header and index,
+	// then frames of full archive from encoder registered with RegisterExportEncoder.
+	ExportFormatZstd ExportFormat = "zstd"
+)
+
+// ExportEncoderFunc returns compressing writer of export format over w.
+type ExportEncoderFunc func(w io.Writer) (io.WriteCloser, error)
+
+const (
+	// exportGzipExtraID is gzip extra subfield ID of index member ("PB").
+	exportGzipExtraID = "PB"
+	// exportZstdSkippableMagic is zstd skippable frame magic used for index frame.
+	exportZstdSkippableMagic = 0x184D2A5B
+	// exportZstdIndexMagic opens index frame payload.
+	exportZstdIndexMagic = "PBOX"
+)
+
+var (
+	// exportEncodersMu guards exportEncoders registry.
+	exportEncodersMu sync.RWMutex
+	// exportEncoders maps export format to registered payload encoder.
+	exportEncoders = make(map[ExportFormat]ExportEncoderFunc)
+)
+
+// ExportIndex is archive header and index read from compressed container.
+type ExportIndex struct {
+	// Format is container format.
+	Format ExportFormat `json:"format" yaml:"format"`
+	// Headers are archive header pairs.
+	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Entries are archive entries with offsets into original archive.
+	Entries []EntryInfo `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// Size is original archive size in bytes.
+	Size int64 `json:"size" yaml:"size"`
+}
+
+// RegisterExportEncoder registers process-wide payload encoder for format.
+// Zstd has no built-in encoder; gzip encoder replaces default gzip.BestCompression writer.
+func RegisterExportEncoder(format ExportFormat, fn ExportEncoderFunc) error {
+	switch format {
+	case ExportFormatGzip, ExportFormatZstd:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedExportFormat, format)
+	}
+
+	if fn == nil {
+		return fmt.Errorf("%w: %s encoder is nil", ErrUnsupportedExportFormat, format)
+	}
+
+	exportEncodersMu.Lock()
+	exportEncoders[format] = fn
+	exportEncodersMu.Unlock()
+
+	return nil
+}
+
+// UnregisterExportEncoder removes encoder registered for format.
+func UnregisterExportEncoder(format ExportFormat) {
+	exportEncodersMu.Lock()
+	delete(exportEncoders, format)
+	exportEncodersMu.Unlock()
+}
+
+// lookupExportEncoder returns encoder for format or error when none is available.
+func lookupExportEncoder(format ExportFormat) (ExportEncoderFunc, error) {
+	exportEncodersMu.RLock()
+	fn := exportEncoders[format]
+	exportEncodersMu.RUnlock()
+
+	switch {
+	case fn != nil:
+		return fn, nil
+	case format == ExportFormatGzip:
+		return func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, gzip.BestCompression)
+		}, nil
+	case format == ExportFormatZstd:
+		return nil, fmt.Errorf("%w: %s encoder is not registered", ErrUnsupportedExportFormat, format)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedExportFormat, format)
+	}
+}
+
+// ExportCompressed writes PBO at pboPath into compressed container at outPath. Archive
+// header and index are stored uncompressed up front, so ReadExportIndex lists entries
+// without decompressing payloads, while standard tools still restore original archive.
+func ExportCompressed(ctx context.Context, pboPath string, outPath string, format ExportFormat) error {
+	encode, err := lookupExportEncoder(format)
+	if err != nil {
+		return err
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		return err
+	}
+	size, dataStart := r.size, r.dataStart
+	_ = r.Close()
+
+	src, err := os.Open(pboPath) //nolint:gosec // caller-selected archive path
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	index := make([]byte, dataStart)
+	if _, err := src.ReadAt(index, 0); err != nil {
+		return fmt.Errorf("read archive index: %w", err)
+	}
+
+	f, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+
+	w := bufio.NewWriterSize(f, DefaultWriteBuffer)
+	err = writeExport(ctx, w, src, index, size, format, encode)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close export file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(outPath)
+		return err
+	}
+
+	return nil
+}
+
+// writeExport writes index part and encoded archive part of container into w.
+func writeExport(
+	ctx context.Context,
+	w io.Writer,
+	src io.ReaderAt,
+	index []byte,
+	size int64,
+	format ExportFormat,
+	encode ExportEncoderFunc,
+) error {
+	var sizeField [8]byte
+	binary.LittleEndian.PutUint64(sizeField[:], uint64(size)) //nolint:gosec // archive size is non-negative
+
+	payloadStart := int64(0)
+	switch format {
+	case ExportFormatGzip:
+		zw, err := gzip.NewWriterLevel(w, gzip.NoCompression)
+		if err != nil {
+			return err
+		}
+
+		zw.Extra = append([]byte(exportGzipExtraID+"\x08\x00"), sizeField[:]...)
+		if _, err := zw.Write(index); err != nil {
+			return fmt.Errorf("write index member: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("write index member: %w", err)
+		}
+
+		payloadStart = int64(len(index))
+
+	case ExportFormatZstd:
+		var frame [8]byte
+		binary.LittleEndian.PutUint32(frame[0:4], exportZstdSkippableMagic)
+		binary.LittleEndian.PutUint32(frame[4:8], uint32(len(exportZstdIndexMagic)+len(sizeField)+len(index))) //nolint:gosec // index is bounded by PBO table limits
+		for _, part := range [][]byte{frame[:], []byte(exportZstdIndexMagic), sizeField[:], index} {
+			if _, err := w.Write(part); err != nil {
+				return fmt.Errorf("write index frame: %w", err)
+			}
+		}
+	}
+
+	enc, err := encode(w)
+	if err != nil {
+		return fmt.Errorf("create %s encoder: %w", format, err)
+	}
+
+	buf := make([]byte, DefaultWriteBuffer)
+	payload := io.NewSectionReader(src, payloadStart, size-payloadStart)
+	for {
+		if err := ctx.Err(); err != nil {
+			_ = enc.Close()
+			return err
+		}
+
+		n, readErr := payload.Read(buf)
+		if n > 0 {
+			if _, err := enc.Write(buf[:n]); err != nil {
+				_ = enc.Close()
+				return fmt.Errorf("compress archive: %w", err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			_ = enc.Close()
+			return fmt.Errorf("read archive: %w", readErr)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("compress archive: %w", err)
+	}
+
+	return nil
+}
+
+// ReadExportIndex reads archive header and index from container written by
+// ExportCompressed without decompressing payloads.
+func ReadExportIndex(path string) (*ExportIndex, error) {
+	f, err := os.Open(path) //nolint:gosec // caller-selected export path
+	if err != nil {
+		return nil, fmt.Errorf("open export file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	br := bufio.NewReader(f)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	var (
+		format ExportFormat
+		index  []byte
+		size   uint64
+	)
+	switch {
+	case magic[0] == 0x1f && magic[1] == 0x8b:
+		format = ExportFormatGzip
+		index, size, err = readGzipExportIndex(br)
+	case binary.LittleEndian.Uint32(magic) == exportZstdSkippableMagic:
+		format = ExportFormatZstd
+		index, size, err = readZstdExportIndex(br)
+	default:
+		return nil, fmt.Errorf("%w: unknown container magic", ErrInvalidExport)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if size < uint64(len(index)) || size > maxPBOData {
+		return nil, fmt.Errorf("%w: archive size %d", ErrInvalidExport, size)
+	}
+
+	r, err := NewReaderFromReaderAtWithOptions(exportIndexReaderAt(index), int64(size), ReaderOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	return &ExportIndex{
+		Format:  format,
+		Headers: r.Headers(),
+		Entries: r.Entries(),
+		Size:    int64(size),
+	}, nil
+}
+
+// readGzipExportIndex reads index member of gzip container.
+func readGzipExportIndex(br *bufio.Reader) ([]byte, uint64, error) {
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	zr.Multistream(false)
+
+	extra := zr.Extra
+	if len(extra) != 12 || string(extra[:2]) != exportGzipExtraID || binary.LittleEndian.Uint16(extra[2:4]) != 8 {
+		return nil, 0, fmt.Errorf("%w: missing index member", ErrInvalidExport)
+	}
+
+	index, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	return index, binary.LittleEndian.Uint64(extra[4:12]), nil
+}
+
+// readZstdExportIndex reads index skippable frame of zstd container.
+func readZstdExportIndex(br *bufio.Reader) ([]byte, uint64, error) {
+	var head [16]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if string(head[8:12]) != exportZstdIndexMagic {
+		return nil, 0, fmt.Errorf("%w: missing index frame", ErrInvalidExport)
+	}
+
+	frameSize := int64(binary.LittleEndian.Uint32(head[4:8]))
+	if frameSize < 12 {
+		return nil, 0, fmt.Errorf("%w: index frame size %d", ErrInvalidExport, frameSize)
+	}
+
+	var sizeField [8]byte
+	copy(sizeField[:4], head[12:16])
+	if _, err := io.ReadFull(br, sizeField[4:]); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	var index bytes.Buffer
+	if _, err := io.CopyN(&index, br, frameSize-12); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	return index.Bytes(), binary.LittleEndian.Uint64(sizeField[:]), nil
+}
+
+// exportIndexReaderAt serves index bytes and zeros for payload region of original archive.
+type exportIndexReaderAt []byte
+
+// ReadAt copies index bytes at off and zero-fills the rest of p.
+func (b exportIndexReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n := 0
+	if off < int64(len(b)) {
+		n = copy(p, b[off:])
+	}
+	clear(p[n:])
+
+	return len(p), nil
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Not parallel: export encoder registry is process-wide.
+func TestExportCompressed(t *testing.T) {
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":   []byte("class CfgPatches {};"),
+		"data/big.txt": bytes.Repeat([]byte("payload "), 4096),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "mod"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	original, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	wantEntries := r.Entries()
+	_ = r.Close()
+
+	checkIndex := func(path string, format ExportFormat) {
+		t.Helper()
+
+		idx, err := ReadExportIndex(path)
+		if err != nil {
+			t.Fatalf("ReadExportIndex(%s): %v", format, err)
+		}
+		if idx.Format != format || idx.Size != int64(len(original)) || !slices.Equal(idx.Entries, wantEntries) ||
+			len(idx.Headers) != 1 || idx.Headers[0].Value != "mod" {
+			t.Fatalf("%s index=%+v", format, idx)
+		}
+	}
+
+	gzPath := filepath.Join(dir, "addon.pbo.gz")
+	if err := ExportCompressed(context.Background(), pboPath, gzPath, ExportFormatGzip); err != nil {
+		t.Fatalf("ExportCompressed(gzip): %v", err)
+	}
+	checkIndex(gzPath, ExportFormatGzip)
+
+	f, err := os.Open(gzPath)
+	if err != nil {
+		t.Fatalf("Open gz: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	restored, err := io.ReadAll(zr)
+	if err != nil || !bytes.Equal(restored, original) {
+		t.Fatalf("gunzip restored %d bytes err=%v, want %d", len(restored), err, len(original))
+	}
+
+	zstPath := filepath.Join(dir, "addon.pbo.zst")
+	if err := ExportCompressed(context.Background(), pboPath, zstPath, ExportFormatZstd); !errors.Is(err, ErrUnsupportedExportFormat) {
+		t.Fatalf("zstd without encoder err=%v, want ErrUnsupportedExportFormat", err)
+	}
+
+	var encoded bytes.Buffer
+	if err := RegisterExportEncoder(ExportFormatZstd, func(w io.Writer) (io.WriteCloser, error) {
+		return nopWriteCloser{io.MultiWriter(w, &encoded)}, nil
+	}); err != nil {
+		t.Fatalf("RegisterExportEncoder: %v", err)
+	}
+	defer UnregisterExportEncoder(ExportFormatZstd)
+
+	if err := ExportCompressed(context.Background(), pboPath, zstPath, ExportFormatZstd); err != nil {
+		t.Fatalf("ExportCompressed(zstd): %v", err)
+	}
+	checkIndex(zstPath, ExportFormatZstd)
+	if !bytes.Equal(encoded.Bytes(), original) {
+		t.Fatalf("zstd encoder got %d bytes, want full archive %d", encoded.Len(), len(original))
+	}
+
+	if _, err := ReadExportIndex(pboPath); !errors.Is(err, ErrInvalidExport) {
+		t.Fatalf("plain pbo err=%v, want ErrInvalidExport", err)
+	}
+}
+
+// nopWriteCloser adds no-op Close to writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing.
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -5,29 +5,41 @@
 package pbo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // extractCopyBufferSize defines per-worker buffer size for file copy during extraction.
 const extractCopyBufferSize = 64 * 1024
 
+// Default output permissions used when ExtractOptions.FilePerm and DirPerm are zero.
+const (
+	defaultExtractFilePerm fs.FileMode = 0o600
+	defaultExtractDirPerm  fs.FileMode = 0o750
+)
+
 // extractWorkItem stores one selected entry with prepared output relative paths.
 type extractWorkItem struct {
-	relPath string
-	relDir  string
-	entry   EntryInfo
+	// prefetch holds stored payload read ahead by extract prefetcher; nil means read on demand.
+	prefetch *prefetchSlot
+	relPath  string
+	relDir   string
+	entry    EntryInfo
 }
 
 // Extract writes selected entries from the PBO to dstDir. Extraction is parallelized
-// by MaxWorkers. By default extraction is fail-fast; set ContinueOnError to keep
-// processing and return the first encountered error at the end.
+// by MaxWorkers. By default extraction is fail-fast; ErrorPolicy selects collecting
+// or skipping failed entries while remaining entries are extracted.
 func (r *Reader) Extract(ctx context.Context, dstDir string, opts ExtractOptions) error {
 	if r == nil || r.ra == nil {
 		return ErrNilReader
@@ -40,30 +52,29 @@ func (r *Reader) Extract(ctx context.Context, dstDir string, opts ExtractOptions
 		return ErrClosed
 	}
 
-	workers := opts.MaxWorkers
-	if workers <= 0 {
-		workers = runtime.GOMAXPROCS(0)
+	dstRootAbs, err := filepath.Abs(dstDir)
+	if err != nil {
+		return fmt.Errorf("resolve output dir: %w", err)
 	}
-	if workers < 1 {
-		workers = 1
+	if opts.LongPaths {
+		dstRootAbs = extendedLengthPath(dstRootAbs)
 	}
 
-	entries := r.entries
-	if opts.Entries != nil {
-		entries = opts.Entries
+	if opts.DryRun {
+		return r.extractDryRun(ctx, dstRootAbs, opts)
 	}
 
-	if len(entries) == 0 {
-		return nil
+	if opts.Atomic {
+		return r.extractAtomic(ctx, dstRootAbs, opts)
 	}
 
-	if !opts.RawNames {
-		sanitizedEntries, sanitizeErr := sanitizeEntryInfoPaths(entries)
-		if sanitizeErr != nil {
-			return sanitizeErr
-		}
+	workItems, err := r.selectExtractWorkItems(opts)
+	if err != nil {
+		return err
+	}
 
-		entries = sanitizedEntries
+	if len(workItems) == 0 {
+		return nil
 	}
 
 	fileMode := opts.FileMode
@@ -71,35 +82,58 @@ func (r *Reader) Extract(ctx context.Context, dstDir string, opts ExtractOptions
 		fileMode = ExtractFileModeAuto
 	}
 
-	dstRootAbs, err := filepath.Abs(dstDir)
-	if err != nil {
-		return fmt.Errorf("resolve output dir: %w", err)
+	if opts.CheckDiskSpace {
+		if err := checkDiskSpace(dstRootAbs, extractRequiredBytes(workItems)); err != nil {
+			return err
+		}
 	}
 
-	if err := os.MkdirAll(dstRootAbs, 0o750); err != nil {
-		return fmt.Errorf("create output dir: %w", err)
+	if err := os.MkdirAll(dstRootAbs, opts.dirPerm()); err != nil {
+		return fmt.Errorf("create output dir: %w", extractPathError(dstRootAbs, err))
+	}
+	if err := opts.applyOutputAttrs(dstRootAbs, true); err != nil {
+		return err
 	}
 
-	workItems, err := prepareExtractWorkItems(entries)
-	if err != nil {
+	if err := prepareExtractDirs(dstRootAbs, workItems, opts); err != nil {
 		return err
 	}
 
-	if len(workItems) == 0 {
-		return nil
+	reporter := newExtractReporter(workItems, opts)
+	limiter := newByteRateLimiter(opts.BytesPerSecond)
+	if opts.Readahead > 0 {
+		stop := r.startExtractPrefetch(ctx, workItems, opts.Readahead)
+		defer stop()
 	}
 
-	if err := prepareExtractDirs(dstRootAbs, workItems); err != nil {
+	return runExtractWorkers(ctx, workItems, opts, func(ctx context.Context, task extractWorkItem, copyBuf []byte) error {
+		return r.extractPreparedEntry(ctx, dstRootAbs, task, fileMode, copyBuf, limiter, reporter, opts)
+	})
+}
+
+// runExtractWorkers runs fn for every work item on MaxWorkers goroutines with per-worker copy buffers.
+// Entry failures are handled according to resolved ErrorPolicy.
+func runExtractWorkers(
+	ctx context.Context,
+	workItems []extractWorkItem,
+	opts ExtractOptions,
+	fn func(ctx context.Context, task extractWorkItem, copyBuf []byte) error,
+) error {
+	policy, err := extractErrorPolicy(opts)
+	if err != nil {
 		return err
 	}
 
-	taskBufferSize := max(workers*2, 1)
+	workers := opts.MaxWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers < 1 {
+		workers = 1
+	}
 
+	taskBufferSize := max(workers*2, 1)
 	taskCh := make(chan extractWorkItem, taskBufferSize)
-	var errCh chan error
-	if opts.ContinueOnError {
-		errCh = make(chan error, len(workItems))
-	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -108,40 +142,31 @@ func (r *Reader) Extract(ctx context.Context, dstDir string, opts ExtractOptions
 		firstErr     error
 		firstErrOnce sync.Once
 	)
-
-	storeFirstErr := func(err error) {
-		if err == nil {
-			return
-		}
-
-		firstErrOnce.Do(func() {
-			firstErr = err
-		})
-	}
+	failures := newExtractFailures(policy, opts)
 
 	var wg sync.WaitGroup
 	for w := 0; w < workers; w++ {
 		wg.Go(func() {
 			copyBuf := make([]byte, extractCopyBufferSize)
 			for task := range taskCh {
-				err := r.extractPreparedEntry(ctx, dstRootAbs, task, fileMode, copyBuf, opts.OnEntryDone)
+				err := fn(ctx, task, copyBuf)
+				task.prefetch.release()
 				if err == nil {
 					continue
 				}
 
-				if !opts.ContinueOnError {
-					storeFirstErr(err)
-					cancel()
-					return
-				}
-
-				if errCh != nil {
-					select {
-					case errCh <- err:
-					case <-ctx.Done():
-						return
+				if policy != ExtractErrorFailFast {
+					if ctx.Err() == nil {
+						failures.add(task.entry, err)
 					}
+					continue
 				}
+
+				firstErrOnce.Do(func() {
+					firstErr = err
+				})
+				cancel()
+				return
 			}
 		})
 	}
@@ -157,15 +182,6 @@ enqueueLoop:
 
 	close(taskCh)
 	wg.Wait()
-	if errCh != nil {
-		close(errCh)
-	}
-
-	if errCh != nil {
-		for err := range errCh {
-			storeFirstErr(err)
-		}
-	}
 
 	if firstErr != nil {
 		return firstErr
@@ -175,7 +191,55 @@ enqueueLoop:
 		return err
 	}
 
-	return nil
+	return failures.err()
+}
+
+// selectExtractWorkItems returns work items for opts.Entries or all parsed entries in payload
+// offset order, sanitizing names unless RawNames is set.
+func (r *Reader) selectExtractWorkItems(opts ExtractOptions) ([]extractWorkItem, error) {
+	if _, err := extractErrorPolicy(opts); err != nil {
+		return nil, err
+	}
+
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	entries := r.entries
+	if opts.Entries != nil {
+		entries = opts.Entries
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	if !opts.RawNames {
+		sanitizedEntries, err := sanitizeEntryInfoPaths(entries)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = sanitizedEntries
+	}
+
+	workItems, err := prepareExtractWorkItems(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	workItems, err = resolveExtractCollisions(workItems, opts.CaseCollision)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dispatch in payload order so workers read archive front to back instead of seeking
+	// back and forth on archives whose index order differs from payload layout.
+	sort.SliceStable(workItems, func(i, j int) bool {
+		return workItems[i].entry.Offset < workItems[j].entry.Offset
+	})
+
+	return workItems, nil
 }
 
 // prepareExtractWorkItems validates selected entries and prepares relative fs paths.
@@ -186,7 +250,7 @@ func prepareExtractWorkItems(entries []EntryInfo) ([]extractWorkItem, error) {
 			continue
 		}
 
-		normalizedPath, err := normalizeExtractEntryPath(entry.Path)
+		normalizedPath, err := NormalizeExtractPath(entry.Path)
 		if err != nil {
 			return nil, fmt.Errorf("normalize entry path %s: %w", entry.Path, err)
 		}
@@ -207,9 +271,162 @@ func prepareExtractWorkItems(entries []EntryInfo) ([]extractWorkItem, error) {
 	return workItems, nil
 }
 
-// prepareExtractDirs creates all unique parent directories needed by work items.
-func prepareExtractDirs(dstRootAbs string, workItems []extractWorkItem) error {
+// extractRequiredBytes sums expected output size of work items.
+func extractRequiredBytes(workItems []extractWorkItem) int64 {
+	var total int64
+	for _, task := range workItems {
+		total += int64(filterOriginalSizeOrDataSize(task.entry))
+	}
+
+	return total
+}
+
+// resolveExtractCollisions applies case collision policy to prepared work items.
+func resolveExtractCollisions(workItems []extractWorkItem, policy ExtractCollisionPolicy) ([]extractWorkItem, error) {
+	if policy == "" {
+		policy = ExtractCollisionSuffix
+	}
+
+	switch policy {
+	case ExtractCollisionSuffix, ExtractCollisionError, ExtractCollisionMerge:
+	default:
+		return nil, fmt.Errorf("%w: unknown case collision policy %q", ErrInvalidExtractPath, policy)
+	}
+
+	firstByKey := make(map[string]int, len(workItems))
+	for i := range workItems {
+		key := strings.ToLower(filepath.ToSlash(workItems[i].relPath))
+		if _, exists := firstByKey[key]; !exists {
+			firstByKey[key] = i
+		}
+	}
+
+	if len(firstByKey) == len(workItems) {
+		return workItems, nil
+	}
+
+	switch policy {
+	case ExtractCollisionError:
+		for i := range workItems {
+			key := strings.ToLower(filepath.ToSlash(workItems[i].relPath))
+			if first := firstByKey[key]; first != i {
+				return nil, fmt.Errorf("%w: %q and %q", ErrExtractPathCollision, workItems[first].entry.Path, workItems[i].entry.Path)
+			}
+		}
+
+	case ExtractCollisionMerge:
+		lastByKey := make(map[string]int, len(firstByKey))
+		for i := range workItems {
+			lastByKey[strings.ToLower(filepath.ToSlash(workItems[i].relPath))] = i
+		}
+
+		out := make([]extractWorkItem, 0, len(lastByKey))
+		for i := range workItems {
+			if lastByKey[strings.ToLower(filepath.ToSlash(workItems[i].relPath))] == i {
+				out = append(out, workItems[i])
+			}
+		}
+
+		return out, nil
+	}
+
+	used := make(map[string]struct{}, len(workItems))
+	nextSuffix := make(map[string]int)
+	out := make([]extractWorkItem, len(workItems))
+	for i, task := range workItems {
+		unique, err := makeSanitizedPathUnique(filepath.ToSlash(task.relPath), used, nextSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("resolve case collision for %s: %w", task.entry.Path, err)
+		}
+
+		task.relPath = filepath.FromSlash(unique)
+		out[i] = task
+	}
+
+	return out, nil
+}
+
+// extractReporter serializes per-entry callbacks and aggregate OnProgress reporting.
+type extractReporter struct {
+	opts  ExtractOptions
+	total EntryStats
+	done  EntryStats
+	mu    sync.Mutex
+}
+
+// newExtractReporter prepares reporter with selected totals.
+func newExtractReporter(workItems []extractWorkItem, opts ExtractOptions) *extractReporter {
+	rep := &extractReporter{opts: opts}
+	if opts.OnProgress == nil {
+		return rep
+	}
+
+	rep.total.Entries = len(workItems)
+	for _, task := range workItems {
+		rep.total.Bytes += int64(filterOriginalSizeOrDataSize(task.entry))
+	}
+
+	return rep
+}
+
+// entryDone reports one written entry.
+func (rep *extractReporter) entryDone(entry EntryInfo, written int64, outputPath string) {
+	if rep.opts.OnEntryDone != nil {
+		rep.opts.OnEntryDone(entry, written, outputPath)
+	}
+
+	rep.progress(written)
+}
+
+// checkSize compares decoded size of compressed or codec-encoded entry with OriginalSize.
+// Mismatch fails with ErrEntrySizeMismatch under StrictSizes, otherwise goes to OnSizeMismatch.
+func (rep *extractReporter) checkSize(entry EntryInfo, written int64, outputPath string) error {
+	if entry.OriginalSize == 0 || (entry.MimeType == MimeNil && !entry.IsCompressed()) {
+		return nil
+	}
+	if written == int64(entry.OriginalSize) {
+		return nil
+	}
+
+	if rep.opts.StrictSizes {
+		return fmt.Errorf("%w: %s: wrote %d bytes, original size %d", ErrEntrySizeMismatch, entry.Path, written, entry.OriginalSize)
+	}
+
+	if rep.opts.OnSizeMismatch != nil {
+		rep.opts.OnSizeMismatch(entry, written, outputPath)
+	}
+
+	return nil
+}
+
+// entrySkipped reports one entry left untouched because output was already identical.
+func (rep *extractReporter) entrySkipped(entry EntryInfo, outputPath string) {
+	if rep.opts.OnEntrySkipped != nil {
+		rep.opts.OnEntrySkipped(entry, outputPath)
+	}
+
+	rep.progress(int64(filterOriginalSizeOrDataSize(entry)))
+}
+
+// progress advances aggregate counters and calls OnProgress.
+func (rep *extractReporter) progress(bytes int64) {
+	if rep.opts.OnProgress == nil {
+		return
+	}
+
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+
+	rep.done.Entries++
+	rep.done.Bytes += bytes
+	rep.opts.OnProgress(rep.done, rep.total)
+}
+
+// prepareExtractDirs creates all unique parent directories needed by work items and
+// applies DirPerm and Chown to every directory between them and dstRootAbs.
+func prepareExtractDirs(dstRootAbs string, workItems []extractWorkItem, opts ExtractOptions) error {
 	seen := make(map[string]struct{}, len(workItems))
+	attrsDone := make(map[string]struct{})
 	for _, task := range workItems {
 		if task.relDir == "" {
 			continue
@@ -222,9 +439,95 @@ func prepareExtractDirs(dstRootAbs string, workItems []extractWorkItem) error {
 		}
 
 		seen[key] = struct{}{}
-		if err := os.MkdirAll(dirPath, 0o750); err != nil {
-			return fmt.Errorf("create output directory %s: %w", dirPath, err)
+		if err := os.MkdirAll(dirPath, opts.dirPerm()); err != nil {
+			return fmt.Errorf("create output directory %s: %w", dirPath, extractPathError(dirPath, err))
 		}
+		if !opts.hasOutputAttrs() {
+			continue
+		}
+
+		// Parents may be shared with earlier items; walk up until first visited one.
+		for dir := dirPath; len(dir) > len(dstRootAbs); dir = filepath.Dir(dir) {
+			if _, done := attrsDone[dir]; done {
+				break
+			}
+
+			attrsDone[dir] = struct{}{}
+			if err := opts.applyEntryAttrs(dir, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dirPerm returns directory creation permission.
+func (opts *ExtractOptions) dirPerm() fs.FileMode {
+	if opts.DirPerm != 0 {
+		return opts.DirPerm
+	}
+
+	return defaultExtractDirPerm
+}
+
+// filePerm returns file creation permission.
+func (opts *ExtractOptions) filePerm() fs.FileMode {
+	if opts.FilePerm != 0 {
+		return opts.FilePerm
+	}
+
+	return defaultExtractFilePerm
+}
+
+// hasOutputAttrs reports whether written paths need explicit permission, owner, or attribute change.
+func (opts *ExtractOptions) hasOutputAttrs() bool {
+	return opts.FilePerm != 0 || opts.DirPerm != 0 || opts.Chown != nil || opts.WindowsAttributes
+}
+
+// extractPathError wraps OS error of creating path with ErrPathTooLong when path length caused it.
+func extractPathError(path string, err error) error {
+	if !isPathTooLongError(err, path) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %d bytes: %w", ErrPathTooLong, len(path), err)
+}
+
+// applyEntryAttrs applies output attributes and WindowsAttributes hidden marker to path
+// created for archive entry (destination root itself is never marked hidden).
+func (opts *ExtractOptions) applyEntryAttrs(path string, isDir bool) error {
+	if opts.WindowsAttributes {
+		if err := setHiddenAttribute(path); err != nil {
+			return fmt.Errorf("set attributes %s: %w", path, err)
+		}
+	}
+
+	return opts.applyOutputAttrs(path, isDir)
+}
+
+// applyOutputAttrs sets exact FilePerm or DirPerm and Chown owner on written path.
+func (opts *ExtractOptions) applyOutputAttrs(path string, isDir bool) error {
+	perm := opts.FilePerm
+	if isDir {
+		perm = opts.DirPerm
+	}
+	if perm != 0 {
+		if err := os.Chmod(path, perm); err != nil {
+			return fmt.Errorf("chmod %s: %w", path, err)
+		}
+	}
+
+	if opts.Chown == nil {
+		return nil
+	}
+
+	uid, gid := opts.Chown(path, isDir)
+	if uid == -1 && gid == -1 {
+		return nil
+	}
+	if err := os.Chown(path, uid, gid); err != nil {
+		return fmt.Errorf("chown %s: %w", path, err)
 	}
 
 	return nil
@@ -237,7 +540,9 @@ func (r *Reader) extractPreparedEntry(
 	task extractWorkItem,
 	fileMode ExtractFileMode,
 	copyBuf []byte,
-	onEntryDone func(entry EntryInfo, written int64, outputPath string),
+	limiter *byteRateLimiter,
+	reporter *extractReporter,
+	opts ExtractOptions,
 ) error {
 	select {
 	case <-ctx.Done():
@@ -247,23 +552,40 @@ func (r *Reader) extractPreparedEntry(
 
 	outPath := filepath.Join(dstRootAbs, task.relPath)
 
-	rc, err := r.openEntryByInfo(&task.entry, task.entry.Path)
+	expectedSize := int64(task.entry.DataSize)
+	if task.entry.OriginalSize > 0 {
+		expectedSize = int64(task.entry.OriginalSize)
+	}
+
+	if fileMode == ExtractFileModeSkipUnchanged {
+		unchanged, err := r.extractOutputUnchanged(ctx, task, outPath, expectedSize, copyBuf, opts.PerEntryTimeout)
+		if err != nil {
+			return fmt.Errorf("compare %s: %w", task.entry.Path, err)
+		}
+		if unchanged {
+			reporter.entrySkipped(task.entry, outPath)
+			return nil
+		}
+	}
+
+	rc, err := r.openTaskEntry(ctx, task, opts.PerEntryTimeout)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = rc.Close() }()
 
-	expectedSize := int64(task.entry.DataSize)
-	if task.entry.OriginalSize > 0 {
-		expectedSize = int64(task.entry.OriginalSize)
+	if opts.WindowsAttributes {
+		if err := clearOverwriteAttributes(outPath); err != nil {
+			return fmt.Errorf("clear attributes %s: %w", task.entry.Path, err)
+		}
 	}
 
-	file, needsTruncate, err := openExtractFile(outPath, fileMode, expectedSize)
+	file, needsTruncate, err := openExtractFile(outPath, fileMode, expectedSize, opts.filePerm())
 	if err != nil {
-		return fmt.Errorf("open %s: %w", task.entry.Path, err)
+		return fmt.Errorf("open %s: %w", task.entry.Path, extractPathError(outPath, err))
 	}
 
-	written, copyErr := copyExtractData(file, rc, copyBuf)
+	written, copyErr := copyExtractData(file, throttleReader(ctx, rc, limiter), copyBuf)
 	if copyErr == nil && needsTruncate {
 		if truncErr := file.Truncate(written); truncErr != nil {
 			_ = file.Close()
@@ -280,18 +602,96 @@ func (r *Reader) extractPreparedEntry(
 		return fmt.Errorf("close %s: %w", task.entry.Path, closeErr)
 	}
 
-	if onEntryDone != nil {
-		onEntryDone(task.entry, written, outPath)
+	if err := reporter.checkSize(task.entry, written, outPath); err != nil {
+		return err
+	}
+
+	if fileMode == ExtractFileModeSkipUnchanged && task.entry.TimeStamp != 0 {
+		modTime := uint32ToTime(task.entry.TimeStamp)
+		if err := os.Chtimes(outPath, modTime, modTime); err != nil {
+			return fmt.Errorf("set mtime %s: %w", task.entry.Path, err)
+		}
 	}
 
+	if err := opts.applyEntryAttrs(outPath, false); err != nil {
+		return err
+	}
+
+	reporter.entryDone(task.entry, written, outPath)
 	return nil
 }
 
+// extractOutputUnchanged reports whether existing output file already holds entry content.
+// Matching size and entry timestamp short-circuit content comparison.
+func (r *Reader) extractOutputUnchanged(
+	ctx context.Context,
+	task extractWorkItem,
+	outPath string,
+	expectedSize int64,
+	buf []byte,
+	timeout time.Duration,
+) (bool, error) {
+	entry := task.entry
+	info, err := os.Stat(outPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.Mode().IsRegular() || info.Size() != expectedSize {
+		return false, nil
+	}
+	if entry.TimeStamp != 0 && info.ModTime().Unix() == int64(entry.TimeStamp) {
+		return true, nil
+	}
+
+	file, err := os.Open(outPath) //nolint:gosec // path is resolved under destination root
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = file.Close() }()
+
+	rc, err := r.openTaskEntry(ctx, task, timeout)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	return readersEqual(rc, file, buf)
+}
+
+// readersEqual compares two streams chunk by chunk using halves of buf.
+func readersEqual(a io.Reader, b io.Reader, buf []byte) (bool, error) {
+	half := len(buf) / 2
+	if half == 0 {
+		return false, io.ErrShortBuffer
+	}
+
+	bufA, bufB := buf[:half], buf[half:2*half]
+	for {
+		nA, errA := io.ReadFull(a, bufA)
+		nB, errB := io.ReadFull(b, bufB)
+		if errA != nil && errA != io.EOF && errA != io.ErrUnexpectedEOF {
+			return false, errA
+		}
+		if errB != nil && errB != io.EOF && errB != io.ErrUnexpectedEOF {
+			return false, errB
+		}
+		if nA != nB || !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return false, nil
+		}
+		if errA != nil || errB != nil {
+			return errA != nil && errB != nil, nil
+		}
+	}
+}
+
 // openExtractFile opens output path according to selected extract file mode.
-func openExtractFile(path string, mode ExtractFileMode, expectedSize int64) (*os.File, bool, error) {
+func openExtractFile(path string, mode ExtractFileMode, expectedSize int64, perm fs.FileMode) (*os.File, bool, error) {
 	switch mode {
 	case ExtractFileModeAuto:
-		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
 		if err == nil {
 			return file, false, nil
 		}
@@ -300,10 +700,10 @@ func openExtractFile(path string, mode ExtractFileMode, expectedSize int64) (*os
 			return nil, false, err
 		}
 
-		file, truncErr := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		file, truncErr := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
 		return file, false, truncErr
 	case ExtractFileModeOverwriteSmart:
-		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o600)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, perm)
 		if err != nil {
 			return nil, false, err
 		}
@@ -316,11 +716,11 @@ func openExtractFile(path string, mode ExtractFileMode, expectedSize int64) (*os
 
 		needsTruncate := info.Size() > expectedSize
 		return file, needsTruncate, nil
-	case ExtractFileModeTruncate:
-		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	case ExtractFileModeTruncate, ExtractFileModeSkipUnchanged:
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
 		return file, false, err
 	case ExtractFileModeCreateOnly:
-		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
 		return file, false, err
 	default:
 		return nil, false, fmt.Errorf("unknown extract file mode %q", mode)
@@ -360,54 +760,3 @@ func copyExtractData(dst *os.File, src io.Reader, buf []byte) (int64, error) {
 		return total, readErr
 	}
 }
-
-// normalizeExtractEntryPath normalizes entry path and rejects absolute/traversal inputs.
-func normalizeExtractEntryPath(entryPath string) (string, error) {
-	raw := strings.TrimSpace(entryPath)
-	if raw == "" {
-		return "", ErrInvalidExtractPath
-	}
-	if strings.ContainsRune(raw, 0) {
-		return "", ErrInvalidExtractPath
-	}
-	if strings.HasPrefix(raw, `/`) || strings.HasPrefix(raw, `\`) {
-		return "", ErrInvalidExtractPath
-	}
-
-	raw = strings.ReplaceAll(raw, `\`, `/`)
-	if hasWindowsAbsDrivePrefix(raw) {
-		return "", ErrInvalidExtractPath
-	}
-
-	parts := strings.Split(raw, `/`)
-	cleanParts := make([]string, 0, len(parts))
-	for _, part := range parts {
-		switch part {
-		case "", ".":
-			continue
-		case "..":
-			return "", ErrInvalidExtractPath
-		default:
-			cleanParts = append(cleanParts, part)
-		}
-	}
-	if len(cleanParts) == 0 {
-		return "", ErrInvalidExtractPath
-	}
-
-	return strings.Join(cleanParts, `/`), nil
-}
-
-// hasWindowsAbsDrivePrefix reports whether path starts with drive-root prefix like C:/.
-func hasWindowsAbsDrivePrefix(path string) bool {
-	if len(path) < 3 {
-		return false
-	}
-
-	return isASCIIAlpha(path[0]) && path[1] == ':' && path[2] == '/'
-}
-
-// isASCIIAlpha reports whether byte is ASCII latin letter.
-func isASCIIAlpha(b byte) bool {
-	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
-}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// extractAtomic extracts into temporary sibling of dstRootAbs and swaps it into place on success.
+// Any entry failure keeps destination untouched, including under ExtractErrorSkipAndReport,
+// where failures are returned as *ExtractError.
+func (r *Reader) extractAtomic(ctx context.Context, dstRootAbs string, opts ExtractOptions) error {
+	// Staging starts empty, so there is nothing unchanged to skip.
+	if opts.FileMode == ExtractFileModeSkipUnchanged {
+		return ErrAtomicSkipUnchanged
+	}
+
+	parent := filepath.Dir(dstRootAbs)
+	if err := os.MkdirAll(parent, opts.dirPerm()); err != nil {
+		return fmt.Errorf("create output parent dir: %w", err)
+	}
+
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(dstRootAbs)+".extract-*")
+	if err != nil {
+		return fmt.Errorf("create staging dir: %w", err)
+	}
+
+	var (
+		failures []EntryFailure
+		mu       sync.Mutex
+	)
+	onFailed := opts.OnEntryFailed
+	opts.OnEntryFailed = func(entry EntryInfo, err error) {
+		mu.Lock()
+		failures = append(failures, EntryFailure{Entry: entry, Err: err})
+		mu.Unlock()
+		if onFailed != nil {
+			onFailed(entry, err)
+		}
+	}
+
+	opts.Atomic = false
+	if err := r.Extract(ctx, staging, opts); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+
+	if len(failures) > 0 {
+		_ = os.RemoveAll(staging)
+		slices.SortStableFunc(failures, func(a, b EntryFailure) int {
+			return cmp.Compare(a.Entry.Offset, b.Entry.Offset)
+		})
+
+		return &ExtractError{Failures: failures}
+	}
+
+	if err := os.Chmod(staging, opts.dirPerm()); err != nil { //nolint:gosec // matches non-atomic output dir mode
+		_ = os.RemoveAll(staging)
+		return fmt.Errorf("chmod staging dir: %w", err)
+	}
+
+	if err := swapExtractDir(staging, dstRootAbs); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+
+	return nil
+}
+
+// swapExtractDir renames staging over dst, moving existing dst aside and removing it afterwards.
+func swapExtractDir(staging string, dst string) error {
+	if _, err := os.Lstat(dst); errors.Is(err, os.ErrNotExist) {
+		if err := os.Rename(staging, dst); err != nil {
+			return fmt.Errorf("move staging dir into place: %w", err)
+		}
+
+		return nil
+	}
+
+	old := staging + ".old"
+	if err := os.Rename(dst, old); err != nil {
+		return fmt.Errorf("move existing output dir aside: %w", err)
+	}
+
+	if err := os.Rename(staging, dst); err != nil {
+		if restoreErr := os.Rename(old, dst); restoreErr != nil {
+			return fmt.Errorf("move staging dir into place: %w (restore previous output: %w)", err, restoreErr)
+		}
+
+		return fmt.Errorf("move staging dir into place: %w", err)
+	}
+
+	if err := os.RemoveAll(old); err != nil {
+		return fmt.Errorf("remove previous output dir: %w", err)
+	}
+
+	return nil
+}
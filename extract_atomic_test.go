@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractAtomicReplacesDestination(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "atomic.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt":     []byte("alpha"),
+		"dir/b.txt": []byte("bravo"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	parent := t.TempDir()
+	dst := filepath.Join(parent, "addon")
+	if err := os.MkdirAll(dst, 0o750); err != nil {
+		t.Fatalf("mkdir dst: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, "stale.txt"), []byte("stale"), 0o600); err != nil {
+		t.Fatalf("write stale: %v", err)
+	}
+
+	if err := r.Extract(context.Background(), dst, ExtractOptions{Atomic: true}); err != nil {
+		t.Fatalf("atomic extract: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "dir", "b.txt"))
+	if err != nil || string(got) != "bravo" {
+		t.Fatalf("dir/b.txt=%q err=%v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "stale.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("stale file survived atomic extract: %v", err)
+	}
+
+	assertOnlyDirEntries(t, parent, "addon")
+}
+
+func TestExtractAtomicKeepsDestinationOnFailure(t *testing.T) {
+	t.Parallel()
+
+	pboPath := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: "ok.txt", data: []byte("fine")},
+		{name: "secret.bin", data: []byte("cipher"), mime: MimeEncoded, originalSize: 6},
+	})
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	parent := t.TempDir()
+	dst := filepath.Join(parent, "addon")
+	if err := os.MkdirAll(dst, 0o750); err != nil {
+		t.Fatalf("mkdir dst: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, "previous.txt"), []byte("previous"), 0o600); err != nil {
+		t.Fatalf("write previous: %v", err)
+	}
+
+	err = r.Extract(context.Background(), dst, ExtractOptions{Atomic: true, MaxWorkers: 1})
+	if !errors.Is(err, ErrEntryCodecNotFound) {
+		t.Fatalf("expected ErrEntryCodecNotFound, got %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dst, "previous.txt"))
+	if err != nil || string(got) != "previous" {
+		t.Fatalf("previous output changed: %q err=%v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "ok.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("partial output leaked into destination: %v", err)
+	}
+
+	assertOnlyDirEntries(t, parent, "addon")
+}
+
+// assertOnlyDirEntries fails when dir contains names other than want (no staging leftovers).
+func assertOnlyDirEntries(t *testing.T, dir string, want ...string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != len(want) {
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Fatalf("dir entries=%v, want %v", names, want)
+	}
+	for i, e := range entries {
+		if e.Name() != want[i] {
+			t.Fatalf("dir entry %q, want %q", e.Name(), want[i])
+		}
+	}
+}
+
+func TestExtractAtomicSkipAndReportKeepsDestination(t *testing.T) {
+	t.Parallel()
+
+	pboPath := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: "ok.txt", data: []byte("fine")},
+		{name: "secret.bin", data: []byte("cipher"), mime: MimeEncoded, originalSize: 6},
+	})
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	parent := t.TempDir()
+	dst := filepath.Join(parent, "addon")
+	if err := os.MkdirAll(dst, 0o750); err != nil {
+		t.Fatalf("mkdir dst: %v", err)
+	}
+
+	var reported int
+	err = r.Extract(context.Background(), dst, ExtractOptions{
+		Atomic:        true,
+		ErrorPolicy:   ExtractErrorSkipAndReport,
+		OnEntryFailed: func(EntryInfo, error) { reported++ },
+	})
+	var extractErr *ExtractError
+	if !errors.As(err, &extractErr) || len(extractErr.Failures) != 1 || !errors.Is(err, ErrEntryCodecNotFound) {
+		t.Fatalf("err=%v, want ExtractError with ErrEntryCodecNotFound", err)
+	}
+	if reported != 1 {
+		t.Fatalf("OnEntryFailed calls=%d, want 1", reported)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "ok.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("partial output swapped into destination: %v", err)
+	}
+	assertOnlyDirEntries(t, parent, "addon")
+
+	err = r.Extract(context.Background(), dst, ExtractOptions{Atomic: true, FileMode: ExtractFileModeSkipUnchanged})
+	if !errors.Is(err, ErrAtomicSkipUnchanged) {
+		t.Fatalf("skip unchanged err=%v, want ErrAtomicSkipUnchanged", err)
+	}
+	assertOnlyDirEntries(t, parent, "addon")
+}
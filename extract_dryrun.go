@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// extractDryRun reports what Extract would write into dstRootAbs without touching disk.
+func (r *Reader) extractDryRun(ctx context.Context, dstRootAbs string, opts ExtractOptions) error {
+	workItems, err := r.selectExtractWorkItems(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.CheckDiskSpace {
+		if err := checkDiskSpace(dstRootAbs, extractRequiredBytes(workItems)); err != nil {
+			return err
+		}
+	}
+
+	reporter := newExtractReporter(workItems, opts)
+	var copyBuf []byte
+	if opts.FileMode == ExtractFileModeSkipUnchanged {
+		copyBuf = make([]byte, extractCopyBufferSize)
+	}
+
+	policy, err := extractErrorPolicy(opts)
+	if err != nil {
+		return err
+	}
+
+	failures := newExtractFailures(policy, opts)
+	for _, task := range workItems {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := r.dryRunExtractEntry(ctx, dstRootAbs, task, opts.FileMode, copyBuf, reporter, opts.PerEntryTimeout); err != nil {
+			if policy == ExtractErrorFailFast {
+				return err
+			}
+			failures.add(task.entry, err)
+		}
+	}
+
+	return failures.err()
+}
+
+// dryRunExtractEntry reports one entry as written or skipped according to file mode.
+func (r *Reader) dryRunExtractEntry(
+	ctx context.Context,
+	dstRootAbs string,
+	task extractWorkItem,
+	fileMode ExtractFileMode,
+	copyBuf []byte,
+	reporter *extractReporter,
+	timeout time.Duration,
+) error {
+	outPath := filepath.Join(dstRootAbs, task.relPath)
+	expectedSize := int64(filterOriginalSizeOrDataSize(task.entry))
+
+	switch fileMode {
+	case ExtractFileModeCreateOnly:
+		if _, err := os.Lstat(outPath); err == nil {
+			return fmt.Errorf("open %s: %w", task.entry.Path, fs.ErrExist)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("open %s: %w", task.entry.Path, err)
+		}
+
+	case ExtractFileModeSkipUnchanged:
+		unchanged, err := r.extractOutputUnchanged(ctx, task, outPath, expectedSize, copyBuf, timeout)
+		if err != nil {
+			return fmt.Errorf("compare %s: %w", task.entry.Path, err)
+		}
+		if unchanged {
+			reporter.entrySkipped(task.entry, outPath)
+			return nil
+		}
+	}
+
+	reporter.entryDone(task.entry, expectedSize, outPath)
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractDryRun(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "dry.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": []byte("void main();"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dst := filepath.Join(t.TempDir(), "out")
+	planned := make(map[string]int64)
+	var total EntryStats
+	err = r.Extract(context.Background(), dst, ExtractOptions{
+		DryRun: true,
+		OnEntryDone: func(_ EntryInfo, written int64, outputPath string) {
+			planned[outputPath] = written
+		},
+		OnProgress: func(_ EntryStats, all EntryStats) { total = all },
+	})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	if planned[filepath.Join(dst, "scripts", "main.c")] != int64(len("void main();")) || len(planned) != 2 {
+		t.Fatalf("planned outputs=%v", planned)
+	}
+	if total.Bytes != int64(len("class CfgPatches {};")+len("void main();")) {
+		t.Fatalf("estimated bytes=%d", total.Bytes)
+	}
+	if _, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("dry run created destination: %v", err)
+	}
+
+	if err := r.Extract(context.Background(), dst, ExtractOptions{}); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	err = r.Extract(context.Background(), dst, ExtractOptions{DryRun: true, FileMode: ExtractFileModeCreateOnly})
+	if !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("expected create-only dry run to report existing file, got %v", err)
+	}
+}
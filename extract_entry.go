@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ExtractEntry writes one entry to destPath (file path, not directory), creating parent
+// directories. FileMode, DryRun, BytesPerSecond, FilePerm, and callbacks apply; DirPerm
+// only sets creation mode of missing parents. Entries, RawNames, CaseCollision, Atomic,
+// and worker options are ignored.
+func (r *Reader) ExtractEntry(ctx context.Context, entryPath string, destPath string, opts ExtractOptions) error {
+	if r == nil || r.ra == nil {
+		return ErrNilReader
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+	if err := r.LoadEntries(); err != nil {
+		return err
+	}
+
+	entry := r.findEntryByName(entryPath)
+	if entry == nil {
+		return fmt.Errorf("%w: %s", ErrEntryNotFound, entryPath)
+	}
+
+	destAbs, err := filepath.Abs(destPath)
+	if err != nil {
+		return fmt.Errorf("resolve output path: %w", err)
+	}
+	if opts.LongPaths {
+		destAbs = extendedLengthPath(destAbs)
+	}
+
+	dstDir := filepath.Dir(destAbs)
+	task := extractWorkItem{relPath: filepath.Base(destAbs), entry: *entry}
+	reporter := newExtractReporter([]extractWorkItem{task}, opts)
+
+	fileMode := opts.FileMode
+	if fileMode == "" {
+		fileMode = ExtractFileModeAuto
+	}
+
+	if opts.CheckDiskSpace {
+		if err := checkDiskSpace(dstDir, extractRequiredBytes([]extractWorkItem{task})); err != nil {
+			return err
+		}
+	}
+
+	copyBuf := make([]byte, extractCopyBufferSize)
+	if opts.DryRun {
+		return r.dryRunExtractEntry(ctx, dstDir, task, fileMode, copyBuf, reporter, opts.PerEntryTimeout)
+	}
+
+	if err := os.MkdirAll(dstDir, opts.dirPerm()); err != nil {
+		return fmt.Errorf("create output dir: %w", extractPathError(dstDir, err))
+	}
+
+	limiter := newByteRateLimiter(opts.BytesPerSecond)
+	return r.extractPreparedEntry(ctx, dstDir, task, fileMode, copyBuf, limiter, reporter, opts)
+}
+
+// ExtractEntries extracts named entries into dstDir with Extract semantics.
+// Missing names fail with ErrEntryNotFound before anything is written; repeated names are
+// extracted once and opts.Entries is replaced.
+func (r *Reader) ExtractEntries(ctx context.Context, paths []string, dstDir string, opts ExtractOptions) error {
+	if r == nil || r.ra == nil {
+		return ErrNilReader
+	}
+	if err := r.LoadEntries(); err != nil {
+		return err
+	}
+
+	entries := make([]EntryInfo, 0, len(paths))
+	seen := make(map[*EntryInfo]struct{}, len(paths))
+	for _, path := range paths {
+		entry := r.findEntryByName(path)
+		if entry == nil {
+			return fmt.Errorf("%w: %s", ErrEntryNotFound, path)
+		}
+		if _, ok := seen[entry]; ok {
+			continue
+		}
+
+		seen[entry] = struct{}{}
+		entries = append(entries, *entry)
+	}
+
+	opts.Entries = entries
+	return r.Extract(ctx, dstDir, opts)
+}
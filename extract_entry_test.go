@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExtractEntry(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "single.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":    []byte("class CfgPatches {};"),
+		"scripts/a.c":   []byte("void a();"),
+		"scripts/b.c":   []byte("void b();"),
+		"data/skip.paa": []byte("img"),
+	}, PackOptions{Compress: includeRules("*.cpp"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dest := filepath.Join(t.TempDir(), "nested", "renamed.cpp")
+	if err := r.ExtractEntry(context.Background(), "config.cpp", dest, ExtractOptions{}); err != nil {
+		t.Fatalf("ExtractEntry: %v", err)
+	}
+	if got, err := os.ReadFile(dest); err != nil || string(got) != "class CfgPatches {};" {
+		t.Fatalf("extracted=%q err=%v", got, err)
+	}
+
+	if err := r.ExtractEntry(context.Background(), "missing.txt", dest, ExtractOptions{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("missing entry err=%v, want ErrEntryNotFound", err)
+	}
+
+	dst := t.TempDir()
+	if err := r.ExtractEntries(context.Background(), []string{"scripts\\a.c", "scripts/a.c", "scripts/b.c"}, dst, ExtractOptions{}); err != nil {
+		t.Fatalf("ExtractEntries: %v", err)
+	}
+	for name, want := range map[string]string{"scripts/a.c": "void a();", "scripts/b.c": "void b();"} {
+		if got, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name))); err != nil || string(got) != want {
+			t.Fatalf("%s=%q err=%v", name, got, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, "config.cpp")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("unrequested entry extracted, stat err=%v", err)
+	}
+
+	err = r.ExtractEntries(context.Background(), []string{"scripts/a.c", "nope"}, t.TempDir(), ExtractOptions{})
+	if !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("ExtractEntries missing err=%v, want ErrEntryNotFound", err)
+	}
+}
+
+func TestExtract_PermissionsAndChown(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions and ownership")
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "perm.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":          []byte("class CfgPatches {};"),
+		"scripts/4_world/a.c": []byte("void a();"),
+		"scripts/b.c":         []byte("void b();"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var (
+		mu    sync.Mutex
+		owned []string
+	)
+	dst := filepath.Join(t.TempDir(), "out")
+	err = r.Extract(context.Background(), dst, ExtractOptions{
+		FilePerm: 0o664,
+		DirPerm:  0o775,
+		Chown: func(outputPath string, isDir bool) (int, int) {
+			rel, _ := filepath.Rel(dst, outputPath)
+			if isDir {
+				rel += "/"
+			}
+
+			mu.Lock()
+			owned = append(owned, filepath.ToSlash(rel))
+			mu.Unlock()
+			return os.Getuid(), os.Getgid()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	for name, want := range map[string]os.FileMode{
+		".":                   0o775,
+		"scripts":             0o775,
+		"scripts/4_world":     0o775,
+		"config.cpp":          0o664,
+		"scripts/4_world/a.c": 0o664,
+	} {
+		info, err := os.Stat(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("Stat %s: %v", name, err)
+		}
+		if info.Mode().Perm() != want {
+			t.Fatalf("%s mode=%v, want %v", name, info.Mode().Perm(), want)
+		}
+	}
+
+	slices.Sort(owned)
+	want := []string{"./", "config.cpp", "scripts/", "scripts/4_world/", "scripts/4_world/a.c", "scripts/b.c"}
+	if !slices.Equal(owned, want) {
+		t.Fatalf("chown paths=%v, want %v", owned, want)
+	}
+
+	dest := filepath.Join(t.TempDir(), "single.cpp")
+	if err := r.ExtractEntry(context.Background(), "config.cpp", dest, ExtractOptions{FilePerm: 0o640}); err != nil {
+		t.Fatalf("ExtractEntry: %v", err)
+	}
+	info, err := os.Stat(dest)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Fatalf("ExtractEntry mode=%v", info.Mode().Perm())
+	}
+}
+
+func TestExtract_PathTooLong(t *testing.T) {
+	t.Parallel()
+
+	longName := strings.Repeat("x", 300) + ".txt"
+	pboPath := filepath.Join(t.TempDir(), "long.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"deep/" + longName: []byte("data"),
+		".hidden/a.txt":    []byte("alpha"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dst := t.TempDir()
+	opts := ExtractOptions{RawNames: true, LongPaths: true, WindowsAttributes: true}
+	err = r.Extract(context.Background(), dst, opts)
+	if runtime.GOOS != "windows" && !errors.Is(err, ErrPathTooLong) {
+		t.Fatalf("Extract long name err=%v, want ErrPathTooLong", err)
+	}
+
+	opts.Entries = []EntryInfo{*r.findEntryByName(".hidden/a.txt")}
+	if err := r.Extract(context.Background(), dst, opts); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dst, ".hidden", "a.txt")); err != nil || string(got) != "alpha" {
+		t.Fatalf("extracted=%q err=%v", got, err)
+	}
+}
+
+func TestExtract_StrictSizes(t *testing.T) {
+	// Not parallel: codec registry is process-wide.
+	const shortMime MimeType = 0x74726f68 // "hort"
+	if err := RegisterEntryCodec(shortMime, EntryCodecFunc(func(payload io.Reader, _ EntryInfo, _ []byte) (io.Reader, error) {
+		return io.LimitReader(payload, 2), nil
+	})); err != nil {
+		t.Fatalf("RegisterEntryCodec: %v", err)
+	}
+	t.Cleanup(func() { UnregisterEntryCodec(shortMime) })
+
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: "plain.txt", data: []byte("plain")},
+		{name: "short.bin", data: []byte("payload"), mime: shortMime, originalSize: 7},
+	})
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var mismatched []string
+	err = r.Extract(context.Background(), t.TempDir(), ExtractOptions{
+		OnSizeMismatch: func(entry EntryInfo, written int64, _ string) {
+			mismatched = append(mismatched, fmt.Sprintf("%s:%d", entry.Path, written))
+		},
+	})
+	if err != nil || len(mismatched) != 1 || mismatched[0] != "short.bin:2" {
+		t.Fatalf("lenient extract err=%v mismatched=%v", err, mismatched)
+	}
+
+	err = r.Extract(context.Background(), t.TempDir(), ExtractOptions{StrictSizes: true})
+	if !errors.Is(err, ErrEntrySizeMismatch) {
+		t.Fatalf("strict extract err=%v, want ErrEntrySizeMismatch", err)
+	}
+
+	err = r.ExtractEntry(context.Background(), "plain.txt", filepath.Join(t.TempDir(), "plain.txt"), ExtractOptions{StrictSizes: true})
+	if err != nil {
+		t.Fatalf("strict raw entry: %v", err)
+	}
+}
+
+func TestExtract_ErrorPolicy(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "policy.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": []byte("A"),
+		"b.txt": []byte("B"),
+		"c.txt": []byte("C"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	extract := func(policy ExtractErrorPolicy, dryRun bool) (string, []string, error) {
+		t.Helper()
+
+		outDir := t.TempDir()
+		for _, name := range []string{"a.txt", "c.txt"} {
+			writeTestFile(t, filepath.Join(outDir, name), []byte("stale"))
+		}
+
+		var failed []string
+		err := r.Extract(context.Background(), outDir, ExtractOptions{
+			FileMode:    ExtractFileModeCreateOnly,
+			ErrorPolicy: policy,
+			DryRun:      dryRun,
+			MaxWorkers:  2,
+			OnEntryFailed: func(entry EntryInfo, _ error) {
+				failed = append(failed, entry.Path)
+			},
+		})
+
+		return outDir, failed, err
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		outDir, failed, err := extract(ExtractErrorCollectAll, dryRun)
+		var extractErr *ExtractError
+		if !errors.As(err, &extractErr) || len(extractErr.Failures) != 2 || !errors.Is(err, os.ErrExist) {
+			t.Fatalf("dryRun=%v collect_all err=%v, want ExtractError with 2 ErrExist failures", dryRun, err)
+		}
+		if extractErr.Failures[0].Entry.Path != "a.txt" || extractErr.Failures[1].Entry.Path != "c.txt" || len(failed) != 2 {
+			t.Fatalf("dryRun=%v failures=%v reported=%v", dryRun, extractErr.Failures, failed)
+		}
+		var batchErr *BatchError
+		if !errors.As(err, &batchErr) || batchErr.Op != "extract" || !slices.Equal(batchErr.Paths(), []string{"a.txt", "c.txt"}) {
+			t.Fatalf("dryRun=%v batch err=%+v, want extract BatchError for a.txt and c.txt", dryRun, batchErr)
+		}
+		if _, statErr := os.Stat(filepath.Join(outDir, "b.txt")); (statErr == nil) == dryRun {
+			t.Fatalf("dryRun=%v b.txt stat err=%v", dryRun, statErr)
+		}
+	}
+
+	outDir, failed, err := extract(ExtractErrorSkipAndReport, false)
+	if err != nil || len(failed) != 2 {
+		t.Fatalf("skip_and_report err=%v reported=%v", err, failed)
+	}
+	if got, err := os.ReadFile(filepath.Join(outDir, "b.txt")); err != nil || string(got) != "B" {
+		t.Fatalf("b.txt=%q err=%v", got, err)
+	}
+
+	if _, _, err := extract(ExtractErrorFailFast, false); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("fail_fast err=%v, want ErrExist", err)
+	}
+	if _, _, err := extract("retry", false); !errors.Is(err, ErrInvalidExtractErrorPolicy) {
+		t.Fatalf("unknown policy err=%v, want ErrInvalidExtractErrorPolicy", err)
+	}
+}
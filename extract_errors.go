@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// EntryFailure is one entry that failed during extraction.
+type EntryFailure struct {
+	// Err is failure cause.
+	Err error `json:"-" yaml:"-"`
+	// Entry is failed entry metadata.
+	Entry EntryInfo `json:"entry" yaml:"entry"`
+}
+
+// ExtractError reports every entry failure of extraction run with ExtractErrorCollectAll.
+type ExtractError struct {
+	// Failures are failed entries in payload offset order.
+	Failures []EntryFailure `json:"failures" yaml:"failures"`
+}
+
+// Error implements error.
+func (e *ExtractError) Error() string {
+	switch len(e.Failures) {
+	case 0:
+		return "extract failed"
+	case 1:
+		return e.Failures[0].Err.Error()
+	}
+
+	return fmt.Sprintf("%d entries failed to extract; first: %v", len(e.Failures), e.Failures[0].Err)
+}
+
+// Unwrap returns failure causes for errors.Is and errors.As.
+func (e *ExtractError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// As converts e to *BatchError so extract failures can be handled like other batch operations.
+func (e *ExtractError) As(target any) bool {
+	batch, ok := target.(**BatchError)
+	if !ok {
+		return false
+	}
+
+	failures := make([]BatchFailure, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		failures = append(failures, BatchFailure{Path: f.Entry.Path, Err: f.Err})
+	}
+	*batch = &BatchError{Op: "extract", Failures: failures}
+
+	return true
+}
+
+// extractErrorPolicy resolves ErrorPolicy default and validates it.
+func extractErrorPolicy(opts ExtractOptions) (ExtractErrorPolicy, error) {
+	switch opts.ErrorPolicy {
+	case "":
+		if opts.ContinueOnError {
+			return ExtractErrorCollectAll, nil
+		}
+		return ExtractErrorFailFast, nil
+
+	case ExtractErrorFailFast, ExtractErrorCollectAll, ExtractErrorSkipAndReport:
+		return opts.ErrorPolicy, nil
+
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidExtractErrorPolicy, opts.ErrorPolicy)
+	}
+}
+
+// extractFailures accumulates entry failures from concurrent workers.
+type extractFailures struct {
+	onFailed func(entry EntryInfo, err error)
+	failures []EntryFailure
+	policy   ExtractErrorPolicy
+	mu       sync.Mutex
+}
+
+// newExtractFailures returns failure collector for resolved policy.
+func newExtractFailures(policy ExtractErrorPolicy, opts ExtractOptions) *extractFailures {
+	return &extractFailures{policy: policy, onFailed: opts.OnEntryFailed}
+}
+
+// add records entry failure and reports it to OnEntryFailed.
+func (f *extractFailures) add(entry EntryInfo, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures = append(f.failures, EntryFailure{Entry: entry, Err: err})
+	if f.onFailed != nil {
+		f.onFailed(entry, err)
+	}
+}
+
+// err returns collected failures as policy result.
+func (f *extractFailures) err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.failures) == 0 || f.policy == ExtractErrorSkipAndReport {
+		return nil
+	}
+
+	failures := slices.Clone(f.failures)
+	slices.SortStableFunc(failures, func(a, b EntryFailure) int {
+		return cmp.Compare(a.Entry.Offset, b.Entry.Offset)
+	})
+
+	return &ExtractError{Failures: failures}
+}
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA1 names content objects, not a security boundary
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ObjectsDirName is directory of content objects inside object store written by ExtractObjects.
+const ObjectsDirName = "objects"
+
+// ObjectEntry maps one archive entry to content object.
+type ObjectEntry struct {
+	// Path is entry path as stored in archive.
+	Path string `json:"path" yaml:"path"`
+	// Object is lowercase hex SHA1 of decoded entry content and object file name.
+	Object string `json:"object" yaml:"object"`
+	// Size is decoded content size in bytes.
+	Size int64 `json:"size" yaml:"size"`
+	// TimeStamp is Unix timestamp from entry record.
+	TimeStamp uint32 `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// ObjectManifest maps archive paths to content objects of object store.
+type ObjectManifest struct {
+	// Headers are archive header pairs in stored order.
+	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Entries are extracted entries sorted by path.
+	Entries []ObjectEntry `json:"entries" yaml:"entries"`
+}
+
+// ObjectChange is one path difference between two object manifests.
+type ObjectChange struct {
+	// Path is entry path.
+	Path string `json:"path" yaml:"path"`
+	// Old is object in first manifest; empty when path was added.
+	Old string `json:"old,omitempty" yaml:"old,omitempty"`
+	// New is object in second manifest; empty when path was removed.
+	New string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// ObjectPath returns path of object inside object store.
+func ObjectPath(storeDir string, object string) string {
+	return filepath.Join(storeDir, ObjectsDirName, object)
+}
+
+// ExtractObjects writes decoded content of selected entries to storeDir/objects/<sha1> and
+// returns manifest mapping entry paths to objects. Objects already present in store are
+// kept, so many similar archives (mission rotations) extracted into one store share
+// identical files. Entries, workers, throttle, error policy, callbacks, FilePerm, DirPerm,
+// and Chown apply; name, collision, FileMode, and Atomic options are ignored.
+// OnEntryDone receives object path.
+func (r *Reader) ExtractObjects(ctx context.Context, storeDir string, opts ExtractOptions) (*ObjectManifest, error) {
+	if r == nil || r.ra == nil {
+		return nil, ErrNilReader
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+	if _, err := extractErrorPolicy(opts); err != nil {
+		return nil, err
+	}
+
+	storeAbs, err := filepath.Abs(storeDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve object store dir: %w", err)
+	}
+
+	objectsDir := filepath.Join(storeAbs, ObjectsDirName)
+	if err := os.MkdirAll(objectsDir, opts.dirPerm()); err != nil {
+		return nil, fmt.Errorf("create objects dir: %w", err)
+	}
+
+	entries := r.entries
+	if opts.Entries != nil {
+		entries = opts.Entries
+	}
+
+	workItems := make([]extractWorkItem, 0, len(entries))
+	for _, entry := range entries {
+		workItems = append(workItems, extractWorkItem{entry: entry, relPath: entry.Path})
+	}
+	sort.SliceStable(workItems, func(i, j int) bool {
+		return workItems[i].entry.Offset < workItems[j].entry.Offset
+	})
+
+	manifest := &ObjectManifest{Headers: r.Headers(), Entries: make([]ObjectEntry, 0, len(workItems))}
+	var mu sync.Mutex
+
+	reporter := newExtractReporter(workItems, opts)
+	limiter := newByteRateLimiter(opts.BytesPerSecond)
+	if opts.Readahead > 0 {
+		stop := r.startExtractPrefetch(ctx, workItems, opts.Readahead)
+		defer stop()
+	}
+
+	err = runExtractWorkers(ctx, workItems, opts, func(ctx context.Context, task extractWorkItem, copyBuf []byte) error {
+		obj, err := r.extractObject(ctx, storeAbs, task, copyBuf, limiter, reporter, opts)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		manifest.Entries = append(manifest.Entries, obj)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(manifest.Entries, func(i, j int) bool {
+		return manifest.Entries[i].Path < manifest.Entries[j].Path
+	})
+
+	return manifest, nil
+}
+
+// extractObject streams one entry into temporary object file while hashing it and moves
+// it to its content address unless that object already exists.
+func (r *Reader) extractObject(
+	ctx context.Context,
+	storeAbs string,
+	task extractWorkItem,
+	copyBuf []byte,
+	limiter *byteRateLimiter,
+	reporter *extractReporter,
+	opts ExtractOptions,
+) (ObjectEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return ObjectEntry{}, err
+	}
+
+	rc, err := r.openTaskEntry(ctx, task, opts.PerEntryTimeout)
+	if err != nil {
+		return ObjectEntry{}, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	tmp, err := os.CreateTemp(filepath.Join(storeAbs, ObjectsDirName), ".tmp-*")
+	if err != nil {
+		return ObjectEntry{}, fmt.Errorf("create object for %s: %w", task.entry.Path, err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	h := sha1.New() //nolint:gosec // content address
+	written, copyErr := copyExtractData(tmp, io.TeeReader(throttleReader(ctx, rc, limiter), h), copyBuf)
+	closeErr := tmp.Close()
+	if copyErr != nil {
+		return ObjectEntry{}, fmt.Errorf("write %s: %w", task.entry.Path, copyErr)
+	}
+	if closeErr != nil {
+		return ObjectEntry{}, fmt.Errorf("close %s: %w", task.entry.Path, closeErr)
+	}
+
+	object := hex.EncodeToString(h.Sum(nil))
+	objectPath := ObjectPath(storeAbs, object)
+	if err := reporter.checkSize(task.entry, written, objectPath); err != nil {
+		return ObjectEntry{}, err
+	}
+
+	if _, err := os.Lstat(objectPath); errors.Is(err, os.ErrNotExist) {
+		if err := opts.applyOutputAttrs(tmpPath, false); err != nil {
+			return ObjectEntry{}, err
+		}
+		if err := os.Rename(tmpPath, objectPath); err != nil {
+			return ObjectEntry{}, fmt.Errorf("store object %s: %w", task.entry.Path, err)
+		}
+	} else if err != nil {
+		return ObjectEntry{}, fmt.Errorf("stat object %s: %w", task.entry.Path, err)
+	}
+
+	reporter.entryDone(task.entry, written, objectPath)
+	return ObjectEntry{
+		Path:      task.entry.Path,
+		Object:    object,
+		Size:      written,
+		TimeStamp: task.entry.TimeStamp,
+	}, nil
+}
+
+// WriteObjectManifest writes manifest as indented JSON to path through temporary file.
+func WriteObjectManifest(path string, manifest *ObjectManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode object manifest: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0o644); err != nil { //nolint:gosec // manifest is public like store
+		return fmt.Errorf("write object manifest: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename object manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ReadObjectManifest reads JSON manifest written by WriteObjectManifest.
+func ReadObjectManifest(path string) (*ObjectManifest, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // caller-provided manifest path
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest ObjectManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decode object manifest %s: %w", path, err)
+	}
+
+	return &manifest, nil
+}
+
+// DiffObjectManifests compares two manifests by path and object and returns added,
+// removed, and changed paths sorted by path. No object content is read.
+func DiffObjectManifests(a *ObjectManifest, b *ObjectManifest) []ObjectChange {
+	objects := make(map[string]string)
+	if a != nil {
+		for _, entry := range a.Entries {
+			objects[entry.Path] = entry.Object
+		}
+	}
+
+	var changes []ObjectChange
+	if b != nil {
+		for _, entry := range b.Entries {
+			old, ok := objects[entry.Path]
+			delete(objects, entry.Path)
+			if !ok || old != entry.Object {
+				changes = append(changes, ObjectChange{Path: entry.Path, Old: old, New: entry.Object})
+			}
+		}
+	}
+	for path, old := range objects {
+		changes = append(changes, ObjectChange{Path: path, Old: old})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
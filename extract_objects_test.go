@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // matches object naming
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractObjects_DedupAndDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	shared := bytes.Repeat([]byte("shared mission script "), 256)
+	first := filepath.Join(dir, "a.pbo")
+	second := filepath.Join(dir, "b.pbo")
+	if err := createTestPBO(first, map[string][]byte{
+		"init.sqf":        shared,
+		"mission.sqm":     []byte("version=1;"),
+		"scripts/old.sqf": []byte("old"),
+	}, PackOptions{Compress: includeRules("*.sqf")}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+	if err := createTestPBO(second, map[string][]byte{
+		"init.sqf":        shared,
+		"mission.sqm":     []byte("version=2;"),
+		"scripts/new.sqf": []byte("new"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	store := filepath.Join(dir, "store")
+	manifests := make([]*ObjectManifest, 0, 2)
+	for _, path := range []string{first, second} {
+		r, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := r.ExtractObjects(context.Background(), store, ExtractOptions{MaxWorkers: 2})
+		_ = r.Close()
+		if err != nil {
+			t.Fatalf("ExtractObjects(%s): %v", path, err)
+		}
+		manifests = append(manifests, m)
+	}
+
+	sum := sha1.Sum(shared) //nolint:gosec // content address
+	sharedObject := hex.EncodeToString(sum[:])
+	if got := manifests[0].Entries[0]; got.Path != "init.sqf" || got.Object != sharedObject || got.Size != int64(len(shared)) {
+		t.Fatalf("first entry = %+v, want init.sqf %s", got, sharedObject)
+	}
+
+	data, err := os.ReadFile(ObjectPath(store, sharedObject))
+	if err != nil || !bytes.Equal(data, shared) {
+		t.Fatalf("shared object content mismatch: %v", err)
+	}
+
+	objects, err := os.ReadDir(filepath.Join(store, ObjectsDirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 5 {
+		t.Fatalf("objects = %d, want 5 (shared init.sqf stored once)", len(objects))
+	}
+
+	manifestPath := filepath.Join(store, "b.json")
+	if err := WriteObjectManifest(manifestPath, manifests[1]); err != nil {
+		t.Fatalf("WriteObjectManifest: %v", err)
+	}
+	loaded, err := ReadObjectManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("ReadObjectManifest: %v", err)
+	}
+
+	changes := DiffObjectManifests(manifests[0], loaded)
+	want := []string{"mission.sqm", `scripts\new.sqf`, `scripts\old.sqf`}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want paths %v", changes, want)
+	}
+	for i, change := range changes {
+		if change.Path != want[i] {
+			t.Fatalf("change[%d] = %+v, want path %s", i, change, want[i])
+		}
+	}
+	if changes[0].Old == "" || changes[0].New == "" || changes[1].Old != "" || changes[2].New != "" {
+		t.Fatalf("unexpected change sides: %+v", changes)
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+//go:build !windows
+
+package pbo
+
+import (
+	"errors"
+	"syscall"
+)
+
+// extendedLengthPath returns path unchanged; extended-length form is Windows-only.
+func extendedLengthPath(path string) string {
+	return path
+}
+
+// isPathTooLongError reports whether err from creating path is ENAMETOOLONG.
+func isPathTooLongError(err error, _ string) bool {
+	return errors.Is(err, syscall.ENAMETOOLONG)
+}
+
+// clearOverwriteAttributes is no-op outside Windows.
+func clearOverwriteAttributes(string) error {
+	return nil
+}
+
+// setHiddenAttribute is no-op outside Windows.
+func setHiddenAttribute(string) error {
+	return nil
+}
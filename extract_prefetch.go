@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// prefetchSlot carries one prefetched stored payload from prefetcher to extract worker.
+type prefetchSlot struct {
+	owner *extractPrefetcher
+	ready chan struct{}
+	err   error
+	data  []byte
+}
+
+// extractPrefetcher reads stored payloads of work items ahead of workers in dispatch order,
+// keeping at most limit bytes in flight so cold disk reads overlap with decompression.
+type extractPrefetcher struct {
+	cond  *sync.Cond
+	mu    sync.Mutex
+	used  int64
+	limit int64
+}
+
+// startExtractPrefetch attaches prefetch slots to items whose payload fits readahead window
+// and starts background reads. Returned stop cancels reads and waits for prefetch goroutine.
+func (r *Reader) startExtractPrefetch(ctx context.Context, workItems []extractWorkItem, readahead int) func() {
+	p := &extractPrefetcher{limit: int64(readahead)}
+	p.cond = sync.NewCond(&p.mu)
+
+	slots := make([]*prefetchSlot, 0, len(workItems))
+	items := make([]EntryInfo, 0, len(workItems))
+	for i := range workItems {
+		entry := workItems[i].entry
+		if entry.DataSize == 0 || int64(entry.DataSize) > p.limit {
+			continue
+		}
+
+		slot := &prefetchSlot{owner: p, ready: make(chan struct{})}
+		workItems[i].prefetch = slot
+		slots = append(slots, slot)
+		items = append(items, entry)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stopWake := context.AfterFunc(ctx, func() {
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i, slot := range slots {
+			size := int64(items[i].DataSize)
+			if !p.acquire(ctx, size) {
+				for _, rest := range slots[i:] {
+					rest.err = ctx.Err()
+					close(rest.ready)
+				}
+
+				return
+			}
+
+			slot.data = make([]byte, size)
+			n, err := r.ra.ReadAt(slot.data, int64(items[i].Offset))
+			if n == len(slot.data) && errors.Is(err, io.EOF) {
+				err = nil
+			}
+
+			slot.err = err
+			close(slot.ready)
+		}
+	})
+
+	return func() {
+		cancel()
+		wg.Wait()
+		stopWake()
+	}
+}
+
+// acquire waits until size bytes fit readahead window; false means ctx was canceled.
+func (p *extractPrefetcher) acquire(ctx context.Context, size int64) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.used > 0 && p.used+size > p.limit {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		p.cond.Wait()
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	p.used += size
+	return true
+}
+
+// payload waits for prefetched stored payload of work item.
+func (s *prefetchSlot) payload(ctx context.Context) ([]byte, error) {
+	select {
+	case <-s.ready:
+		return s.data, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release returns slot bytes to readahead window after worker finished with item.
+// Nil slot is no-op.
+func (s *prefetchSlot) release() {
+	if s == nil {
+		return
+	}
+
+	<-s.ready
+	if s.err != nil {
+		return
+	}
+
+	s.owner.mu.Lock()
+	s.owner.used -= int64(len(s.data))
+	s.owner.mu.Unlock()
+	s.owner.cond.Broadcast()
+	s.data = nil
+}
+
+// openTaskEntry opens decoded stream of work item, using prefetched payload when available.
+// Positive timeout guards open and reads against stalled source.
+func (r *Reader) openTaskEntry(ctx context.Context, task extractWorkItem, timeout time.Duration) (io.ReadCloser, error) {
+	return openWithDeadline(task.entry.Path, timeout, func() (io.ReadCloser, error) {
+		return r.openTaskPayload(ctx, task)
+	})
+}
+
+// openTaskPayload opens decoded stream of work item without timeout.
+func (r *Reader) openTaskPayload(ctx context.Context, task extractWorkItem) (io.ReadCloser, error) {
+	if task.prefetch == nil {
+		return r.openEntryByInfo(&task.entry, task.entry.Path)
+	}
+
+	data, err := task.prefetch.payload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.openEntryPayload(&task.entry, task.entry.Path, bytes.NewReader(data))
+}
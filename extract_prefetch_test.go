@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestExtract_Readahead(t *testing.T) {
+	t.Parallel()
+
+	files := make(map[string][]byte, 40)
+	for i := range 40 {
+		files[fmt.Sprintf("dir/f%02d.txt", i)] = bytes.Repeat([]byte{byte('a' + i%26)}, 10+i*7)
+	}
+	files["big.bin"] = bytes.Repeat([]byte("B"), 4096)
+
+	pboPath := filepath.Join(t.TempDir(), "readahead.pbo")
+	if err := createTestPBO(pboPath, files, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, workers := range []int{1, 4} {
+		dst := t.TempDir()
+		if err := r.Extract(context.Background(), dst, ExtractOptions{MaxWorkers: workers, Readahead: 256}); err != nil {
+			t.Fatalf("Extract workers=%d: %v", workers, err)
+		}
+
+		for name, want := range files {
+			got, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+			if err != nil || !bytes.Equal(got, want) {
+				t.Fatalf("workers=%d %s mismatch err=%v", workers, name, err)
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	seen := make(map[string][]byte, len(files))
+	sink := ExtractSinkFunc(func(_ context.Context, entry EntryInfo, content io.Reader) error {
+		data, err := io.ReadAll(content)
+		mu.Lock()
+		seen[entry.Path] = data
+		mu.Unlock()
+		return err
+	})
+	if err := r.ExtractTo(context.Background(), sink, ExtractOptions{MaxWorkers: 3, Readahead: 128}); err != nil {
+		t.Fatalf("ExtractTo: %v", err)
+	}
+	for name, want := range files {
+		if !bytes.Equal(seen[name], want) {
+			t.Fatalf("ExtractTo %s mismatch", name)
+		}
+	}
+}
+
+func TestExtract_ReadaheadFailFast(t *testing.T) {
+	t.Parallel()
+
+	files := make(map[string][]byte, 20)
+	for i := range 20 {
+		files[fmt.Sprintf("f%02d.txt", i)] = []byte("payload")
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "failfast.pbo")
+	if err := createTestPBO(pboPath, files, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dst := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dst, "f00.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	err = r.Extract(context.Background(), dst, ExtractOptions{
+		MaxWorkers: 2,
+		Readahead:  16,
+		FileMode:   ExtractFileModeCreateOnly,
+	})
+	if !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("Extract err=%v, want fs.ErrExist", err)
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+// ExtractSink receives decoded entry streams from ExtractTo.
+// WriteEntry is called from MaxWorkers goroutines; set MaxWorkers to 1 for sinks
+// that are not safe for concurrent use (tar.Writer, zip.Writer).
+type ExtractSink interface {
+	// WriteEntry consumes content of one entry; entry Path is slash-separated relative path.
+	WriteEntry(ctx context.Context, entry EntryInfo, content io.Reader) error
+}
+
+// ExtractSinkFunc adapts plain function to ExtractSink.
+type ExtractSinkFunc func(ctx context.Context, entry EntryInfo, content io.Reader) error
+
+// WriteEntry calls f(ctx, entry, content).
+func (f ExtractSinkFunc) WriteEntry(ctx context.Context, entry EntryInfo, content io.Reader) error {
+	return f(ctx, entry, content)
+}
+
+// ExtractTo streams selected entries to sink instead of filesystem using the same
+// worker, filter, throttle, and progress machinery as Extract. FileMode and Atomic
+// are ignored; OnEntryDone receives empty output path.
+func (r *Reader) ExtractTo(ctx context.Context, sink ExtractSink, opts ExtractOptions) error {
+	if r == nil || r.ra == nil {
+		return ErrNilReader
+	}
+	if sink == nil {
+		return ErrNilWriter
+	}
+
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+
+	workItems, err := r.selectExtractWorkItems(opts)
+	if err != nil {
+		return err
+	}
+
+	if len(workItems) == 0 {
+		return nil
+	}
+
+	reporter := newExtractReporter(workItems, opts)
+	limiter := newByteRateLimiter(opts.BytesPerSecond)
+	if opts.Readahead > 0 {
+		stop := r.startExtractPrefetch(ctx, workItems, opts.Readahead)
+		defer stop()
+	}
+
+	return runExtractWorkers(ctx, workItems, opts, func(ctx context.Context, task extractWorkItem, _ []byte) error {
+		return r.extractEntryToSink(ctx, sink, task, limiter, reporter, opts.PerEntryTimeout)
+	})
+}
+
+// extractEntryToSink opens one work item and passes its decoded stream to sink.
+func (r *Reader) extractEntryToSink(
+	ctx context.Context,
+	sink ExtractSink,
+	task extractWorkItem,
+	limiter *byteRateLimiter,
+	reporter *extractReporter,
+	timeout time.Duration,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rc, err := r.openTaskEntry(ctx, task, timeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	entry := task.entry
+	entry.Path = filepath.ToSlash(task.relPath)
+
+	content := &countingReader{r: throttleReader(ctx, rc, limiter)}
+	if err := sink.WriteEntry(ctx, entry, content); err != nil {
+		return fmt.Errorf("sink %s: %w", task.entry.Path, err)
+	}
+
+	reporter.entryDone(task.entry, content.n, "")
+	return nil
+}
+
+// countingReader counts bytes read from underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from underlying reader and counts returned bytes.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestExtractToMemorySink(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"a.txt":            []byte("alpha"),
+		"dir/b.txt":        []byte("bravo"),
+		"dir/sub/c.sqf":    bytes.Repeat([]byte("charlie "), 512),
+		"dir/sub/d.rvmat ": []byte("delta"),
+	}
+	pboPath := filepath.Join(t.TempDir(), "sink.pbo")
+	if err := createTestPBO(pboPath, files, PackOptions{Compress: includeRules("*.sqf")}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var (
+		mu  sync.Mutex
+		got = make(map[string][]byte)
+	)
+	var progress EntryStats
+	err = r.ExtractTo(context.Background(), ExtractSinkFunc(func(_ context.Context, entry EntryInfo, content io.Reader) error {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		got[entry.Path] = data
+		mu.Unlock()
+		return nil
+	}), ExtractOptions{
+		MaxWorkers: 4,
+		OnProgress: func(done EntryStats, _ EntryStats) { progress = done },
+	})
+	if err != nil {
+		t.Fatalf("ExtractTo: %v", err)
+	}
+
+	if len(got) != len(files) {
+		t.Fatalf("sink received %d entries, want %d", len(got), len(files))
+	}
+	if !bytes.Equal(got["dir/sub/c.sqf"], files["dir/sub/c.sqf"]) {
+		t.Fatalf("compressed entry content mismatch")
+	}
+	if _, ok := got["dir/sub/d.rvmat"]; !ok {
+		t.Fatalf("sanitized path missing from sink: %v", got)
+	}
+	if progress.Entries != len(files) {
+		t.Fatalf("progress entries=%d, want %d", progress.Entries, len(files))
+	}
+}
+
+func TestExtractToTarSinkAndErrors(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "tar.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt":     []byte("alpha"),
+		"dir/b.txt": []byte("bravo"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err = r.ExtractTo(context.Background(), ExtractSinkFunc(func(_ context.Context, entry EntryInfo, content io.Reader) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name: entry.Path,
+			Mode: 0o644,
+			Size: int64(filterOriginalSizeOrDataSize(entry)),
+		}); err != nil {
+			return err
+		}
+
+		_, err := io.Copy(tw, content)
+		return err
+	}), ExtractOptions{MaxWorkers: 1})
+	if err != nil {
+		t.Fatalf("ExtractTo tar: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+
+	tr := tar.NewReader(&buf)
+	names := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar next: %v", err)
+		}
+
+		data, _ := io.ReadAll(tr)
+		names[hdr.Name] = string(data)
+	}
+	if names["a.txt"] != "alpha" || names["dir/b.txt"] != "bravo" {
+		t.Fatalf("unexpected tar content: %v", names)
+	}
+
+	sinkErr := errors.New("sink failed")
+	err = r.ExtractTo(context.Background(), ExtractSinkFunc(func(context.Context, EntryInfo, io.Reader) error {
+		return sinkErr
+	}), ExtractOptions{MaxWorkers: 1})
+	if !errors.Is(err, sinkErr) {
+		t.Fatalf("expected sink error, got %v", err)
+	}
+
+	if err := r.ExtractTo(context.Background(), nil, ExtractOptions{}); !errors.Is(err, ErrNilWriter) {
+		t.Fatalf("expected ErrNilWriter, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+//go:build windows
+
+package pbo
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Windows path limits and error codes not exported by syscall.
+const (
+	// windowsMaxPath is MAX_PATH including terminating NUL.
+	windowsMaxPath = 260
+	// errorFilenameExcedRange is ERROR_FILENAME_EXCED_RANGE.
+	errorFilenameExcedRange syscall.Errno = 206
+)
+
+// extendedLengthPath converts absolute path to \\?\ (or \\?\UNC\) form, which lifts
+// MAX_PATH limit and disables Win32 name normalization such as trailing dot removal.
+func extendedLengthPath(path string) string {
+	switch {
+	case strings.HasPrefix(path, `\\?\`), strings.HasPrefix(path, `\\.\`):
+		return path
+	case strings.HasPrefix(path, `\\`):
+		return `\\?\UNC\` + path[2:]
+	default:
+		return `\\?\` + path
+	}
+}
+
+// isPathTooLongError reports whether err from creating path is caused by path length limit.
+func isPathTooLongError(err error, path string) bool {
+	if errors.Is(err, errorFilenameExcedRange) {
+		return true
+	}
+
+	return len(path) >= windowsMaxPath && !strings.HasPrefix(path, `\\?\`) &&
+		errors.Is(err, syscall.ERROR_PATH_NOT_FOUND)
+}
+
+// clearOverwriteAttributes removes read-only and hidden attributes of existing path, which
+// make overwrite with truncation fail with access denied.
+func clearOverwriteAttributes(path string) error {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+
+	attrs, err := syscall.GetFileAttributes(pathPtr)
+	if err != nil {
+		if errors.Is(err, syscall.ERROR_FILE_NOT_FOUND) || errors.Is(err, syscall.ERROR_PATH_NOT_FOUND) {
+			return nil
+		}
+
+		return err
+	}
+	blocking := uint32(syscall.FILE_ATTRIBUTE_READONLY | syscall.FILE_ATTRIBUTE_HIDDEN)
+	if attrs&blocking == 0 {
+		return nil
+	}
+
+	return syscall.SetFileAttributes(pathPtr, attrs&^blocking)
+}
+
+// setHiddenAttribute marks dot-prefixed file or directory name hidden, as such names are on Unix.
+func setHiddenAttribute(path string) error {
+	if !strings.HasPrefix(filepath.Base(path), ".") {
+		return nil
+	}
+
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+
+	attrs, err := syscall.GetFileAttributes(pathPtr)
+	if err != nil {
+		return err
+	}
+
+	return syscall.SetFileAttributes(pathPtr, attrs|syscall.FILE_ATTRIBUTE_HIDDEN)
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // Trailer format requires SHA1.
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// FidelityClass identifies one kind of byte-level divergence between archive and its repack.
+type FidelityClass string
+
+// Repack divergence classes reported by RoundTrip.
+const (
+	// FidelityHeaderLayout means fixed header block or header key-value pairs differ.
+	FidelityHeaderLayout FidelityClass = "header_layout"
+	// FidelityOrdering means source entry order differs from path-sorted writer order.
+	FidelityOrdering FidelityClass = "ordering"
+	// FidelityOffsets means stored index offsets are non-zero or payloads have gaps/tail bytes.
+	FidelityOffsets FidelityClass = "offsets"
+	// FidelityTimestamps means entry timestamp fields differ.
+	FidelityTimestamps FidelityClass = "timestamps"
+	// FidelityEntryFields means mime, original size, or data size fields differ.
+	FidelityEntryFields FidelityClass = "entry_fields"
+	// FidelityPayload means entry payload bytes differ.
+	FidelityPayload FidelityClass = "payload"
+	// FidelityTrailer means SHA1 trailer is absent in one archive or stored hash is invalid.
+	FidelityTrailer FidelityClass = "trailer"
+)
+
+// FidelityDivergence describes one detected divergence.
+type FidelityDivergence struct {
+	// Class is divergence kind.
+	Class FidelityClass `json:"class" yaml:"class"`
+	// Path is entry path for entry-level divergences; empty for archive-level ones.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Detail is human-readable description.
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// FidelityReport is RoundTrip result.
+type FidelityReport struct {
+	// Divergences lists detected divergences grouped by archive area.
+	Divergences []FidelityDivergence `json:"divergences,omitempty" yaml:"divergences,omitempty"`
+	// OriginalSize is source archive size in bytes.
+	OriginalSize int64 `json:"original_size" yaml:"original_size"`
+	// RepackedSize is repacked archive size in bytes.
+	RepackedSize int64 `json:"repacked_size" yaml:"repacked_size"`
+	// FirstDiffOffset is offset of the first differing byte, or -1 when archives are identical.
+	FirstDiffOffset int64 `json:"first_diff_offset" yaml:"first_diff_offset"`
+	// Identical reports byte-for-byte equal repack.
+	Identical bool `json:"identical" yaml:"identical"`
+}
+
+// Classes returns distinct divergence classes in first-seen order.
+func (r *FidelityReport) Classes() []FidelityClass {
+	if r == nil {
+		return nil
+	}
+
+	seen := make(map[FidelityClass]struct{}, len(r.Divergences))
+	classes := make([]FidelityClass, 0, 4)
+	for _, d := range r.Divergences {
+		if _, ok := seen[d.Class]; ok {
+			continue
+		}
+
+		seen[d.Class] = struct{}{}
+		classes = append(classes, d.Class)
+	}
+
+	return classes
+}
+
+// RoundTrip reads archive at path, repacks it in memory through standard writer
+// (path-sorted entries, payloads copied as stored, source headers, trailer when source has one)
+// and reports every divergence from source bytes. Both archives are held in memory.
+func RoundTrip(path string) (*FidelityReport, error) {
+	original, err := os.ReadFile(path) //nolint:gosec // path is provided by caller
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+
+	src, err := NewReaderFromReaderAtWithOptions(bytes.NewReader(original), int64(len(original)), ReaderOptions{
+		OffsetMode: OffsetModeStoredCompat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repacked, err := repackForFidelity(src)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FidelityReport{
+		OriginalSize:    int64(len(original)),
+		RepackedSize:    int64(len(repacked)),
+		FirstDiffOffset: firstDiffOffset(original, repacked),
+	}
+	report.Identical = report.FirstDiffOffset < 0
+	if report.Identical {
+		return report, nil
+	}
+
+	rep, err := NewReaderFromReaderAt(bytes.NewReader(repacked), int64(len(repacked)))
+	if err != nil {
+		return nil, fmt.Errorf("parse repacked archive: %w", err)
+	}
+
+	report.compareHeaders(src, rep)
+	if err := report.compareEntries(original, repacked, src, rep); err != nil {
+		return nil, err
+	}
+	report.compareTrailer(original, src)
+
+	return report, nil
+}
+
+// repackForFidelity rewrites source archive with standard writer into memory.
+func repackForFidelity(src *Reader) ([]byte, error) {
+	plan := make([]rewriteEntry, len(src.entries))
+	for i := range src.entries {
+		entryPath, err := NormalizeEntryPath(src.entries[i].Path)
+		if err != nil {
+			entryPath = src.entries[i].Path
+		}
+
+		plan[i] = rewriteEntry{source: &src.entries[i], path: entryPath}
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		return plan[i].path < plan[j].path
+	})
+
+	buf := &memoryBuffer{}
+	if _, err := rewriteArchive(context.Background(), buf, src, plan, PackOptions{
+		Headers:               src.Headers(),
+		AllowDuplicateHeaders: true,
+	}); err != nil {
+		return nil, fmt.Errorf("repack: %w", err)
+	}
+
+	if src.hasTrailer {
+		sum := sha1.Sum(buf.data) //nolint:gosec // Trailer format requires SHA1.
+		buf.data = append(append(buf.data, 0x00), sum[:]...)
+	}
+
+	return buf.data, nil
+}
+
+// compareHeaders reports fixed header block and header pair differences.
+func (r *FidelityReport) compareHeaders(src *Reader, rep *Reader) {
+	if !bytes.Equal(src.header, rep.header) {
+		r.add(FidelityHeaderLayout, "", fmt.Sprintf("fixed header block %x != %x", src.header, rep.header))
+	}
+
+	srcHeaders, repHeaders := src.Headers(), rep.Headers()
+	if len(srcHeaders) != len(repHeaders) {
+		r.add(FidelityHeaderLayout, "", fmt.Sprintf("%d header pairs != %d", len(srcHeaders), len(repHeaders)))
+		return
+	}
+
+	for i := range srcHeaders {
+		if srcHeaders[i] != repHeaders[i] {
+			r.add(FidelityHeaderLayout, "", fmt.Sprintf("header %d %s=%q rewritten as %s=%q",
+				i, srcHeaders[i].Key, srcHeaders[i].Value, repHeaders[i].Key, repHeaders[i].Value))
+		}
+	}
+}
+
+// compareEntries reports ordering, index field, payload, and gap differences.
+func (r *FidelityReport) compareEntries(original []byte, repacked []byte, src *Reader, rep *Reader) error {
+	srcRows, err := rawIndexRows(original)
+	if err != nil {
+		return err
+	}
+
+	repRows, err := rawIndexRows(repacked)
+	if err != nil {
+		return err
+	}
+
+	for i := range min(len(srcRows), len(repRows)) {
+		if srcRows[i].path != repRows[i].path {
+			r.add(FidelityOrdering, srcRows[i].path, fmt.Sprintf("entry %d is %q in source and %q in path-sorted repack",
+				i, srcRows[i].path, repRows[i].path))
+			break
+		}
+	}
+
+	repByPath := make(map[string]int, len(repRows))
+	for i := range repRows {
+		repByPath[repRows[i].path] = i
+	}
+
+	var payloadSum int64
+	for i, s := range srcRows {
+		payloadSum += int64(s.dataSize)
+
+		j, ok := repByPath[s.path]
+		if !ok {
+			r.add(FidelityEntryFields, s.path, "entry path rewritten by writer normalization")
+			continue
+		}
+
+		p := repRows[j]
+		if s.offset != p.offset {
+			r.add(FidelityOffsets, s.path, fmt.Sprintf("stored offset %d != %d", s.offset, p.offset))
+		}
+		if s.timestamp != p.timestamp {
+			r.add(FidelityTimestamps, s.path, fmt.Sprintf("timestamp %d != %d", s.timestamp, p.timestamp))
+		}
+		if s.mime != p.mime || s.originalSize != p.originalSize || s.dataSize != p.dataSize {
+			r.add(FidelityEntryFields, s.path, fmt.Sprintf("mime/original/data %#x/%d/%d != %#x/%d/%d",
+				s.mime, s.originalSize, s.dataSize, p.mime, p.originalSize, p.dataSize))
+		}
+
+		if i < len(src.entries) && j < len(rep.entries) {
+			a, b := src.entries[i], rep.entries[j]
+			if !bytes.Equal(entryPayloadBytes(original, a), entryPayloadBytes(repacked, b)) {
+				r.add(FidelityPayload, s.path, "payload bytes differ")
+			}
+		}
+	}
+
+	dataEnd := int64(len(original))
+	if src.hasTrailer {
+		dataEnd -= 1 + shaSize
+	}
+	if extra := dataEnd - src.dataStart - payloadSum; extra != 0 {
+		r.add(FidelityOffsets, "", fmt.Sprintf("%d bytes between or after payloads are not reproduced", extra))
+	}
+
+	return nil
+}
+
+// compareTrailer reports invalid source trailer hash.
+func (r *FidelityReport) compareTrailer(original []byte, src *Reader) {
+	if !src.hasTrailer {
+		return
+	}
+
+	body := original[:len(original)-1-shaSize]
+	if sha1.Sum(body) != src.sha1Trailer { //nolint:gosec // Trailer format requires SHA1.
+		r.add(FidelityTrailer, "", "source SHA1 trailer does not match content; repack writes valid trailer")
+	}
+}
+
+// add appends one divergence.
+func (r *FidelityReport) add(class FidelityClass, path string, detail string) {
+	r.Divergences = append(r.Divergences, FidelityDivergence{Class: class, Path: path, Detail: detail})
+}
+
+// rawIndexRow is one entry table row exactly as stored.
+type rawIndexRow struct {
+	path         string
+	mime         uint32
+	originalSize uint32
+	offset       uint32
+	timestamp    uint32
+	dataSize     uint32
+}
+
+// rawIndexRows parses entry table rows without offset resolution or filtering.
+func rawIndexRows(data []byte) ([]rawIndexRow, error) {
+	_, _, off, err := parseHeaderSection(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []rawIndexRow
+	for {
+		end := bytes.IndexByte(data[min(off, int64(len(data))):], 0)
+		if end < 0 || off+int64(end)+21 > int64(len(data)) {
+			return nil, fmt.Errorf("%w: truncated entry table", ErrInvalidHeader)
+		}
+
+		name := string(data[off : off+int64(end)])
+		fields := data[off+int64(end)+1 : off+int64(end)+21]
+		off += int64(end) + 21
+		if name == "" {
+			return rows, nil
+		}
+
+		rows = append(rows, rawIndexRow{
+			path:         name,
+			mime:         binary.LittleEndian.Uint32(fields[0:4]),
+			originalSize: binary.LittleEndian.Uint32(fields[4:8]),
+			offset:       binary.LittleEndian.Uint32(fields[8:12]),
+			timestamp:    binary.LittleEndian.Uint32(fields[12:16]),
+			dataSize:     binary.LittleEndian.Uint32(fields[16:20]),
+		})
+	}
+}
+
+// entryPayloadBytes returns stored payload slice of resolved entry.
+func entryPayloadBytes(data []byte, entry EntryInfo) []byte {
+	start := min(int64(entry.Offset), int64(len(data)))
+	end := min(start+int64(entry.DataSize), int64(len(data)))
+
+	return data[start:end]
+}
+
+// firstDiffOffset returns first differing byte offset or -1 for equal slices.
+func firstDiffOffset(a []byte, b []byte) int64 {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return int64(i)
+		}
+	}
+
+	if len(a) != len(b) {
+		return int64(n)
+	}
+
+	return -1
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRoundTripFidelity(t *testing.T) {
+	t.Parallel()
+
+	ownPath := filepath.Join(t.TempDir(), "own.pbo")
+	if err := createTestPBO(ownPath, map[string][]byte{
+		"config.cpp":  []byte("class CfgPatches {};"),
+		"scripts/a.c": []byte("void a();"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "own"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	report, err := RoundTrip(ownPath)
+	if err != nil {
+		t.Fatalf("RoundTrip own: %v", err)
+	}
+	if !report.Identical || report.FirstDiffOffset != -1 || len(report.Divergences) != 0 {
+		t.Fatalf("own archive must round-trip identically: %+v", report)
+	}
+
+	unsorted := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: `z.txt`, data: []byte("z")},
+		{name: `a.txt`, data: []byte("a")},
+	})
+	report, err = RoundTrip(unsorted)
+	if err != nil {
+		t.Fatalf("RoundTrip unsorted: %v", err)
+	}
+	if report.Identical || !slices.Contains(report.Classes(), FidelityOrdering) {
+		t.Fatalf("expected ordering divergence: %+v", report)
+	}
+
+	report, err = RoundTrip(writeGappedPBO(t, 16))
+	if err != nil {
+		t.Fatalf("RoundTrip gapped: %v", err)
+	}
+	classes := report.Classes()
+	if !slices.Contains(classes, FidelityOffsets) || slices.Contains(classes, FidelityPayload) {
+		t.Fatalf("expected offsets divergence without payload changes: %+v", report)
+	}
+}
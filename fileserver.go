@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA1 is used for cache validators, not for security.
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// textEntryExtensions are game text formats served as UTF-8 plain text.
+var textEntryExtensions = map[string]struct{}{
+	".bisurf": {}, ".c": {}, ".cfg": {}, ".cpp": {}, ".ext": {}, ".h": {}, ".hpp": {},
+	".inc": {}, ".layout": {}, ".rvmat": {}, ".sqf": {}, ".sqm": {}, ".sqs": {}, ".txt": {},
+}
+
+// fileServer serves reader entries over HTTP.
+type fileServer struct {
+	r *Reader
+	// etags caches entry ETag by normalized entry path.
+	etags sync.Map
+}
+
+// FileServer returns handler serving entries of r by URL path ("/scripts/a.c" serves "scripts\a.c").
+// Only GET and HEAD are allowed. Raw entries support range requests; compressed and codec-decoded
+// entries are streamed whole. ETag is SHA1 of stored payload, computed on first request.
+// Use http.StripPrefix to mount under a sub-path.
+func FileServer(r *Reader) http.Handler {
+	return &fileServer{r: r}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *fileServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.r == nil || s.r.ra == nil {
+		http.Error(w, ErrNilReader.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.r.mu.Lock()
+	closed := s.r.closed
+	s.r.mu.Unlock()
+	if closed {
+		http.Error(w, ErrClosed.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+req.URL.Path), "/")
+	info := s.r.findEntryByName(name)
+	if name == "" || info == nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	etag, err := s.entryETag(name, info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	modTime := uint32ToTime(info.TimeStamp)
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Content-Type", entryContentType(info.Path))
+
+	if info.MimeType == MimeNil && !info.IsCompressed() {
+		http.ServeContent(w, req, name, modTime, io.NewSectionReader(s.r.ra, int64(info.Offset), int64(info.DataSize)))
+		return
+	}
+
+	if etagMatches(req.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if !modTime.IsZero() {
+		h.Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	}
+	h.Set("Accept-Ranges", "none")
+	if info.MimeType == MimeCompress {
+		h.Set("Content-Length", strconv.FormatUint(uint64(info.OriginalSize), 10))
+	}
+
+	if req.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if n, err := s.r.WriteEntryTo(w, name); err != nil && n == 0 {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrEntryTooLarge) || errors.Is(err, ErrLimitExceeded) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		http.Error(w, err.Error(), status)
+	}
+}
+
+// entryETag returns cached strong ETag of entry stored payload.
+func (s *fileServer) entryETag(name string, info *EntryInfo) (string, error) {
+	key := NormalizePath(name)
+	if etag, ok := s.etags.Load(key); ok {
+		return etag.(string), nil //nolint:forcetypeassert // map holds only strings
+	}
+
+	hasher := sha1.New() //nolint:gosec // cache validator
+	if _, err := io.Copy(hasher, io.NewSectionReader(s.r.ra, int64(info.Offset), int64(info.DataSize))); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hex.EncodeToString(hasher.Sum(nil)) + `"`
+	s.etags.Store(key, etag)
+
+	return etag, nil
+}
+
+// entryContentType returns Content-Type by entry extension.
+func entryContentType(entryPath string) string {
+	ext := entryExtension(entryPath)
+	if _, ok := textEntryExtensions[ext]; ok {
+		return "text/plain; charset=utf-8"
+	}
+	if ctype := mime.TypeByExtension(ext); ext != "" && ctype != "" {
+		return ctype
+	}
+
+	return "application/octet-stream"
+}
+
+// etagMatches reports whether If-None-Match header matches etag (weak comparison).
+func etagMatches(header string, etag string) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileServer(t *testing.T) {
+	t.Parallel()
+
+	compressible := bytes.Repeat([]byte("class A {};\n"), 512)
+	pboPath := filepath.Join(t.TempDir(), "serve.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":     compressible,
+		"data/image.png": []byte("0123456789"),
+	}, PackOptions{Compress: includeRules("*.cpp"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	handler := FileServer(r)
+	serve := func(method string, target string, header http.Header) *http.Response {
+		req := httptest.NewRequest(method, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	body := func(resp *http.Response) []byte {
+		data, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		return data
+	}
+
+	resp := serve(http.MethodGet, "/config.cpp", nil)
+	if got := body(resp); resp.StatusCode != http.StatusOK || !bytes.Equal(got, compressible) {
+		t.Fatalf("compressed GET status=%d len=%d", resp.StatusCode, len(got))
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Fatalf("content type=%q", ct)
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+	if resp := serve(http.MethodGet, "/config.cpp", http.Header{"If-None-Match": {etag}}); resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("conditional GET status=%d, want 304", resp.StatusCode)
+	}
+
+	resp = serve(http.MethodGet, "/data/image.png", http.Header{"Range": {"bytes=2-5"}})
+	if got := body(resp); resp.StatusCode != http.StatusPartialContent || string(got) != "2345" {
+		t.Fatalf("range GET status=%d body=%q", resp.StatusCode, got)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "image/png" {
+		t.Fatalf("png content type=%q", ct)
+	}
+
+	if resp := serve(http.MethodGet, "/missing.txt", nil); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("missing status=%d", resp.StatusCode)
+	}
+	if resp := serve(http.MethodPost, "/config.cpp", nil); resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("POST status=%d", resp.StatusCode)
+	}
+}
@@ -4,7 +4,15 @@
 
 package pbo
 
-import "strings"
+import (
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/woozymasta/pathrules"
+)
 
 // filterOriginalSizeOrDataSize returns OriginalSize when present, otherwise DataSize.
 func filterOriginalSizeOrDataSize(entry EntryInfo) uint32 {
@@ -15,8 +23,62 @@ func filterOriginalSizeOrDataSize(entry EntryInfo) uint32 {
 	return entry.OriginalSize
 }
 
+// EntryFilter identifies reader filter that hid an entry.
+type EntryFilter string
+
+// Reader entry filters reported by Reader.HiddenEntries.
+const (
+	// EntryFilterJunk is EnableJunkFilter or JunkFilter.
+	EntryFilterJunk EntryFilter = "junk"
+	// EntryFilterSize is MinEntryOriginalSize or MinEntryDataSize.
+	EntryFilterSize EntryFilter = "size"
+	// EntryFilterMime is ReaderOptions.MimeTypes.
+	EntryFilterMime EntryFilter = "mime"
+	// EntryFilterTime is ModifiedAfter or ModifiedBefore.
+	EntryFilterTime EntryFilter = "time"
+	// EntryFilterASCII is FilterASCIIOnly.
+	EntryFilterASCII EntryFilter = "ascii_only"
+	// EntryFilterPrefix is EntryPathPrefix.
+	EntryFilterPrefix EntryFilter = "prefix"
+	// EntryFilterRules is EntryRules.
+	EntryFilterRules EntryFilter = "rules"
+	// EntryFilterPattern is EntryPattern.
+	EntryFilterPattern EntryFilter = "pattern"
+)
+
+// HiddenEntry is entry removed from visible list by reader filter.
+type HiddenEntry struct {
+	// Filter is filter that removed entry.
+	Filter EntryFilter `json:"filter" yaml:"filter"`
+	// Reason is why entry was removed.
+	Reason string `json:"reason" yaml:"reason"`
+	// Entry is hidden entry metadata.
+	Entry EntryInfo `json:"entry" yaml:"entry"`
+}
+
+// HiddenEntries returns entries removed by ReaderOptions filters in filter order
+// (junk, size, mime, time, ASCII-only, prefix, rules, pattern), each in table order; nil when nothing was removed.
+// Diagnostic tools can show what was excluded without reopening archive.
+func (r *Reader) HiddenEntries() []HiddenEntry {
+	if r == nil {
+		return nil
+	}
+
+	_ = r.LoadEntries()
+
+	return r.hidden
+}
+
+// hideEntry appends entry to hidden list when list is tracked.
+func hideEntry(hidden *[]HiddenEntry, entry EntryInfo, filter EntryFilter, reason string) {
+	if hidden != nil {
+		*hidden = append(*hidden, HiddenEntry{Filter: filter, Reason: reason, Entry: entry})
+	}
+}
+
 // filterEntriesBySize keeps entries that satisfy min original and packed size thresholds.
-func filterEntriesBySize(entries []EntryInfo, minOriginalSize uint32, minDataSize uint32) []EntryInfo {
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesBySize(entries []EntryInfo, minOriginalSize uint32, minDataSize uint32, hidden *[]HiddenEntry) []EntryInfo {
 	if minOriginalSize == 0 && minDataSize == 0 {
 		return entries
 	}
@@ -24,10 +86,12 @@ func filterEntriesBySize(entries []EntryInfo, minOriginalSize uint32, minDataSiz
 	out := make([]EntryInfo, 0, len(entries))
 	for _, entry := range entries {
 		if filterOriginalSizeOrDataSize(entry) < minOriginalSize {
+			hideEntry(hidden, entry, EntryFilterSize, "original size below minimum")
 			continue
 		}
 
 		if entry.DataSize < minDataSize {
+			hideEntry(hidden, entry, EntryFilterSize, "data size below minimum")
 			continue
 		}
 
@@ -37,11 +101,61 @@ func filterEntriesBySize(entries []EntryInfo, minOriginalSize uint32, minDataSiz
 	return out
 }
 
+// filterEntriesByMime keeps entries whose mime marker is listed in mimeTypes.
+// Empty mimeTypes keeps all entries. Removed entries are appended to hidden when it is not nil.
+func filterEntriesByMime(entries []EntryInfo, mimeTypes []MimeType, hidden *[]HiddenEntry) []EntryInfo {
+	if len(mimeTypes) == 0 {
+		return entries
+	}
+
+	out := make([]EntryInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !slices.Contains(mimeTypes, entry.MimeType) {
+			hideEntry(hidden, entry, EntryFilterMime, "mime type not listed")
+			continue
+		}
+
+		out = append(out, entry)
+	}
+
+	return out
+}
+
+// filterEntriesByTime keeps entries with timestamp after "after" and before "before";
+// zero bounds are open and entries without timestamp are kept since their age is unknown.
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesByTime(entries []EntryInfo, after time.Time, before time.Time, hidden *[]HiddenEntry) []EntryInfo {
+	if after.IsZero() && before.IsZero() {
+		return entries
+	}
+
+	out := make([]EntryInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.TimeStamp != 0 {
+			modTime := entry.Time()
+			if !after.IsZero() && !modTime.After(after) {
+				hideEntry(hidden, entry, EntryFilterTime, "modified before range")
+				continue
+			}
+			if !before.IsZero() && !modTime.Before(before) {
+				hideEntry(hidden, entry, EntryFilterTime, "modified after range")
+				continue
+			}
+		}
+
+		out = append(out, entry)
+	}
+
+	return out
+}
+
 // filterEntriesByASCIIOnly keeps entries whose path contains only ASCII bytes.
-func filterEntriesByASCIIOnly(entries []EntryInfo) []EntryInfo {
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesByASCIIOnly(entries []EntryInfo, hidden *[]HiddenEntry) []EntryInfo {
 	out := make([]EntryInfo, 0, len(entries))
 	for _, entry := range entries {
 		if !filterPathIsASCIIOnly(entry.Path) {
+			hideEntry(hidden, entry, EntryFilterASCII, "non-ASCII path")
 			continue
 		}
 
@@ -63,7 +177,8 @@ func filterPathIsASCIIOnly(pathValue string) bool {
 }
 
 // filterEntriesByPrefix keeps entries under prefix (or exact match if it points to a file).
-func filterEntriesByPrefix(entries []EntryInfo, prefix string) []EntryInfo {
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesByPrefix(entries []EntryInfo, prefix string, hidden *[]HiddenEntry) []EntryInfo {
 	prefix = NormalizePath(prefix)
 	if prefix == "" {
 		return entries
@@ -75,14 +190,18 @@ func filterEntriesByPrefix(entries []EntryInfo, prefix string) []EntryInfo {
 		entryPath := NormalizePath(entry.Path)
 		if entryPath == prefix || strings.HasPrefix(entryPath, normalizedPrefix) {
 			out = append(out, entry)
+			continue
 		}
+
+		hideEntry(hidden, entry, EntryFilterPrefix, "outside entry path prefix")
 	}
 
 	return out
 }
 
 // filterEntriesBySanitizedPrefix keeps entries under prefix in sanitized path namespace.
-func filterEntriesBySanitizedPrefix(entries []EntryInfo, prefix string) []EntryInfo {
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesBySanitizedPrefix(entries []EntryInfo, prefix string, hidden *[]HiddenEntry) []EntryInfo {
 	normalizedPrefix := NormalizePath(prefix)
 	if normalizedPrefix == "" {
 		return entries
@@ -90,6 +209,9 @@ func filterEntriesBySanitizedPrefix(entries []EntryInfo, prefix string) []EntryI
 
 	sanitizedPrefix, err := SanitizePath(normalizedPrefix)
 	if err != nil || sanitizedPrefix == "" {
+		for _, entry := range entries {
+			hideEntry(hidden, entry, EntryFilterPrefix, "invalid entry path prefix")
+		}
 		return nil
 	}
 
@@ -98,19 +220,87 @@ func filterEntriesBySanitizedPrefix(entries []EntryInfo, prefix string) []EntryI
 	for _, entry := range entries {
 		sanitizedEntryPath, sanitizeErr := SanitizePath(entry.Path)
 		if sanitizeErr != nil || sanitizedEntryPath == "" {
+			hideEntry(hidden, entry, EntryFilterPrefix, "path cannot be sanitized")
 			continue
 		}
 
 		if sanitizedEntryPath == sanitizedPrefix || strings.HasPrefix(sanitizedEntryPath, sanitizedPrefixWithSlash) {
 			out = append(out, entry)
+			continue
 		}
+
+		hideEntry(hidden, entry, EntryFilterPrefix, "outside entry path prefix")
 	}
 
 	return out
 }
 
+// filterEntriesByPath keeps entries whose normalized slash path passes ordered rules and
+// matches pattern; empty rules and nil pattern keep all entries.
+// Removed entries are appended to hidden when it is not nil.
+func filterEntriesByPath(entries []EntryInfo, rules []pathrules.Rule, pattern *regexp.Regexp, hidden *[]HiddenEntry) ([]EntryInfo, error) {
+	matcher, err := newEntryRulesMatcher(rules)
+	if err != nil {
+		return nil, err
+	}
+	if matcher == nil && pattern == nil {
+		return entries, nil
+	}
+
+	out := make([]EntryInfo, 0, len(entries))
+	for _, entry := range entries {
+		entryPath := NormalizePath(entry.Path)
+		if matcher != nil && !matcher.Included(entryPath, false) {
+			hideEntry(hidden, entry, EntryFilterRules, "excluded by entry rules")
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(entryPath) {
+			hideEntry(hidden, entry, EntryFilterPattern, "does not match entry pattern")
+			continue
+		}
+
+		out = append(out, entry)
+	}
+
+	return out, nil
+}
+
+// JunkFilter is policy of ReaderOptions.JunkFilter. Built-in checks (zero data size,
+// compressed entry without original size, invalid path) always apply first; size and
+// mime thresholds are ReaderOptions.MinEntryDataSize and ReaderOptions.MimeTypes.
+type JunkFilter struct {
+	// Predicate returns non-empty reason to drop entry that passed built-in checks.
+	Predicate func(entry EntryInfo) string `json:"-" yaml:"-"`
+}
+
+// JunkEntries returns entries dropped by junk filter in table order; nil when filter
+// is disabled or nothing was dropped.
+func (r *Reader) JunkEntries() []HiddenEntry {
+	var out []HiddenEntry
+	for _, entry := range r.HiddenEntries() {
+		if entry.Filter == EntryFilterJunk {
+			out = append(out, entry)
+		}
+	}
+
+	return out
+}
+
+// junkFilter returns effective junk policy; nil when junk filter is disabled.
+func (opts ReaderOptions) junkFilter() *JunkFilter {
+	if opts.JunkFilter != nil {
+		return opts.JunkFilter
+	}
+	if opts.EnableJunkFilter {
+		return &JunkFilter{}
+	}
+
+	return nil
+}
+
 // filterJunkEntries removes malformed or unusable entries from parsed table.
-func filterJunkEntries(entries []EntryInfo) []EntryInfo {
+// Removed entries are appended to hidden when it is not nil.
+func filterJunkEntries(entries []EntryInfo, policy *JunkFilter, logger *slog.Logger, hidden *[]HiddenEntry) []EntryInfo {
 	if len(entries) == 0 {
 		return entries
 	}
@@ -118,18 +308,45 @@ func filterJunkEntries(entries []EntryInfo) []EntryInfo {
 	filtered := make([]EntryInfo, 0, len(entries))
 	for i := range entries {
 		entry := entries[i]
-		if entry.DataSize == 0 {
-			continue
-		}
-		if entry.MimeType == MimeCompress && entry.OriginalSize == 0 {
-			continue
-		}
-		if _, err := normalizeExtractEntryPath(entry.Path); err != nil {
+		if reason := policy.reason(entry); reason != "" {
+			logger.Debug("junk entry dropped", "path", entry.Path, "reason", reason)
+			hideEntry(hidden, entry, EntryFilterJunk, reason)
 			continue
 		}
 
 		filtered = append(filtered, entry)
 	}
 
+	if dropped := len(entries) - len(filtered); dropped > 0 {
+		logger.Info("junk entries dropped", "dropped", dropped, "kept", len(filtered))
+	}
+
 	return filtered
 }
+
+// reason returns why entry is junk under policy or empty string for usable entry.
+func (f *JunkFilter) reason(entry EntryInfo) string {
+	if reason := junkEntryReason(entry); reason != "" {
+		return reason
+	}
+	if f.Predicate != nil {
+		return f.Predicate(entry)
+	}
+
+	return ""
+}
+
+// junkEntryReason returns why entry is junk or empty string for usable entry.
+func junkEntryReason(entry EntryInfo) string {
+	if entry.DataSize == 0 {
+		return "zero data size"
+	}
+	if entry.MimeType == MimeCompress && entry.OriginalSize == 0 {
+		return "compressed entry without original size"
+	}
+	if _, err := NormalizeExtractPath(entry.Path); err != nil {
+		return "invalid path"
+	}
+
+	return ""
+}
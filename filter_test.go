@@ -4,7 +4,13 @@
 
 package pbo
 
-import "testing"
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/woozymasta/pathrules"
+)
 
 func TestFilterEntriesBySize(t *testing.T) {
 	t.Parallel()
@@ -15,7 +21,7 @@ func TestFilterEntriesBySize(t *testing.T) {
 		{Path: "c.txt", DataSize: 12, OriginalSize: 0},
 	}
 
-	filtered := filterEntriesBySize(entries, 12, 5)
+	filtered := filterEntriesBySize(entries, 12, 5, nil)
 	if len(filtered) != 2 {
 		t.Fatalf("len(filtered)=%d, want 2", len(filtered))
 	}
@@ -87,7 +93,7 @@ func TestFilterEntriesByPrefix(t *testing.T) {
 		{Path: "scripts/c.txt"},
 	}
 
-	filtered := filterEntriesByPrefix(entries, "data")
+	filtered := filterEntriesByPrefix(entries, "data", nil)
 	if len(filtered) != 2 {
 		t.Fatalf("len(filtered)=%d, want 2", len(filtered))
 	}
@@ -111,7 +117,7 @@ func TestFilterEntriesByASCIIOnly(t *testing.T) {
 		{Path: "config.cpp"},
 	}
 
-	filtered := filterEntriesByASCIIOnly(entries)
+	filtered := filterEntriesByASCIIOnly(entries, nil)
 	if len(filtered) != 2 {
 		t.Fatalf("len(filtered)=%d, want 2", len(filtered))
 	}
@@ -125,6 +131,80 @@ func TestFilterEntriesByASCIIOnly(t *testing.T) {
 	}
 }
 
+func TestFilterEntriesByMimeAndTime(t *testing.T) {
+	t.Parallel()
+
+	entries := []EntryInfo{
+		{Path: "raw.txt", MimeType: MimeNil, TimeStamp: 1000},
+		{Path: "packed.txt", MimeType: MimeCompress, TimeStamp: 2000},
+		{Path: "secret.txt", MimeType: MimeEncoded, TimeStamp: 3000},
+		{Path: "undated.txt", MimeType: MimeNil},
+	}
+
+	var hidden []HiddenEntry
+	filtered := filterEntriesByMime(entries, []MimeType{MimeNil, MimeCompress}, &hidden)
+	if len(filtered) != 3 || len(hidden) != 1 || hidden[0].Entry.Path != "secret.txt" || hidden[0].Filter != EntryFilterMime {
+		t.Fatalf("mime filtered=%v hidden=%v", filtered, hidden)
+	}
+
+	hidden = nil
+	filtered = filterEntriesByTime(entries, time.Unix(1000, 0), time.Unix(3000, 0), &hidden)
+	if len(filtered) != 2 || filtered[0].Path != "packed.txt" || filtered[1].Path != "undated.txt" {
+		t.Fatalf("time filtered=%v, want packed.txt and undated.txt", filtered)
+	}
+	if len(hidden) != 2 || hidden[0].Filter != EntryFilterTime || hidden[1].Filter != EntryFilterTime {
+		t.Fatalf("time hidden=%v", hidden)
+	}
+
+	if got := filterEntriesByTime(entries, time.Time{}, time.Time{}, nil); len(got) != len(entries) {
+		t.Fatalf("open time range filtered=%d, want %d", len(got), len(entries))
+	}
+}
+
+func TestOpenWithOptions_EntryRulesAndPattern(t *testing.T) {
+	t.Parallel()
+
+	path := createManualPBOWithNamedEntries(t, []manualEntry{
+		{name: `scripts\4_world\a.c`, data: []byte("a")},
+		{name: `scripts\4_world\b.txt`, data: []byte("b")},
+		{name: `scripts\5_mission\c.c`, data: []byte("c")},
+		{name: `junk\x1f.c`, data: []byte("x")},
+	})
+
+	r, err := OpenWithOptions(path, ReaderOptions{
+		EntryRules: []pathrules.Rule{
+			{Action: pathrules.ActionInclude, Pattern: "scripts/**/*.c"},
+		},
+		EntryPattern: regexp.MustCompile(`/4_world/`),
+	})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if len(entries) != 1 || entries[0].Path != `scripts\4_world\a.c` {
+		t.Fatalf("entries = %+v, want only 4_world a.c", entries)
+	}
+
+	filters := make(map[string]EntryFilter)
+	for _, h := range r.HiddenEntries() {
+		filters[h.Entry.Path] = h.Filter
+	}
+	if filters[`scripts\4_world\b.txt`] != EntryFilterRules || filters[`junk\x1f.c`] != EntryFilterRules ||
+		filters[`scripts\5_mission\c.c`] != EntryFilterPattern {
+		t.Fatalf("hidden filters = %v", filters)
+	}
+
+	listed, err := ListEntriesWithOptions(path, ReaderOptions{EntryPattern: regexp.MustCompile(`\.c$`)})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions: %v", err)
+	}
+	if len(listed) != 3 {
+		t.Fatalf("listed entries = %d, want 3", len(listed))
+	}
+}
+
 func TestOpenWithOptions_PrefixFilterBeforeSanitize(t *testing.T) {
 	t.Parallel()
 
@@ -216,7 +296,7 @@ func TestFilterEntriesBySanitizedPrefix_EmptyPrefix(t *testing.T) {
 		{Path: `scripts\4_world\ other \  .{22877a6d-37a1-461a-91b0-dbda5aaebc99}\COM2.c`},
 	}
 
-	filtered := filterEntriesBySanitizedPrefix(entries, "")
+	filtered := filterEntriesBySanitizedPrefix(entries, "", nil)
 	if len(filtered) != len(entries) {
 		t.Fatalf("len(filtered)=%d, want %d", len(filtered), len(entries))
 	}
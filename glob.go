@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// Glob returns entries whose normalized path matches pattern case-insensitively.
+// Pattern uses pathrules glob syntax ("scripts/**", "*.layout"); "\" separators are accepted.
+// Invalid patterns match nothing.
+func (r *Reader) Glob(pattern string) []EntryInfo {
+	if r == nil {
+		return nil
+	}
+
+	matcher, err := newEntryRulesMatcher([]pathrules.Rule{{Action: pathrules.ActionInclude, Pattern: pattern}})
+	if err != nil || matcher == nil {
+		return nil
+	}
+
+	return matchEntries(r.loadedEntries(), matcher)
+}
+
+// ExtractMatching extracts entries selected by ordered rules (last matching rule wins,
+// case-insensitive). Unmatched entries are skipped when rules contain include rule,
+// otherwise rules act as exclusions. Non-nil opts.Entries narrows candidates further.
+func (r *Reader) ExtractMatching(ctx context.Context, dstDir string, rules []pathrules.Rule, opts ExtractOptions) error {
+	if r == nil {
+		return ErrNilReader
+	}
+
+	matcher, err := newEntryRulesMatcher(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := r.LoadEntries(); err != nil {
+		return err
+	}
+
+	candidates := r.entries
+	if opts.Entries != nil {
+		candidates = opts.Entries
+	}
+
+	if matcher != nil {
+		candidates = matchEntries(candidates, matcher)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	opts.Entries = candidates
+	return r.Extract(ctx, dstDir, opts)
+}
+
+// newEntryRulesMatcher compiles entry selection rules; nil matcher means rules are empty.
+func newEntryRulesMatcher(rules []pathrules.Rule) (*pathrules.Matcher, error) {
+	normalized := make([]pathrules.Rule, 0, len(rules))
+	defaultAction := pathrules.ActionInclude
+	for _, rule := range rules {
+		pattern := normalizePathForMatching(rule.Pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if rule.Action == pathrules.ActionInclude {
+			defaultAction = pathrules.ActionExclude
+		}
+
+		normalized = append(normalized, pathrules.Rule{Action: rule.Action, Pattern: pattern})
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	matcher, err := pathrules.NewMatcher(normalized, pathrules.MatcherOptions{
+		CaseInsensitive: true,
+		DefaultAction:   defaultAction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("compile entry rules: %w", err)
+	}
+
+	return matcher, nil
+}
+
+// matchEntries returns copy of entries included by matcher.
+func matchEntries(entries []EntryInfo, matcher *pathrules.Matcher) []EntryInfo {
+	matched := make([]EntryInfo, 0, len(entries))
+	for _, entry := range entries {
+		candidate := NormalizePath(entry.Path)
+		if candidate != "" && matcher.Included(candidate, false) {
+			matched = append(matched, entry)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// openGlobTestReader packs fixed tree and opens it.
+func openGlobTestReader(t *testing.T) *Reader {
+	t.Helper()
+
+	pboPath := filepath.Join(t.TempDir(), "glob.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":              []byte("cfg"),
+		"scripts/3_game/a.c":      []byte("a"),
+		"scripts/4_world/b.c":     []byte("b"),
+		"gui/layouts/menu.layout": []byte("menu"),
+		"gui/layouts/hud.LAYOUT":  []byte("hud"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+
+	return r
+}
+
+// entryPathsOf returns sorted entry paths.
+func entryPathsOf(entries []EntryInfo) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func TestReaderGlob(t *testing.T) {
+	t.Parallel()
+
+	r := openGlobTestReader(t)
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: "scripts/**", want: []string{`scripts\3_game\a.c`, `scripts\4_world\b.c`}},
+		{pattern: `scripts\4_world\*.c`, want: []string{`scripts\4_world\b.c`}},
+		{pattern: "*.layout", want: []string{`gui\layouts\hud.LAYOUT`, `gui\layouts\menu.layout`}},
+		{pattern: "missing/**", want: []string{}},
+		{pattern: "", want: nil},
+	}
+
+	for _, tc := range tests {
+		got := entryPathsOf(r.Glob(tc.pattern))
+		if tc.want == nil {
+			if len(got) != 0 {
+				t.Fatalf("Glob(%q)=%v, want none", tc.pattern, got)
+			}
+			continue
+		}
+		if len(got) != len(tc.want) {
+			t.Fatalf("Glob(%q)=%v, want %v", tc.pattern, got, tc.want)
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Fatalf("Glob(%q)=%v, want %v", tc.pattern, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestReaderExtractMatching(t *testing.T) {
+	t.Parallel()
+
+	r := openGlobTestReader(t)
+
+	dst := t.TempDir()
+	err := r.ExtractMatching(context.Background(), dst, []pathrules.Rule{
+		{Action: pathrules.ActionInclude, Pattern: "scripts/**"},
+		{Action: pathrules.ActionExclude, Pattern: "scripts/4_world/**"},
+	}, ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractMatching: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dst, "scripts", "3_game", "a.c")); err != nil {
+		t.Fatalf("included entry missing: %v", err)
+	}
+	for _, rel := range []string{"config.cpp", filepath.Join("scripts", "4_world", "b.c")} {
+		if _, err := os.Stat(filepath.Join(dst, rel)); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("excluded entry %s extracted: %v", rel, err)
+		}
+	}
+
+	excludeOnly := t.TempDir()
+	err = r.ExtractMatching(context.Background(), excludeOnly, []pathrules.Rule{
+		{Action: pathrules.ActionExclude, Pattern: "gui/**"},
+	}, ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractMatching exclude-only: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(excludeOnly, "config.cpp")); err != nil {
+		t.Fatalf("exclude-only rules dropped unmatched entry: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(excludeOnly, "gui")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("excluded gui tree extracted: %v", err)
+	}
+}
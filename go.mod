@@ -1,8 +1,9 @@
 module github.com/woozymasta/pbo
 
-go 1.25.5
+go 1.26.0
 
 require (
 	github.com/woozymasta/lzss v0.1.6
 	github.com/woozymasta/pathrules v0.1.2
+	golang.org/x/text v0.42.0
 )
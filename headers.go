@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Well-known header keys used by Arma/DayZ tooling.
+const (
+	// HeaderKeyPrefix is virtual path root of archive entries.
+	HeaderKeyPrefix = "prefix"
+	// HeaderKeyProduct is game product name ("dayz", "arma3").
+	HeaderKeyProduct = "product"
+	// HeaderKeyVersion is addon or build version string.
+	HeaderKeyVersion = "version"
+)
+
+// Prefix returns normalized value of the first "prefix" header or empty string.
+func (r *Reader) Prefix() string {
+	return NormalizePrefixHeader(r.firstHeaderValue(HeaderKeyPrefix))
+}
+
+// Product returns value of the first "product" header or empty string.
+func (r *Reader) Product() string {
+	return r.firstHeaderValue(HeaderKeyProduct)
+}
+
+// Version returns value of the first "version" header or empty string.
+func (r *Reader) Version() string {
+	return r.firstHeaderValue(HeaderKeyVersion)
+}
+
+// WithStandardHeaders returns copy of opts with Headers replaced by prefix, product,
+// version (empty values are omitted) followed by extra headers in given order.
+// Prefix is normalized to "\" separators; extra must not repeat standard keys.
+func (opts PackOptions) WithStandardHeaders(prefix string, product string, version string, extra ...HeaderPair) (PackOptions, error) {
+	headers := make([]HeaderPair, 0, 3+len(extra))
+
+	if strings.TrimSpace(prefix) != "" {
+		normalized := NormalizePrefixHeader(prefix)
+		if normalized == "" {
+			return opts, fmt.Errorf("%w: prefix %q", ErrInvalidHeaderPair, prefix)
+		}
+
+		headers = append(headers, HeaderPair{Key: HeaderKeyPrefix, Value: normalized})
+	}
+
+	for _, h := range []HeaderPair{{Key: HeaderKeyProduct, Value: product}, {Key: HeaderKeyVersion, Value: version}} {
+		h.Value = strings.TrimSpace(h.Value)
+		if h.Value == "" {
+			continue
+		}
+
+		if err := validateHeaderPair(h.Key, h.Value); err != nil {
+			return opts, err
+		}
+
+		headers = append(headers, h)
+	}
+
+	for _, h := range extra {
+		if err := validateHeaderPair(h.Key, h.Value); err != nil {
+			return opts, err
+		}
+
+		if isStandardHeaderKey(h.Key) {
+			return opts, fmt.Errorf("%w: %q", ErrDuplicateHeaderKey, h.Key)
+		}
+
+		headers = append(headers, h)
+	}
+
+	opts.Headers = headers
+	return opts, nil
+}
+
+// firstHeaderValue returns value of the first header with key (case-insensitive).
+func (r *Reader) firstHeaderValue(key string) string {
+	if r == nil {
+		return ""
+	}
+
+	for _, h := range r.headers {
+		if strings.EqualFold(h.Key, key) {
+			return h.Value
+		}
+	}
+
+	return ""
+}
+
+// isStandardHeaderKey reports whether key is prefix, product, or version (case-insensitive).
+func isStandardHeaderKey(key string) bool {
+	key = strings.TrimSpace(key)
+	return strings.EqualFold(key, HeaderKeyPrefix) ||
+		strings.EqualFold(key, HeaderKeyProduct) ||
+		strings.EqualFold(key, HeaderKeyVersion)
+}
+
+// validateHeaderPair rejects empty keys and NUL bytes that would break header section.
+func validateHeaderPair(key string, value string) error {
+	if strings.TrimSpace(key) == "" || strings.ContainsRune(key, 0) || strings.ContainsRune(value, 0) {
+		return fmt.Errorf("%w: %q", ErrInvalidHeaderPair, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithStandardHeadersAndAccessors(t *testing.T) {
+	t.Parallel()
+
+	opts, err := PackOptions{}.WithStandardHeaders("/my/addon/", "dayz", " 1.2 ", HeaderPair{Key: "author", Value: "me"})
+	if err != nil {
+		t.Fatalf("WithStandardHeaders: %v", err)
+	}
+
+	want := []HeaderPair{
+		{Key: "prefix", Value: `my\addon`},
+		{Key: "product", Value: "dayz"},
+		{Key: "version", Value: "1.2"},
+		{Key: "author", Value: "me"},
+	}
+	if len(opts.Headers) != len(want) {
+		t.Fatalf("headers=%v, want %v", opts.Headers, want)
+	}
+	for i := range want {
+		if opts.Headers[i] != want[i] {
+			t.Fatalf("headers=%v, want %v", opts.Headers, want)
+		}
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "std.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("a")}, opts); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.Prefix() != `my\addon` || r.Product() != "dayz" || r.Version() != "1.2" {
+		t.Fatalf("accessors prefix=%q product=%q version=%q", r.Prefix(), r.Product(), r.Version())
+	}
+
+	onlyPrefix, err := PackOptions{}.WithStandardHeaders("addon", "", "")
+	if err != nil || len(onlyPrefix.Headers) != 1 {
+		t.Fatalf("prefix-only headers=%v, err=%v", onlyPrefix.Headers, err)
+	}
+
+	if _, err := (PackOptions{}).WithStandardHeaders("..", "", ""); !errors.Is(err, ErrInvalidHeaderPair) {
+		t.Fatalf("expected ErrInvalidHeaderPair for empty normalized prefix, got %v", err)
+	}
+	if _, err := (PackOptions{}).WithStandardHeaders("a", "", "", HeaderPair{Key: "Version", Value: "2"}); !errors.Is(err, ErrDuplicateHeaderKey) {
+		t.Fatalf("expected ErrDuplicateHeaderKey, got %v", err)
+	}
+	if _, err := (PackOptions{}).WithStandardHeaders("a", "dayz\x00", ""); !errors.Is(err, ErrInvalidHeaderPair) {
+		t.Fatalf("expected ErrInvalidHeaderPair for NUL value, got %v", err)
+	}
+
+	var nilReader *Reader
+	if nilReader.Prefix() != "" || nilReader.Version() != "" {
+		t.Fatalf("nil reader accessors must return empty strings")
+	}
+}
+
+func TestReader_VirtualEntries(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "virtual.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"3_game/x.c": []byte("x")}, PackOptions{
+		Headers: []HeaderPair{{Key: HeaderKeyPrefix, Value: "dz/scripts"}},
+	}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.VirtualEntries()
+	if len(entries) != 1 || entries[0].Path != `\dz\scripts\3_game\x.c` {
+		t.Fatalf("VirtualEntries=%+v", entries)
+	}
+	if r.Entries()[0].Path != `3_game\x.c` {
+		t.Fatalf("Entries path changed: %q", r.Entries()[0].Path)
+	}
+}
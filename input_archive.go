@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strings"
+)
+
+// InputsFromZip returns one Input per regular file of zip archive.
+// SizeHint and ModTime are taken from zip headers; files are opened lazily by
+// Input.Open during pack, so zr must stay usable until pack completes.
+func InputsFromZip(zr *zip.Reader) ([]Input, error) {
+	if zr == nil {
+		return nil, ErrNilReader
+	}
+
+	inputs := make([]Input, 0, len(zr.File))
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		archivePath := archiveInputPath(f.Name)
+		if archivePath == "" {
+			continue
+		}
+		if f.UncompressedSize64 > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: %s", ErrSizeOverflow, f.Name)
+		}
+
+		inputs = append(inputs, Input{
+			Path:     archivePath,
+			ModTime:  f.Modified,
+			SizeHint: int64(f.UncompressedSize64), //nolint:gosec // bounded by MaxUint32 above
+			Open:     f.Open,
+		})
+	}
+
+	return inputs, nil
+}
+
+// InputsFromTar reads tar stream to end and returns one Input per regular file.
+// Tar is sequential, so file contents are buffered in memory; SizeHint and ModTime
+// are taken from tar headers. Directories, links, and special files are skipped.
+func InputsFromTar(tr *tar.Reader) ([]Input, error) {
+	if tr == nil {
+		return nil, ErrNilReader
+	}
+
+	var inputs []Input
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return inputs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar header: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		archivePath := archiveInputPath(hdr.Name)
+		if archivePath == "" {
+			continue
+		}
+		if hdr.Size < 0 || hdr.Size > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: %s", ErrSizeOverflow, hdr.Name)
+		}
+
+		data := make([]byte, hdr.Size)
+		if _, err := io.ReadFull(tr, data); err != nil {
+			return nil, fmt.Errorf("read tar entry %s: %w", hdr.Name, err)
+		}
+
+		inputs = append(inputs, Input{
+			Path:     archivePath,
+			ModTime:  hdr.ModTime,
+			SizeHint: hdr.Size,
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		})
+	}
+}
+
+// archiveInputPath cleans zip or tar member name into slash-separated input path,
+// dropping "./" and leading separators. Empty result means member has no file name.
+func archiveInputPath(name string) string {
+	cleaned := path.Clean("/" + strings.ReplaceAll(name, `\`, "/"))
+	return strings.TrimPrefix(cleaned, "/")
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/woozymasta/pathrules"
+)
+
+func TestInputsFromZipAndTar(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Unix(1700000000, 0).UTC()
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{name: "./config.cpp", data: []byte("class CfgPatches {};")},
+		{name: "scripts/init.sqf", data: bytes.Repeat([]byte("hint 1; "), 64)},
+	}
+	want := map[string][]byte{
+		"config.cpp":       files[0].data,
+		`scripts\init.sqf`: files[1].data,
+	}
+
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	if _, err := zw.Create("scripts/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: modTime})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(f.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	if err := tw.WriteHeader(&tar.Header{Name: "scripts/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(f.data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(zipBuf.Bytes()), int64(zipBuf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zipInputs, err := InputsFromZip(zr)
+	if err != nil {
+		t.Fatalf("InputsFromZip: %v", err)
+	}
+	tarInputs, err := InputsFromTar(tar.NewReader(&tarBuf))
+	if err != nil {
+		t.Fatalf("InputsFromTar: %v", err)
+	}
+
+	for name, inputs := range map[string][]Input{"zip": zipInputs, "tar": tarInputs} {
+		if len(inputs) != len(files) {
+			t.Fatalf("%s inputs = %d, want %d", name, len(inputs), len(files))
+		}
+		for _, in := range inputs {
+			if in.SizeHint <= 0 || !in.ModTime.Equal(modTime) {
+				t.Fatalf("%s input %s: size=%d mod=%v", name, in.Path, in.SizeHint, in.ModTime)
+			}
+		}
+
+		pboPath := filepath.Join(t.TempDir(), name+".pbo")
+		if _, err := PackFile(context.Background(), pboPath, inputs, PackOptions{RequireModTime: true}); err != nil {
+			t.Fatalf("%s PackFile: %v", name, err)
+		}
+
+		r, err := Open(pboPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range r.Entries() {
+			rc, err := r.OpenEntryInfo(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := io.ReadAll(rc)
+			_ = rc.Close()
+			if err != nil || !bytes.Equal(data, want[entry.Path]) {
+				t.Fatalf("%s entry %s content mismatch: %v", name, entry.Path, err)
+			}
+			if entry.TimeStamp != 1700000000 {
+				t.Fatalf("%s entry %s timestamp = %d", name, entry.Path, entry.TimeStamp)
+			}
+		}
+		_ = r.Close()
+	}
+}
+
+func TestInputsFromFS(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Unix(1700000000, 0)
+	fsys := fstest.MapFS{
+		"mod/config.cpp":       {Data: []byte("class CfgPatches {};"), ModTime: modTime},
+		"mod/scripts/init.sqf": {Data: []byte("hint 1;"), ModTime: modTime},
+		"mod/notes.md":         {Data: []byte("skip me"), ModTime: modTime},
+		"other/x.cpp":          {Data: []byte("outside root")},
+	}
+
+	inputs, err := InputsFromFS(fsys, "mod", []pathrules.Rule{
+		{Action: pathrules.ActionExclude, Pattern: "*.md"},
+	})
+	if err != nil {
+		t.Fatalf("InputsFromFS: %v", err)
+	}
+
+	got := make(map[string]Input, len(inputs))
+	for _, in := range inputs {
+		got[in.Path] = in
+	}
+	if len(got) != 2 || got["config.cpp"].Open == nil || got["scripts/init.sqf"].Open == nil {
+		t.Fatalf("inputs = %+v, want config.cpp and scripts/init.sqf", inputs)
+	}
+
+	in := got["scripts/init.sqf"]
+	if in.SizeHint != 7 || !in.ModTime.Equal(modTime) {
+		t.Fatalf("init.sqf size=%d mod=%v", in.SizeHint, in.ModTime)
+	}
+
+	rc, err := in.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil || string(data) != "hint 1;" {
+		t.Fatalf("init.sqf content = %q, %v", data, err)
+	}
+
+	all, err := InputsFromFS(fsys, "", nil)
+	if err != nil {
+		t.Fatalf("InputsFromFS(all): %v", err)
+	}
+	if len(all) != len(fsys) {
+		t.Fatalf("all inputs = %d, want %d", len(all), len(fsys))
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// InputsFromDir walks srcDir and returns one Input per regular file.
+// Input paths are relative to srcDir, SizeHint and ModTime are taken from file info.
+// Files are opened lazily by Input.Open during pack.
+func InputsFromDir(srcDir string) ([]Input, error) {
+	rootAbs, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve source dir: %w", err)
+	}
+
+	inputs := make([]Input, 0, 64)
+	walkErr := filepath.WalkDir(rootAbs, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", filePath, err)
+		}
+
+		relPath, err := filepath.Rel(rootAbs, filePath)
+		if err != nil {
+			return fmt.Errorf("resolve relative path %s: %w", filePath, err)
+		}
+
+		inputs = append(inputs, newFileInput(filePath, filepath.ToSlash(relPath), info))
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("walk source dir: %w", walkErr)
+	}
+
+	return inputs, nil
+}
+
+// InputsFromFS walks root of fsys (embed.FS, zip.Reader, fstest.MapFS) and returns one
+// Input per regular file selected by ordered rules like ExtractMatching; nil rules select
+// all files. Input paths are relative to root, and files are opened lazily from fsys.
+func InputsFromFS(fsys fs.FS, root string, rules []pathrules.Rule) ([]Input, error) {
+	if fsys == nil {
+		return nil, ErrNilReader
+	}
+	if root == "" {
+		root = "."
+	}
+
+	matcher, err := newEntryRulesMatcher(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]Input, 0, 64)
+	walkErr := fs.WalkDir(fsys, root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath := filePath
+		if root != "." {
+			relPath = strings.TrimPrefix(filePath, root+"/")
+		}
+		if matcher != nil && !matcher.Included(normalizePathForMatching(relPath), false) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", filePath, err)
+		}
+
+		inputs = append(inputs, Input{
+			Path:     relPath,
+			ModTime:  info.ModTime(),
+			SizeHint: info.Size(),
+			Open: func() (io.ReadCloser, error) {
+				return fsys.Open(filePath)
+			},
+		})
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("walk source fs: %w", walkErr)
+	}
+
+	return inputs, nil
+}
+
+// PackDir packs all regular files under srcDir into outPath and appends SHA1 trailer.
+// Prefix file in srcDir root (see ReadPrefixFile) is not packed; its prefix and headers
+// are used for keys missing from opts.Headers unless IgnorePrefixFile is set.
+func PackDir(ctx context.Context, srcDir string, outPath string, opts PackOptions) (*PackResult, error) {
+	inputs, err := InputsFromDir(srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.IgnorePrefixFile {
+		pf, err := ReadPrefixFile(srcDir)
+		if err != nil {
+			return nil, err
+		}
+
+		if pf != nil {
+			inputs = withoutPrefixFileInputs(inputs)
+			opts = applyPrefixFile(opts, pf)
+		}
+	}
+
+	return PackFile(ctx, outPath, inputs, opts)
+}
+
+// newFileInput builds Input for one local file.
+func newFileInput(filePath string, archivePath string, info fs.FileInfo) Input {
+	return Input{
+		Path:     archivePath,
+		ModTime:  info.ModTime(),
+		SizeHint: info.Size(),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(filePath) //nolint:gosec // path comes from caller-selected source tree walk
+		},
+	}
+}
+
+// hasInputPathFold reports whether inputs contain archive path (case-insensitive, normalized).
+func hasInputPathFold(inputs []Input, archivePath string) bool {
+	want := NormalizePath(archivePath)
+	for i := range inputs {
+		if strings.EqualFold(NormalizePath(inputs[i].Path), want) {
+			return true
+		}
+	}
+
+	return false
+}
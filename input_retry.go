@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"syscall"
+	"time"
+)
+
+// maxOpenRetryBackoff bounds exponential growth of OpenRetry delay.
+const maxOpenRetryBackoff = time.Hour
+
+// OpenRetry retries failed Input.Open calls during pack with exponential backoff.
+// Only opening is retried; read errors of opened stream fail entry as before.
+type OpenRetry struct {
+	// Retryable reports whether Open error is transient. Nil uses IsTransientOpenError.
+	Retryable func(err error) bool `json:"-" yaml:"-"`
+	// Attempts is maximum number of Open calls per input, including first one.
+	// Zero or one disables retry.
+	Attempts int `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	// Backoff is delay before second attempt; it doubles for every next attempt.
+	Backoff time.Duration `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// attempts returns number of Open calls allowed per input.
+func (r *OpenRetry) attempts() int {
+	if r == nil || r.Attempts < 1 {
+		return 1
+	}
+
+	return r.Attempts
+}
+
+// retryable reports whether err may be retried.
+func (r *OpenRetry) retryable(err error) bool {
+	if r == nil {
+		return false
+	}
+	if r.Retryable == nil {
+		return IsTransientOpenError(err)
+	}
+
+	return r.Retryable(err)
+}
+
+// IsTransientOpenError reports whether Input.Open error is worth retrying: timeouts
+// (ErrEntryTimeout, context.DeadlineExceeded, net timeouts) and busy, interrupted,
+// or reset system calls. Missing files, permission errors, and cancellation are not.
+func IsTransientOpenError(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, fs.ErrNotExist),
+		errors.Is(err, fs.ErrPermission):
+		return false
+	case errors.Is(err, ErrEntryTimeout),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, syscall.EAGAIN),
+		errors.Is(err, syscall.EBUSY),
+		errors.Is(err, syscall.EINTR),
+		errors.Is(err, syscall.ETIMEDOUT),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNABORTED):
+		return true
+	}
+
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// delay returns backoff before attempt following failed attempt n (1-based).
+func (r *OpenRetry) delay(n int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < n && d > 0 && d < maxOpenRetryBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, maxOpenRetryBackoff)
+}
+
+// openInputReader opens source stream for one input, guarded by timeout when set and
+// retried per retry policy. Backoff waits end early when ctx is canceled.
+func openInputReader(ctx context.Context, in Input, timeout time.Duration, retry *OpenRetry) (io.ReadCloser, error) {
+	if in.Open == nil {
+		return nil, fmt.Errorf("input %s: Open is nil", in.Path)
+	}
+
+	attempts := retry.attempts()
+	for attempt := 1; ; attempt++ {
+		rc, err := openWithDeadline(in.Path, timeout, in.Open)
+		if err == nil {
+			return rc, nil
+		}
+
+		if attempt >= attempts || !retry.retryable(err) {
+			if attempt > 1 {
+				return nil, fmt.Errorf("open input %s after %d attempts: %w", in.Path, attempt, err)
+			}
+
+			return nil, fmt.Errorf("open input %s: %w", in.Path, err)
+		}
+
+		if ctxErr := sleepContext(ctx, retry.delay(attempt)); ctxErr != nil {
+			return nil, fmt.Errorf("open input %s: retry after %d attempts: %w (last error: %v)", in.Path, attempt, ctxErr, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var errTransientOpen = errors.New("transient open failure")
+
+// flakyInput returns input whose Open fails first failures times with err.
+func flakyInput(path string, failures int, err error, calls *int) Input {
+	return Input{
+		Path: path,
+		Open: func() (io.ReadCloser, error) {
+			*calls++
+			if *calls <= failures {
+				return nil, err
+			}
+			return io.NopCloser(bytes.NewReader([]byte(path))), nil
+		},
+	}
+}
+
+func TestPack_OpenRetry(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.pbo")
+	retry := &OpenRetry{
+		Attempts:  3,
+		Backoff:   time.Millisecond,
+		Retryable: func(err error) bool { return errors.Is(err, errTransientOpen) },
+	}
+
+	var calls int
+	if _, err := PackFile(context.Background(), out, []Input{flakyInput("a.txt", 2, errTransientOpen, &calls)}, PackOptions{OpenRetry: retry}); err != nil {
+		t.Fatalf("PackFile with retry: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("Open calls = %d, want 3", calls)
+	}
+
+	calls = 0
+	_, err := PackFile(context.Background(), out, []Input{flakyInput("a.txt", 5, errTransientOpen, &calls)}, PackOptions{OpenRetry: retry})
+	if !errors.Is(err, errTransientOpen) || calls != 3 {
+		t.Fatalf("exhausted retry err = %v calls = %d, want transient error after 3 calls", err, calls)
+	}
+
+	calls = 0
+	permanent := errors.New("permission denied")
+	_, err = PackFile(context.Background(), out, []Input{flakyInput("a.txt", 1, permanent, &calls)}, PackOptions{OpenRetry: retry})
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Fatalf("non-retryable err = %v calls = %d, want one call", err, calls)
+	}
+}
+
+func TestOpenInputReader_RetryCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	_, err := openInputReader(ctx, flakyInput("a.txt", 1, syscall.EBUSY, &calls), 0, &OpenRetry{Attempts: 2, Backoff: time.Hour})
+	if !errors.Is(err, context.Canceled) || calls != 1 || !strings.Contains(err.Error(), "a.txt") {
+		t.Fatalf("canceled retry err = %v calls = %d", err, calls)
+	}
+}
+
+func TestOpenRetry_DefaultTransientOnly(t *testing.T) {
+	t.Parallel()
+
+	retry := &OpenRetry{Attempts: 3, Backoff: time.Millisecond}
+	for _, tc := range []struct {
+		err   error
+		calls int
+	}{
+		{err: &fs.PathError{Op: "open", Path: "a.txt", Err: syscall.EBUSY}, calls: 3},
+		{err: fmt.Errorf("wrapped: %w", ErrEntryTimeout), calls: 3},
+		{err: &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}, calls: 1},
+		{err: &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrPermission}, calls: 1},
+		{err: errTransientOpen, calls: 1},
+	} {
+		var calls int
+		_, err := openInputReader(context.Background(), flakyInput("a.txt", 5, tc.err, &calls), 0, retry)
+		if !errors.Is(err, tc.err) || calls != tc.calls {
+			t.Fatalf("%v: err = %v calls = %d, want %d", tc.err, err, calls, tc.calls)
+		}
+	}
+}
+
+func TestOpenRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	r := &OpenRetry{Backoff: 10 * time.Millisecond}
+	if got := r.delay(1); got != 10*time.Millisecond {
+		t.Fatalf("delay(1) = %s", got)
+	}
+	if got := r.delay(3); got != 40*time.Millisecond {
+		t.Fatalf("delay(3) = %s", got)
+	}
+	if got := r.delay(100); got != maxOpenRetryBackoff {
+		t.Fatalf("delay(100) = %s, want cap", got)
+	}
+
+	var none *OpenRetry
+	if none.attempts() != 1 || none.retryable(errTransientOpen) {
+		t.Fatal("nil OpenRetry must open once")
+	}
+}
@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Well-known server layout directory and file names.
+const (
+	// ModDirPrefix is the leading marker of mod directories in server root ("@CF").
+	ModDirPrefix = "@"
+	// AddonsDirName is the mod subdirectory holding PBO archives.
+	AddonsDirName = "addons"
+	// KeysDirName is the directory holding .bikey public keys.
+	KeysDirName = "keys"
+	// BikeyExtension is the public key file extension.
+	BikeyExtension = ".bikey"
+	// BisignExtension is the signature file extension.
+	BisignExtension = ".bisign"
+)
+
+// ModLayout describes one mod directory discovered in server layout.
+type ModLayout struct {
+	// Name is mod directory name including "@" marker.
+	Name string `json:"name" yaml:"name"`
+	// Dir is absolute mod directory path.
+	Dir string `json:"dir" yaml:"dir"`
+	// Addons are absolute .pbo paths from addons directory sorted by file name.
+	Addons []string `json:"addons,omitempty" yaml:"addons,omitempty"`
+	// Keys are absolute .bikey paths from mod keys directory sorted by file name.
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+// FindMods discovers all "@"-prefixed mod directories under serverDir sorted by name.
+func FindMods(serverDir string) ([]ModLayout, error) {
+	rootAbs, err := filepath.Abs(serverDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve server dir: %w", err)
+	}
+
+	dirEntries, err := os.ReadDir(rootAbs)
+	if err != nil {
+		return nil, fmt.Errorf("read server dir: %w", err)
+	}
+
+	mods := make([]ModLayout, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() || !strings.HasPrefix(dirEntry.Name(), ModDirPrefix) {
+			continue
+		}
+
+		mod, err := loadModLayout(filepath.Join(rootAbs, dirEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mods = append(mods, *mod)
+	}
+
+	sort.Slice(mods, func(i, j int) bool {
+		return strings.ToLower(mods[i].Name) < strings.ToLower(mods[j].Name)
+	})
+
+	return mods, nil
+}
+
+// FindMod locates one mod by name under serverDir.
+// Name lookup is case-insensitive and "@" marker is optional.
+func FindMod(serverDir string, modName string) (*ModLayout, error) {
+	rootAbs, err := filepath.Abs(serverDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve server dir: %w", err)
+	}
+
+	wanted := strings.TrimSpace(modName)
+	if wanted == "" {
+		return nil, fmt.Errorf("%w: empty mod name", ErrModNotFound)
+	}
+	if !strings.HasPrefix(wanted, ModDirPrefix) {
+		wanted = ModDirPrefix + wanted
+	}
+
+	dirName, err := findChildDirFold(rootAbs, wanted)
+	if err != nil {
+		return nil, err
+	}
+	if dirName == "" {
+		return nil, fmt.Errorf("%w: %s", ErrModNotFound, modName)
+	}
+
+	return loadModLayout(filepath.Join(rootAbs, dirName))
+}
+
+// ServerKeysDir returns server-level keys directory path (case-insensitive lookup).
+// Missing directory resolves to serverDir/keys.
+func ServerKeysDir(serverDir string) (string, error) {
+	rootAbs, err := filepath.Abs(serverDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve server dir: %w", err)
+	}
+
+	dirName, err := findChildDirFold(rootAbs, KeysDirName)
+	if err != nil {
+		return "", err
+	}
+	if dirName == "" {
+		dirName = KeysDirName
+	}
+
+	return filepath.Join(rootAbs, dirName), nil
+}
+
+// OpenMod locates mod by name and opens all its addon archives as one MultiReader.
+func OpenMod(serverDir string, modName string, opts ReaderOptions) (*MultiReader, error) {
+	mod, err := FindMod(serverDir, modName)
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenMulti(mod.Addons, opts)
+}
+
+// loadModLayout collects addons and keys from one mod directory.
+func loadModLayout(modDir string) (*ModLayout, error) {
+	mod := &ModLayout{
+		Name: filepath.Base(modDir),
+		Dir:  modDir,
+	}
+
+	addons, err := listModFilesByExt(modDir, AddonsDirName, FileExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := listModFilesByExt(modDir, KeysDirName, BikeyExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	mod.Addons = addons
+	mod.Keys = keys
+	return mod, nil
+}
+
+// listModFilesByExt lists files with extension from case-insensitive subdirectory.
+func listModFilesByExt(modDir string, subDir string, ext string) ([]string, error) {
+	dirName, err := findChildDirFold(modDir, subDir)
+	if err != nil || dirName == "" {
+		return nil, err
+	}
+
+	return listFilesByExt(filepath.Join(modDir, dirName), ext)
+}
+
+// listFilesByExt lists files of dirPath with extension (case-insensitive) in case-insensitive order.
+func listFilesByExt(dirPath string, ext string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dirPath, err)
+	}
+
+	out := make([]string, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.EqualFold(filepath.Ext(dirEntry.Name()), ext) {
+			continue
+		}
+
+		out = append(out, filepath.Join(dirPath, dirEntry.Name()))
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return strings.ToLower(out[i]) < strings.ToLower(out[j])
+	})
+
+	return out, nil
+}
+
+// findChildDirFold returns exact name of child directory matching name case-insensitively.
+// Empty result means no such directory.
+func findChildDirFold(parent string, name string) (string, error) {
+	info, err := os.Stat(filepath.Join(parent, name))
+	if err == nil && info.IsDir() {
+		return name, nil
+	}
+
+	dirEntries, err := os.ReadDir(parent)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("read %s: %w", parent, err)
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() && strings.EqualFold(dirEntry.Name(), name) {
+			return dirEntry.Name(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// MultiReader groups several opened archives addressed by prefix header.
+// Virtual paths are prefix header plus entry path, matched case-insensitively;
+// later archives override files of earlier ones with equal virtual path, like game
+// load order. MultiReader is safe for concurrent reads.
+type MultiReader struct {
+	// index maps lowercase slash-separated virtual path to winning file; built on first lookup.
+	index map[string]multiFile
+	// readers stores opened archives in input order.
+	readers []*Reader
+	// paths stores archive file paths paired with readers.
+	paths []string
+	// prefixes stores normalized slash-separated prefix per reader.
+	prefixes []string
+	// shadowed counts entries hidden by later archives or duplicate paths.
+	shadowed  int
+	indexOnce sync.Once
+}
+
+// multiFile locates one virtual file: archive index and entry index in its reader.
+type multiFile struct {
+	archive int
+	entry   int
+}
+
+// OpenMulti opens several archives and groups them into one MultiReader.
+// Already opened readers are closed when one archive fails to open.
+func OpenMulti(paths []string, opts ReaderOptions) (*MultiReader, error) {
+	m := &MultiReader{
+		readers:  make([]*Reader, 0, len(paths)),
+		paths:    make([]string, 0, len(paths)),
+		prefixes: make([]string, 0, len(paths)),
+	}
+
+	for _, archivePath := range paths {
+		r, err := OpenWithOptions(archivePath, opts)
+		if err != nil {
+			_ = m.Close()
+			return nil, fmt.Errorf("open %s: %w", archivePath, err)
+		}
+
+		m.readers = append(m.readers, r)
+		m.paths = append(m.paths, archivePath)
+		m.prefixes = append(m.prefixes, NormalizePath(pboPrefixFromHeaders(r.Headers())))
+	}
+
+	return m, nil
+}
+
+// buildIndex indexes entries of all archives in load order. Archives whose
+// entries fail to load are skipped; OpenBankSet loads them up front to report errors.
+func (m *MultiReader) buildIndex() {
+	m.index = make(map[string]multiFile)
+	for archive, r := range m.readers {
+		if r.LoadEntries() != nil {
+			continue
+		}
+
+		for i, entry := range r.entries {
+			key := multiIndexKey(m.prefixes[archive], entry.Path)
+			if prev, ok := m.index[key]; ok {
+				m.shadowed++
+				// Duplicate inside one archive keeps first entry, like Reader lookups.
+				if prev.archive == archive {
+					continue
+				}
+			}
+
+			m.index[key] = multiFile{archive: archive, entry: i}
+		}
+	}
+}
+
+// multiIndexKey returns lowercase slash-separated index key of prefix and entry path.
+func multiIndexKey(prefix string, entryPath string) string {
+	entryPath = NormalizePath(entryPath)
+	if prefix == "" {
+		return strings.ToLower(entryPath)
+	}
+
+	return strings.ToLower(prefix + "/" + entryPath)
+}
+
+// lookup returns winning file of virtual path.
+func (m *MultiReader) lookup(virtualPath string) (multiFile, bool) {
+	m.indexOnce.Do(m.buildIndex)
+	file, ok := m.index[strings.ToLower(NormalizePath(virtualPath))]
+
+	return file, ok
+}
+
+// Len returns number of grouped archives.
+func (m *MultiReader) Len() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.readers)
+}
+
+// Reader returns archive reader by index.
+func (m *MultiReader) Reader(i int) *Reader {
+	if m == nil || i < 0 || i >= len(m.readers) {
+		return nil
+	}
+
+	return m.readers[i]
+}
+
+// Path returns archive file path by index.
+func (m *MultiReader) Path(i int) string {
+	if m == nil || i < 0 || i >= len(m.paths) {
+		return ""
+	}
+
+	return m.paths[i]
+}
+
+// Prefix returns normalized slash-separated prefix header of archive by index.
+func (m *MultiReader) Prefix(i int) string {
+	if m == nil || i < 0 || i >= len(m.prefixes) {
+		return ""
+	}
+
+	return m.prefixes[i]
+}
+
+// Find resolves prefixed virtual path ("my_mod/scripts/main.c") to archive index and entry.
+// Later archive wins when several provide equal virtual path.
+func (m *MultiReader) Find(virtualPath string) (int, EntryInfo, bool) {
+	if m == nil {
+		return -1, EntryInfo{}, false
+	}
+
+	file, ok := m.lookup(virtualPath)
+	if !ok {
+		return -1, EntryInfo{}, false
+	}
+
+	return file.archive, m.readers[file.archive].entries[file.entry], true
+}
+
+// OpenEntry opens entry by prefixed virtual path.
+func (m *MultiReader) OpenEntry(virtualPath string) (io.ReadCloser, error) {
+	idx, entry, ok := m.Find(virtualPath)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, virtualPath)
+	}
+
+	return m.readers[idx].OpenEntryInfo(entry)
+}
+
+// ReadEntry reads full content of entry by prefixed virtual path.
+func (m *MultiReader) ReadEntry(virtualPath string) ([]byte, error) {
+	rc, err := m.OpenEntry(virtualPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	return io.ReadAll(rc)
+}
+
+// Close closes all grouped readers and returns first close error.
+func (m *MultiReader) Close() error {
+	if m == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, r := range m.readers {
+		if err := r.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindModAndOpenMod(t *testing.T) {
+	t.Parallel()
+
+	serverDir := t.TempDir()
+	addonsDir := filepath.Join(serverDir, "@MyMod", "Addons")
+	keysDir := filepath.Join(serverDir, "@MyMod", "Keys")
+	for _, dir := range []string{addonsDir, keysDir, filepath.Join(serverDir, "@Other"), filepath.Join(serverDir, "keys")} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(keysDir, "mymod.bikey"), []byte("key"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	err := createTestPBO(filepath.Join(addonsDir, "scripts.pbo"), map[string][]byte{
+		"Scripts/main.c": []byte("main"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: `my_mod\scripts`}}})
+	if err != nil {
+		t.Fatalf("create scripts.pbo: %v", err)
+	}
+
+	err = createTestPBO(filepath.Join(addonsDir, "data.pbo"), map[string][]byte{
+		"config.cpp": []byte("cfg"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: `my_mod`}}})
+	if err != nil {
+		t.Fatalf("create data.pbo: %v", err)
+	}
+
+	mods, err := FindMods(serverDir)
+	if err != nil {
+		t.Fatalf("FindMods: %v", err)
+	}
+	if len(mods) != 2 || mods[0].Name != "@MyMod" || mods[1].Name != "@Other" {
+		t.Fatalf("mods=%+v", mods)
+	}
+
+	mod, err := FindMod(serverDir, "mymod")
+	if err != nil {
+		t.Fatalf("FindMod: %v", err)
+	}
+	if len(mod.Addons) != 2 || len(mod.Keys) != 1 {
+		t.Fatalf("addons=%v keys=%v", mod.Addons, mod.Keys)
+	}
+
+	keys, err := ServerKeysDir(serverDir)
+	if err != nil {
+		t.Fatalf("ServerKeysDir: %v", err)
+	}
+	if keys != filepath.Join(serverDir, "keys") {
+		t.Fatalf("keys dir=%q", keys)
+	}
+
+	m, err := OpenMod(serverDir, "@mymod", ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenMod: %v", err)
+	}
+	defer func() { _ = m.Close() }()
+
+	data, err := m.ReadEntry(`MY_MOD\scripts\Scripts\main.c`)
+	if err != nil {
+		t.Fatalf("ReadEntry nested prefix: %v", err)
+	}
+	if string(data) != "main" {
+		t.Fatalf("data=%q", data)
+	}
+
+	data, err = m.ReadEntry("my_mod/config.cpp")
+	if err != nil {
+		t.Fatalf("ReadEntry root prefix: %v", err)
+	}
+	if string(data) != "cfg" {
+		t.Fatalf("data=%q", data)
+	}
+
+	if _, err := m.ReadEntry("other/config.cpp"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+
+	if _, err := FindMod(serverDir, "missing"); !errors.Is(err, ErrModNotFound) {
+		t.Fatalf("expected ErrModNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import "fmt"
+
+// Resource limit names reported by LimitError.
+const (
+	// LimitMaxEntries is ReaderLimits.MaxEntries.
+	LimitMaxEntries = "max_entries"
+	// LimitMaxIndexBytes is ReaderLimits.MaxIndexBytes.
+	LimitMaxIndexBytes = "max_index_bytes"
+	// LimitMaxHeaderPairs is ReaderLimits.MaxHeaderPairs.
+	LimitMaxHeaderPairs = "max_header_pairs"
+	// LimitMaxTotalDecompressed is ReaderLimits.MaxTotalDecompressed.
+	LimitMaxTotalDecompressed = "max_total_decompressed"
+)
+
+// ReaderLimits bound resources one archive may consume. Zero field means unlimited.
+type ReaderLimits struct {
+	// MaxIndexBytes caps entry table size in bytes.
+	MaxIndexBytes int64 `json:"max_index_bytes,omitempty" yaml:"max_index_bytes,omitempty"`
+	// MaxTotalDecompressed caps bytes produced by compressed and codec-decoded entries
+	// over reader lifetime, summed across all opened entries.
+	MaxTotalDecompressed int64 `json:"max_total_decompressed,omitempty" yaml:"max_total_decompressed,omitempty"`
+	// MaxEntries caps number of entry table rows.
+	MaxEntries int `json:"max_entries,omitempty" yaml:"max_entries,omitempty"`
+	// MaxHeaderPairs caps number of header key-value pairs.
+	MaxHeaderPairs int `json:"max_header_pairs,omitempty" yaml:"max_header_pairs,omitempty"`
+}
+
+// LimitError reports archive exceeding one of ReaderLimits.
+type LimitError struct {
+	// Limit is limit name (LimitMaxEntries, LimitMaxIndexBytes, ...).
+	Limit string `json:"limit" yaml:"limit"`
+	// Max is configured limit value.
+	Max int64 `json:"max" yaml:"max"`
+	// Actual is value reached when limit was exceeded.
+	Actual int64 `json:"actual" yaml:"actual"`
+}
+
+// Error implements error.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %s %d exceeds %d", ErrLimitExceeded, e.Limit, e.Actual, e.Max)
+}
+
+// Unwrap returns ErrLimitExceeded.
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// checkLimit returns LimitError when limit is set and actual exceeds it.
+func checkLimit(name string, limit int64, actual int64) error {
+	if limit <= 0 || actual <= limit {
+		return nil
+	}
+
+	return &LimitError{Limit: name, Max: limit, Actual: actual}
+}
+
+// checkTable checks entry count and index size limits.
+func (l ReaderLimits) checkTable(entries int, indexBytes int64) error {
+	if err := checkLimit(LimitMaxEntries, int64(l.MaxEntries), int64(entries)); err != nil {
+		return err
+	}
+
+	return checkLimit(LimitMaxIndexBytes, l.MaxIndexBytes, indexBytes)
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestReaderLimits_Parse(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "limits.pbo")
+	if err := createTestPBO(path, map[string][]byte{
+		"a.txt": []byte("a"),
+		"b.txt": []byte("b"),
+		"c.txt": []byte("c"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "p"}, {Key: "product", Value: "x"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		limits ReaderLimits
+		limit  string
+	}{
+		{name: "entries", limits: ReaderLimits{MaxEntries: 2}, limit: LimitMaxEntries},
+		{name: "index bytes", limits: ReaderLimits{MaxIndexBytes: 40}, limit: LimitMaxIndexBytes},
+		{name: "header pairs", limits: ReaderLimits{MaxHeaderPairs: 1}, limit: LimitMaxHeaderPairs},
+	}
+
+	for _, tt := range tests {
+		_, err := OpenWithOptions(path, ReaderOptions{Limits: tt.limits, RecoverMode: true})
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || !errors.Is(err, ErrLimitExceeded) || limitErr.Limit != tt.limit {
+			t.Fatalf("%s: Open err=%v, want %s LimitError", tt.name, err, tt.limit)
+		}
+
+		if _, err := ListEntriesWithOptions(path, ReaderOptions{Limits: tt.limits}); !errors.Is(err, ErrLimitExceeded) {
+			t.Fatalf("%s: ListEntries err=%v, want ErrLimitExceeded", tt.name, err)
+		}
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{Limits: ReaderLimits{MaxEntries: 3, MaxHeaderPairs: 2}})
+	if err != nil {
+		t.Fatalf("Open at limits: %v", err)
+	}
+	_ = r.Close()
+}
+
+func TestReaderLimits_MaxTotalDecompressed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "total.pbo")
+	payload := bytes.Repeat([]byte("z"), 4096)
+	if err := createTestPBO(path, map[string][]byte{
+		"a.txt": payload,
+		"b.txt": payload,
+		"c.bin": payload,
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{Limits: ReaderLimits{MaxTotalDecompressed: 6000}})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if _, err := r.ReadEntry("a.txt"); err != nil {
+		t.Fatalf("ReadEntry a.txt: %v", err)
+	}
+	if _, err := r.ReadEntry("c.bin"); err != nil {
+		t.Fatalf("ReadEntry raw c.bin: %v", err)
+	}
+	if _, err := r.ReadEntry("b.txt"); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("ReadEntry b.txt err=%v, want ErrLimitExceeded", err)
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import "log/slog"
+
+// discardLogger drops all records when options carry no logger.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// loggerOrDiscard returns l or discard logger when l is nil.
+func loggerOrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return discardLogger
+	}
+
+	return l
+}
+
+// logger returns configured reader logger or discard logger.
+func (opts ReaderOptions) logger() *slog.Logger {
+	return loggerOrDiscard(opts.Logger)
+}
+
+// logger returns configured pack logger or discard logger.
+func (opts *PackOptions) logger() *slog.Logger {
+	return loggerOrDiscard(opts.Logger)
+}
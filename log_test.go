@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogger_ReaderEvents(t *testing.T) {
+	t.Parallel()
+
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: `a.txt`, data: []byte("alpha"), offset: 1 << 30},
+		{name: `empty.txt`},
+	})
+
+	var logs bytes.Buffer
+	r, err := OpenWithOptions(path, ReaderOptions{
+		OffsetMode:       OffsetModeStoredCompat,
+		EnableJunkFilter: true,
+		Logger:           slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	out := logs.String()
+	for _, want := range []string{
+		"stored offsets rejected",
+		`msg="junk entry dropped" path=empty.txt reason="zero data size"`,
+		"junk entries dropped",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLogger_CompressionSkipReason(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	outPath := filepath.Join(t.TempDir(), "out.pbo")
+	if err := createTestPBO(outPath, map[string][]byte{"a.txt": []byte("xyz")}, PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+		Logger:          slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	if out := logs.String(); !strings.Contains(out, `msg="compression skipped" path=a.txt reason="compressed size not smaller than raw"`) {
+		t.Fatalf("unexpected log:\n%s", out)
+	}
+}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// MergeConflictPolicy controls which entry wins when several sources contain the same path.
+type MergeConflictPolicy string
+
+// Merge conflict resolution policies.
+const (
+	// MergeConflictError fails merge on the first duplicate path.
+	MergeConflictError MergeConflictPolicy = "error"
+	// MergeConflictFirstWins keeps entry from the earliest source in list.
+	MergeConflictFirstWins MergeConflictPolicy = "first_wins"
+	// MergeConflictLastWins keeps entry from the latest source in list.
+	MergeConflictLastWins MergeConflictPolicy = "last_wins"
+)
+
+// MergeOptions configures Merge behavior.
+type MergeOptions struct {
+	// OnConflict is called for every duplicate path resolved by FirstWins/LastWins policy.
+	OnConflict func(path string, keptSource string, droppedSource string) `json:"-" yaml:"-"`
+	// PackOptions configure output archive. Empty Headers inherit headers of the first source.
+	// Compression settings are not used because payloads are copied as stored.
+	PackOptions PackOptions `json:"pack_options,omitzero" yaml:"pack_options,omitzero"`
+	// ReaderOptions configure source archive parsing.
+	ReaderOptions ReaderOptions `json:"reader_options,omitzero" yaml:"reader_options,omitzero"`
+	// Conflict selects duplicate path policy. Default is MergeConflictError.
+	Conflict MergeConflictPolicy `json:"conflict,omitempty" yaml:"conflict,omitempty"`
+}
+
+// mergeCandidate stores one resolved merge entry with its source index.
+type mergeCandidate struct {
+	entry  EntryInfo
+	source int
+}
+
+// Merge combines entries from several source archives into outPath and appends SHA1 trailer.
+// Stored payloads (including compressed ones) are copied as raw bytes without recompression.
+func Merge(ctx context.Context, outPath string, sources []string, opts MergeOptions) (*PackResult, error) {
+	if len(sources) == 0 {
+		return nil, ErrEmptyInputs
+	}
+
+	if opts.Conflict == "" {
+		opts.Conflict = MergeConflictError
+	}
+
+	switch opts.Conflict {
+	case MergeConflictError, MergeConflictFirstWins, MergeConflictLastWins:
+	default:
+		return nil, fmt.Errorf("unknown merge conflict policy %q", opts.Conflict)
+	}
+
+	if err := ensureOutputNotSource(outPath, sources); err != nil {
+		return nil, err
+	}
+
+	readerOpts := opts.ReaderOptions
+	readerOpts.LazyEntries = false
+
+	readers := make([]*Reader, 0, len(sources))
+	defer func() {
+		for _, r := range readers {
+			_ = r.Close()
+		}
+	}()
+
+	for _, sourcePath := range sources {
+		r, err := OpenWithOptions(sourcePath, readerOpts)
+		if err != nil {
+			return nil, fmt.Errorf("open source %s: %w", sourcePath, err)
+		}
+
+		readers = append(readers, r)
+	}
+
+	plan, err := buildMergePlan(readers, sources, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	packOpts := opts.PackOptions
+	if len(packOpts.Headers) == 0 {
+		packOpts.Headers = readers[0].Headers()
+		packOpts.AllowDuplicateHeaders = true
+	}
+
+	f, err := os.OpenFile(outPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create PBO file: %w", err)
+	}
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+
+	res, err := rewriteArchive(ctx, f, nil, plan, packOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Sync(); err != nil {
+		return nil, fmt.Errorf("sync PBO file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close PBO file: %w", err)
+	}
+	f = nil
+
+	if err := writeSHA1Trailer(outPath); err != nil {
+		return nil, fmt.Errorf("write SHA1 trailer: %w", err)
+	}
+
+	return res, nil
+}
+
+// buildMergePlan resolves source entries by conflict policy into deterministic rewrite plan.
+func buildMergePlan(readers []*Reader, sources []string, opts MergeOptions) ([]rewriteEntry, error) {
+	if err := validatePathCaseSensitivity(opts.PackOptions.PathCaseSensitivity); err != nil {
+		return nil, err
+	}
+
+	state := make(map[string]mergeCandidate)
+	for sourceIdx, r := range readers {
+		for _, entry := range r.entries {
+			path, err := NormalizeEntryPath(entry.Path)
+			if err != nil {
+				return nil, fmt.Errorf("%w: source %s entry %q", ErrInvalidEntryPath, sources[sourceIdx], entry.Path)
+			}
+
+			entry.Path = path
+			key := editorPathKey(path, opts.PackOptions.PathCaseSensitivity)
+			existing, exists := state[key]
+			if !exists {
+				state[key] = mergeCandidate{entry: entry, source: sourceIdx}
+				continue
+			}
+
+			if existing.source == sourceIdx {
+				return nil, fmt.Errorf("%w: %q in %s", ErrDuplicateEntryPath, path, sources[sourceIdx])
+			}
+
+			switch opts.Conflict {
+			case MergeConflictFirstWins:
+				if opts.OnConflict != nil {
+					opts.OnConflict(path, sources[existing.source], sources[sourceIdx])
+				}
+			case MergeConflictLastWins:
+				if opts.OnConflict != nil {
+					opts.OnConflict(path, sources[sourceIdx], sources[existing.source])
+				}
+
+				state[key] = mergeCandidate{entry: entry, source: sourceIdx}
+			default:
+				return nil, fmt.Errorf(
+					"%w: %q in %s and %s",
+					ErrDuplicateEntryPath, path, sources[existing.source], sources[sourceIdx],
+				)
+			}
+		}
+	}
+
+	plan := make([]rewriteEntry, 0, len(state))
+	for _, candidate := range state {
+		entry := candidate.entry
+		plan = append(plan, rewriteEntry{
+			path:         entry.Path,
+			source:       &entry,
+			sourceReader: readers[candidate.source],
+		})
+	}
+
+	sort.Slice(plan, func(i, j int) bool { return plan[i].path < plan[j].path })
+
+	return plan, nil
+}
+
+// ensureOutputNotSource rejects writing output over one of the input archives.
+func ensureOutputNotSource(outPath string, sources []string) error {
+	outAbs, err := filepath.Abs(outPath)
+	if err != nil {
+		return fmt.Errorf("resolve output path: %w", err)
+	}
+
+	outInfo, outStatErr := os.Stat(outAbs)
+	for _, sourcePath := range sources {
+		sourceAbs, err := filepath.Abs(sourcePath)
+		if err != nil {
+			return fmt.Errorf("resolve source path: %w", err)
+		}
+
+		if sourceAbs == outAbs {
+			return fmt.Errorf("%w: %s", ErrOutputIsSource, outPath)
+		}
+
+		if outStatErr != nil {
+			continue
+		}
+
+		if sourceInfo, err := os.Stat(sourceAbs); err == nil && os.SameFile(outInfo, sourceInfo) {
+			return fmt.Errorf("%w: %s", ErrOutputIsSource, outPath)
+		}
+	}
+
+	return nil
+}
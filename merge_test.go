@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestMerge_ConflictPolicies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.pbo")
+	second := filepath.Join(dir, "second.pbo")
+	compressed := bytes.Repeat([]byte("compressible "), 512)
+
+	if err := createTestPBO(first, map[string][]byte{
+		"shared.txt":  []byte("from-first"),
+		"scripts/a.c": compressed,
+	}, PackOptions{
+		Headers:         []HeaderPair{{Key: "prefix", Value: "first"}},
+		Compress:        includeRules("*.c"),
+		MinCompressSize: 1,
+	}); err != nil {
+		t.Fatalf("create first: %v", err)
+	}
+	if err := createTestPBO(second, map[string][]byte{
+		"SHARED.txt": []byte("from-second"),
+		"b.txt":      []byte("b"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("create second: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		policy MergeConflictPolicy
+		want   string
+	}{
+		{name: "first wins", policy: MergeConflictFirstWins, want: "from-first"},
+		{name: "last wins", policy: MergeConflictLastWins, want: "from-second"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			outPath := filepath.Join(t.TempDir(), "merged.pbo")
+			conflicts := 0
+			res, err := Merge(context.Background(), outPath, []string{first, second}, MergeOptions{
+				Conflict:   tc.policy,
+				OnConflict: func(string, string, string) { conflicts++ },
+			})
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			if res.WrittenEntries != 3 || conflicts != 1 {
+				t.Fatalf("written=%d conflicts=%d", res.WrittenEntries, conflicts)
+			}
+			if res.CompressedEntries != 1 {
+				t.Fatalf("CompressedEntries=%d, want copied compressed payload", res.CompressedEntries)
+			}
+
+			r, err := Open(outPath)
+			if err != nil {
+				t.Fatalf("Open merged: %v", err)
+			}
+			defer func() { _ = r.Close() }()
+
+			if got := pboPrefixFromHeaders(r.Headers()); got != "first" {
+				t.Fatalf("prefix=%q, want inherited from first source", got)
+			}
+
+			shared := findEntry(r.Entries(), "shared.txt")
+			if shared == nil {
+				t.Fatal("shared entry missing")
+			}
+			data, err := r.ReadEntry(shared.Path)
+			if err != nil {
+				t.Fatalf("ReadEntry shared: %v", err)
+			}
+			if string(data) != tc.want {
+				t.Fatalf("shared=%q, want %q", data, tc.want)
+			}
+
+			data, err = r.ReadEntry("scripts/a.c")
+			if err != nil {
+				t.Fatalf("ReadEntry compressed: %v", err)
+			}
+			if !bytes.Equal(data, compressed) {
+				t.Fatal("compressed payload mismatch after merge")
+			}
+		})
+	}
+}
+
+func TestMerge_ErrorPolicyAndOutputGuard(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.pbo")
+	second := filepath.Join(dir, "second.pbo")
+	for _, path := range []string{first, second} {
+		if err := createTestPBO(path, map[string][]byte{"same.txt": []byte("x")}, PackOptions{}); err != nil {
+			t.Fatalf("create %s: %v", path, err)
+		}
+	}
+
+	_, err := Merge(context.Background(), filepath.Join(dir, "out.pbo"), []string{first, second}, MergeOptions{})
+	if !errors.Is(err, ErrDuplicateEntryPath) {
+		t.Fatalf("expected ErrDuplicateEntryPath, got %v", err)
+	}
+
+	_, err = Merge(context.Background(), first, []string{first, second}, MergeOptions{Conflict: MergeConflictLastWins})
+	if !errors.Is(err, ErrOutputIsSource) {
+		t.Fatalf("expected ErrOutputIsSource, got %v", err)
+	}
+}
@@ -47,7 +47,10 @@ func ReadHeadersFromReaderAtWithOptions(ra io.ReaderAt, size int64, opts ReaderO
 		return nil, err
 	}
 
-	_, headers, _, err := parseHeaderSection(readerAt)
+	headers, err := withArchiveDecryptor(readerAt, size, opts.EntryKey, func(ra io.ReaderAt, _ int64, _ bool) ([]headerPair, error) {
+		_, headers, _, err := parseHeaderSectionLimited(ra, opts.Limits.MaxHeaderPairs)
+		return headers, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -100,31 +103,50 @@ func ListEntriesFromReaderAtWithOptions(ra io.ReaderAt, size int64, opts ReaderO
 		return nil, err
 	}
 
-	_, _, tableOffset, err := parseHeaderSection(readerAt)
+	r, err := withArchiveDecryptor(readerAt, size, opts.EntryKey, func(ra io.ReaderAt, size int64, _ bool) (*Reader, error) {
+		_, _, tableOffset, err := parseHeaderSectionLimited(ra, opts.Limits.MaxHeaderPairs)
+		if err != nil {
+			return nil, err
+		}
+
+		r := &Reader{
+			ra:              ra,
+			entryKey:        opts.EntryKey,
+			maxDecompressed: opts.MaxEntryDecompressedSize,
+			maxTotal:        opts.Limits.MaxTotalDecompressed,
+		}
+		if _, err := r.parseEntryTable(ra, tableOffset, size, opts); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	r := &Reader{}
-	entriesEnd, err := r.parseEntriesBuffered(readerAt, tableOffset, size)
-	if err != nil {
+	if err := decodeEntryNames(r.entries, opts.NameEncoding); err != nil {
 		return nil, err
 	}
-
-	if err := resolveEntryOffsets(r.entries, entriesEnd, size, opts.OffsetMode); err != nil {
+	if err := normalizeEntryUnicode(r.entries, opts.UnicodeForm); err != nil {
 		return nil, err
 	}
-	if opts.EnableJunkFilter {
-		r.entries = filterJunkEntries(r.entries)
+	if policy := opts.junkFilter(); policy != nil {
+		r.entries = filterJunkEntries(r.entries, policy, opts.logger(), nil)
 	}
-	r.entries = filterEntriesBySize(r.entries, opts.MinEntryOriginalSize, opts.MinEntryDataSize)
+	r.entries = filterEntriesBySize(r.entries, opts.MinEntryOriginalSize, opts.MinEntryDataSize, nil)
+	r.entries = filterEntriesByMime(r.entries, opts.MimeTypes, nil)
+	r.entries = filterEntriesByTime(r.entries, opts.ModifiedAfter, opts.ModifiedBefore, nil)
 	if opts.FilterASCIIOnly {
-		r.entries = filterEntriesByASCIIOnly(r.entries)
+		r.entries = filterEntriesByASCIIOnly(r.entries, nil)
 	}
 	if opts.SanitizeNames {
-		r.entries = filterEntriesBySanitizedPrefix(r.entries, opts.EntryPathPrefix)
+		r.entries = filterEntriesBySanitizedPrefix(r.entries, opts.EntryPathPrefix, nil)
 	} else {
-		r.entries = filterEntriesByPrefix(r.entries, opts.EntryPathPrefix)
+		r.entries = filterEntriesByPrefix(r.entries, opts.EntryPathPrefix, nil)
+	}
+	r.entries, err = filterEntriesByPath(r.entries, opts.EntryRules, opts.EntryPattern, nil)
+	if err != nil {
+		return nil, err
 	}
 	if opts.SanitizeControlChars {
 		r.entries, err = sanitizeEntryInfoControlPaths(r.entries)
@@ -139,11 +161,9 @@ func ListEntriesFromReaderAtWithOptions(ra io.ReaderAt, size int64, opts ReaderO
 		if err != nil {
 			return nil, err
 		}
-
-		return entries, nil
 	}
 
-	return entries, nil
+	return r.appendNestedEntries(entries, opts, opts.NestedDepth)
 }
 
 // openFileWithSize opens a file and returns a handle plus current size.
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultMissionMarkerFiles are files that identify a mission folder (DayZ init.c, Arma mission.sqm).
+var defaultMissionMarkerFiles = []string{"init.c", "mission.sqm"}
+
+// MissionCycleOptions configures BuildMissionCycle behavior.
+type MissionCycleOptions struct {
+	// PackOptions are applied to every packed mission.
+	PackOptions PackOptions `json:"pack_options,omitzero" yaml:"pack_options,omitzero"`
+	// MarkerFiles lists root files of which at least one must exist in a mission folder.
+	// Empty means "init.c" or "mission.sqm".
+	MarkerFiles []string `json:"marker_files,omitempty" yaml:"marker_files,omitempty"`
+	// GameType is signature hash game type used when SignVersion is set.
+	GameType GameType `json:"game_type,omitempty" yaml:"game_type,omitempty"`
+	// SignVersion enables hash set calculation for each mission when non-zero.
+	SignVersion SignVersion `json:"sign_version,omitempty" yaml:"sign_version,omitempty"`
+	// ContinueOnError keeps packing remaining missions when one mission fails.
+	ContinueOnError bool `json:"continue_on_error,omitempty" yaml:"continue_on_error,omitempty"`
+}
+
+// MissionResult describes one mission processed by BuildMissionCycle.
+type MissionResult struct {
+	// Err is mission failure reason; nil on success.
+	Err error `json:"-" yaml:"-"`
+	// Pack is pack statistics for successfully written mission.
+	Pack *PackResult `json:"pack,omitempty" yaml:"pack,omitempty"`
+	// HashSet is signature hash set when MissionCycleOptions.SignVersion is set.
+	HashSet *HashSet `json:"hash_set,omitempty" yaml:"hash_set,omitempty"`
+	// Name is mission folder name (for example "dayzOffline.chernarusplus").
+	Name string `json:"name" yaml:"name"`
+	// SourceDir is absolute mission folder path.
+	SourceDir string `json:"source_dir" yaml:"source_dir"`
+	// OutputPath is absolute written archive path.
+	OutputPath string `json:"output_path,omitempty" yaml:"output_path,omitempty"`
+	// Error is textual form of Err for serialized reports.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Trailer is verified SHA1 trailer digest of written archive.
+	Trailer [20]byte `json:"trailer" yaml:"trailer"`
+}
+
+// MissionCycleReport summarizes one BuildMissionCycle run.
+type MissionCycleReport struct {
+	// Missions are per-mission results sorted by name.
+	Missions []MissionResult `json:"missions" yaml:"missions"`
+	// Packed is number of successfully packed and validated missions.
+	Packed int `json:"packed" yaml:"packed"`
+	// Failed is number of failed missions.
+	Failed int `json:"failed" yaml:"failed"`
+	// Duration is end-to-end cycle duration.
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// BuildMissionCycle packs every mission folder found directly under missionsDir into
+// outDir/<mission>.pbo, validates written archives (trailer and entry table), and
+// returns summary report. Folders without marker files are reported as failed.
+// Without ContinueOnError the first failure stops the cycle and is returned with partial report.
+func BuildMissionCycle(
+	ctx context.Context,
+	missionsDir string,
+	outDir string,
+	opts MissionCycleOptions,
+) (*MissionCycleReport, error) {
+	startedAt := time.Now()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if opts.SignVersion != 0 {
+		if err := validateSignHashArgs(opts.SignVersion, opts.GameType); err != nil {
+			return nil, err
+		}
+	}
+
+	markers := opts.MarkerFiles
+	if len(markers) == 0 {
+		markers = defaultMissionMarkerFiles
+	}
+
+	missionsAbs, err := filepath.Abs(missionsDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve missions dir: %w", err)
+	}
+
+	outAbs, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve output dir: %w", err)
+	}
+
+	dirEntries, err := os.ReadDir(missionsAbs)
+	if err != nil {
+		return nil, fmt.Errorf("read missions dir: %w", err)
+	}
+
+	if err := os.MkdirAll(outAbs, 0o750); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	names := make([]string, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			names = append(names, dirEntry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	report := &MissionCycleReport{Missions: make([]MissionResult, 0, len(names))}
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			report.Duration = time.Since(startedAt)
+			return report, err
+		}
+
+		result := buildMission(ctx, filepath.Join(missionsAbs, name), outAbs, markers, opts)
+		report.Missions = append(report.Missions, result)
+		if result.Err == nil {
+			report.Packed++
+			continue
+		}
+
+		report.Failed++
+		if !opts.ContinueOnError {
+			report.Duration = time.Since(startedAt)
+			return report, fmt.Errorf("mission %s: %w", name, result.Err)
+		}
+	}
+
+	report.Duration = time.Since(startedAt)
+	return report, nil
+}
+
+// buildMission packs and validates one mission folder.
+func buildMission(
+	ctx context.Context,
+	missionDir string,
+	outDir string,
+	markers []string,
+	opts MissionCycleOptions,
+) MissionResult {
+	result := MissionResult{
+		Name:      filepath.Base(missionDir),
+		SourceDir: missionDir,
+	}
+
+	fail := func(err error) MissionResult {
+		result.Err = err
+		result.Error = err.Error()
+		return result
+	}
+
+	inputs, err := InputsFromDir(missionDir)
+	if err != nil {
+		return fail(err)
+	}
+
+	hasMarker := false
+	for _, marker := range markers {
+		if hasInputPathFold(inputs, marker) {
+			hasMarker = true
+			break
+		}
+	}
+	if !hasMarker {
+		return fail(fmt.Errorf("%w: none of %s found", ErrInvalidMission, strings.Join(markers, ", ")))
+	}
+
+	outPath := filepath.Join(outDir, result.Name+FileExtension)
+	if opts.SignVersion != 0 {
+		res, hs, err := PackAndHashFile(ctx, outPath, inputs, opts.PackOptions, opts.SignVersion, opts.GameType)
+		if err != nil {
+			return fail(err)
+		}
+
+		result.Pack = res
+		result.HashSet = &hs
+	} else {
+		res, err := PackFile(ctx, outPath, inputs, opts.PackOptions)
+		if err != nil {
+			return fail(err)
+		}
+
+		result.Pack = res
+	}
+	result.OutputPath = outPath
+
+	trailer, err := VerifySHA1Trailer(outPath)
+	if err != nil {
+		return fail(fmt.Errorf("validate trailer: %w", err))
+	}
+	result.Trailer = trailer
+
+	entries, err := ListEntriesWithOptions(outPath, ReaderOptions{SealedKey: opts.PackOptions.SealedKey})
+	if err != nil {
+		return fail(fmt.Errorf("validate entries: %w", err))
+	}
+	if len(entries) != result.Pack.WrittenEntries {
+		return fail(fmt.Errorf("validate entries: %d parsed, %d written", len(entries), result.Pack.WrittenEntries))
+	}
+
+	return result
+}
+
+// WriteSummary writes human-readable one-line-per-mission summary.
+func (r *MissionCycleReport) WriteSummary(w io.Writer) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, m := range r.Missions {
+		var err error
+		if m.Err != nil {
+			_, err = fmt.Fprintf(w, "FAIL %s: %v\n", m.Name, m.Err)
+		} else {
+			_, err = fmt.Fprintf(
+				w,
+				"OK   %s: %d entries, %d bytes, sha1 %s\n",
+				m.Name, m.Pack.WrittenEntries, m.Pack.DataSize+m.Pack.IndexSize, hex.EncodeToString(m.Trailer[:]),
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "packed %d, failed %d in %s\n", r.Packed, r.Failed, r.Duration.Round(time.Millisecond))
+	return err
+}
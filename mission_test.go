@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildMissionCycle(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	missionsDir := filepath.Join(root, "mpmissions")
+	outDir := filepath.Join(root, "out")
+
+	writeMissionFile(t, filepath.Join(missionsDir, "dayzOffline.chernarusplus", "init.c"), "void main() {}")
+	writeMissionFile(t, filepath.Join(missionsDir, "dayzOffline.chernarusplus", "db", "types.xml"), "<types/>")
+	writeMissionFile(t, filepath.Join(missionsDir, "broken.enoch", "readme.txt"), "no marker")
+
+	report, err := BuildMissionCycle(context.Background(), missionsDir, outDir, MissionCycleOptions{
+		ContinueOnError: true,
+		SignVersion:     SignVersionV3,
+		GameType:        GameTypeDayZ,
+	})
+	if err != nil {
+		t.Fatalf("BuildMissionCycle: %v", err)
+	}
+
+	if report.Packed != 1 || report.Failed != 1 || len(report.Missions) != 2 {
+		t.Fatalf("report=%+v", report)
+	}
+
+	broken := report.Missions[0]
+	if broken.Name != "broken.enoch" || !errors.Is(broken.Err, ErrInvalidMission) {
+		t.Fatalf("broken mission=%+v", broken)
+	}
+
+	ok := report.Missions[1]
+	if ok.Err != nil || ok.HashSet == nil || ok.Pack.WrittenEntries != 2 {
+		t.Fatalf("packed mission=%+v", ok)
+	}
+
+	data, err := readEntryFromFile(ok.OutputPath, "db/types.xml")
+	if err != nil {
+		t.Fatalf("read packed entry: %v", err)
+	}
+	if string(data) != "<types/>" {
+		t.Fatalf("data=%q", data)
+	}
+
+	var summary bytes.Buffer
+	if err := report.WriteSummary(&summary); err != nil {
+		t.Fatalf("WriteSummary: %v", err)
+	}
+	if !strings.Contains(summary.String(), "packed 1, failed 1") {
+		t.Fatalf("summary=%q", summary.String())
+	}
+}
+
+func TestBuildMissionCycle_FailFast(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	missionsDir := filepath.Join(root, "mpmissions")
+	writeMissionFile(t, filepath.Join(missionsDir, "a.broken", "readme.txt"), "x")
+	writeMissionFile(t, filepath.Join(missionsDir, "b.valid", "mission.sqm"), "x")
+
+	report, err := BuildMissionCycle(context.Background(), missionsDir, filepath.Join(root, "out"), MissionCycleOptions{})
+	if !errors.Is(err, ErrInvalidMission) {
+		t.Fatalf("expected ErrInvalidMission, got %v", err)
+	}
+	if report == nil || len(report.Missions) != 1 {
+		t.Fatalf("report=%+v", report)
+	}
+}
+
+func writeMissionFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
@@ -5,9 +5,15 @@
 package pbo
 
 import (
+	"crypto"
+	"encoding/binary"
 	"io"
+	"io/fs"
+	"log/slog"
+	"regexp"
 	"time"
 
+	"github.com/woozymasta/lzss"
 	"github.com/woozymasta/pathrules"
 )
 
@@ -57,8 +63,12 @@ type HashSet struct {
 
 // EntryInfo describes a single parsed PBO entry.
 type EntryInfo struct {
-	// Path is the entry path as stored in archive index.
+	// Path is the entry path as stored in archive index, or sanitized path with
+	// ReaderOptions.SanitizeNames and SanitizeControlChars.
 	Path string `json:"path" yaml:"path"`
+	// OriginalPath is path stored in archive index when sanitization rewrote Path; empty otherwise.
+	// Reader lookups (OpenEntry, ReadEntry, Has, Stat) accept either name.
+	OriginalPath string `json:"original_path,omitempty" yaml:"original_path,omitempty"`
 	// Offset is byte offset of entry payload.
 	Offset uint32 `json:"offset" yaml:"offset"`
 	// DataSize is stored payload size in bytes.
@@ -69,6 +79,29 @@ type EntryInfo struct {
 	TimeStamp uint32 `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
 	// MimeType stores entry mime marker.
 	MimeType MimeType `json:"mime_type,omitempty" yaml:"mime_type,omitempty"`
+	// Reserved is raw value of index offset field as stored. Common tools write zero;
+	// some store payload offset or own metadata here.
+	Reserved uint32 `json:"reserved,omitempty" yaml:"reserved,omitempty"`
+	// Checksum is hex digest of decompressed content set by Reader.HashEntries; empty otherwise.
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+}
+
+// RawFields returns 20-byte index field block of entry as stored in archive:
+// mime, original size, reserved, timestamp, and data size (little-endian).
+func (e *EntryInfo) RawFields() [20]byte {
+	var fields [20]byte
+	binary.LittleEndian.PutUint32(fields[0:4], uint32(e.MimeType))
+	binary.LittleEndian.PutUint32(fields[4:8], e.OriginalSize)
+	binary.LittleEndian.PutUint32(fields[8:12], e.Reserved)
+	binary.LittleEndian.PutUint32(fields[12:16], e.TimeStamp)
+	binary.LittleEndian.PutUint32(fields[16:20], e.DataSize)
+
+	return fields
+}
+
+// Time returns entry timestamp as UTC time; zero time when timestamp is 0.
+func (e *EntryInfo) Time() time.Time {
+	return uint32ToTime(e.TimeStamp)
 }
 
 // IsCompressed reports whether this entry is stored with LZSS compression.
@@ -86,6 +119,9 @@ type Input struct {
 	Path string `json:"path" yaml:"path"`
 	// SizeHint is expected size in bytes (zero when unknown).
 	SizeHint int64 `json:"size_hint,omitempty" yaml:"size_hint,omitempty"`
+	// OriginalSize marks pre-compressed input: Open yields payload already in LZSS (Cprs) form
+	// which is stored as-is with this uncompressed size. Zero means plain input.
+	OriginalSize uint32 `json:"original_size,omitempty" yaml:"original_size,omitempty"`
 }
 
 // HeaderPair is a PBO header key-value pair written in provided order.
@@ -113,12 +149,44 @@ type PackEntryProgress struct {
 	CompressionCandidate bool `json:"compression_candidate,omitempty" yaml:"compression_candidate,omitempty"`
 	// Compressed reports whether compressed payload was actually written.
 	Compressed bool `json:"compressed,omitempty" yaml:"compressed,omitempty"`
+	// Digest is hex digest of original entry content when PackOptions.EntryHash is set.
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
+	// ModTime is entry timestamp written to index after ZeroTimestamps and SourceDateEpoch;
+	// zero time when timestamp is 0.
+	ModTime time.Time `json:"mod_time,omitzero" yaml:"mod_time,omitzero"`
+}
+
+// EntryStats is aggregate entry and byte counters reported by progress callbacks.
+// Bytes count original (uncompressed) content size.
+type EntryStats struct {
+	// Entries is number of entries.
+	Entries int `json:"entries" yaml:"entries"`
+	// Bytes is total original content bytes.
+	Bytes int64 `json:"bytes" yaml:"bytes"`
 }
 
 // PackOptions configures pack behavior.
 type PackOptions struct {
 	// OnEntryDone is called after one entry is fully written to archive payload.
 	OnEntryDone func(entry PackEntryProgress) `json:"-" yaml:"-"`
+	// OnProgress is called after each written entry with aggregate done and planned totals.
+	// Total bytes of inputs without SizeHint are unknown and counted as zero.
+	OnProgress func(done EntryStats, total EntryStats) `json:"-" yaml:"-"`
+	// Compressor compresses selected entries. Nil means LZSSCompressor with CompressOptions.
+	Compressor Compressor `json:"-" yaml:"-"`
+	// Logger receives debug events such as compression skips with reasons. Nil disables logging.
+	Logger *slog.Logger `json:"-" yaml:"-"`
+	// OrderLess sorts inputs when Order is PackOrderCustom; sort is stable.
+	// Input paths are already normalized to "\" separators.
+	OrderLess func(a Input, b Input) bool `json:"-" yaml:"-"`
+	// EntryFields rewrites encoded 20-byte index field block (see EntryInfo.RawFields) of
+	// each written entry, for example to restore third-party reserved or timestamp values.
+	// Data size field must stay unchanged. Not applied by Editor.CommitAppend.
+	EntryFields func(path string, fields [20]byte) [20]byte `json:"-" yaml:"-"`
+	// CompressOptions tune default LZSS compressor (ignored with custom Compressor).
+	// Lower SearchLimit packs faster with worse ratio. Non-default Checksum or MinMatchLength
+	// produce payloads that game and default readers cannot decode.
+	CompressOptions *lzss.CompressOptions `json:"compress_options,omitempty" yaml:"compress_options,omitempty"`
 	// Headers are written in deterministic order.
 	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
 	// SealedKey enables sealed archive transform when set.
@@ -128,14 +196,88 @@ type PackOptions struct {
 	Compress []pathrules.Rule `json:"compress,omitempty" yaml:"compress,omitempty"`
 	// CompressMatcherOptions control compression path rule matching.
 	CompressMatcherOptions pathrules.MatcherOptions `json:"compress_matcher_options,omitzero" yaml:"compress_matcher_options,omitzero"`
+	// EntryHash enables per-entry digest of original content (for example crypto.SHA256).
+	// Zero disables hashing. SHA1, SHA256, and XXH64 are always available.
+	// Pre-compressed inputs (Input.OriginalSize) are hashed as stored.
+	EntryHash crypto.Hash `json:"entry_hash,omitempty" yaml:"entry_hash,omitempty"`
+	// Order selects entry order of written archive. Default is PackOrderPath.
+	Order PackOrder `json:"order,omitempty" yaml:"order,omitempty"`
+	// PathCaseSensitivity controls duplicate path detection in pack, edit, and merge.
+	// Default is PathCaseInsensitive; EditOptions and MergeOptions use their PackOptions value.
+	PathCaseSensitivity PathCaseSensitivity `json:"path_case_sensitivity,omitempty" yaml:"path_case_sensitivity,omitempty"`
+	// StreamMode selects entry table strategy for PackToWriter. Default is auto.
+	StreamMode PackStreamMode `json:"stream_mode,omitempty" yaml:"stream_mode,omitempty"`
+	// SourceDateEpoch replaces every written entry timestamp when non-zero (reproducible builds).
+	SourceDateEpoch time.Time `json:"source_date_epoch,omitzero" yaml:"source_date_epoch,omitzero"`
 	// WriterBufferSize is buffered writer size in bytes.
 	WriterBufferSize int `json:"writer_buffer_size,omitempty" yaml:"writer_buffer_size,omitempty"`
+	// BytesPerSecond caps payload write throughput of this pack job. Zero means unlimited.
+	BytesPerSecond int64 `json:"bytes_per_second,omitempty" yaml:"bytes_per_second,omitempty"`
+	// PerEntryTimeout fails pack with ErrEntryTimeout when Input.Open or input stream makes
+	// no progress for this long; every read chunk re-arms it. Stalled stream is closed while
+	// its Read is still pending, so Close must be safe to call concurrently. Zero disables.
+	PerEntryTimeout time.Duration `json:"per_entry_timeout,omitempty" yaml:"per_entry_timeout,omitempty"`
+	// OpenRetry retries transient Input.Open failures with backoff. Nil opens each input once.
+	OpenRetry *OpenRetry `json:"open_retry,omitempty" yaml:"open_retry,omitempty"`
+	// CompressWorkers is number of goroutines compressing known-size candidates ahead of
+	// writer; output stays byte-identical to sequential pack. Zero or one compresses in
+	// writer goroutine. With more workers, Compressor and Input.Open must be safe for
+	// concurrent use; up to 2*CompressWorkers entries are held in memory.
+	CompressWorkers int `json:"compress_workers,omitempty" yaml:"compress_workers,omitempty"`
+	// PayloadAlignment pads payload section start (and each entry with AlignEntries) to
+	// multiple of this many bytes, for example 4096 for memory-mapped consumers. Start
+	// padding is filler header pair, so sequential offsets stay valid for every reader.
+	// Values 0 and 1 disable padding.
+	PayloadAlignment int `json:"payload_alignment,omitempty" yaml:"payload_alignment,omitempty"`
 	// MinCompressSize disables compression for entries smaller than this size.
 	// Default is 512 bytes.
 	MinCompressSize uint32 `json:"min_compress_size,omitempty" yaml:"min_compress_size,omitempty"`
 	// MaxCompressSize disables compression for entries larger than this size.
 	// Default is 16 MiB and also bounds known-size in-memory compression path.
 	MaxCompressSize uint32 `json:"max_compress_size,omitempty" yaml:"max_compress_size,omitempty"`
+	// SpoolCompressSize enables spooled streaming compression for known-size candidates
+	// above MaxCompressSize up to this size. Zero disables. Requires StreamCompressor.
+	SpoolCompressSize uint32 `json:"spool_compress_size,omitempty" yaml:"spool_compress_size,omitempty"`
+	// SpoolDir is directory for spooled compression temp files. Empty means os.TempDir.
+	SpoolDir string `json:"spool_dir,omitempty" yaml:"spool_dir,omitempty"`
+	// AllowDuplicateHeaders writes repeated header keys in given order.
+	// By default keys must be unique (case-insensitive) and duplicates fail with ErrDuplicateHeaderKey.
+	AllowDuplicateHeaders bool `json:"allow_duplicate_headers,omitempty" yaml:"allow_duplicate_headers,omitempty"`
+	// ZeroTimestamps writes zero timestamp for every entry and takes precedence over SourceDateEpoch.
+	ZeroTimestamps bool `json:"zero_timestamps,omitempty" yaml:"zero_timestamps,omitempty"`
+	// RequireModTime fails pack with ErrMissingModTime when Input.ModTime is zero instead of
+	// writing zero timestamp. Ignored when ZeroTimestamps or SourceDateEpoch set timestamps.
+	RequireModTime bool `json:"require_mod_time,omitempty" yaml:"require_mod_time,omitempty"`
+	// VerifyAfterWrite re-reads every written payload after index patch and compares CRC32
+	// of decoded content with source stream; mismatch fails with ErrVerifyMismatch.
+	// Output must implement io.ReaderAt.
+	VerifyAfterWrite bool `json:"verify_after_write,omitempty" yaml:"verify_after_write,omitempty"`
+	// AlignEntries applies PayloadAlignment to every entry payload, not only the first.
+	// Padding between payloads implies WriteStoredOffsets: such archives must be read with
+	// OffsetModeStoredCompat or OffsetModeStoredStrict; default readers and the game misread them.
+	AlignEntries bool `json:"align_entries,omitempty" yaml:"align_entries,omitempty"`
+	// WriteStoredOffsets writes payload offsets into index offset field instead of zero,
+	// for consumers that rely on them (see OffsetModeStoredCompat). Offsets are absolute
+	// unless RelativeStoredOffsets is set.
+	WriteStoredOffsets bool `json:"write_stored_offsets,omitempty" yaml:"write_stored_offsets,omitempty"`
+	// RelativeStoredOffsets makes stored offsets relative to payload section start.
+	RelativeStoredOffsets bool `json:"relative_stored_offsets,omitempty" yaml:"relative_stored_offsets,omitempty"`
+	// DedupEqualPayloads hashes inputs before write and stores identical payloads once;
+	// duplicate index records point at shared payload through stored offsets (implies WriteStoredOffsets).
+	// Such archives must be read with OffsetModeStoredCompat or OffsetModeStoredStrict.
+	DedupEqualPayloads bool `json:"dedup_equal_payloads,omitempty" yaml:"dedup_equal_payloads,omitempty"`
+	// IgnorePrefixFile makes PackDir pack $PBOPREFIX$-style files as regular entries
+	// instead of reading headers from them.
+	IgnorePrefixFile bool `json:"ignore_prefix_file,omitempty" yaml:"ignore_prefix_file,omitempty"`
+	// UnicodeForm normalizes input paths to NFC or NFD before duplicate checks and ordering,
+	// so names collected on macOS (decomposed) match ones authored elsewhere.
+	UnicodeForm UnicodeForm `json:"unicode_form,omitempty" yaml:"unicode_form,omitempty"`
+	// NameEncoding writes entry names in legacy code page (windows-1251, windows-1252)
+	// for tools that expect them; read such archives back with same ReaderOptions.NameEncoding.
+	NameEncoding NameEncoding `json:"name_encoding,omitempty" yaml:"name_encoding,omitempty"`
+
+	// packer carries state prepared by NewPacker; nil for one-shot pack calls.
+	packer *Packer
 }
 
 // PackResult contains pack output statistics.
@@ -154,17 +296,41 @@ type PackResult struct {
 	CompressedEntries int `json:"compressed_entries,omitempty" yaml:"compressed_entries,omitempty"`
 	// SkippedCompressionEntries is number of compression candidates stored as raw payload.
 	SkippedCompressionEntries int `json:"skipped_compression_entries,omitempty" yaml:"skipped_compression_entries,omitempty"`
+	// DedupEntries is number of entries sharing payload of earlier identical entry.
+	DedupEntries int `json:"dedup_entries,omitempty" yaml:"dedup_entries,omitempty"`
+	// DedupBytes is stored payload bytes saved by DedupEntries.
+	DedupBytes int64 `json:"dedup_bytes,omitempty" yaml:"dedup_bytes,omitempty"`
+	// EntryDigests are per-entry digests in write order when PackOptions.EntryHash is set.
+	EntryDigests []EntryDigest `json:"entry_digests,omitempty" yaml:"entry_digests,omitempty"`
+	// Extensions is per-extension compression breakdown sorted by extension.
+	Extensions []ExtensionStats `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 	// Duration is end-to-end pack core duration.
 	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
 }
 
 // EditOptions configures file-based archive edit flow.
 type EditOptions struct {
+	// Logger is used by PackOptions and ReaderOptions that have no own Logger. Nil disables logging.
+	Logger *slog.Logger `json:"-" yaml:"-"`
 	// PackOptions are applied for added/replaced entries during commit.
 	PackOptions PackOptions `json:"pack_options,omitzero" yaml:"pack_options,omitzero"`
+	// ReaderOptions configure source archive parsing (for example OffsetMode for gapped archives).
+	// Nil SealedKey, empty NameEncoding and UnicodeForm fall back to PackOptions values.
+	// Entry filters drop filtered entries on commit.
+	ReaderOptions ReaderOptions `json:"reader_options,omitzero" yaml:"reader_options,omitzero"`
+	// BackupDir is directory for commit backups; empty means archive directory.
+	BackupDir string `json:"backup_dir,omitempty" yaml:"backup_dir,omitempty"`
+	// BackupNameTemplate is backup file name with placeholders {name} (archive file name),
+	// {stem} (name without extension), {timestamp} (UTC 20060102T150405Z), and {unix}.
+	// Empty means "{name}.bak".
+	BackupNameTemplate string `json:"backup_name_template,omitempty" yaml:"backup_name_template,omitempty"`
 	// BackupKeep controls how many backup generations are kept after successful commit.
 	// 0 means remove backup, 1 keeps only `<archive>.bak`, N keeps `.bak` + `.bak.1..N-1`.
+	// Templates with time placeholders keep N newest backups instead of numbered rotation.
 	BackupKeep int `json:"backup_keep,omitempty" yaml:"backup_keep,omitempty"`
+	// BackupCopy copies archive to backup instead of renaming it, for backup
+	// directories on another filesystem. Archive is rewritten in place.
+	BackupCopy bool `json:"backup_copy,omitempty" yaml:"backup_copy,omitempty"`
 }
 
 // OffsetMode controls how reader resolves payload offsets from index table.
@@ -185,41 +351,153 @@ type ReaderOptions struct {
 	// SealedKey enables sealed archive decode when set.
 	// Nil keeps standard plain PBO read behavior.
 	SealedKey *SealedKey `json:"sealed_key,omitempty" yaml:"sealed_key,omitempty"`
+	// EntryKey is passed to codecs registered by RegisterEntryCodec (for example MimeEncoded decryptor).
+	EntryKey []byte `json:"-" yaml:"-"`
+	// Logger receives parse anomalies, offset fallback decisions, and dropped junk entries.
+	// Nil disables logging.
+	Logger *slog.Logger `json:"-" yaml:"-"`
 	// OffsetMode controls whether stored index offsets are used.
 	OffsetMode OffsetMode `json:"offset_mode,omitempty" yaml:"offset_mode,omitempty"`
+	// Limits bound entry table, header, and decompression resources for untrusted archives.
+	Limits ReaderLimits `json:"limits,omitzero" yaml:"limits,omitzero"`
 	// EntryPathPrefix keeps entries whose normalized path is equal to prefix or starts with "prefix/".
 	EntryPathPrefix string `json:"entry_path_prefix,omitempty" yaml:"entry_path_prefix,omitempty"`
+	// EntryRules keeps entries selected by ordered rules like ExtractMatching,
+	// matched against normalized slash path.
+	EntryRules []pathrules.Rule `json:"entry_rules,omitempty" yaml:"entry_rules,omitempty"`
+	// EntryPattern keeps entries whose normalized slash path matches regexp.
+	EntryPattern *regexp.Regexp `json:"-" yaml:"-"`
+	// NestedDepth makes ListEntries* functions recurse into nested ".pbo" entries up to this depth.
+	// Nested entry paths are prefixed with container entry path and offsets are absolute for
+	// plain containers; entries of compressed containers report container offset. Compressed
+	// containers are decoded under Limits and MaxEntryDecompressedSize. Zero disables; Reader ignores it.
+	NestedDepth int `json:"nested_depth,omitempty" yaml:"nested_depth,omitempty"`
+	// MaxDecompressStreams bounds background goroutines decoding compressed entry streams
+	// (zero means GOMAXPROCS). Streams opened past the bound are decoded incrementally by Read.
+	MaxDecompressStreams int `json:"max_decompress_streams,omitempty" yaml:"max_decompress_streams,omitempty"`
 	// MinEntryOriginalSize keeps entries with original size >= this value.
 	// For uncompressed entries OriginalSize is treated as DataSize.
 	MinEntryOriginalSize uint32 `json:"min_entry_original_size,omitempty" yaml:"min_entry_original_size,omitempty"`
 	// MinEntryDataSize keeps entries with packed payload size >= this value.
 	MinEntryDataSize uint32 `json:"min_entry_data_size,omitempty" yaml:"min_entry_data_size,omitempty"`
+	// MimeTypes keeps entries whose mime marker is listed (MimeNil, MimeCompress, MimeEncoded).
+	// Empty keeps all.
+	MimeTypes []MimeType `json:"mime_types,omitempty" yaml:"mime_types,omitempty"`
+	// ModifiedAfter keeps entries with timestamp after this time; zero disables.
+	// Entries without timestamp pass time filters.
+	ModifiedAfter time.Time `json:"modified_after,omitzero" yaml:"modified_after,omitzero"`
+	// ModifiedBefore keeps entries with timestamp before this time; zero disables.
+	ModifiedBefore time.Time `json:"modified_before,omitzero" yaml:"modified_before,omitzero"`
+	// MaxEntryDecompressedSize fails opening compressed or codec-decoded entries whose content
+	// exceeds this size with EntrySizeError. Zero means unlimited; raw entries are not limited.
+	MaxEntryDecompressedSize uint32 `json:"max_entry_decompressed_size,omitempty" yaml:"max_entry_decompressed_size,omitempty"`
 	// EnableJunkFilter drops malformed/mangled entries from visible entry list.
 	EnableJunkFilter bool `json:"enable_junk_filter,omitempty" yaml:"enable_junk_filter,omitempty"`
+	// JunkFilter enables junk filter with custom predicate on top of built-in checks; dropped
+	// entries are listed by Reader.JunkEntries. Nil with EnableJunkFilter uses built-in checks.
+	JunkFilter *JunkFilter `json:"junk_filter,omitempty" yaml:"junk_filter,omitempty"`
+	// UnicodeForm normalizes entry paths to NFC or NFD before other filters; stored names
+	// of changed entries are kept in EntryInfo.OriginalPath.
+	UnicodeForm UnicodeForm `json:"unicode_form,omitempty" yaml:"unicode_form,omitempty"`
+	// NameEncoding transcodes legacy Windows-1251/1252 entry names to UTF-8 before
+	// UnicodeForm; auto decodes only names that are not valid UTF-8.
+	NameEncoding NameEncoding `json:"name_encoding,omitempty" yaml:"name_encoding,omitempty"`
 	// FilterASCIIOnly keeps only entries with ASCII-only path bytes.
 	FilterASCIIOnly bool `json:"filter_ascii_only,omitempty" yaml:"filter_ascii_only,omitempty"`
 	// SanitizeControlChars rewrites control/format runes in entry paths for safe textual output.
 	SanitizeControlChars bool `json:"sanitize_control_chars,omitempty" yaml:"sanitize_control_chars,omitempty"`
 	// SanitizeNames rewrites entry paths to filesystem-safe names for listing workflows.
 	SanitizeNames bool `json:"sanitize_names,omitempty" yaml:"sanitize_names,omitempty"`
+	// RecoverMode salvages plausible entries from truncated or garbage-interleaved tables
+	// instead of failing. Recovered entries use sequential offsets; see Reader.RecoveryReport.
+	// Scan is bounded by Limits.MaxIndexBytes and stops at long implausible runs.
+	RecoverMode bool `json:"recover_mode,omitempty" yaml:"recover_mode,omitempty"`
+	// BuildIndex builds path lookup index at parse time instead of on first lookup,
+	// moving index cost out of first ReadEntry/OpenEntry call.
+	BuildIndex bool `json:"build_index,omitempty" yaml:"build_index,omitempty"`
+	// LazyEntries defers entry table parse to first entry access (Entries, ReadEntry, Extract,
+	// LoadEntries), so header and trailer checks skip index cost. Entry table errors surface
+	// from LoadEntries and entry-reading calls instead of Open.
+	LazyEntries bool `json:"lazy_entries,omitempty" yaml:"lazy_entries,omitempty"`
+	// InternPaths slices entry paths from one shared string instead of allocating one
+	// string per entry, cutting parse allocations on indexes with tens of thousands of entries.
+	// Any retained path keeps whole name block alive.
+	InternPaths bool `json:"intern_paths,omitempty" yaml:"intern_paths,omitempty"`
 }
 
 // ExtractOptions configures Extract behavior.
 type ExtractOptions struct {
 	// OnEntryDone is called after one entry is fully written to disk.
 	OnEntryDone func(entry EntryInfo, written int64, outputPath string) `json:"-" yaml:"-"`
+	// OnEntrySkipped is called for entries left untouched by ExtractFileModeSkipUnchanged.
+	OnEntrySkipped func(entry EntryInfo, outputPath string) `json:"-" yaml:"-"`
+	// OnSizeMismatch is called when decoded size of compressed or encoded entry differs from
+	// OriginalSize and StrictSizes is false.
+	OnSizeMismatch func(entry EntryInfo, written int64, outputPath string) `json:"-" yaml:"-"`
+	// OnEntryFailed is called for every failed entry under ExtractErrorCollectAll and
+	// ExtractErrorSkipAndReport. Calls are serialized across workers.
+	OnEntryFailed func(entry EntryInfo, err error) `json:"-" yaml:"-"`
+	// OnProgress is called after each extracted or skipped entry with aggregate done and selected totals.
+	// Calls are serialized across workers.
+	OnProgress func(done EntryStats, total EntryStats) `json:"-" yaml:"-"`
+	// Chown returns owner and group for written file or directory under destination;
+	// -1 keeps current value as in os.Chown. Nil leaves ownership to the process user.
+	// Changing ownership is not supported on Windows.
+	Chown func(outputPath string, isDir bool) (uid int, gid int) `json:"-" yaml:"-"`
 	// FileMode controls output file creation policy.
 	FileMode ExtractFileMode `json:"file_mode,omitempty" yaml:"file_mode,omitempty"`
+	// CaseCollision controls entries whose output paths differ only by letter case.
+	// Default is ExtractCollisionSuffix. Sanitized names (RawNames false) never collide.
+	CaseCollision ExtractCollisionPolicy `json:"case_collision,omitempty" yaml:"case_collision,omitempty"`
+	// ErrorPolicy controls how entry failures affect remaining entries and returned error.
+	// Default is ExtractErrorFailFast, or ExtractErrorCollectAll when ContinueOnError is set.
+	ErrorPolicy ExtractErrorPolicy `json:"error_policy,omitempty" yaml:"error_policy,omitempty"`
 	// Entries limits extraction to selected metadata list; nil means all parsed entries.
 	Entries []EntryInfo `json:"-" yaml:"-"`
 	// MaxWorkers is number of extraction workers (zero means GOMAXPROCS).
 	MaxWorkers int `json:"max_workers,omitempty" yaml:"max_workers,omitempty"`
+	// Readahead is stored payload bytes read ahead of workers in entry order, overlapping
+	// cold disk reads with decompression. Larger entries are read on demand. Zero disables.
+	Readahead int `json:"readahead,omitempty" yaml:"readahead,omitempty"`
+	// BytesPerSecond caps total payload throughput across all workers. Zero means unlimited.
+	BytesPerSecond int64 `json:"bytes_per_second,omitempty" yaml:"bytes_per_second,omitempty"`
+	// PerEntryTimeout fails entry with ErrEntryTimeout when opening or reading its content
+	// makes no progress for this long; every read chunk re-arms it. Zero disables.
+	PerEntryTimeout time.Duration `json:"per_entry_timeout,omitempty" yaml:"per_entry_timeout,omitempty"`
+	// FilePerm is exact permission set on written files regardless of umask.
+	// Zero creates files with 0o600 filtered by umask.
+	FilePerm fs.FileMode `json:"file_perm,omitempty" yaml:"file_perm,omitempty"`
+	// DirPerm is exact permission set on destination directory and directories under it
+	// regardless of umask. Zero creates directories with 0o750 filtered by umask.
+	DirPerm fs.FileMode `json:"dir_perm,omitempty" yaml:"dir_perm,omitempty"`
 	// ContinueOnError keeps extraction running when one or more entries fail.
-	// Default false is fail-fast mode.
+	// Default false is fail-fast mode. Shorthand for ErrorPolicy ExtractErrorCollectAll.
 	ContinueOnError bool `json:"continue_on_error,omitempty" yaml:"continue_on_error,omitempty"`
 	// RawNames disables default path sanitization during extract.
 	// When false (default), extract rewrites names to filesystem-safe output paths.
 	RawNames bool `json:"raw_names,omitempty" yaml:"raw_names,omitempty"`
+	// Atomic extracts into temporary sibling directory and renames it over destination only
+	// after all entries succeed. Existing destination directory is replaced as a whole and
+	// OnEntryDone reports paths inside staging directory. Under ExtractErrorSkipAndReport any
+	// failed entry keeps destination and returns *ExtractError. ExtractFileModeSkipUnchanged
+	// is rejected with ErrAtomicSkipUnchanged since staging starts empty.
+	Atomic bool `json:"atomic,omitempty" yaml:"atomic,omitempty"`
+	// DryRun resolves output paths, collisions, and FileMode decisions without writing.
+	// OnEntryDone receives final output paths and expected sizes; OnProgress totals estimate disk usage.
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	// CheckDiskSpace compares total expected entry size with free space of destination
+	// filesystem before writing and fails with DiskSpaceError when it does not fit.
+	CheckDiskSpace bool `json:"check_disk_space,omitempty" yaml:"check_disk_space,omitempty"`
+	// StrictSizes fails entry with ErrEntrySizeMismatch when decoded size of compressed or
+	// encoded entry differs from OriginalSize instead of keeping short or long output file.
+	StrictSizes bool `json:"strict_sizes,omitempty" yaml:"strict_sizes,omitempty"`
+	// LongPaths writes through Windows extended-length paths (\\?\ prefix), lifting MAX_PATH
+	// limit and keeping raw names with trailing dots or spaces verbatim. Output paths passed
+	// to callbacks carry the prefix. No-op on other platforms.
+	LongPaths bool `json:"long_paths,omitempty" yaml:"long_paths,omitempty"`
+	// WindowsAttributes clears read-only and hidden attributes of existing output files
+	// before overwrite and marks dot-prefixed output names hidden. No-op on other platforms.
+	WindowsAttributes bool `json:"windows_attributes,omitempty" yaml:"windows_attributes,omitempty"`
 }
 
 // ExtractFileMode controls output file open behavior during extraction.
@@ -235,6 +513,61 @@ const (
 	ExtractFileModeTruncate ExtractFileMode = "truncate"
 	// ExtractFileModeCreateOnly creates files only when absent and fails on existing files.
 	ExtractFileModeCreateOnly ExtractFileMode = "create_only"
+	// ExtractFileModeSkipUnchanged leaves existing files with identical content untouched and
+	// truncates others. Written files get entry timestamp as mtime so repeated runs compare by size+mtime.
+	ExtractFileModeSkipUnchanged ExtractFileMode = "skip_unchanged"
+)
+
+// PackOrder controls entry order in packed archive.
+type PackOrder string
+
+// Entry ordering policies for packing.
+const (
+	// PackOrderPath sorts entries by normalized path for deterministic output.
+	PackOrderPath PackOrder = "path"
+	// PackOrderPreserveInput keeps entries in inputs slice order (original authoring order).
+	PackOrderPreserveInput PackOrder = "preserve_input"
+	// PackOrderCustom sorts entries with PackOptions.OrderLess.
+	PackOrderCustom PackOrder = "custom"
+)
+
+// PathCaseSensitivity controls whether entry paths differing only by letter case are distinct.
+type PathCaseSensitivity string
+
+// Entry path case sensitivity policies for pack, edit, and merge duplicate detection.
+const (
+	// PathCaseInsensitive treats paths differing only by case as duplicates (default, matches game VFS).
+	PathCaseInsensitive PathCaseSensitivity = "insensitive"
+	// PathCaseSensitive keeps paths differing only by case as separate entries, as in some
+	// historical archives. Such archives extract with ExtractOptions.CaseCollision handling.
+	PathCaseSensitive PathCaseSensitivity = "sensitive"
+)
+
+// ExtractCollisionPolicy controls output paths that collide on case-insensitive filesystems.
+type ExtractCollisionPolicy string
+
+// Case-only output path collision policies for extraction.
+const (
+	// ExtractCollisionSuffix writes later colliding entries with "~N" name suffix.
+	ExtractCollisionSuffix ExtractCollisionPolicy = "suffix"
+	// ExtractCollisionError fails extraction before writing anything.
+	ExtractCollisionError ExtractCollisionPolicy = "error"
+	// ExtractCollisionMerge writes only the last colliding entry to shared output path.
+	ExtractCollisionMerge ExtractCollisionPolicy = "merge"
+)
+
+// ExtractErrorPolicy controls extraction behavior when individual entries fail.
+type ExtractErrorPolicy string
+
+// Entry failure policies for extraction.
+const (
+	// ExtractErrorFailFast cancels remaining entries and returns the first error.
+	ExtractErrorFailFast ExtractErrorPolicy = "fail_fast"
+	// ExtractErrorCollectAll extracts remaining entries and returns ExtractError with every failure.
+	ExtractErrorCollectAll ExtractErrorPolicy = "collect_all"
+	// ExtractErrorSkipAndReport extracts remaining entries, reports failures only through
+	// OnEntryFailed, and returns nil unless context is canceled.
+	ExtractErrorSkipAndReport ExtractErrorPolicy = "skip_and_report"
 )
 
 // applyDefaults fills zero-valued pack options with defaults.
@@ -261,6 +594,14 @@ func (opts *PackOptions) applyDefaults() {
 	if opts.CompressMatcherOptions.DefaultAction == pathrules.ActionUnknown {
 		opts.CompressMatcherOptions.DefaultAction = pathrules.ActionExclude
 	}
+
+	if opts.StreamMode == "" {
+		opts.StreamMode = PackStreamModeAuto
+	}
+
+	if opts.Compressor == nil {
+		opts.Compressor = LZSSCompressor{Options: opts.CompressOptions}
+	}
 }
 
 // applyDefaults fills zero-valued reader options with defaults.
@@ -273,6 +614,12 @@ func (opts *ReaderOptions) applyDefaults() {
 // applyDefaults fills zero-valued edit options with defaults.
 func (opts *EditOptions) applyDefaults() {
 	opts.PackOptions.applyDefaults()
+	if opts.PackOptions.Logger == nil {
+		opts.PackOptions.Logger = opts.Logger
+	}
+	if opts.ReaderOptions.Logger == nil {
+		opts.ReaderOptions.Logger = opts.Logger
+	}
 
 	if opts.BackupKeep < 0 {
 		opts.BackupKeep = 0
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NameEncoding selects legacy single-byte encoding of entry names stored in archive.
+type NameEncoding string
+
+// Name encodings for ReaderOptions.NameEncoding and PackOptions.NameEncoding.
+const (
+	// NameEncodingNone keeps entry names as stored bytes (default).
+	NameEncodingNone NameEncoding = ""
+	// NameEncodingAuto transcodes names that are not valid UTF-8, guessing Windows-1251
+	// or Windows-1252 from their letters. Reader only.
+	NameEncodingAuto NameEncoding = "auto"
+	// NameEncodingWindows1251 is Cyrillic code page used by older Russian-language tools.
+	NameEncodingWindows1251 NameEncoding = "windows-1251"
+	// NameEncodingWindows1252 is Western European code page used by older Windows tools.
+	NameEncodingWindows1252 NameEncoding = "windows-1252"
+)
+
+// charmap returns code page of explicit encoding; nil for none and auto.
+func (e NameEncoding) charmap() (*charmap.Charmap, error) {
+	switch e {
+	case NameEncodingNone, NameEncodingAuto:
+		return nil, nil
+	case NameEncodingWindows1251:
+		return charmap.Windows1251, nil
+	case NameEncodingWindows1252:
+		return charmap.Windows1252, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNameEncoding, e)
+	}
+}
+
+// DecodeEntryName transcodes stored entry name from enc to UTF-8. NameEncodingAuto
+// keeps valid UTF-8 names and decodes others with DetectNameEncoding result.
+func DecodeEntryName(name string, enc NameEncoding) (string, error) {
+	if enc == NameEncodingAuto {
+		if utf8.ValidString(name) {
+			return name, nil
+		}
+
+		enc = DetectNameEncoding([]string{name})
+	}
+
+	cm, err := enc.charmap()
+	if err != nil || cm == nil {
+		return name, err
+	}
+
+	return cm.NewDecoder().String(name)
+}
+
+// EncodeEntryName transcodes UTF-8 entry name to enc for writing. Name is composed
+// to NFC first; runes missing from code page fail with ErrInvalidEntryPath.
+// NameEncodingAuto fails with ErrInvalidNameEncoding since pack needs explicit code page.
+func EncodeEntryName(name string, enc NameEncoding) (string, error) {
+	if enc == NameEncodingAuto {
+		return "", fmt.Errorf("%w: %q needs explicit code page for pack", ErrInvalidNameEncoding, enc)
+	}
+
+	cm, err := enc.charmap()
+	if err != nil || cm == nil {
+		return name, err
+	}
+
+	encoded, err := cm.NewEncoder().String(norm.NFC.String(name))
+	if err != nil {
+		return "", fmt.Errorf("%w: %q not representable in %s", ErrInvalidEntryPath, name, enc)
+	}
+
+	return encoded, nil
+}
+
+// DetectNameEncoding guesses legacy code page of names that are not valid UTF-8.
+// Words written mostly with high-byte letters vote for Windows-1251 (Cyrillic),
+// words of ASCII letters with occasional accents vote for Windows-1252; ties pick
+// Windows-1251. Returns NameEncodingNone when every name is valid UTF-8.
+func DetectNameEncoding(names []string) NameEncoding {
+	var cyrillic, latin int
+	legacy := false
+	for _, name := range names {
+		if utf8.ValidString(name) {
+			continue
+		}
+
+		legacy = true
+		var high, ascii int
+		for i := 0; i <= len(name); i++ {
+			if i < len(name) {
+				c := name[i]
+				switch {
+				case c >= 0xC0 || c == 0xA8 || c == 0xB8:
+					high++
+					continue
+				case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+					ascii++
+					continue
+				}
+			}
+
+			// Word boundary: vote only for words that contain legacy letters.
+			if high > 0 {
+				if high >= ascii {
+					cyrillic++
+				} else {
+					latin++
+				}
+			}
+			high, ascii = 0, 0
+		}
+	}
+
+	switch {
+	case !legacy:
+		return NameEncodingNone
+	case latin > cyrillic:
+		return NameEncodingWindows1252
+	default:
+		return NameEncodingWindows1251
+	}
+}
+
+// decodeEntryNames transcodes entry paths in place to UTF-8, keeping stored names of
+// changed entries in OriginalPath. Auto detection votes over whole entry table.
+func decodeEntryNames(entries []EntryInfo, enc NameEncoding) error {
+	if enc == NameEncodingNone {
+		return nil
+	}
+
+	if enc == NameEncodingAuto {
+		names := make([]string, len(entries))
+		for i := range entries {
+			names[i] = entries[i].Path
+		}
+
+		enc = DetectNameEncoding(names)
+		if enc == NameEncodingNone {
+			return nil
+		}
+
+		// Keep names that are already valid UTF-8 in mixed tables.
+		for i := range entries {
+			if utf8.ValidString(entries[i].Path) {
+				continue
+			}
+			if err := decodeEntryName(&entries[i], enc); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for i := range entries {
+		if err := decodeEntryName(&entries[i], enc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeEntryName transcodes one entry path to UTF-8 with explicit encoding.
+func decodeEntryName(entry *EntryInfo, enc NameEncoding) error {
+	decoded, err := DecodeEntryName(entry.Path, enc)
+	if err != nil {
+		return err
+	}
+
+	stored := entry.Path
+	entry.Path = decoded
+	keepOriginalPath(entry, stored)
+	return nil
+}
+
+// encodeEntryNames returns stored names of rewrite plan entries in enc.
+func encodeEntryNames(plan []rewriteEntry, enc NameEncoding) ([]string, error) {
+	names := make([]string, len(plan))
+	for i := range plan {
+		name, err := EncodeEntryName(plan[i].path, enc)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+
+	return names, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectNameEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		names []string
+		want  NameEncoding
+	}{
+		{name: "utf8", names: []string{`scripts\main.c`, "data\\тест.txt"}, want: NameEncodingNone},
+		{name: "cyrillic", names: []string{"scripts\\\xf2\xe5\xf1\xf2.c"}, want: NameEncodingWindows1251},
+		{name: "latin", names: []string{"data\\caf\xe9.txt", "data\\r\xe9sum\xe9.txt"}, want: NameEncodingWindows1252},
+	}
+
+	for _, tt := range tests {
+		if got := DetectNameEncoding(tt.names); got != tt.want {
+			t.Fatalf("%s: DetectNameEncoding = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeDecodeEntryName(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodeEntryName(`scripts\тест.c`, NameEncodingWindows1251)
+	if err != nil || encoded != "scripts\\\xf2\xe5\xf1\xf2.c" {
+		t.Fatalf("EncodeEntryName = %q, %v", encoded, err)
+	}
+
+	decoded, err := DecodeEntryName(encoded, NameEncodingAuto)
+	if err != nil || decoded != `scripts\тест.c` {
+		t.Fatalf("DecodeEntryName auto = %q, %v", decoded, err)
+	}
+
+	if _, err := EncodeEntryName(`data\日本.txt`, NameEncodingWindows1252); !errors.Is(err, ErrInvalidEntryPath) {
+		t.Fatalf("unrepresentable err = %v, want ErrInvalidEntryPath", err)
+	}
+	if _, err := EncodeEntryName("a.txt", NameEncodingAuto); !errors.Is(err, ErrInvalidNameEncoding) {
+		t.Fatalf("auto pack err = %v, want ErrInvalidNameEncoding", err)
+	}
+	if _, err := DecodeEntryName("a.txt", "koi8-r"); !errors.Is(err, ErrInvalidNameEncoding) {
+		t.Fatalf("unknown encoding err = %v, want ErrInvalidNameEncoding", err)
+	}
+}
+
+func TestPackAndOpen_NameEncodingRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "legacy.pbo")
+	err := createTestPBO(path, map[string][]byte{
+		"scripts/тест.c": []byte("A"),
+		"config.cpp":     []byte("B"),
+	}, PackOptions{NameEncoding: NameEncodingWindows1251})
+	if err != nil {
+		t.Fatalf("pack windows-1251: %v", err)
+	}
+
+	raw, err := ListEntriesWithOptions(path, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions raw: %v", err)
+	}
+	if raw[1].Path != "scripts\\\xf2\xe5\xf1\xf2.c" {
+		t.Fatalf("stored name = %q, want windows-1251 bytes", raw[1].Path)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{NameEncoding: NameEncodingAuto})
+	if err != nil {
+		t.Fatalf("OpenWithOptions auto: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if entries[1].Path != `scripts\тест.c` || entries[1].OriginalPath != raw[1].Path {
+		t.Fatalf("decoded entry = %q (original %q)", entries[1].Path, entries[1].OriginalPath)
+	}
+	if entries[0].OriginalPath != "" {
+		t.Fatalf("ASCII entry OriginalPath = %q, want empty", entries[0].OriginalPath)
+	}
+
+	data, err := r.ReadEntry(`scripts\тест.c`)
+	if err != nil || string(data) != "A" {
+		t.Fatalf("ReadEntry decoded = %q, %v", data, err)
+	}
+}
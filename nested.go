@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OpenNested opens PBO stored as entryPath inside outer archive without extraction.
+func OpenNested(outer *Reader, entryPath string) (*Reader, error) {
+	return OpenNestedWithOptions(outer, entryPath, ReaderOptions{})
+}
+
+// OpenNestedWithOptions opens nested PBO entry using reader options.
+// Plain entries are read in place through outer source; compressed or encoded
+// entries are decoded into memory. Nested reader is valid while outer is open.
+func OpenNestedWithOptions(outer *Reader, entryPath string, opts ReaderOptions) (*Reader, error) {
+	if outer == nil || outer.ra == nil {
+		return nil, ErrNilReader
+	}
+
+	outer.mu.Lock()
+	closed := outer.closed
+	outer.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	info := outer.findEntryByName(entryPath)
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEntryNotFound, entryPath)
+	}
+
+	return outer.openNestedEntry(info, opts)
+}
+
+// openNestedEntry parses entry payload as PBO archive.
+func (r *Reader) openNestedEntry(info *EntryInfo, opts ReaderOptions) (*Reader, error) {
+	ra, size, err := r.nestedArchiveSource(info)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReaderFromReaderAtWithOptions(ra, size, opts)
+}
+
+// nestedArchiveSource returns random-access view of nested archive payload. Plain
+// entries are read in place; others are decoded into memory under reader limits.
+func (r *Reader) nestedArchiveSource(info *EntryInfo) (io.ReaderAt, int64, error) {
+	if info.MimeType == MimeNil && !info.IsCompressed() {
+		return io.NewSectionReader(r.ra, int64(info.Offset), int64(info.DataSize)), int64(info.DataSize), nil
+	}
+
+	rc, err := r.openEntryByInfo(info, info.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read nested archive %s: %w", info.Path, err)
+	}
+
+	return bytes.NewReader(data), int64(len(data)), nil
+}
+
+// appendNestedEntries returns entries with contents of nested ".pbo" entries inserted
+// after their container. Entries that do not parse as PBO stay plain entries and are
+// logged; failures reading container payload, including limit errors, are returned.
+// Nested offsets are absolute in r source for plain containers; entries of decoded
+// containers report container offset since their payload exists only after decoding.
+func (r *Reader) appendNestedEntries(entries []EntryInfo, opts ReaderOptions, depth int) ([]EntryInfo, error) {
+	if depth <= 0 {
+		return entries, nil
+	}
+
+	innerOpts := ReaderOptions{
+		Logger:                   opts.Logger,
+		OffsetMode:               opts.OffsetMode,
+		EntryKey:                 opts.EntryKey,
+		Limits:                   opts.Limits,
+		MaxEntryDecompressedSize: opts.MaxEntryDecompressedSize,
+	}
+	out := make([]EntryInfo, 0, len(entries))
+	for i := range entries {
+		container := entries[i]
+		out = append(out, container)
+		if !strings.HasSuffix(strings.ToLower(container.Path), ".pbo") {
+			continue
+		}
+
+		ra, size, err := r.nestedArchiveSource(&container)
+		if err != nil {
+			return nil, err
+		}
+
+		inner, err := NewReaderFromReaderAtWithOptions(ra, size, innerOpts)
+		if err != nil {
+			opts.logger().Info("nested entry is not a PBO archive", "path", container.Path, "error", err)
+			continue
+		}
+
+		nestedEntries, err := inner.appendNestedEntries(inner.entries, innerOpts, depth-1)
+		if err != nil {
+			return nil, fmt.Errorf("nested archive %s: %w", container.Path, err)
+		}
+
+		plain := container.MimeType == MimeNil && !container.IsCompressed()
+		for _, nested := range nestedEntries {
+			nested.Path = container.Path + `\` + nested.Path
+			if plain {
+				nested.Offset += container.Offset
+			} else {
+				nested.Offset = container.Offset
+			}
+			out = append(out, nested)
+		}
+	}
+
+	return out, nil
+}
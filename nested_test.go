@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// packTestPBOBytes packs files and returns archive bytes.
+func packTestPBOBytes(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "inner.pbo")
+	if err := createTestPBO(path, files, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read packed archive: %v", err)
+	}
+
+	return data
+}
+
+func TestOpenNestedAndListDepth(t *testing.T) {
+	t.Parallel()
+
+	deepest := packTestPBOBytes(t, map[string][]byte{"deep.txt": []byte("deep")})
+	inner := packTestPBOBytes(t, map[string][]byte{
+		"script.c":  bytes.Repeat([]byte("void f();\n"), 200),
+		"child.pbo": deepest,
+	})
+
+	outerPath := filepath.Join(t.TempDir(), "outer.pbo")
+	if err := createTestPBO(outerPath, map[string][]byte{
+		"plain/inner.pbo":  inner,
+		"packed/inner.pbo": inner,
+		"readme.txt":       []byte("hi"),
+	}, PackOptions{Compress: includeRules("packed/*"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	outer, err := Open(outerPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = outer.Close() }()
+
+	if packed := findEntry(outer.Entries(), `packed\inner.pbo`); packed == nil || !packed.IsCompressed() {
+		t.Fatalf("packed nested archive must be compressed: %+v", packed)
+	}
+
+	for _, name := range []string{"plain/inner.pbo", "packed/inner.pbo"} {
+		nested, err := OpenNested(outer, name)
+		if err != nil {
+			t.Fatalf("OpenNested %s: %v", name, err)
+		}
+
+		got, err := nested.ReadEntry("script.c")
+		if err != nil || !bytes.Equal(got, bytes.Repeat([]byte("void f();\n"), 200)) {
+			t.Fatalf("nested %s ReadEntry err=%v", name, err)
+		}
+		_ = nested.Close()
+	}
+
+	if _, err := OpenNested(outer, "missing.pbo"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("expected ErrEntryNotFound, got %v", err)
+	}
+	if _, err := OpenNested(outer, "readme.txt"); err == nil {
+		t.Fatalf("expected parse error for non-PBO entry")
+	}
+
+	flat, err := ListEntries(outerPath)
+	if err != nil || len(flat) != 3 {
+		t.Fatalf("ListEntries depth 0: %d entries, err=%v", len(flat), err)
+	}
+
+	oneLevel, err := ListEntriesWithOptions(outerPath, ReaderOptions{NestedDepth: 1})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions depth 1: %v", err)
+	}
+	if len(oneLevel) != 7 || findEntry(oneLevel, `packed\inner.pbo\script.c`) == nil {
+		t.Fatalf("depth 1 entries=%v", entryPathsOf(oneLevel))
+	}
+
+	twoLevels, err := ListEntriesWithOptions(outerPath, ReaderOptions{NestedDepth: 2})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions depth 2: %v", err)
+	}
+	if len(twoLevels) != 9 || findEntry(twoLevels, `plain\inner.pbo\child.pbo\deep.txt`) == nil {
+		t.Fatalf("depth 2 entries=%v", entryPathsOf(twoLevels))
+	}
+}
+
+func TestListNestedOffsetsLimitsAndInvalid(t *testing.T) {
+	t.Parallel()
+
+	script := bytes.Repeat([]byte("void f();\n"), 200)
+	inner := packTestPBOBytes(t, map[string][]byte{"script.c": script})
+
+	outerPath := filepath.Join(t.TempDir(), "outer.pbo")
+	if err := createTestPBO(outerPath, map[string][]byte{
+		"plain/inner.pbo":  inner,
+		"packed/inner.pbo": inner,
+		"bogus.pbo":        []byte("not an archive"),
+	}, PackOptions{Compress: includeRules("packed/*"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	raw, err := os.ReadFile(outerPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	entries, err := ListEntriesWithOptions(outerPath, ReaderOptions{NestedDepth: 1})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions: %v", err)
+	}
+	if len(entries) != 5 || findEntry(entries, "bogus.pbo") == nil {
+		t.Fatalf("entries=%v", entryPathsOf(entries))
+	}
+
+	plain := findEntry(entries, `plain\inner.pbo\script.c`)
+	if plain == nil || !bytes.Equal(raw[plain.Offset:plain.Offset+plain.DataSize], script) {
+		t.Fatalf("plain nested entry offset does not address payload in outer archive: %+v", plain)
+	}
+
+	container := findEntry(entries, `packed\inner.pbo`)
+	packed := findEntry(entries, `packed\inner.pbo\script.c`)
+	if container == nil || packed == nil || packed.Offset != container.Offset {
+		t.Fatalf("compressed nested entry offset=%+v, want container offset %+v", packed, container)
+	}
+
+	_, err = ListEntriesWithOptions(outerPath, ReaderOptions{NestedDepth: 1, MaxEntryDecompressedSize: 64})
+	if !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("limited nested listing err=%v, want ErrEntryTooLarge", err)
+	}
+}
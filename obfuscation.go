@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxObfuscationExamples limits number of sample paths kept per finding.
+const maxObfuscationExamples = 3
+
+// ObfuscationTechnique identifies one index obfuscation technique.
+type ObfuscationTechnique string
+
+// Detected index obfuscation techniques.
+const (
+	// ObfuscationFakeOffsets means non-zero stored offsets disagree with sequential payload layout.
+	ObfuscationFakeOffsets ObfuscationTechnique = "fake_offsets"
+	// ObfuscationReservedNames means path segments use reserved DOS/Windows device names.
+	ObfuscationReservedNames ObfuscationTechnique = "reserved_names"
+	// ObfuscationGUIDSuffix means path segments end with ".{GUID}" shell namespace suffix.
+	ObfuscationGUIDSuffix ObfuscationTechnique = "guid_suffix"
+	// ObfuscationZeroSizeDecoys means entries have no payload or broken compressed sizes.
+	ObfuscationZeroSizeDecoys ObfuscationTechnique = "zero_size_decoys"
+	// ObfuscationControlChars means paths contain control, format, or replacement runes.
+	ObfuscationControlChars ObfuscationTechnique = "control_chars"
+	// ObfuscationNonASCII means paths contain non-ASCII bytes used as padding.
+	ObfuscationNonASCII ObfuscationTechnique = "non_ascii"
+	// ObfuscationInvalidPaths means paths are empty, absolute, or escape archive root.
+	ObfuscationInvalidPaths ObfuscationTechnique = "invalid_paths"
+	// ObfuscationDuplicatePaths means several entries share one case-insensitive path.
+	ObfuscationDuplicatePaths ObfuscationTechnique = "duplicate_paths"
+	// ObfuscationUnknownMime means entries use mime markers other than raw or compressed.
+	ObfuscationUnknownMime ObfuscationTechnique = "unknown_mime"
+)
+
+// ObfuscationFinding is one detected technique with affected entry count.
+type ObfuscationFinding struct {
+	// Technique is detected obfuscation technique.
+	Technique ObfuscationTechnique `json:"technique" yaml:"technique"`
+	// Examples are up to three affected paths with control runes replaced.
+	Examples []string `json:"examples,omitempty" yaml:"examples,omitempty"`
+	// Count is number of affected entries.
+	Count int `json:"count" yaml:"count"`
+}
+
+// ObfuscationReport summarizes index obfuscation techniques found in one archive.
+type ObfuscationReport struct {
+	// Findings are detected techniques sorted by name.
+	Findings []ObfuscationFinding `json:"findings,omitempty" yaml:"findings,omitempty"`
+	// Entries is number of raw index entries.
+	Entries int `json:"entries" yaml:"entries"`
+	// SuspiciousEntries is number of entries matched by at least one technique.
+	SuspiciousEntries int `json:"suspicious_entries" yaml:"suspicious_entries"`
+}
+
+// AnalyzeObfuscation parses raw index of archive at path without filters and
+// classifies obfuscation techniques found in entry table.
+func AnalyzeObfuscation(path string) (*ObfuscationReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open PBO: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat: %w", err)
+	}
+
+	r := &Reader{}
+	_, _, off, err := parseHeaderSection(f)
+	if err != nil {
+		return nil, err
+	}
+
+	dataStart, err := r.parseEntriesBuffered(f, off, fi.Size(), ReaderLimits{}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return analyzeObfuscationEntries(r.entries, dataStart), nil
+}
+
+// Obfuscated reports whether at least one technique was detected.
+func (r *ObfuscationReport) Obfuscated() bool {
+	return r != nil && len(r.Findings) > 0
+}
+
+// Count returns number of entries affected by technique.
+func (r *ObfuscationReport) Count(technique ObfuscationTechnique) int {
+	if r == nil {
+		return 0
+	}
+
+	for _, finding := range r.Findings {
+		if finding.Technique == technique {
+			return finding.Count
+		}
+	}
+
+	return 0
+}
+
+// ReaderOptions returns reader options that neutralize detected techniques.
+func (r *ObfuscationReport) ReaderOptions() ReaderOptions {
+	opts := ReaderOptions{OffsetMode: OffsetModeSequential}
+	if r.Count(ObfuscationZeroSizeDecoys) > 0 || r.Count(ObfuscationInvalidPaths) > 0 {
+		opts.EnableJunkFilter = true
+	}
+	if r.Count(ObfuscationControlChars) > 0 {
+		opts.SanitizeControlChars = true
+	}
+	if r.Count(ObfuscationReservedNames) > 0 ||
+		r.Count(ObfuscationGUIDSuffix) > 0 ||
+		r.Count(ObfuscationNonASCII) > 0 ||
+		r.Count(ObfuscationDuplicatePaths) > 0 {
+		opts.SanitizeNames = true
+	}
+
+	return opts
+}
+
+// analyzeObfuscationEntries classifies raw entries with stored offsets as read from index.
+func analyzeObfuscationEntries(entries []EntryInfo, dataStart int64) *ObfuscationReport {
+	findings := make(map[ObfuscationTechnique]*ObfuscationFinding)
+	add := func(technique ObfuscationTechnique, entryPath string) {
+		finding, ok := findings[technique]
+		if !ok {
+			finding = &ObfuscationFinding{Technique: technique}
+			findings[technique] = finding
+		}
+
+		finding.Count++
+		if len(finding.Examples) < maxObfuscationExamples {
+			example, _ := sanitizeRelativePathWith(entryPath, sanitizeControlCharPathSegment)
+			finding.Examples = append(finding.Examples, example)
+		}
+	}
+
+	report := &ObfuscationReport{Entries: len(entries)}
+	seen := make(map[string]struct{}, len(entries))
+	relOffset := int64(0)
+	for _, entry := range entries {
+		techniques := classifyObfuscatedEntry(entry, dataStart, relOffset)
+
+		key := strings.ToLower(NormalizePath(entry.Path))
+		if _, dup := seen[key]; dup {
+			techniques = append(techniques, ObfuscationDuplicatePaths)
+		}
+		seen[key] = struct{}{}
+
+		for _, technique := range techniques {
+			add(technique, entry.Path)
+		}
+		if len(techniques) > 0 {
+			report.SuspiciousEntries++
+		}
+
+		relOffset += int64(entry.DataSize)
+	}
+
+	report.Findings = make([]ObfuscationFinding, 0, len(findings))
+	for _, finding := range findings {
+		report.Findings = append(report.Findings, *finding)
+	}
+	sort.Slice(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Technique < report.Findings[j].Technique
+	})
+
+	return report
+}
+
+// classifyObfuscatedEntry returns per-entry techniques (duplicates are detected by caller).
+func classifyObfuscatedEntry(entry EntryInfo, dataStart int64, relOffset int64) []ObfuscationTechnique {
+	var techniques []ObfuscationTechnique
+
+	if entry.Offset != 0 && int64(entry.Offset) != relOffset && int64(entry.Offset) != dataStart+relOffset {
+		techniques = append(techniques, ObfuscationFakeOffsets)
+	}
+
+	if entry.DataSize == 0 || (entry.MimeType == MimeCompress && entry.OriginalSize == 0) {
+		techniques = append(techniques, ObfuscationZeroSizeDecoys)
+	}
+
+	if entry.MimeType != MimeNil && entry.MimeType != MimeCompress {
+		techniques = append(techniques, ObfuscationUnknownMime)
+	}
+
+	if _, err := NormalizeExtractPath(entry.Path); err != nil {
+		techniques = append(techniques, ObfuscationInvalidPaths)
+	}
+
+	if !filterPathIsASCIIOnly(entry.Path) {
+		techniques = append(techniques, ObfuscationNonASCII)
+	}
+
+	var controlChars, reserved, guid bool
+	for _, r := range entry.Path {
+		if isUnsafeControlCharRune(r) {
+			controlChars = true
+			break
+		}
+	}
+
+	for segment := range strings.FieldsFuncSeq(entry.Path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if isReservedDeviceName(segment) {
+			reserved = true
+		}
+		if sanitizeWindowsGUIDSuffix(segment) != segment {
+			guid = true
+		}
+	}
+
+	if controlChars {
+		techniques = append(techniques, ObfuscationControlChars)
+	}
+	if reserved {
+		techniques = append(techniques, ObfuscationReservedNames)
+	}
+	if guid {
+		techniques = append(techniques, ObfuscationGUIDSuffix)
+	}
+
+	return techniques
+}
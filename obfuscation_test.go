@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// rawTestEntry describes one index row written verbatim by writeRawEntryTablePBO.
+type rawTestEntry struct {
+	name         string
+	data         []byte
+	mime         MimeType
+	originalSize uint32
+	offset       uint32
+}
+
+// writeRawEntryTablePBO writes archive with index rows exactly as specified.
+func writeRawEntryTablePBO(t *testing.T, entries []rawTestEntry) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(header[1:5], uint32(MimeHeader))
+	buf.Write(header)
+	buf.WriteByte(0)
+
+	for _, entry := range entries {
+		buf.WriteString(entry.name)
+		buf.WriteByte(0)
+
+		var fields [20]byte
+		binary.LittleEndian.PutUint32(fields[0:4], uint32(entry.mime))
+		binary.LittleEndian.PutUint32(fields[4:8], entry.originalSize)
+		binary.LittleEndian.PutUint32(fields[8:12], entry.offset)
+		binary.LittleEndian.PutUint32(fields[16:20], uint32(len(entry.data))) //nolint:gosec // test payloads are small
+		buf.Write(fields[:])
+	}
+	buf.Write(make([]byte, 21))
+
+	for _, entry := range entries {
+		buf.Write(entry.data)
+	}
+
+	path := filepath.Join(t.TempDir(), "raw.pbo")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write raw pbo: %v", err)
+	}
+
+	return path
+}
+
+func TestAnalyzeObfuscation_ClassifiesTechniques(t *testing.T) {
+	t.Parallel()
+
+	path := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: `config.cpp`, data: []byte("class CfgPatches {};")},
+		{name: `scripts\con.c`, data: []byte("void f();")},
+		{name: `data\x.paa.{21EC2020-3AEA-1069-A2DD-08002B30309D}`, data: []byte("img")},
+		{name: "decoy.bin"},
+		{name: "bad\x07name.txt", data: []byte("a")},
+		{name: "pad　　.txt", data: []byte("b")},
+		{name: `..\escape.txt`, data: []byte("c")},
+		{name: `CONFIG.CPP`, data: []byte("dup")},
+		{name: "fake.bin", data: []byte("d"), offset: 0xFFFFFF00},
+	})
+
+	report, err := AnalyzeObfuscation(path)
+	if err != nil {
+		t.Fatalf("AnalyzeObfuscation: %v", err)
+	}
+
+	if report.Entries != 9 || !report.Obfuscated() {
+		t.Fatalf("report=%+v", report)
+	}
+
+	want := map[ObfuscationTechnique]int{
+		ObfuscationReservedNames:  1,
+		ObfuscationGUIDSuffix:     1,
+		ObfuscationZeroSizeDecoys: 1,
+		ObfuscationControlChars:   1,
+		ObfuscationNonASCII:       1,
+		ObfuscationInvalidPaths:   1,
+		ObfuscationDuplicatePaths: 1,
+		ObfuscationFakeOffsets:    1,
+	}
+	for technique, count := range want {
+		if got := report.Count(technique); got != count {
+			t.Fatalf("%s count=%d, want %d (findings=%+v)", technique, got, count, report.Findings)
+		}
+	}
+	if report.SuspiciousEntries != 8 {
+		t.Fatalf("suspicious=%d, want 8", report.SuspiciousEntries)
+	}
+
+	opts := report.ReaderOptions()
+	if !opts.EnableJunkFilter || !opts.SanitizeControlChars || !opts.SanitizeNames {
+		t.Fatalf("suggested options=%+v", opts)
+	}
+	if _, err := OpenWithOptions(path, opts); err != nil {
+		t.Fatalf("open with suggested options: %v", err)
+	}
+}
+
+func TestAnalyzeObfuscation_CleanArchive(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "clean.pbo")
+	if err := createTestPBO(path, map[string][]byte{"a.txt": []byte("a"), "dir/b.txt": []byte("b")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	report, err := AnalyzeObfuscation(path)
+	if err != nil {
+		t.Fatalf("AnalyzeObfuscation: %v", err)
+	}
+	if report.Obfuscated() || report.Entries != 2 {
+		t.Fatalf("report=%+v", report)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// dedupPayloadKey identifies payloads that can share one stored copy.
+type dedupPayloadKey struct {
+	sum          [sha256.Size]byte
+	size         int64
+	originalSize uint32
+	mime         MimeType
+	// source separates copied stored payloads from input streams.
+	source bool
+}
+
+// planPayloadDedup hashes rewrite plan payloads and returns, for every item, index of
+// earlier item with identical payload or -1 when item payload must be written.
+func planPayloadDedup(
+	ctx context.Context,
+	src *Reader,
+	rewritePlan []rewriteEntry,
+	copyBuf []byte,
+	opts PackOptions,
+) ([]int, error) {
+	sharedWith := make([]int, len(rewritePlan))
+	seen := make(map[dedupPayloadKey]int, len(rewritePlan))
+
+	for i, item := range rewritePlan {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		key, err := dedupKeyForItem(ctx, src, item, copyBuf, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if primary, ok := seen[key]; ok {
+			sharedWith[i] = primary
+			continue
+		}
+
+		seen[key] = i
+		sharedWith[i] = -1
+	}
+
+	return sharedWith, nil
+}
+
+// dedupKeyForItem hashes stored payload of source-backed item or raw stream of input-backed item.
+func dedupKeyForItem(
+	ctx context.Context,
+	src *Reader,
+	item rewriteEntry,
+	copyBuf []byte,
+	opts PackOptions,
+) (dedupPayloadKey, error) {
+	sum := sha256.New()
+
+	if item.source != nil {
+		itemSrc := item.sourceOf(src)
+		if itemSrc == nil || itemSrc.ra == nil {
+			return dedupPayloadKey{}, ErrNilReader
+		}
+
+		payload := io.NewSectionReader(itemSrc.ra, int64(item.source.Offset), int64(item.source.DataSize))
+		n, err := io.CopyBuffer(sum, payload, copyBuf)
+		if err != nil {
+			return dedupPayloadKey{}, fmt.Errorf("hash source %s: %w", item.path, err)
+		}
+
+		key := dedupPayloadKey{
+			size:         n,
+			originalSize: item.source.OriginalSize,
+			mime:         item.source.MimeType,
+			source:       true,
+		}
+		sum.Sum(key.sum[:0])
+		return key, nil
+	}
+
+	if item.input == nil {
+		return dedupPayloadKey{}, fmt.Errorf("entry %s: missing input/source", item.path)
+	}
+
+	rc, err := openInputReader(ctx, *item.input, opts.PerEntryTimeout, opts.OpenRetry)
+	if err != nil {
+		return dedupPayloadKey{}, err
+	}
+
+	n, err := io.CopyBuffer(sum, rc, copyBuf)
+	closeErr := rc.Close()
+	if err != nil {
+		return dedupPayloadKey{}, fmt.Errorf("hash input %s: %w", item.input.Path, err)
+	}
+	if closeErr != nil {
+		return dedupPayloadKey{}, fmt.Errorf("close input %s: %w", item.input.Path, closeErr)
+	}
+
+	key := dedupPayloadKey{size: n, originalSize: item.input.OriginalSize}
+	sum.Sum(key.sum[:0])
+	return key, nil
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+)
+
+// compressPipeline compresses in-memory candidates of rewrite plan in worker goroutines
+// ahead of writer. Writer consumes results in plan order; in-flight results are bounded.
+type compressPipeline struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	results []chan compressPipelineResult
+	slots   chan struct{}
+	wg      sync.WaitGroup
+}
+
+// compressPipelineResult is worker output for one plan item.
+type compressPipelineResult struct {
+	err  error
+	cand compressedCandidate
+}
+
+// startCompressPipeline starts workers for in-memory compression candidates of plan.
+// It returns nil when CompressWorkers disables pipeline or plan has no candidates.
+// sharedWith marks deduplicated items that are not written (see planPayloadDedup).
+func startCompressPipeline(
+	ctx context.Context,
+	rewritePlan []rewriteEntry,
+	sharedWith []int,
+	opts PackOptions,
+	matcher *compressMatcher,
+) *compressPipeline {
+	if opts.CompressWorkers <= 1 {
+		return nil
+	}
+
+	candidates := make([]int, 0, len(rewritePlan))
+	for i, item := range rewritePlan {
+		if sharedWith != nil && sharedWith[i] >= 0 {
+			continue
+		}
+		if isPipelineCandidate(opts, matcher, item) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	p := &compressPipeline{
+		results: make([]chan compressPipelineResult, len(rewritePlan)),
+		slots:   make(chan struct{}, 2*opts.CompressWorkers),
+	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	for _, i := range candidates {
+		p.results[i] = make(chan compressPipelineResult, 1)
+	}
+
+	jobs := make(chan int)
+	p.wg.Go(func() {
+		defer close(jobs)
+		for _, i := range candidates {
+			select {
+			case p.slots <- struct{}{}:
+			case <-p.ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- i:
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	})
+
+	for range opts.CompressWorkers {
+		p.wg.Go(func() {
+			copyBuf, releaseCopyBuffer := acquirePackCopyBuffer()
+			defer releaseCopyBuffer()
+
+			for i := range jobs {
+				p.results[i] <- compressPipelineItem(p.ctx, *rewritePlan[i].input, opts, copyBuf)
+			}
+		})
+	}
+
+	return p
+}
+
+// isPipelineCandidate reports whether item takes in-memory compression path in writer.
+func isPipelineCandidate(opts PackOptions, matcher *compressMatcher, item rewriteEntry) bool {
+	if item.input == nil || item.input.OriginalSize != 0 {
+		return false
+	}
+	if !shouldUseCompressionForInput(opts, matcher, *item.input) {
+		return false
+	}
+
+	maxEntrySize := int64(^uint32(0))
+	if shouldUseSpoolCompressPath(opts, item.input.SizeHint, maxEntrySize) {
+		return false
+	}
+
+	return shouldUseInMemoryCompressPath(opts, item.input.SizeHint, maxEntrySize)
+}
+
+// compressPipelineItem reads and compresses one candidate input.
+func compressPipelineItem(ctx context.Context, in Input, opts PackOptions, copyBuf []byte) compressPipelineResult {
+	rc, err := openInputReader(ctx, in, opts.PerEntryTimeout, opts.OpenRetry)
+	if err != nil {
+		return compressPipelineResult{err: err}
+	}
+
+	cand, err := compressCandidateInMemory(rc, in, opts, int64(^uint32(0)), copyBuf)
+	closeErr := rc.Close()
+	if err != nil {
+		return compressPipelineResult{err: err}
+	}
+	if closeErr != nil {
+		return compressPipelineResult{err: fmt.Errorf("close input %s: %w", in.Path, closeErr)}
+	}
+
+	return compressPipelineResult{cand: cand}
+}
+
+// writePipelineCandidate writes candidate compressed by pipeline and feeds raw content
+// to entry hasher and verify checksum that would otherwise see input stream.
+func writePipelineCandidate(
+	dst io.Writer,
+	in Input,
+	cand compressedCandidate,
+	hasher hash.Hash,
+	verifySum hash.Hash32,
+	currentOffset uint32,
+) (writtenEntry, error) {
+	if hasher != nil {
+		hasher.Reset()
+		_, _ = hasher.Write(cand.raw)
+	}
+	if verifySum != nil {
+		_, _ = verifySum.Write(cand.raw)
+	}
+
+	record, err := writeCompressedCandidate(dst, in, cand, currentOffset)
+	if err != nil {
+		return writtenEntry{}, err
+	}
+
+	record.compressionCandidate = true
+	return record, nil
+}
+
+// result waits for compressed candidate of plan item i.
+// ok is false when item is not handled by pipeline (including nil pipeline).
+func (p *compressPipeline) result(i int) (compressedCandidate, bool, error) {
+	if p == nil || p.results[i] == nil {
+		return compressedCandidate{}, false, nil
+	}
+
+	select {
+	case res := <-p.results[i]:
+		<-p.slots
+		return res.cand, true, res.err
+	case <-p.ctx.Done():
+		return compressedCandidate{}, true, p.ctx.Err()
+	}
+}
+
+// stop cancels pending work and waits for workers to exit.
+func (p *compressPipeline) stop() {
+	if p == nil {
+		return
+	}
+
+	p.cancel()
+	p.wg.Wait()
+}
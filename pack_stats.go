@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"sort"
+	"strings"
+)
+
+// ExtensionStats is compression effectiveness of entries sharing one file extension.
+type ExtensionStats struct {
+	// Extension is lower-case extension with leading dot; empty for names without extension.
+	Extension string `json:"extension" yaml:"extension"`
+	// Entries is number of written entries.
+	Entries int `json:"entries" yaml:"entries"`
+	// CompressedEntries is number of entries written with compressed payload.
+	CompressedEntries int `json:"compressed_entries,omitempty" yaml:"compressed_entries,omitempty"`
+	// SkippedNotSmaller is number of compression candidates stored raw because output was not smaller.
+	SkippedNotSmaller int `json:"skipped_not_smaller,omitempty" yaml:"skipped_not_smaller,omitempty"`
+	// OriginalBytes is total original content bytes.
+	OriginalBytes int64 `json:"original_bytes" yaml:"original_bytes"`
+	// PackedBytes is total stored payload bytes.
+	PackedBytes int64 `json:"packed_bytes" yaml:"packed_bytes"`
+	// Ratio is PackedBytes divided by OriginalBytes (1 means no gain); zero when OriginalBytes is zero.
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+}
+
+// extensionStatsCollector accumulates per-extension pack statistics.
+type extensionStatsCollector map[string]*ExtensionStats
+
+// add records one written entry.
+func (c extensionStatsCollector) add(path string, record writtenEntry) {
+	ext := entryExtension(path)
+	stats, ok := c[ext]
+	if !ok {
+		stats = &ExtensionStats{Extension: ext}
+		c[ext] = stats
+	}
+
+	stats.Entries++
+	stats.OriginalBytes += writtenEntryContentSize(record)
+	stats.PackedBytes += int64(record.dataSize)
+	if record.mime == MimeCompress {
+		stats.CompressedEntries++
+	}
+	if record.compressionCandidate && record.skipReason == skipReasonNotSmaller {
+		stats.SkippedNotSmaller++
+	}
+}
+
+// result returns collected stats sorted by extension.
+func (c extensionStatsCollector) result() []ExtensionStats {
+	if len(c) == 0 {
+		return nil
+	}
+
+	out := make([]ExtensionStats, 0, len(c))
+	for _, stats := range c {
+		if stats.OriginalBytes > 0 {
+			stats.Ratio = float64(stats.PackedBytes) / float64(stats.OriginalBytes)
+		}
+
+		out = append(out, *stats)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Extension < out[j].Extension
+	})
+
+	return out
+}
+
+// entryExtension returns lower-case extension of last path segment.
+func entryExtension(path string) string {
+	name := path[strings.LastIndexAny(path, `\/`)+1:]
+	dot := strings.LastIndexByte(name, '.')
+	if dot <= 0 {
+		return ""
+	}
+
+	return strings.ToLower(name[dot:])
+}
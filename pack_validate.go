@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// InputIssueKind identifies one kind of problem found by ValidateInputs.
+type InputIssueKind string
+
+// Input issue kinds reported by ValidateInputs.
+const (
+	// InputIssueInvalidPath means input path is empty, invalid, or not encodable in NameEncoding.
+	InputIssueInvalidPath InputIssueKind = "invalid_path"
+	// InputIssueDuplicatePath means input path conflicts with earlier input under PathCaseSensitivity.
+	InputIssueDuplicatePath InputIssueKind = "duplicate_path"
+	// InputIssueMissingOpen means input has nil Open function.
+	InputIssueMissingOpen InputIssueKind = "missing_open"
+	// InputIssueMissingModTime means input has zero ModTime while RequireModTime is set.
+	InputIssueMissingModTime InputIssueKind = "missing_mod_time"
+	// InputIssueSizeOverflow means SizeHint of one input or all inputs exceeds PBO limits.
+	InputIssueSizeOverflow InputIssueKind = "size_overflow"
+	// InputIssueUnusedCompressRule means include compression rule matches no input path.
+	InputIssueUnusedCompressRule InputIssueKind = "unused_compress_rule"
+	// InputIssueInvalidOptions means pack options are rejected before any input is read.
+	InputIssueInvalidOptions InputIssueKind = "invalid_options"
+)
+
+// InputIssue is one problem that would fail or weaken pack of inputs.
+type InputIssue struct {
+	// Err is sentinel-wrapped error pack would return; nil for advisory kinds.
+	Err error `json:"-" yaml:"-"`
+	// Kind is issue kind.
+	Kind InputIssueKind `json:"kind" yaml:"kind"`
+	// Path is input path as given; empty for option and archive-level issues.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Rule is compression rule pattern for InputIssueUnusedCompressRule.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	// Detail is human-readable description.
+	Detail string `json:"detail" yaml:"detail"`
+}
+
+// ValidateInputs checks inputs against opts without opening them and returns every
+// problem found: invalid and duplicate paths, missing Open or ModTime, SizeHint over
+// 4 GiB budget, and include compression rules that match no input. Unknown SizeHint
+// (zero or negative) is not counted. Empty result means pack is expected to pass
+// these checks; read and write errors can still occur.
+func ValidateInputs(inputs []Input, opts PackOptions) []InputIssue {
+	var issues []InputIssue
+	add := func(kind InputIssueKind, path string, err error) {
+		issues = append(issues, InputIssue{Err: err, Kind: kind, Path: path, Detail: err.Error()})
+	}
+
+	pathCase := opts.PathCaseSensitivity
+	if err := validatePathCaseSensitivity(pathCase); err != nil {
+		add(InputIssueInvalidOptions, "", err)
+		pathCase = PathCaseInsensitive
+	}
+
+	requireModTime := opts.RequireModTime && !opts.ZeroTimestamps && opts.SourceDateEpoch.IsZero()
+	seen := make(map[string]string, len(inputs))
+	paths := make([]string, 0, len(inputs))
+	var total int64
+	for _, in := range inputs {
+		if in.Open == nil {
+			add(InputIssueMissingOpen, in.Path, fmt.Errorf("input %s: Open is nil", in.Path))
+		}
+		if requireModTime && in.ModTime.IsZero() {
+			add(InputIssueMissingModTime, in.Path, fmt.Errorf("%w: %s", ErrMissingModTime, in.Path))
+		}
+		if in.SizeHint > int64(^uint32(0)) {
+			add(InputIssueSizeOverflow, in.Path, fmt.Errorf("%w: %s size %d exceeds 4 GiB", ErrSizeOverflow, in.Path, in.SizeHint))
+		}
+		if in.SizeHint > 0 {
+			total += in.SizeHint
+		}
+
+		path, err := validateInputPath(in.Path, opts)
+		if err != nil {
+			add(InputIssueInvalidPath, in.Path, err)
+			continue
+		}
+
+		key := editorPathKey(path, pathCase)
+		if existing, ok := seen[key]; ok {
+			add(InputIssueDuplicatePath, in.Path, fmt.Errorf("%w: %q conflicts with %q", ErrDuplicateEntryPath, path, existing))
+			continue
+		}
+		seen[key] = path
+		paths = append(paths, path)
+	}
+
+	if total > maxPBOData {
+		add(InputIssueSizeOverflow, "", fmt.Errorf("%w: estimated data %d exceeds 4 GiB", ErrSizeOverflow, total))
+	}
+
+	return append(issues, unusedCompressRules(paths, opts)...)
+}
+
+// validateInputPath returns canonical entry path of raw as pack writes it and checks
+// that it is encodable in opts.NameEncoding.
+func validateInputPath(raw string, opts PackOptions) (string, error) {
+	unicodePath, err := NormalizeUnicodePath(raw, opts.UnicodeForm)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := NormalizeEntryPath(unicodePath)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := EncodeEntryName(path, opts.NameEncoding); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// unusedCompressRules reports include compression rules that match none of paths.
+// Each rule is compiled alone, so rules shadowed by later excludes still count as used.
+func unusedCompressRules(paths []string, opts PackOptions) []InputIssue {
+	var issues []InputIssue
+	for _, rule := range normalizeCompressRules(opts.Compress) {
+		if rule.Action != pathrules.ActionInclude {
+			continue
+		}
+
+		matcher, err := pathrules.NewMatcher([]pathrules.Rule{rule}, opts.CompressMatcherOptions)
+		if err != nil {
+			err = fmt.Errorf("%w: rule %q: %w", ErrInvalidCompressPattern, rule.Pattern, err)
+			issues = append(issues, InputIssue{Err: err, Kind: InputIssueInvalidOptions, Rule: rule.Pattern, Detail: err.Error()})
+			continue
+		}
+
+		matched := false
+		for _, path := range paths {
+			if matcher.Decide(NormalizePath(path), false).Matched {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			issues = append(issues, InputIssue{
+				Kind:   InputIssueUnusedCompressRule,
+				Rule:   rule.Pattern,
+				Detail: fmt.Sprintf("compression rule %q matches no input", rule.Pattern),
+			})
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/woozymasta/pathrules"
+)
+
+func TestValidateInputs(t *testing.T) {
+	t.Parallel()
+
+	inputs := streamTestInputs(map[string][]byte{"config.cpp": []byte("class CfgPatches {};")})
+	inputs = append(inputs,
+		Input{Path: "CONFIG.CPP", Open: inputs[0].Open},
+		Input{Path: "", Open: inputs[0].Open},
+		Input{Path: "big.paa", SizeHint: 5 << 30},
+		Input{Path: "data/model.p3d", Open: inputs[0].Open},
+	)
+
+	issues := ValidateInputs(inputs, PackOptions{
+		RequireModTime: true,
+		Compress: []pathrules.Rule{
+			{Action: pathrules.ActionInclude, Pattern: "*.cpp"},
+			{Action: pathrules.ActionInclude, Pattern: "*.sqf"},
+		},
+	})
+
+	kinds := make(map[InputIssueKind][]InputIssue)
+	for _, issue := range issues {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue)
+	}
+
+	if got := kinds[InputIssueDuplicatePath]; len(got) != 1 || !errors.Is(got[0].Err, ErrDuplicateEntryPath) {
+		t.Fatalf("duplicate issues = %+v", got)
+	}
+	if got := kinds[InputIssueInvalidPath]; len(got) != 1 || !errors.Is(got[0].Err, ErrInvalidEntryPath) {
+		t.Fatalf("invalid path issues = %+v", got)
+	}
+	if got := kinds[InputIssueMissingOpen]; len(got) != 1 || got[0].Path != "big.paa" {
+		t.Fatalf("missing open issues = %+v", got)
+	}
+	if got := kinds[InputIssueMissingModTime]; len(got) != 4 {
+		t.Fatalf("missing mod time issues = %d, want 4", len(got))
+	}
+	if got := kinds[InputIssueSizeOverflow]; len(got) != 2 || got[1].Path != "" || !errors.Is(got[1].Err, ErrSizeOverflow) {
+		t.Fatalf("size overflow issues = %+v", got)
+	}
+	if got := kinds[InputIssueUnusedCompressRule]; len(got) != 1 || got[0].Rule != "*.sqf" || got[0].Err != nil {
+		t.Fatalf("unused rule issues = %+v", got)
+	}
+}
+
+func TestValidateInputs_Clean(t *testing.T) {
+	t.Parallel()
+
+	inputs := streamTestInputs(map[string][]byte{
+		"config.cpp":     []byte("A"),
+		"scripts/main.c": []byte("B"),
+		"scripts/тест.c": []byte("C"),
+		"data/image.paa": []byte("D"),
+	})
+
+	if issues := ValidateInputs(inputs, PackOptions{}); len(issues) != 0 {
+		t.Fatalf("ValidateInputs = %+v, want none", issues)
+	}
+
+	issues := ValidateInputs(inputs, PackOptions{NameEncoding: NameEncodingWindows1252, PathCaseSensitivity: "bad"})
+	if len(issues) != 2 || issues[0].Kind != InputIssueInvalidOptions || issues[1].Kind != InputIssueInvalidPath {
+		t.Fatalf("ValidateInputs options = %+v", issues)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// packVerifier records CRC32 of source streams and checks written payloads against them.
+type packVerifier struct {
+	sums []packVerifySum
+}
+
+// packVerifySum is source checksum of one written entry.
+type packVerifySum struct {
+	crc uint32
+	// stored means crc covers payload as stored (pre-compressed or copied entries).
+	stored bool
+}
+
+// wrapInput returns item copy whose input stream is fed to returned CRC32 accumulator.
+func (v *packVerifier) wrapInput(item rewriteEntry) (rewriteEntry, hash.Hash32) {
+	sum := crc32.NewIEEE()
+	in := *item.input
+	open := in.Open
+	in.Open = func() (io.ReadCloser, error) {
+		if open == nil {
+			return nil, fmt.Errorf("input %s: Open is nil", in.Path)
+		}
+
+		rc, err := open()
+		if err != nil {
+			return nil, err
+		}
+
+		return readCloser{Reader: io.TeeReader(rc, sum), Closer: rc}, nil
+	}
+	item.input = &in
+
+	return item, sum
+}
+
+// addInput records checksum of input-backed entry.
+func (v *packVerifier) addInput(in *Input, sum hash.Hash32) {
+	v.sums = append(v.sums, packVerifySum{crc: sum.Sum32(), stored: in.OriginalSize != 0})
+}
+
+// addShared records checksum of entry sharing payload with earlier entry primary.
+func (v *packVerifier) addShared(primary int) {
+	v.sums = append(v.sums, v.sums[primary])
+}
+
+// addSource records checksum of stored payload copied from source archive.
+func (v *packVerifier) addSource(src io.ReaderAt, info EntryInfo, copyBuf []byte) error {
+	sum := crc32.NewIEEE()
+	payload := io.NewSectionReader(src, int64(info.Offset), int64(info.DataSize))
+	if _, err := io.CopyBuffer(sum, payload, copyBuf); err != nil {
+		return fmt.Errorf("checksum source %s: %w", info.Path, err)
+	}
+
+	v.sums = append(v.sums, packVerifySum{crc: sum.Sum32(), stored: true})
+	return nil
+}
+
+// verify re-reads written entries from out and compares them with recorded checksums.
+// Plain inputs are compared after decompression, stored payloads as written.
+// Every mismatching entry is reported through BatchError.
+func (v *packVerifier) verify(out io.ReaderAt, entries []EntryInfo, copyBuf []byte) error {
+	r := &Reader{ra: out}
+	var failures []BatchFailure
+	for i := range entries {
+		if err := v.verifyEntry(r, out, entries[i], v.sums[i], copyBuf); err != nil {
+			failures = append(failures, BatchFailure{Path: entries[i].Path, Err: err})
+		}
+	}
+
+	if len(failures) != 0 {
+		return &BatchError{Op: "verify", Failures: failures}
+	}
+
+	return nil
+}
+
+// verifyEntry compares one written entry with its recorded checksum.
+func (v *packVerifier) verifyEntry(r *Reader, out io.ReaderAt, entry EntryInfo, want packVerifySum, copyBuf []byte) error {
+	var (
+		rc  io.ReadCloser
+		err error
+	)
+	if want.stored {
+		rc = io.NopCloser(io.NewSectionReader(out, int64(entry.Offset), int64(entry.DataSize)))
+	} else {
+		rc, err = r.openEntryByInfo(&entry, entry.Path)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrVerifyMismatch, entry.Path, err)
+		}
+	}
+
+	sum := crc32.NewIEEE()
+	_, err = io.CopyBuffer(sum, rc, copyBuf)
+	_ = rc.Close()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrVerifyMismatch, entry.Path, err)
+	}
+
+	if got := sum.Sum32(); got != want.crc {
+		return fmt.Errorf("%w: %s: crc32 %08x, source %08x", ErrVerifyMismatch, entry.Path, got, want.crc)
+	}
+
+	return nil
+}
+
+// readCloser pairs wrapping reader with close of underlying stream.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPack_VerifyAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"a.txt": bytes.Repeat([]byte("alpha "), 256),
+		"b.bin": []byte("raw payload"),
+	}
+	opts := PackOptions{
+		Compress:         includeRules("*.txt"),
+		MinCompressSize:  1,
+		VerifyAfterWrite: true,
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "verify.pbo")
+	if err := createTestPBO(pboPath, files, opts); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{PackOptions: opts})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Add(sessionInput("c.txt", "charlie")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit with copied entries: %v", err)
+	}
+
+	corrupt := opts
+	corrupt.Compressor = CompressorFunc(func(data []byte) ([]byte, error) {
+		out, err := LZSSCompressor{}.Compress(data)
+		if err == nil && len(out) > 4 {
+			out[len(out)/2] ^= 0xff
+		}
+		return out, err
+	})
+	badPath := filepath.Join(t.TempDir(), "bad.pbo")
+	if err := createTestPBO(badPath, files, corrupt); !errors.Is(err, ErrVerifyMismatch) {
+		t.Fatalf("corrupt compressor err=%v, want ErrVerifyMismatch", err)
+	}
+
+	sizeHint := PackOptions{StreamMode: PackStreamModeSizeHint, VerifyAfterWrite: true}
+	if _, err := PackToWriter(context.Background(), io.Discard, streamTestInputs(files), sizeHint); !errors.Is(err, ErrReaderAtRequired) {
+		t.Fatalf("size-hint stream err=%v, want ErrReaderAtRequired", err)
+	}
+}
+
+func TestPack_VerifyAfterWriteReportsAllMismatches(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"a.txt": bytes.Repeat([]byte("alpha "), 256),
+		"b.txt": bytes.Repeat([]byte("bravo "), 256),
+		"c.bin": []byte("raw payload"),
+	}
+	opts := PackOptions{
+		Compress:         includeRules("*.txt"),
+		MinCompressSize:  1,
+		VerifyAfterWrite: true,
+		Compressor: CompressorFunc(func(data []byte) ([]byte, error) {
+			out, err := LZSSCompressor{}.Compress(data)
+			if err == nil && len(out) > 4 {
+				out[len(out)/2] ^= 0xff
+			}
+			return out, err
+		}),
+	}
+
+	err := createTestPBO(filepath.Join(t.TempDir(), "bad.pbo"), files, opts)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, ErrVerifyMismatch) {
+		t.Fatalf("err=%v, want BatchError wrapping ErrVerifyMismatch", err)
+	}
+
+	paths := batchErr.Paths()
+	slices.Sort(paths)
+	if batchErr.Op != "verify" || !slices.Equal(paths, []string{"a.txt", "b.txt"}) {
+		t.Fatalf("batch op=%q paths=%v, want verify a.txt and b.txt", batchErr.Op, paths)
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Packer packs archives with options prepared once: defaults applied, values validated,
+// compress rules compiled, and write buffers pooled. Use it for services that pack many
+// archives with the same options. Packer is safe for concurrent use.
+type Packer struct {
+	compress *compressMatcher
+	// writers pools bufio writers of non-default WriterBufferSize.
+	writers sync.Pool
+	opts    PackOptions
+}
+
+// NewPacker validates opts and prepares reusable pack state.
+func NewPacker(opts PackOptions) (*Packer, error) {
+	opts.applyDefaults()
+
+	if err := validatePathCaseSensitivity(opts.PathCaseSensitivity); err != nil {
+		return nil, err
+	}
+	if err := validatePayloadAlignment(opts.PayloadAlignment); err != nil {
+		return nil, err
+	}
+	if _, err := newEntryHasher(opts.EntryHash); err != nil {
+		return nil, err
+	}
+
+	compress, err := newCompressMatcher(opts.Compress, opts.CompressMatcherOptions)
+	if err != nil {
+		return nil, fmt.Errorf("compile compress rules: %w", err)
+	}
+	if compress == nil {
+		compress = &compressMatcher{}
+	}
+
+	p := &Packer{compress: compress, opts: opts}
+	size := opts.WriterBufferSize
+	p.writers.New = func() any {
+		return bufio.NewWriterSize(io.Discard, size)
+	}
+	p.opts.packer = p
+
+	return p, nil
+}
+
+// Options returns copy of options with defaults applied.
+func (p *Packer) Options() PackOptions {
+	opts := p.opts
+	opts.packer = nil
+
+	return opts
+}
+
+// Pack writes a PBO to out from inputs, like Pack with packer options.
+func (p *Packer) Pack(ctx context.Context, out io.WriteSeeker, inputs []Input) (*PackResult, error) {
+	return Pack(ctx, out, inputs, p.opts)
+}
+
+// PackFile writes a PBO to outPath and appends SHA1 trailer, like PackFile with packer options.
+func (p *Packer) PackFile(ctx context.Context, outPath string, inputs []Input) (*PackResult, error) {
+	return PackFile(ctx, outPath, inputs, p.opts)
+}
+
+// PackToWriter writes a PBO to non-seekable out, like PackToWriter with packer options.
+func (p *Packer) PackToWriter(ctx context.Context, out io.Writer, inputs []Input) (*PackResult, error) {
+	return PackToWriter(ctx, out, inputs, p.opts)
+}
+
+// compileCompressRules returns compress matcher prepared by Packer or compiles opts rules.
+func (opts *PackOptions) compileCompressRules() (*compressMatcher, error) {
+	if opts.packer != nil {
+		return opts.packer.compress, nil
+	}
+
+	matcher, err := newCompressMatcher(opts.Compress, opts.CompressMatcherOptions)
+	if err != nil {
+		return nil, fmt.Errorf("compile compress rules: %w", err)
+	}
+
+	return matcher, nil
+}
+
+// acquireWriter returns buffered writer over out and release callback, reusing
+// Packer pool for non-default buffer sizes.
+func (opts *PackOptions) acquireWriter(out io.Writer) (*bufio.Writer, func()) {
+	if opts.packer == nil || opts.WriterBufferSize == DefaultWriteBuffer {
+		return acquirePackWriter(out, opts.WriterBufferSize)
+	}
+
+	pool := &opts.packer.writers
+	w := pool.Get().(*bufio.Writer) //nolint:forcetypeassert // pool contains only *bufio.Writer
+	w.Reset(out)
+
+	return w, func() {
+		w.Reset(io.Discard)
+		pool.Put(w)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestPacker_MatchesPackFile(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("class CfgPatches {};\n"), 64)
+	inputs := []Input{
+		{Path: "config.cpp", SizeHint: int64(len(payload)), Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}},
+		{Path: "data/a.paa", Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("texture"))), nil
+		}},
+	}
+	opts := PackOptions{Compress: includeRules("*.cpp"), ZeroTimestamps: true, WriterBufferSize: 8192}
+
+	dir := t.TempDir()
+	wantPath := filepath.Join(dir, "want.pbo")
+	if _, err := PackFile(context.Background(), wantPath, inputs, opts); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	want, err := os.ReadFile(wantPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	packer, err := NewPacker(opts)
+	if err != nil {
+		t.Fatalf("NewPacker: %v", err)
+	}
+	if got := packer.Options(); got.MinCompressSize != DefaultMinCompressSize || got.packer != nil {
+		t.Fatalf("Options=%+v", got)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Go(func() {
+			outPath := filepath.Join(dir, fmt.Sprintf("out%d.pbo", i))
+			res, err := packer.PackFile(context.Background(), outPath, inputs)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			got, err := os.ReadFile(outPath)
+			switch {
+			case err != nil:
+				errs[i] = err
+			case !bytes.Equal(got, want) || res.CompressedEntries != 1:
+				errs[i] = fmt.Errorf("pack %d differs from PackFile output", i)
+			}
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewPacker(PackOptions{PayloadAlignment: -1}); !errors.Is(err, ErrInvalidPayloadAlignment) {
+		t.Fatalf("NewPacker bad alignment err=%v", err)
+	}
+}
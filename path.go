@@ -34,6 +34,23 @@ func NormalizePrefixHeader(raw string) string {
 	return strings.ReplaceAll(normalized, "/", `\`)
 }
 
+// ResolveVirtualPath joins prefix header and entry path into game virtual path with
+// leading "\" ("dz\scripts" + "3_game/x.c" -> "\dz\scripts\3_game\x.c").
+// Empty prefix yields "\" + entry path; empty entry path yields prefix root.
+func ResolveVirtualPath(prefix string, entryPath string) string {
+	prefix = NormalizePrefixHeader(prefix)
+	entryPath = strings.ReplaceAll(NormalizePath(entryPath), "/", `\`)
+
+	switch {
+	case prefix == "":
+		return `\` + entryPath
+	case entryPath == "":
+		return `\` + prefix
+	default:
+		return `\` + prefix + `\` + entryPath
+	}
+}
+
 // normalizePathForMatching normalizes user/input paths for matcher use.
 func normalizePathForMatching(path string) string {
 	path = strings.TrimSpace(path)
@@ -42,12 +59,73 @@ func normalizePathForMatching(path string) string {
 	return path
 }
 
-// normalizeArchiveEntryPath converts input path to canonical archive form with "\" separators.
-func normalizeArchiveEntryPath(raw string) (string, error) {
+// NormalizeEntryPath converts input path to canonical archive form with "\" separators,
+// the form pack and edit store for Input.Path. Paths empty after normalization or
+// containing NUL fail with ErrInvalidEntryPath.
+func NormalizeEntryPath(raw string) (string, error) {
 	normalizedPath := NormalizePath(raw)
-	if normalizedPath == "" {
+	if normalizedPath == "" || strings.ContainsRune(normalizedPath, 0) {
 		return "", fmt.Errorf("%w: %q", ErrInvalidEntryPath, raw)
 	}
 
 	return strings.ReplaceAll(normalizedPath, "/", `\`), nil
 }
+
+// ValidateEntryPath reports whether raw is accepted as Input.Path by pack and edit.
+func ValidateEntryPath(raw string) error {
+	_, err := NormalizeEntryPath(raw)
+	return err
+}
+
+// NormalizeExtractPath converts archive entry path to relative "/"-separated output path
+// used by Extract. Empty, absolute, drive-rooted, NUL-containing, and ".." traversal paths
+// fail with ErrInvalidExtractPath.
+func NormalizeExtractPath(entryPath string) (string, error) {
+	raw := strings.TrimSpace(entryPath)
+	if raw == "" {
+		return "", ErrInvalidExtractPath
+	}
+	if strings.ContainsRune(raw, 0) {
+		return "", ErrInvalidExtractPath
+	}
+	if strings.HasPrefix(raw, `/`) || strings.HasPrefix(raw, `\`) {
+		return "", ErrInvalidExtractPath
+	}
+
+	raw = strings.ReplaceAll(raw, `\`, `/`)
+	if hasWindowsAbsDrivePrefix(raw) {
+		return "", ErrInvalidExtractPath
+	}
+
+	parts := strings.Split(raw, `/`)
+	cleanParts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			return "", ErrInvalidExtractPath
+		default:
+			cleanParts = append(cleanParts, part)
+		}
+	}
+	if len(cleanParts) == 0 {
+		return "", ErrInvalidExtractPath
+	}
+
+	return strings.Join(cleanParts, `/`), nil
+}
+
+// hasWindowsAbsDrivePrefix reports whether path starts with drive-root prefix like C:/.
+func hasWindowsAbsDrivePrefix(path string) bool {
+	if len(path) < 3 {
+		return false
+	}
+
+	return isASCIIAlpha(path[0]) && path[1] == ':' && path[2] == '/'
+}
+
+// isASCIIAlpha reports whether byte is ASCII latin letter.
+func isASCIIAlpha(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -64,29 +64,85 @@ func TestNormalizePrefixHeader(t *testing.T) {
 	}
 }
 
+func TestResolveVirtualPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		prefix string
+		entry  string
+		want   string
+	}{
+		{name: "prefix and entry", prefix: `dz\scripts`, entry: "3_game/x.c", want: `\dz\scripts\3_game\x.c`},
+		{name: "slash prefix", prefix: "/my_mod/data/", entry: `\tex\a.paa`, want: `\my_mod\data\tex\a.paa`},
+		{name: "empty prefix", prefix: "", entry: "config.cpp", want: `\config.cpp`},
+		{name: "empty entry", prefix: "my_mod", entry: "", want: `\my_mod`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ResolveVirtualPath(tc.prefix, tc.entry)
+			if got != tc.want {
+				t.Fatalf("ResolveVirtualPath(%q, %q)=%q, want %q", tc.prefix, tc.entry, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestNormalizeArchiveEntryPath(t *testing.T) {
 	t.Parallel()
 
 	t.Run("valid", func(t *testing.T) {
 		t.Parallel()
 
-		got, err := normalizeArchiveEntryPath(`.\metricz/scripts\5_Mission\config.cpp`)
+		got, err := NormalizeEntryPath(`.\metricz/scripts\5_Mission\config.cpp`)
 		if err != nil {
-			t.Fatalf("normalizeArchiveEntryPath: %v", err)
+			t.Fatalf("NormalizeEntryPath: %v", err)
 		}
 
 		want := `metricz\scripts\5_Mission\config.cpp`
 		if got != want {
-			t.Fatalf("normalizeArchiveEntryPath=%q, want %q", got, want)
+			t.Fatalf("NormalizeEntryPath=%q, want %q", got, want)
 		}
 	})
 
 	t.Run("invalid", func(t *testing.T) {
 		t.Parallel()
 
-		_, err := normalizeArchiveEntryPath("/")
+		_, err := NormalizeEntryPath("/")
 		if !errors.Is(err, ErrInvalidEntryPath) {
 			t.Fatalf("expected ErrInvalidEntryPath, got %v", err)
 		}
 	})
+
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+
+		for _, raw := range []string{"/", "a\x00b.txt"} {
+			if err := ValidateEntryPath(raw); !errors.Is(err, ErrInvalidEntryPath) {
+				t.Fatalf("ValidateEntryPath(%q): expected ErrInvalidEntryPath, got %v", raw, err)
+			}
+		}
+		if err := ValidateEntryPath(`scripts\config.cpp`); err != nil {
+			t.Fatalf("ValidateEntryPath valid: %v", err)
+		}
+	})
+}
+
+func TestNormalizeExtractPath(t *testing.T) {
+	t.Parallel()
+
+	got, err := NormalizeExtractPath(`scripts\./4_World//a.c`)
+	if err != nil || got != "scripts/4_World/a.c" {
+		t.Fatalf("NormalizeExtractPath=%q err=%v", got, err)
+	}
+
+	for _, raw := range []string{"", `\abs`, "C:/x", "a/../../b", "a\x00b"} {
+		if _, err := NormalizeExtractPath(raw); !errors.Is(err, ErrInvalidExtractPath) {
+			t.Fatalf("NormalizeExtractPath(%q): expected ErrInvalidExtractPath, got %v", raw, err)
+		}
+	}
 }
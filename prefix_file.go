@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Source tree files that carry archive prefix by tool convention.
+const (
+	// PrefixFilePBOPrefix is Mikero tools prefix file; may hold key=value header lines.
+	PrefixFilePBOPrefix = "$PBOPREFIX$"
+	// PrefixFilePBOPrefixTxt is $PBOPREFIX$ variant with .txt extension.
+	PrefixFilePBOPrefixTxt = "$PBOPREFIX$.txt"
+	// PrefixFilePrefix is legacy BinPBO prefix file.
+	PrefixFilePrefix = "$PREFIX$"
+	// PrefixFileTxt is plain text prefix file used by some community packers.
+	PrefixFileTxt = "pboprefix.txt"
+)
+
+// prefixFileNames lists prefix file names in lookup priority order.
+var prefixFileNames = []string{
+	PrefixFilePBOPrefix,
+	PrefixFilePBOPrefixTxt,
+	PrefixFilePrefix,
+	PrefixFileTxt,
+}
+
+// PrefixFile is parsed prefix file from source tree root.
+type PrefixFile struct {
+	// Name is file name as found in source directory.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Prefix is normalized "prefix" header value.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Headers are extra key=value headers declared after prefix.
+	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// IsPrefixFileName reports whether name is one of known prefix file names (case-insensitive).
+func IsPrefixFileName(name string) bool {
+	for _, known := range prefixFileNames {
+		if strings.EqualFold(name, known) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParsePrefixFile parses prefix file content. The first bare line is prefix;
+// "key=value" lines are headers, "prefix=" sets prefix. Blank lines and lines
+// starting with "//", ";" or "#" are ignored.
+func ParsePrefixFile(data []byte) (PrefixFile, error) {
+	var pf PrefixFile
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			if pf.Prefix != "" {
+				return PrefixFile{}, fmt.Errorf("%w: unexpected line %q", ErrInvalidPrefixFile, line)
+			}
+
+			key, value = HeaderKeyPrefix, line
+		}
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !strings.EqualFold(key, HeaderKeyPrefix) {
+			if err := validateHeaderPair(key, value); err != nil {
+				return PrefixFile{}, fmt.Errorf("%w: %w", ErrInvalidPrefixFile, err)
+			}
+
+			pf.Headers = append(pf.Headers, HeaderPair{Key: key, Value: value})
+			continue
+		}
+
+		if pf.Prefix != "" {
+			return PrefixFile{}, fmt.Errorf("%w: duplicate prefix", ErrInvalidPrefixFile)
+		}
+
+		pf.Prefix = NormalizePrefixHeader(value)
+		if pf.Prefix == "" {
+			return PrefixFile{}, fmt.Errorf("%w: empty prefix", ErrInvalidPrefixFile)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return PrefixFile{}, fmt.Errorf("%w: %w", ErrInvalidPrefixFile, err)
+	}
+
+	if pf.Prefix == "" {
+		return PrefixFile{}, fmt.Errorf("%w: no prefix", ErrInvalidPrefixFile)
+	}
+
+	return pf, nil
+}
+
+// ReadPrefixFile finds the first known prefix file directly in dir and parses it.
+// It returns nil without error when dir has no prefix file.
+func ReadPrefixFile(dir string) (*PrefixFile, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read source dir: %w", err)
+	}
+
+	for _, known := range prefixFileNames {
+		for _, d := range dirEntries {
+			if !d.Type().IsRegular() || !strings.EqualFold(d.Name(), known) {
+				continue
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, d.Name())) //nolint:gosec // name comes from caller-selected dir listing
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", d.Name(), err)
+			}
+
+			pf, err := ParsePrefixFile(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", d.Name(), err)
+			}
+
+			pf.Name = d.Name()
+			return &pf, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// WritePrefixFile writes pf into dir as pf.Name (default $PBOPREFIX$): prefix
+// line followed by key=value header lines.
+func WritePrefixFile(dir string, pf PrefixFile) error {
+	prefix := NormalizePrefixHeader(pf.Prefix)
+	if prefix == "" {
+		return fmt.Errorf("%w: empty prefix", ErrInvalidPrefixFile)
+	}
+
+	name := pf.Name
+	if name == "" {
+		name = PrefixFilePBOPrefix
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(prefix)
+	buf.WriteString("\r\n")
+	for _, h := range pf.Headers {
+		if err := validateHeaderPair(h.Key, h.Value); err != nil {
+			return err
+		}
+		if strings.EqualFold(strings.TrimSpace(h.Key), HeaderKeyPrefix) || strings.ContainsAny(h.Key+h.Value, "=\r\n") {
+			return fmt.Errorf("%w: %q", ErrInvalidHeaderPair, h.Key)
+		}
+
+		fmt.Fprintf(&buf, "%s=%s\r\n", h.Key, h.Value)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// applyPrefixFile adds prefix file headers missing from opts.Headers, prefix first.
+func applyPrefixFile(opts PackOptions, pf *PrefixFile) PackOptions {
+	if pf == nil {
+		return opts
+	}
+
+	headers := make([]HeaderPair, 0, len(pf.Headers)+len(opts.Headers)+1)
+	for _, h := range append([]HeaderPair{{Key: HeaderKeyPrefix, Value: pf.Prefix}}, pf.Headers...) {
+		if !hasHeaderKey(opts.Headers, h.Key) {
+			headers = append(headers, h)
+		}
+	}
+
+	opts.Headers = append(headers, opts.Headers...)
+	return opts
+}
+
+// hasHeaderKey reports whether headers contain key (case-insensitive).
+func hasHeaderKey(headers []HeaderPair, key string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(strings.TrimSpace(h.Key), strings.TrimSpace(key)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutPrefixFileInputs drops root-level prefix files from inputs.
+func withoutPrefixFileInputs(inputs []Input) []Input {
+	return slices.DeleteFunc(inputs, func(in Input) bool {
+		return !strings.Contains(in.Path, "/") && IsPrefixFileName(in.Path)
+	})
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParsePrefixFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    PrefixFile
+		wantErr bool
+	}{
+		{name: "bare", data: "\xef\xbb\xbfmy_mod/data\r\n", want: PrefixFile{Prefix: `my_mod\data`}},
+		{
+			name: "mikero headers",
+			data: "// comment\nprefix=my_mod\nproduct=dayz\nversion = 1.2\n",
+			want: PrefixFile{Prefix: "my_mod", Headers: []HeaderPair{{Key: "product", Value: "dayz"}, {Key: "version", Value: "1.2"}}},
+		},
+		{name: "bare then header", data: "x\\y\nauthor=me\n", want: PrefixFile{Prefix: `x\y`, Headers: []HeaderPair{{Key: "author", Value: "me"}}}},
+		{name: "empty", data: "\n; nothing\n", wantErr: true},
+		{name: "duplicate", data: "a\nprefix=b\n", wantErr: true},
+		{name: "second bare", data: "a\nb\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePrefixFile([]byte(tt.data))
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPrefixFile) {
+				t.Fatalf("%s: err=%v, want ErrInvalidPrefixFile", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || got.Prefix != tt.want.Prefix || !slices.Equal(got.Headers, tt.want.Headers) {
+			t.Fatalf("%s: got=%+v err=%v, want %+v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestPrefixFile_PackDirAndSyncDir(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "config.cpp"), []byte("class CfgPatches {};"))
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0o750); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeTestFile(t, filepath.Join(src, "sub", PrefixFileTxt), []byte("nested is regular entry"))
+	if err := WritePrefixFile(src, PrefixFile{Prefix: "my_mod/addon", Headers: []HeaderPair{{Key: "product", Value: "dayz"}}}); err != nil {
+		t.Fatalf("WritePrefixFile: %v", err)
+	}
+
+	pf, err := ReadPrefixFile(src)
+	if err != nil || pf == nil || pf.Name != PrefixFilePBOPrefix || pf.Prefix != `my_mod\addon` {
+		t.Fatalf("ReadPrefixFile=%+v err=%v", pf, err)
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	opts := PackOptions{Headers: []HeaderPair{{Key: "product", Value: "arma3"}}}
+	if _, err := PackDir(context.Background(), src, pboPath, opts); err != nil {
+		t.Fatalf("PackDir: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	prefix, product, entries := r.Prefix(), r.Product(), len(r.Entries())
+	_ = r.Close()
+	if prefix != `my_mod\addon` || product != "arma3" || entries != 2 {
+		t.Fatalf("prefix=%q product=%q entries=%d", prefix, product, entries)
+	}
+
+	if err := WritePrefixFile(src, PrefixFile{Prefix: "renamed"}); err != nil {
+		t.Fatalf("WritePrefixFile: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	res, err := editor.SyncDir(src, "", SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncDir: %v", err)
+	}
+	if res.Prefix != "renamed" || len(res.Added)+len(res.Replaced)+len(res.Deleted) != 0 {
+		t.Fatalf("SyncDir result=%+v", res)
+	}
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	r, err = Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if r.Prefix() != "renamed" {
+		t.Fatalf("prefix after sync=%q", r.Prefix())
+	}
+}
@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// Binarized (raP) config entry types.
+const (
+	rapEntryClass       = 0
+	rapEntryValue       = 1
+	rapEntryArray       = 2
+	rapEntryExtern      = 3
+	rapEntryDelete      = 4
+	rapEntryArrayExtend = 5
+)
+
+// Binarized (raP) config array element types.
+const (
+	rapElemString   = 0
+	rapElemFloat    = 1
+	rapElemInt      = 2
+	rapElemArray    = 3
+	rapElemVariable = 4
+	// rapValueInt64 is scalar value type of newer binarizers, not used in arrays.
+	rapValueInt64 = 6
+)
+
+// rapBodyOffset is offset of root class body after signature and three uint32 fields.
+const rapBodyOffset = 16
+
+// rapMaxArrayDepth bounds nested array recursion of malformed configs.
+const rapMaxArrayDepth = 64
+
+// rapDecoder reads binarized config fields from in-memory data.
+type rapDecoder struct {
+	data []byte
+	pos  int
+}
+
+// rapCfgPatches returns CfgPatches classes of binarized config data.
+func rapCfgPatches(data []byte) ([]AddonPatch, error) {
+	if !bytes.HasPrefix(data, rapSignature) || len(data) < rapBodyOffset {
+		return nil, fmt.Errorf("%w: missing raP signature", ErrInvalidConfig)
+	}
+
+	d := &rapDecoder{data: data, pos: rapBodyOffset}
+	var patches []AddonPatch
+	err := d.classBody(func(kind byte, name string) error {
+		if kind != rapEntryClass || !strings.EqualFold(name, "CfgPatches") {
+			return d.skipEntry(kind)
+		}
+
+		return d.classAt(func(body *rapDecoder) error {
+			return body.classBody(func(kind byte, name string) error {
+				if kind != rapEntryClass {
+					return body.skipEntry(kind)
+				}
+
+				patch := AddonPatch{Name: name}
+				err := body.classAt(func(addon *rapDecoder) error {
+					return addon.classBody(func(kind byte, name string) error {
+						if (kind != rapEntryArray && kind != rapEntryArrayExtend) || !strings.EqualFold(name, "requiredAddons") {
+							return addon.skipEntry(kind)
+						}
+
+						var values []string
+						if err := addon.array(0, &values); err != nil {
+							return err
+						}
+
+						patch.RequiredAddons = append(patch.RequiredAddons, values...)
+						return nil
+					})
+				})
+				patches = append(patches, patch)
+				return err
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return patches, nil
+}
+
+// classBody reads inherited class name and entries, calling fn after each entry name.
+// fn must consume remaining entry payload, directly or through skipEntry.
+func (d *rapDecoder) classBody(fn func(kind byte, name string) error) error {
+	if _, err := d.cstring(); err != nil {
+		return err
+	}
+
+	count, err := d.varint()
+	if err != nil {
+		return err
+	}
+
+	for range count {
+		kind, err := d.byte()
+		if err != nil {
+			return err
+		}
+
+		// Value subtype and array extend flag precede entry name.
+		var sub byte
+		switch kind {
+		case rapEntryValue:
+			if sub, err = d.byte(); err != nil {
+				return err
+			}
+		case rapEntryArrayExtend:
+			if _, err := d.uint32(); err != nil {
+				return err
+			}
+		}
+
+		name, err := d.cstring()
+		if err != nil {
+			return err
+		}
+
+		if kind == rapEntryValue {
+			if err := d.value(sub); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(kind, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipEntry consumes payload of class, array, extern, or delete entry after its name.
+func (d *rapDecoder) skipEntry(kind byte) error {
+	switch kind {
+	case rapEntryClass:
+		_, err := d.uint32()
+		return err
+	case rapEntryArray, rapEntryArrayExtend:
+		return d.array(0, nil)
+	case rapEntryExtern, rapEntryDelete:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown raP entry type %d at %d", ErrInvalidConfig, kind, d.pos)
+	}
+}
+
+// classAt reads class body offset and runs fn with decoder positioned at body.
+func (d *rapDecoder) classAt(fn func(body *rapDecoder) error) error {
+	offset, err := d.uint32()
+	if err != nil {
+		return err
+	}
+	if int64(offset) < rapBodyOffset || int64(offset) >= int64(len(d.data)) {
+		return fmt.Errorf("%w: raP class offset %d out of range", ErrInvalidConfig, offset)
+	}
+
+	return fn(&rapDecoder{data: d.data, pos: int(offset)})
+}
+
+// array reads array elements, appending string and variable elements to out when not nil.
+func (d *rapDecoder) array(depth int, out *[]string) error {
+	if depth > rapMaxArrayDepth {
+		return fmt.Errorf("%w: raP array nesting too deep", ErrInvalidConfig)
+	}
+
+	count, err := d.varint()
+	if err != nil {
+		return err
+	}
+
+	for range count {
+		kind, err := d.byte()
+		if err != nil {
+			return err
+		}
+
+		switch kind {
+		case rapElemString, rapElemVariable:
+			s, err := d.cstring()
+			if err != nil {
+				return err
+			}
+			if out != nil {
+				*out = append(*out, s)
+			}
+		case rapElemFloat, rapElemInt:
+			if _, err := d.uint32(); err != nil {
+				return err
+			}
+		case rapElemArray:
+			if err := d.array(depth+1, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: unknown raP array element type %d at %d", ErrInvalidConfig, kind, d.pos)
+		}
+	}
+
+	return nil
+}
+
+// value consumes scalar value of subtype.
+func (d *rapDecoder) value(sub byte) error {
+	switch sub {
+	case rapElemString, rapElemVariable:
+		_, err := d.cstring()
+		return err
+	case rapElemFloat, rapElemInt:
+		_, err := d.uint32()
+		return err
+	case rapValueInt64:
+		return d.skip(8)
+	default:
+		return fmt.Errorf("%w: unknown raP value type %d at %d", ErrInvalidConfig, sub, d.pos)
+	}
+}
+
+// byte reads one byte.
+func (d *rapDecoder) byte() (byte, error) {
+	if err := d.need(1); err != nil {
+		return 0, err
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+// uint32 reads little-endian uint32.
+func (d *rapDecoder) uint32() (uint32, error) {
+	if err := d.need(4); err != nil {
+		return 0, err
+	}
+
+	v := binary.LittleEndian.Uint32(d.data[d.pos:])
+	d.pos += 4
+	return v, nil
+}
+
+// varint reads 7-bit compressed integer of entry and element counts.
+func (d *rapDecoder) varint() (int, error) {
+	var v int
+	for shift := 0; shift < 32; shift += 7 {
+		b, err := d.byte()
+		if err != nil {
+			return 0, err
+		}
+
+		v |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: raP compressed integer overflow at %d", ErrInvalidConfig, d.pos)
+}
+
+// cstring reads NUL-terminated string.
+func (d *rapDecoder) cstring() (string, error) {
+	end := bytes.IndexByte(d.data[min(d.pos, len(d.data)):], 0)
+	if end < 0 {
+		return "", fmt.Errorf("%w: unterminated raP string at %d", ErrInvalidConfig, d.pos)
+	}
+
+	s := string(d.data[d.pos : d.pos+end])
+	d.pos += end + 1
+	return s, nil
+}
+
+// skip advances over n bytes.
+func (d *rapDecoder) skip(n int) error {
+	if err := d.need(n); err != nil {
+		return err
+	}
+
+	d.pos += n
+	return nil
+}
+
+// need checks that n more bytes are available.
+func (d *rapDecoder) need(n int) error {
+	if d.pos+n > len(d.data) {
+		return fmt.Errorf("%w: truncated raP data at %d", ErrInvalidConfig, d.pos)
+	}
+
+	return nil
+}
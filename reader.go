@@ -11,9 +11,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
 	"math"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -33,6 +37,12 @@ var (
 )
 
 // Reader provides read-only access to a parsed PBO file.
+//
+// Reader is safe for concurrent use: all read methods may be called from multiple
+// goroutines sharing one source handle through ReadAt. Compressed streams are decoded
+// by at most ReaderOptions.MaxDecompressStreams background goroutines; streams opened
+// while all slots are busy are decoded incrementally in the reading goroutine. Close must not
+// race with reads; use Clone for goroutines that need an independently closed handle.
 type Reader struct {
 	// ra is the underlying random-access reader used for payload reads.
 	ra io.ReaderAt
@@ -54,10 +64,40 @@ type Reader struct {
 	entryIndexOnce sync.Once
 	// mu guards closed state and close operation.
 	mu sync.Mutex
+	// entryKey is key passed to registered entry codecs.
+	entryKey []byte
+	// decompressSem bounds background decompression goroutines; shared with clones.
+	decompressSem chan struct{}
+	// maxTotal is ReaderLimits.MaxTotalDecompressed; zero means unlimited.
+	maxTotal int64
+	// decompressed counts bytes produced by compressed and codec-decoded entries.
+	decompressed atomic.Int64
+	// maxDecompressed is ReaderOptions.MaxEntryDecompressedSize; zero means unlimited.
+	maxDecompressed uint32
+	// recovery is entry table recovery report when RecoverMode salvaged entries.
+	recovery *RecoveryReport
+	// hidden lists entries removed by reader filters.
+	hidden []HiddenEntry
+	// diagnostics are non-fatal parse observations.
+	diagnostics []ParseIssue
 	// sha1Trailer stores optional trailer hash when present.
 	sha1Trailer [shaSize]byte
 	// hasTrailer reports whether trailing 0x00 + SHA1 was detected.
 	hasTrailer bool
+	// data is archive content when Reader was created by NewReaderFromBytes.
+	data []byte
+	// lazy holds options for deferred entry table parse (ReaderOptions.LazyEntries).
+	lazy *ReaderOptions
+	// lazyErr is deferred entry table parse error.
+	lazyErr error
+	// tableOffset is absolute offset of entry table.
+	tableOffset int64
+	// lazyOnce runs deferred entry table parse.
+	lazyOnce sync.Once
+	// decrypted reports that ra is plain view returned by registered ArchiveDecryptor.
+	decrypted bool
+	// closer is non-file source owned through NewReaderOwned.
+	closer io.Closer
 	// closed reports whether Close was already called.
 	closed bool
 }
@@ -100,6 +140,35 @@ func OpenWithOptions(path string, opts ReaderOptions) (*Reader, error) {
 	return r, nil
 }
 
+// ReadAtCloser is random-access source Reader can own.
+type ReadAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// NewReaderOwned parses PBO from rac and makes Reader own it: Close closes rac, and rac
+// is closed when parsing fails. *os.File sources also let Clone open independent handles.
+// Readers from NewReaderFromReaderAt never close their source.
+func NewReaderOwned(rac ReadAtCloser, size int64, opts ReaderOptions) (*Reader, error) {
+	if rac == nil {
+		return nil, ErrReaderAtRequired
+	}
+
+	r, err := NewReaderFromReaderAtWithOptions(rac, size, opts)
+	if err != nil {
+		_ = rac.Close()
+		return nil, err
+	}
+
+	if f, ok := rac.(*os.File); ok {
+		r.file = f
+	} else {
+		r.closer = rac
+	}
+
+	return r, nil
+}
+
 // NewReaderFromReaderAt parses PBO from existing ReaderAt and known size.
 func NewReaderFromReaderAt(ra io.ReaderAt, size int64) (*Reader, error) {
 	return NewReaderFromReaderAtWithOptions(ra, size, ReaderOptions{})
@@ -114,11 +183,43 @@ func NewReaderFromReaderAtWithOptions(ra io.ReaderAt, size int64, opts ReaderOpt
 		return nil, err
 	}
 
-	r := &Reader{ra: readerAt, size: size}
-	if err := r.parse(readerAt, size, opts); err != nil {
+	return withArchiveDecryptor(readerAt, size, opts.EntryKey, func(ra io.ReaderAt, size int64, decrypted bool) (*Reader, error) {
+		r := &Reader{
+			ra:              ra,
+			size:            size,
+			entryKey:        opts.EntryKey,
+			decompressSem:   newDecompressSem(opts.MaxDecompressStreams),
+			maxDecompressed: opts.MaxEntryDecompressedSize,
+			maxTotal:        opts.Limits.MaxTotalDecompressed,
+			decrypted:       decrypted,
+		}
+		if err := r.parse(ra, size, opts); err != nil {
+			return nil, err
+		}
+
+		if opts.BuildIndex && r.lazy == nil {
+			r.entryIndexOnce.Do(r.buildEntryIndex)
+		}
+
+		return r, nil
+	})
+}
+
+// NewReaderFromBytes creates Reader over in-memory archive bytes. Compressed entries
+// of plain archives are decoded directly from b instead of background pipe streams.
+// b must not be modified while Reader is in use.
+func NewReaderFromBytes(b []byte, opts ReaderOptions) (*Reader, error) {
+	br := bytes.NewReader(b)
+	r, err := NewReaderFromReaderAtWithOptions(br, int64(len(b)), opts)
+	if err != nil {
 		return nil, err
 	}
 
+	// Sealed and decrypted archives read through transforming wrapper; keep stream path there.
+	if r.ra == io.ReaderAt(br) {
+		r.data = b
+	}
+
 	return r, nil
 }
 
@@ -128,11 +229,52 @@ func (r *Reader) Entries() []EntryInfo {
 		return nil
 	}
 
-	entries := make([]EntryInfo, len(r.entries))
-	copy(entries, r.entries)
+	loaded := r.loadedEntries()
+	entries := make([]EntryInfo, len(loaded))
+	copy(entries, loaded)
+	return entries
+}
+
+// VirtualEntries returns a copy of parsed entries with Path resolved by ResolveVirtualPath
+// against archive prefix header ("\dz\scripts\3_game\x.c").
+func (r *Reader) VirtualEntries() []EntryInfo {
+	if r == nil {
+		return nil
+	}
+
+	prefix := r.Prefix()
+	entries := r.Entries()
+	for i := range entries {
+		entries[i].Path = ResolveVirtualPath(prefix, entries[i].Path)
+	}
+
 	return entries
 }
 
+// EntriesIter returns iterator over parsed entries without copying entry slice.
+func (r *Reader) EntriesIter() iter.Seq[EntryInfo] {
+	return func(yield func(EntryInfo) bool) {
+		if r == nil {
+			return
+		}
+
+		for _, entry := range r.loadedEntries() {
+			if !yield(entry) {
+				return
+			}
+		}
+	}
+}
+
+// EntryCount returns number of parsed entries.
+func (r *Reader) EntryCount() int {
+	if r == nil {
+		return 0
+	}
+
+	return len(r.loadedEntries())
+}
+
 // Headers returns parsed headers in original order.
 func (r *Reader) Headers() []HeaderPair {
 	if r == nil {
@@ -148,6 +290,22 @@ func (r *Reader) Headers() []HeaderPair {
 	return out
 }
 
+// HeaderValues returns values of all headers with key (case-insensitive) in archive order.
+func (r *Reader) HeaderValues(key string) []string {
+	if r == nil {
+		return nil
+	}
+
+	var values []string
+	for _, h := range r.headers {
+		if strings.EqualFold(h.Key, key) {
+			values = append(values, h.Value)
+		}
+	}
+
+	return values
+}
+
 // Close closes the underlying file if reader owns one.
 func (r *Reader) Close() error {
 	r.mu.Lock()
@@ -161,6 +319,9 @@ func (r *Reader) Close() error {
 	if r.file != nil {
 		return r.file.Close()
 	}
+	if r.closer != nil {
+		return r.closer.Close()
+	}
 
 	return nil
 }
@@ -177,51 +338,95 @@ func (r *Reader) SHA1Trailer() ([20]byte, bool) {
 
 // parse reads and validates PBO structure from ReaderAt.
 func (r *Reader) parse(ra io.ReaderAt, size int64, opts ReaderOptions) error {
-	header, headers, off, err := parseHeaderSection(ra)
+	header, headers, off, err := parseHeaderSectionLimited(ra, opts.Limits.MaxHeaderPairs)
 	if err != nil {
 		return err
 	}
 	r.header = header
 	r.headers = headers
+	r.tableOffset = off
+
+	if opts.LazyEntries {
+		r.lazy = &opts
+	} else if err := r.parseEntries(ra, size, off, opts); err != nil {
+		return err
+	}
 
+	// check for SHA1 trailer
+	if size >= 21 {
+		var tail [21]byte
+		if _, err := ra.ReadAt(tail[:], size-21); err == nil && tail[0] == 0x00 {
+			r.hasTrailer = true
+			copy(r.sha1Trailer[:], tail[1:21])
+		}
+	}
+	if !r.hasTrailer {
+		r.addIssue(ParseIssueTrailerMissing, "", "no 0x00 + SHA1 trailer")
+		opts.logger().Debug("sha1 trailer not found")
+	}
+
+	return nil
+}
+
+// parseEntries parses entry table at off and applies reader entry filters.
+func (r *Reader) parseEntries(ra io.ReaderAt, size int64, off int64, opts ReaderOptions) error {
 	// Parse entry table with sequential buffered reads to reduce ReadAt syscall overhead.
-	entriesEnd, err := r.parseEntriesBuffered(ra, off, size)
+	// OffsetMode defines how payload offsets are resolved:
+	// sequential from payload start, stored offsets, or strict stored validation.
+	// RecoverMode rescans malformed tables for plausible records instead of failing.
+	entriesEnd, err := r.parseEntryTable(ra, off, size, opts)
 	if err != nil {
 		return err
 	}
 	r.dataStart = entriesEnd
+	r.collectEntryIssues()
 
-	// OffsetMode defines how payload offsets are resolved:
-	// sequential from payload start, stored offsets, or strict stored validation.
-	if err := resolveEntryOffsets(r.entries, entriesEnd, size, opts.OffsetMode); err != nil {
+	// NameEncoding and UnicodeForm run first so filters and lookups see normalized names.
+	if err := decodeEntryNames(r.entries, opts.NameEncoding); err != nil {
+		return err
+	}
+	if err := normalizeEntryUnicode(r.entries, opts.UnicodeForm); err != nil {
 		return err
 	}
 
 	// EnableJunkFilter drops clearly unusable table rows:
 	// zero-size entries, broken compressed rows, and unsafe/raw invalid paths.
-	if opts.EnableJunkFilter {
-		r.entries = filterJunkEntries(r.entries)
+	// JunkFilter adds custom predicate on top.
+	if policy := opts.junkFilter(); policy != nil {
+		r.entries = filterJunkEntries(r.entries, policy, opts.logger(), &r.hidden)
 	}
 
 	// MinEntryOriginalSize/MinEntryDataSize keep only entries above size thresholds.
 	// This removes tiny noise blobs before heavier path processing.
-	r.entries = filterEntriesBySize(r.entries, opts.MinEntryOriginalSize, opts.MinEntryDataSize)
+	r.entries = filterEntriesBySize(r.entries, opts.MinEntryOriginalSize, opts.MinEntryDataSize, &r.hidden)
+
+	// MimeTypes and ModifiedAfter/ModifiedBefore keep entries of listed storage kinds
+	// and timestamp window, for bulk scans and incremental syncs.
+	r.entries = filterEntriesByMime(r.entries, opts.MimeTypes, &r.hidden)
+	r.entries = filterEntriesByTime(r.entries, opts.ModifiedAfter, opts.ModifiedBefore, &r.hidden)
 
 	// FilterASCIIOnly keeps only ASCII-path entries.
 	// Useful for quickly excluding heavily obfuscated unicode paths.
 	if opts.FilterASCIIOnly {
-		r.entries = filterEntriesByASCIIOnly(r.entries)
+		r.entries = filterEntriesByASCIIOnly(r.entries, &r.hidden)
 	}
 
 	// EntryPathPrefix applies path scoping after cheap filters:
 	// with SanitizeNames=true we match in sanitized namespace so user-provided
 	// normalized prefixes still match mangled/raw archive names.
 	if opts.SanitizeNames {
-		r.entries = filterEntriesBySanitizedPrefix(r.entries, opts.EntryPathPrefix)
+		r.entries = filterEntriesBySanitizedPrefix(r.entries, opts.EntryPathPrefix, &r.hidden)
 	} else {
-		r.entries = filterEntriesByPrefix(r.entries, opts.EntryPathPrefix)
+		r.entries = filterEntriesByPrefix(r.entries, opts.EntryPathPrefix, &r.hidden)
 	}
 
+	// EntryRules and EntryPattern narrow entries by glob rules and regexp on normalized path.
+	entries, err := filterEntriesByPath(r.entries, opts.EntryRules, opts.EntryPattern, &r.hidden)
+	if err != nil {
+		return err
+	}
+	r.entries = entries
+
 	// SanitizeControlChars rewrites C0/C1 and format runes in path text.
 	// This prevents terminal/control-sequence injection in listing output.
 	if opts.SanitizeControlChars {
@@ -244,20 +449,16 @@ func (r *Reader) parse(ra io.ReaderAt, size int64, opts ReaderOptions) error {
 		r.entries = sanitizedEntries
 	}
 
-	// check for SHA1 trailer
-	if size >= 21 {
-		var tail [21]byte
-		if _, err := ra.ReadAt(tail[:], size-21); err == nil && tail[0] == 0x00 {
-			r.hasTrailer = true
-			copy(r.sha1Trailer[:], tail[1:21])
-		}
-	}
-
 	return nil
 }
 
 // parseHeaderSection parses fixed header and key-value header pairs and returns entry table offset.
 func parseHeaderSection(ra io.ReaderAt) ([]byte, []headerPair, int64, error) {
+	return parseHeaderSectionLimited(ra, 0)
+}
+
+// parseHeaderSectionLimited is parseHeaderSection failing with LimitError past maxPairs (zero = unlimited).
+func parseHeaderSectionLimited(ra io.ReaderAt, maxPairs int) ([]byte, []headerPair, int64, error) {
 	header := make([]byte, headerSize)
 	if _, err := ra.ReadAt(header, 0); err != nil {
 		if err == io.EOF {
@@ -267,8 +468,12 @@ func parseHeaderSection(ra io.ReaderAt) ([]byte, []headerPair, int64, error) {
 		return nil, nil, 0, fmt.Errorf("read header: %w", err)
 	}
 
-	// The first directory entry must be "Vers".
-	if MimeType(binary.LittleEndian.Uint32(header[1:5])) != MimeHeader {
+	// The first directory entry must be "Vers"; "Enco" marks encrypted bank.
+	switch MimeType(binary.LittleEndian.Uint32(header[1:5])) {
+	case MimeHeader:
+	case MimeEncoded:
+		return nil, nil, 0, &EncryptedArchiveError{Kind: EncryptionEnco}
+	default:
 		return nil, nil, 0, ErrInvalidHeader
 	}
 
@@ -292,13 +497,23 @@ func parseHeaderSection(ra io.ReaderAt) ([]byte, []headerPair, int64, error) {
 
 		off += int64(n)
 		headers = append(headers, headerPair{Key: key, Value: value})
+		if err := checkLimit(LimitMaxHeaderPairs, int64(maxPairs), int64(len(headers))); err != nil {
+			return nil, nil, 0, err
+		}
 	}
 
 	return header, headers, off, nil
 }
 
 // parseEntriesBuffered parses entry records from index table and returns payload start offset.
-func (r *Reader) parseEntriesBuffered(ra io.ReaderAt, tableOffset int64, size int64) (int64, error) {
+// With internPaths entry paths are sliced from one string holding all names.
+func (r *Reader) parseEntriesBuffered(
+	ra io.ReaderAt,
+	tableOffset int64,
+	size int64,
+	limits ReaderLimits,
+	internPaths bool,
+) (int64, error) {
 	if tableOffset >= size {
 		return 0, fmt.Errorf("read entry filename: %w", io.EOF)
 	}
@@ -315,14 +530,26 @@ func (r *Reader) parseEntriesBuffered(ra io.ReaderAt, tableOffset int64, size in
 		r.entries = make([]EntryInfo, 0, estimatedCap)
 	}
 
+	var (
+		// fields is shared across records; io.ReadFull makes it escape to heap.
+		fields [20]byte
+		// arena collects names of pending entries and nameEnds their end offsets
+		// when internPaths is set; full arena is flushed into one string.
+		arena    []byte
+		nameEnds []int
+	)
+	if internPaths {
+		arena = make([]byte, 0, pathArenaSize)
+	}
+	pending := len(r.entries)
+
 	for {
-		filename, nameBytes, err := readNullTerminatedBuffered(br, &spill)
+		name, nameBytes, err := readNullTerminatedBytes(br, &spill)
 		if err != nil {
 			return 0, fmt.Errorf("read entry filename: %w", err)
 		}
 
 		off += int64(nameBytes)
-		var fields [20]byte
 		if _, err := io.ReadFull(br, fields[:]); err != nil {
 			return 0, fmt.Errorf("read entry fields: %w", err)
 		}
@@ -334,13 +561,34 @@ func (r *Reader) parseEntriesBuffered(ra io.ReaderAt, tableOffset int64, size in
 		timestamp := binary.LittleEndian.Uint32(fields[12:16])
 		dataSize := binary.LittleEndian.Uint32(fields[16:20])
 
-		if filename == "" && mimeType == 0 && originalSize == 0 && offset == 0 && timestamp == 0 && dataSize == 0 {
+		if len(name) == 0 && mimeType == 0 && originalSize == 0 && offset == 0 && timestamp == 0 && dataSize == 0 {
+			if internPaths {
+				sliceEntryPaths(r.entries[pending:], string(arena), nameEnds)
+			}
+
 			return off, nil
 		}
 
-		if len(filename) > maxNameLen {
+		if len(name) > maxNameLen {
 			return 0, ErrFileNameTooLong
 		}
+		if err := limits.checkTable(len(r.entries)+1, off-tableOffset); err != nil {
+			return 0, err
+		}
+
+		var filename string
+		if internPaths {
+			if len(arena)+len(name) > cap(arena) {
+				sliceEntryPaths(r.entries[pending:], string(arena), nameEnds)
+				arena, nameEnds = arena[:0], nameEnds[:0]
+				pending = len(r.entries)
+			}
+
+			arena = append(arena, name...)
+			nameEnds = append(nameEnds, len(arena))
+		} else {
+			filename = string(name)
+		}
 
 		r.entries = append(r.entries, EntryInfo{
 			Path:         filename,
@@ -349,10 +597,23 @@ func (r *Reader) parseEntriesBuffered(ra io.ReaderAt, tableOffset int64, size in
 			OriginalSize: originalSize,
 			TimeStamp:    timestamp,
 			MimeType:     mimeType,
+			Reserved:     offset,
 		})
 	}
 }
 
+// pathArenaSize is name block size used by ReaderOptions.InternPaths.
+const pathArenaSize = 64 * 1024
+
+// sliceEntryPaths assigns entry paths as consecutive substrings of names ending at nameEnds.
+func sliceEntryPaths(entries []EntryInfo, names string, nameEnds []int) {
+	start := 0
+	for i, end := range nameEnds {
+		entries[i].Path = names[start:end]
+		start = end
+	}
+}
+
 // estimateEntryCapacity returns a conservative initial capacity for parsed entry metadata.
 func estimateEntryCapacity(remainingBytes int64) int {
 	if remainingBytes <= 0 {
@@ -378,43 +639,51 @@ func estimateEntryCapacity(remainingBytes int64) int {
 }
 
 // resolveEntryOffsets applies selected offset policy and validates payload bounds.
-func resolveEntryOffsets(entries []EntryInfo, dataStart int64, totalSize int64, mode OffsetMode) error {
+// It reports whether stored index offsets were applied.
+func resolveEntryOffsets(
+	entries []EntryInfo,
+	dataStart int64,
+	totalSize int64,
+	mode OffsetMode,
+	logger *slog.Logger,
+) (bool, error) {
+	var (
+		usedStored bool
+		err        error
+	)
 	switch mode {
 	case OffsetModeSequential:
-		if err := assignSequentialOffsets(entries, dataStart); err != nil {
-			return err
-		}
 	case OffsetModeStoredCompat:
-		usedStored, err := tryAssignStoredOffsets(entries, dataStart, totalSize)
-		if err != nil {
-			if err := assignSequentialOffsets(entries, dataStart); err != nil {
-				return err
-			}
-		}
-		if !usedStored {
-			if err := assignSequentialOffsets(entries, dataStart); err != nil {
-				return err
-			}
+		usedStored, err = tryAssignStoredOffsets(entries, dataStart, totalSize)
+		switch {
+		case err != nil:
+			usedStored = false
+			logger.Info("stored offsets rejected, using sequential offsets", "error", err)
+		case usedStored:
+			logger.Debug("using stored offsets", "entries", len(entries))
+		default:
+			logger.Debug("no stored offsets, using sequential offsets", "entries", len(entries))
 		}
 	case OffsetModeStoredStrict:
-		usedStored, err := tryAssignStoredOffsets(entries, dataStart, totalSize)
+		usedStored, err = tryAssignStoredOffsets(entries, dataStart, totalSize)
 		if err != nil {
-			return fmt.Errorf("%w: %w", ErrInvalidEntryOffset, err)
-		}
-		if !usedStored {
-			if err := assignSequentialOffsets(entries, dataStart); err != nil {
-				return err
-			}
+			return false, fmt.Errorf("%w: %w", ErrInvalidEntryOffset, err)
 		}
 	default:
-		return fmt.Errorf("%w: unknown offset mode %q", ErrInvalidEntryOffset, mode)
+		return false, fmt.Errorf("%w: unknown offset mode %q", ErrInvalidEntryOffset, mode)
+	}
+
+	if !usedStored {
+		if err := assignSequentialOffsets(entries, dataStart); err != nil {
+			return false, err
+		}
 	}
 
 	if err := validateResolvedOffsets(entries, dataStart, totalSize); err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return usedStored, nil
 }
 
 // assignSequentialOffsets derives payload offsets from dataStart and previous entry sizes.
@@ -484,6 +753,9 @@ func assignStoredOffsets(entries []EntryInfo, dataStart int64, totalSize int64,
 		adjust = 0
 	}
 
+	// shared maps resolved offsets to payload sizes so deduplicated records may point back.
+	shared := make(map[int64]uint32, len(entries))
+
 	for i := range entries {
 		raw := int64(entries[i].Offset)
 		resolved := raw + adjust
@@ -494,7 +766,12 @@ func assignStoredOffsets(entries []EntryInfo, dataStart int64, totalSize int64,
 			return fmt.Errorf("entry %s offset out of range", entries[i].Path)
 		}
 		if resolved < prev {
-			return fmt.Errorf("entry %s offset is not monotonic", entries[i].Path)
+			if size, ok := shared[resolved]; !ok || size != entries[i].DataSize {
+				return fmt.Errorf("entry %s offset is not monotonic", entries[i].Path)
+			}
+
+			entries[i].Offset = uint32(resolved) //nolint:gosec // equals earlier validated offset
+			continue
 		}
 
 		end := resolved + int64(entries[i].DataSize)
@@ -503,6 +780,7 @@ func assignStoredOffsets(entries []EntryInfo, dataStart int64, totalSize int64,
 		}
 
 		entries[i].Offset = uint32(resolved) //nolint:gosec // bounded by range check above
+		shared[resolved] = entries[i].DataSize
 		prev = resolved
 	}
 
@@ -526,8 +804,9 @@ func validateResolvedOffsets(entries []EntryInfo, dataStart int64, totalSize int
 	return nil
 }
 
-// readNullTerminatedBuffered reads a NUL-terminated string from buffered stream.
-func readNullTerminatedBuffered(br *bufio.Reader, spill *[]byte) (string, int, error) {
+// readNullTerminatedBytes reads a NUL-terminated name from buffered stream.
+// Returned bytes are valid until next read from br or spill reuse.
+func readNullTerminatedBytes(br *bufio.Reader, spill *[]byte) ([]byte, int, error) {
 	consumed := 0
 	*spill = (*spill)[:0]
 
@@ -541,16 +820,16 @@ func readNullTerminatedBuffered(br *bufio.Reader, spill *[]byte) (string, int, e
 		}
 
 		if err != nil {
-			return "", 0, err
+			return nil, 0, err
 		}
 
 		segment := chunk[:len(chunk)-1]
 		if len(*spill) == 0 {
-			return string(segment), consumed, nil
+			return segment, consumed, nil
 		}
 
 		*spill = append(*spill, segment...)
-		return string(*spill), consumed, nil
+		return *spill, consumed, nil
 	}
 }
 
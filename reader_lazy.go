@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+// LoadEntries parses entry table of reader opened with ReaderOptions.LazyEntries and
+// returns parse error. It is no-op for eagerly parsed readers and safe for concurrent use;
+// entry accessors call it implicitly and see no entries when it fails.
+func (r *Reader) LoadEntries() error {
+	if r == nil {
+		return ErrNilReader
+	}
+	if r.lazy == nil {
+		return nil
+	}
+
+	r.lazyOnce.Do(func() {
+		r.lazyErr = r.parseEntries(r.ra, r.size, r.tableOffset, *r.lazy)
+		if r.lazyErr != nil {
+			r.entries = nil
+			return
+		}
+
+		if r.lazy.BuildIndex {
+			r.entryIndexOnce.Do(r.buildEntryIndex)
+		}
+	})
+
+	return r.lazyErr
+}
+
+// loadedEntries returns parsed entries, running deferred parse first; nil when it failed.
+func (r *Reader) loadedEntries() []EntryInfo {
+	if err := r.LoadEntries(); err != nil {
+		return nil
+	}
+
+	return r.entries
+}
@@ -5,11 +5,16 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestOpen_InvalidHeader(t *testing.T) {
@@ -41,6 +46,166 @@ func TestOpen_EmptyFile(t *testing.T) {
 	}
 }
 
+func TestReader_EntriesIterMatchesEntries(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "iter.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": []byte("a"),
+		"b.txt": []byte("b"),
+		"c.txt": []byte("c"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if r.EntryCount() != len(entries) {
+		t.Fatalf("EntryCount=%d, want %d", r.EntryCount(), len(entries))
+	}
+
+	i := 0
+	for entry := range r.EntriesIter() {
+		if entry != entries[i] {
+			t.Fatalf("iter entry %d=%+v, want %+v", i, entry, entries[i])
+		}
+		i++
+		if i == 2 {
+			break
+		}
+	}
+	if i != 2 {
+		t.Fatalf("iterator stopped after %d entries, want early break at 2", i)
+	}
+
+	var nilReader *Reader
+	for range nilReader.EntriesIter() {
+		t.Fatal("nil reader iterator yielded entry")
+	}
+	if nilReader.EntryCount() != 0 {
+		t.Fatal("nil reader EntryCount != 0")
+	}
+}
+
+func TestOpenWithOptions_BuildIndexEagerly(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "index.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"dir/a.txt": []byte("alpha"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{BuildIndex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if len(r.entryIndex) != 1 {
+		t.Fatalf("entry index size=%d, want 1 before first lookup", len(r.entryIndex))
+	}
+
+	got, err := r.ReadEntry("dir/a.txt")
+	if err != nil || string(got) != "alpha" {
+		t.Fatalf("ReadEntry=%q err=%v", got, err)
+	}
+}
+
+func TestOpenWithOptions_LazyEntries(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "lazy.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("alpha"), "b.txt": []byte("beta")}, PackOptions{
+		Headers: []HeaderPair{{Key: "prefix", Value: "lazy"}},
+	}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{LazyEntries: true, BuildIndex: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.entries != nil || r.Prefix() != "lazy" {
+		t.Fatalf("entries parsed eagerly or prefix=%q", r.Prefix())
+	}
+	if _, ok := r.SHA1Trailer(); !ok {
+		t.Fatal("SHA1 trailer not detected")
+	}
+	if data, err := r.ReadEntry("b.txt"); err != nil || string(data) != "beta" {
+		t.Fatalf("ReadEntry=%q err=%v", data, err)
+	}
+	if r.EntryCount() != 2 || len(r.entryIndex) != 2 {
+		t.Fatalf("EntryCount=%d index=%d", r.EntryCount(), len(r.entryIndex))
+	}
+
+	data, err := os.ReadFile(pboPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	brokenPath := filepath.Join(t.TempDir(), "broken.pbo")
+	writeTestFile(t, brokenPath, data[:r.tableOffset+3])
+
+	broken, err := OpenWithOptions(brokenPath, ReaderOptions{LazyEntries: true})
+	if err != nil {
+		t.Fatalf("lazy open of broken table: %v", err)
+	}
+	defer func() { _ = broken.Close() }()
+
+	if broken.Prefix() != "lazy" {
+		t.Fatalf("broken prefix=%q", broken.Prefix())
+	}
+	loadErr := broken.LoadEntries()
+	if loadErr == nil || len(broken.Entries()) != 0 {
+		t.Fatalf("LoadEntries err=%v entries=%v", loadErr, broken.Entries())
+	}
+	if _, err := broken.ReadEntry("a.txt"); !errors.Is(err, loadErr) {
+		t.Fatalf("ReadEntry err=%v, want %v", err, loadErr)
+	}
+}
+
+func TestOpenWithOptions_InternPathsMatchesDefault(t *testing.T) {
+	t.Parallel()
+
+	// Enough long names to span several path arena blocks.
+	files := make(map[string][]byte, 2000)
+	for i := range 2000 {
+		files[fmt.Sprintf("addons/some_long_directory_name/file_%05d.paa", i)] = []byte{byte(i)}
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "intern.pbo")
+	if err := createTestPBO(pboPath, files, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	want, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = want.Close() }()
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{InternPaths: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if !slices.Equal(r.Entries(), want.Entries()) {
+		t.Fatalf("interned entries differ from default parse")
+	}
+	if data, err := r.ReadEntry("addons/some_long_directory_name/file_01999.paa"); err != nil || !bytes.Equal(data, []byte{byte(1999 % 256)}) {
+		t.Fatalf("ReadEntry=%v err=%v", data, err)
+	}
+}
+
 func TestOpenWithOptions_StoredOffsetCompatReadsGappedPayload(t *testing.T) {
 	t.Parallel()
 
@@ -176,6 +341,271 @@ func TestOpenWithOptions_JunkFilter(t *testing.T) {
 	}
 }
 
+func TestOpenWithOptions_JunkFilterPolicy(t *testing.T) {
+	t.Parallel()
+
+	path := createManualPBOWithJunkEntries(t)
+
+	r, err := OpenWithOptions(path, ReaderOptions{EnableJunkFilter: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	reasons := make(map[string]string)
+	for _, junk := range r.JunkEntries() {
+		reasons[junk.Entry.Path] = junk.Reason
+	}
+	_ = r.Close()
+
+	want := map[string]string{
+		"zero.bin":    "zero data size",
+		"badcprs.bin": "compressed entry without original size",
+		"../evil.txt": "invalid path",
+	}
+	if len(reasons) != len(want) {
+		t.Fatalf("junk entries = %v, want %v", reasons, want)
+	}
+	for name, reason := range want {
+		if reasons[name] != reason {
+			t.Fatalf("junk %s reason = %q, want %q", name, reasons[name], reason)
+		}
+	}
+
+	r, err = OpenWithOptions(path, ReaderOptions{JunkFilter: &JunkFilter{
+		Predicate: func(entry EntryInfo) string {
+			if entry.Path == "keep2.txt" {
+				return "custom"
+			}
+			return ""
+		},
+	}})
+	if err != nil {
+		t.Fatalf("OpenWithOptions policy: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if len(entries) != 1 || entries[0].Path != "keep1.txt" {
+		t.Fatalf("policy entries = %+v, want keep1.txt", entries)
+	}
+	junk := r.JunkEntries()
+	if len(junk) != 4 || junk[3].Entry.Path != "keep2.txt" || junk[3].Reason != "custom" {
+		t.Fatalf("policy junk = %+v", junk)
+	}
+
+	r2, err := OpenWithOptions(path, ReaderOptions{JunkFilter: &JunkFilter{}, MinEntryDataSize: 6})
+	if err != nil {
+		t.Fatalf("OpenWithOptions min size: %v", err)
+	}
+	defer func() { _ = r2.Close() }()
+	if got := len(r2.Entries()); got != 0 {
+		t.Fatalf("min size entries = %d, want 0", got)
+	}
+	if got := len(r2.JunkEntries()); got != 3 {
+		t.Fatalf("min size junk entries = %d, want 3", got)
+	}
+}
+
+func TestReader_HiddenEntries(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "hidden.pbo")
+	if err := createTestPBO(path, map[string][]byte{
+		"data/keep.txt":   []byte("visible payload"),
+		"data/tiny.txt":   []byte("x"),
+		"data/файл.txt":   []byte("unicode payload"),
+		"other/skip.txt":  []byte("outside prefix"),
+		"data/nested.txt": []byte("nested payload"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{
+		MinEntryOriginalSize: 2,
+		FilterASCIIOnly:      true,
+		EntryPathPrefix:      "data",
+	})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if got := len(r.Entries()); got != 2 {
+		t.Fatalf("visible entries = %d, want 2", got)
+	}
+
+	want := map[string]EntryFilter{
+		`data\tiny.txt`:  EntryFilterSize,
+		`data\файл.txt`:  EntryFilterASCII,
+		`other\skip.txt`: EntryFilterPrefix,
+	}
+	hidden := r.HiddenEntries()
+	if len(hidden) != len(want) {
+		t.Fatalf("hidden = %+v, want %d entries", hidden, len(want))
+	}
+	for _, h := range hidden {
+		if want[h.Entry.Path] != h.Filter || h.Reason == "" {
+			t.Fatalf("hidden %s filter=%q reason=%q, want filter %q", h.Entry.Path, h.Filter, h.Reason, want[h.Entry.Path])
+		}
+	}
+	if r.JunkEntries() != nil {
+		t.Fatalf("junk entries = %+v, want none", r.JunkEntries())
+	}
+
+	plain, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = plain.Close() }()
+	if plain.HiddenEntries() != nil {
+		t.Fatalf("unfiltered reader hidden = %+v", plain.HiddenEntries())
+	}
+}
+
+func TestNewReaderFromBytes(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"plain.txt":   []byte("plain payload"),
+		"packed.sqf":  bytes.Repeat([]byte("compressible line\n"), 200),
+		"another.sqf": bytes.Repeat([]byte("more text "), 300),
+	}
+	path := filepath.Join(t.TempDir(), "mem.pbo")
+	if err := createTestPBO(path, files, PackOptions{Compress: includeRules("*.sqf")}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReaderFromBytes(data, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("NewReaderFromBytes: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.data == nil {
+		t.Fatal("expected direct slice access for plain archive")
+	}
+	for name, want := range files {
+		got, err := r.ReadEntry(name)
+		if err != nil {
+			t.Fatalf("ReadEntry %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("ReadEntry %s content mismatch", name)
+		}
+	}
+
+	limited, err := NewReaderFromBytes(data, ReaderOptions{MaxEntryDecompressedSize: 100})
+	if err != nil {
+		t.Fatalf("NewReaderFromBytes limited: %v", err)
+	}
+	defer func() { _ = limited.Close() }()
+	if _, err := limited.ReadEntry("packed.sqf"); !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("ReadEntry limited err = %v, want ErrEntryTooLarge", err)
+	}
+}
+
+// countingCloser is in-memory ReadAtCloser counting Close calls.
+type countingCloser struct {
+	*bytes.Reader
+	closes int
+}
+
+// Close counts close call.
+func (c *countingCloser) Close() error {
+	c.closes++
+	return nil
+}
+
+func TestNewReaderOwned_ClosesSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "owned.pbo")
+	if err := createTestPBO(path, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := &countingCloser{Reader: bytes.NewReader(data)}
+	r, err := NewReaderOwned(src, int64(len(data)), ReaderOptions{})
+	if err != nil {
+		t.Fatalf("NewReaderOwned: %v", err)
+	}
+	if got, err := r.ReadEntry("a.txt"); err != nil || string(got) != "alpha" {
+		t.Fatalf("ReadEntry = %q, %v", got, err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if src.closes != 1 {
+		t.Fatalf("source closes = %d, want 1", src.closes)
+	}
+
+	bad := &countingCloser{Reader: bytes.NewReader([]byte("not a pbo"))}
+	if _, err := NewReaderOwned(bad, 9, ReaderOptions{}); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if bad.closes != 1 {
+		t.Fatalf("source closes after parse error = %d, want 1", bad.closes)
+	}
+
+	if _, err := NewReaderOwned(nil, 0, ReaderOptions{}); !errors.Is(err, ErrReaderAtRequired) {
+		t.Fatalf("nil source err = %v, want ErrReaderAtRequired", err)
+	}
+}
+
+func TestReader_HasAndStat(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "stat.pbo")
+	if err := createTestPBO(path, map[string][]byte{
+		"scripts/3_game/a.c": []byte("class A {};"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, name := range []string{`scripts\3_game\a.c`, "scripts/3_game/a.c", "/scripts/3_game/a.c"} {
+		if !r.Has(name) {
+			t.Fatalf("Has(%q) = false", name)
+		}
+
+		info, err := r.Stat(name)
+		if err != nil {
+			t.Fatalf("Stat(%q): %v", name, err)
+		}
+		if info.Path != `scripts\3_game\a.c` || info.DataSize != 11 {
+			t.Fatalf("Stat(%q) = %+v", name, info)
+		}
+	}
+
+	if r.Has("scripts/missing.c") {
+		t.Fatal("Has(missing) = true")
+	}
+	if _, err := r.Stat("scripts/missing.c"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("Stat(missing) err = %v, want ErrEntryNotFound", err)
+	}
+
+	var nilReader *Reader
+	if nilReader.Has("a") {
+		t.Fatal("nil reader Has = true")
+	}
+}
+
 func TestReadHeaders_DoesNotRequireValidEntryTable(t *testing.T) {
 	t.Parallel()
 
@@ -285,6 +715,44 @@ func TestReadEntry_NotFound(t *testing.T) {
 	}
 }
 
+func TestWriteEntryTo(t *testing.T) {
+	t.Parallel()
+
+	compressible := bytes.Repeat([]byte("abc"), 2048)
+	pboPath := filepath.Join(t.TempDir(), "write_to.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"packed.txt": compressible,
+		"raw.bin":    []byte("raw bytes"),
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if !findEntry(r.Entries(), "packed.txt").IsCompressed() {
+		t.Fatal("packed.txt is expected to be compressed")
+	}
+
+	for name, want := range map[string][]byte{"packed.txt": compressible, "raw.bin": []byte("raw bytes")} {
+		var buf bytes.Buffer
+		n, err := r.WriteEntryTo(&buf, name)
+		if err != nil || n != int64(len(want)) || !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("WriteEntryTo %s n=%d err=%v equal=%v", name, n, err, bytes.Equal(buf.Bytes(), want))
+		}
+	}
+
+	if _, err := r.WriteEntryTo(io.Discard, "missing"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("missing err=%v, want ErrEntryNotFound", err)
+	}
+	if _, err := r.WriteEntryTo(nil, "raw.bin"); !errors.Is(err, ErrNilWriter) {
+		t.Fatalf("nil writer err=%v, want ErrNilWriter", err)
+	}
+}
+
 func TestPackRoundTrip(t *testing.T) {
 	dir := t.TempDir()
 	outPath := filepath.Join(dir, "out.pbo")
@@ -331,12 +799,10 @@ func TestPackRoundTrip(t *testing.T) {
 	if _, err := f.Seek(0, io.SeekStart); err != nil {
 		t.Fatal(err)
 	}
-	r, err := NewReaderFromReaderAt(f, fi.Size())
+	r, err := NewReaderOwned(f, fi.Size(), ReaderOptions{})
 	if err != nil {
-		t.Fatalf("NewReaderFromReaderAt: %v", err)
+		t.Fatalf("NewReaderOwned: %v", err)
 	}
-	r.file = f
-	r.ra = f
 
 	entries := r.Entries()
 	if len(entries) != 2 {
@@ -436,12 +902,10 @@ func TestPack_NormalizesPrefixHeaderValue(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r, err := NewReaderFromReaderAt(f, fi.Size())
+	r, err := NewReaderOwned(f, fi.Size(), ReaderOptions{})
 	if err != nil {
-		t.Fatalf("NewReaderFromReaderAt: %v", err)
+		t.Fatalf("NewReaderOwned: %v", err)
 	}
-	r.file = f
-	r.ra = f
 	defer func() { _ = r.Close() }()
 
 	headers := r.Headers()
@@ -458,6 +922,56 @@ func TestPack_NormalizesPrefixHeaderValue(t *testing.T) {
 	}
 }
 
+func TestPack_DuplicateHeaders(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	headers := []HeaderPair{
+		{Key: "prefix", Value: "addon"},
+		{Key: "requires", Value: "a"},
+		{Key: "Requires", Value: "b"},
+	}
+	files := map[string][]byte{"a.txt": []byte("ok")}
+
+	err := createTestPBO(filepath.Join(dir, "strict.pbo"), files, PackOptions{Headers: headers})
+	if !errors.Is(err, ErrDuplicateHeaderKey) {
+		t.Fatalf("expected ErrDuplicateHeaderKey, got %v", err)
+	}
+
+	pboPath := filepath.Join(dir, "dup.pbo")
+	if err := createTestPBO(pboPath, files, PackOptions{Headers: headers, AllowDuplicateHeaders: true}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.SetHeader("version", "1"); err != nil {
+		t.Fatalf("SetHeader: %v", err)
+	}
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	values := r.HeaderValues("REQUIRES")
+	if len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Fatalf("HeaderValues=%v, want [a b]", values)
+	}
+	if got := r.Headers(); len(got) != 4 || got[2].Key != "Requires" || got[3].Key != "version" {
+		t.Fatalf("headers after edit=%v", got)
+	}
+	if values := r.HeaderValues("missing"); values != nil {
+		t.Fatalf("HeaderValues(missing)=%v, want nil", values)
+	}
+}
+
 func TestExtractRoundTrip(t *testing.T) {
 	pboPath := createManualPBO(t, []byte("hello"))
 	r, err := Open(pboPath)
@@ -480,6 +994,48 @@ func TestExtractRoundTrip(t *testing.T) {
 	}
 }
 
+func TestExtract_OnProgressReportsTotals(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "progress.pbo")
+	err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt":     bytes.Repeat([]byte("a"), 4096),
+		"dir/b.txt": []byte("bbb"),
+		"dir/c.txt": []byte("c"),
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var (
+		calls    int
+		lastDone EntryStats
+		total    EntryStats
+	)
+	err = r.Extract(context.Background(), t.TempDir(), ExtractOptions{
+		MaxWorkers: 3,
+		OnProgress: func(done EntryStats, all EntryStats) {
+			calls++
+			lastDone = done
+			total = all
+		},
+	})
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := EntryStats{Entries: 3, Bytes: 4096 + 3 + 1}
+	if calls != 3 || total != want || lastDone != want {
+		t.Fatalf("calls=%d done=%+v total=%+v, want %+v", calls, lastDone, total, want)
+	}
+}
+
 func TestExtract_DefaultModeRewritesExistingFiles(t *testing.T) {
 	t.Parallel()
 
@@ -689,6 +1245,81 @@ func TestExtract_OverwriteSmart_TruncatesOnlyWhenNeeded(t *testing.T) {
 	}
 }
 
+func TestExtract_SkipUnchangedLeavesIdenticalFiles(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "skip.pbo")
+	inputs := streamTestInputs(map[string][]byte{
+		"a.txt":     []byte("alpha"),
+		"dir/b.txt": []byte("bravo"),
+	})
+	if _, err := PackFile(context.Background(), pboPath, inputs, PackOptions{}); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	extDir := t.TempDir()
+	extract := func() (int32, int32) {
+		var written, skipped atomic.Int32
+		err := r.Extract(context.Background(), extDir, ExtractOptions{
+			FileMode:       ExtractFileModeSkipUnchanged,
+			OnEntryDone:    func(EntryInfo, int64, string) { written.Add(1) },
+			OnEntrySkipped: func(EntryInfo, string) { skipped.Add(1) },
+		})
+		if err != nil {
+			t.Fatalf("extract skip_unchanged: %v", err)
+		}
+
+		return written.Load(), skipped.Load()
+	}
+
+	if written, skipped := extract(); written != 2 || skipped != 0 {
+		t.Fatalf("first run written=%d skipped=%d, want 2/0", written, skipped)
+	}
+
+	info, err := os.Stat(filepath.Join(extDir, "a.txt"))
+	if err != nil {
+		t.Fatalf("stat extracted file: %v", err)
+	}
+	if info.ModTime().Unix() != 1700000000 {
+		t.Fatalf("extracted mtime=%v, want entry timestamp", info.ModTime())
+	}
+
+	if written, skipped := extract(); written != 0 || skipped != 2 {
+		t.Fatalf("second run written=%d skipped=%d, want 0/2", written, skipped)
+	}
+
+	// Same size, different content and mtime must be rewritten.
+	stalePath := filepath.Join(extDir, "dir", "b.txt")
+	if err := os.WriteFile(stalePath, []byte("BRAVO"), 0o600); err != nil {
+		t.Fatalf("write stale file: %v", err)
+	}
+
+	if written, skipped := extract(); written != 1 || skipped != 1 {
+		t.Fatalf("third run written=%d skipped=%d, want 1/1", written, skipped)
+	}
+
+	got, err := os.ReadFile(stalePath)
+	if err != nil || string(got) != "bravo" {
+		t.Fatalf("rewritten file=%q err=%v", got, err)
+	}
+
+	// Same size and content with foreign mtime is detected by content comparison.
+	otherTime := time.Unix(1600000000, 0)
+	if err := os.Chtimes(stalePath, otherTime, otherTime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	if written, skipped := extract(); written != 0 || skipped != 2 {
+		t.Fatalf("fourth run written=%d skipped=%d, want 0/2", written, skipped)
+	}
+}
+
 func TestExtract_RejectsUnsafeEntryPaths(t *testing.T) {
 	t.Parallel()
 
@@ -853,6 +1484,42 @@ func TestReadEntry_CompressedCorruptedPayload(t *testing.T) {
 	}
 }
 
+func TestReadEntry_MaxEntryDecompressedSize(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), "bomb.pbo")
+	payload := bytes.Repeat([]byte("a"), 64*1024)
+	if err := createTestPBO(outPath, map[string][]byte{
+		"big.txt":   payload,
+		"small.txt": []byte("small"),
+		"raw.bin":   payload,
+	}, PackOptions{Compress: includeRules("*.txt"), MinCompressSize: 1}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(outPath, ReaderOptions{MaxEntryDecompressedSize: 1024})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	_, err = r.ReadEntry("big.txt")
+	var sizeErr *EntrySizeError
+	if !errors.As(err, &sizeErr) || !errors.Is(err, ErrEntryTooLarge) {
+		t.Fatalf("ReadEntry big.txt err=%v, want EntrySizeError", err)
+	}
+	if sizeErr.Path != "big.txt" || sizeErr.Size != int64(len(payload)) || sizeErr.Limit != 1024 {
+		t.Fatalf("size error=%+v", sizeErr)
+	}
+
+	if got, err := r.ReadEntry("small.txt"); err != nil || string(got) != "small" {
+		t.Fatalf("ReadEntry small.txt=%q err=%v", got, err)
+	}
+	if got, err := r.ReadEntry("raw.bin"); err != nil || len(got) != len(payload) {
+		t.Fatalf("ReadEntry raw.bin len=%d err=%v, raw entries are not limited", len(got), err)
+	}
+}
+
 func TestCheckSHA1Trailer(t *testing.T) {
 	pboPath := createMinimalPBO(t)
 	hash, err := checkSHA1TrailerForTest(pboPath)
@@ -1185,3 +1852,90 @@ func createManualPBOMalformedStoredOffset(t *testing.T) string {
 
 	return path
 }
+
+func TestExtractCaseCollisionPolicies(t *testing.T) {
+	t.Parallel()
+
+	pboPath := writeRawEntryTablePBO(t, []rawTestEntry{
+		{name: `data\Config.cpp`, data: []byte("upper")},
+		{name: `DATA\config.cpp`, data: []byte("lower")},
+		{name: `data\other.txt`, data: []byte("other")},
+	})
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	err = r.Extract(context.Background(), t.TempDir(), ExtractOptions{RawNames: true, CaseCollision: ExtractCollisionError})
+	if !errors.Is(err, ErrExtractPathCollision) {
+		t.Fatalf("expected ErrExtractPathCollision, got %v", err)
+	}
+
+	collect := func(policy ExtractCollisionPolicy) map[string]string {
+		got := make(map[string]string)
+		var mu sync.Mutex
+		err := r.ExtractTo(context.Background(), ExtractSinkFunc(func(_ context.Context, entry EntryInfo, content io.Reader) error {
+			data, err := io.ReadAll(content)
+			mu.Lock()
+			got[entry.Path] = string(data)
+			mu.Unlock()
+			return err
+		}), ExtractOptions{RawNames: true, CaseCollision: policy})
+		if err != nil {
+			t.Fatalf("ExtractTo %s: %v", policy, err)
+		}
+
+		return got
+	}
+
+	suffixed := collect("")
+	if len(suffixed) != 3 || suffixed["data/Config.cpp"] != "upper" || suffixed["DATA/config~2.cpp"] != "lower" {
+		t.Fatalf("suffix policy output=%v", suffixed)
+	}
+
+	merged := collect(ExtractCollisionMerge)
+	if len(merged) != 2 || merged["DATA/config.cpp"] != "lower" {
+		t.Fatalf("merge policy output=%v", merged)
+	}
+}
+
+func TestExtract_DispatchesInOffsetOrder(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "order.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"a.txt": []byte("aaaa"),
+		"b.txt": []byte("bbbb"),
+		"c.txt": []byte("cccc"),
+		"d.txt": []byte("dddd"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	reversed := r.Entries()
+	slices.Reverse(reversed)
+
+	var offsets []uint32
+	err = r.Extract(context.Background(), t.TempDir(), ExtractOptions{
+		Entries:    reversed,
+		MaxWorkers: 1,
+		OnEntryDone: func(entry EntryInfo, _ int64, _ string) {
+			offsets = append(offsets, entry.Offset)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	if len(offsets) != len(reversed) || !slices.IsSorted(offsets) {
+		t.Fatalf("dispatch offsets=%v, want ascending", offsets)
+	}
+}
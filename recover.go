@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// recoverRecordWindow is max bytes needed to decode one entry record (name, terminator, fields).
+const recoverRecordWindow = maxNameLen + 1 + 20
+
+// RecoveryReport describes entry table salvage performed by ReaderOptions.RecoverMode.
+type RecoveryReport struct {
+	// Cause is strict parse error that triggered recovery.
+	Cause error `json:"-" yaml:"-"`
+	// CauseText is textual form of Cause for serialized reports.
+	CauseText string `json:"cause" yaml:"cause"`
+	// SkippedBytes is number of index bytes skipped while resynchronizing.
+	SkippedBytes int64 `json:"skipped_bytes" yaml:"skipped_bytes"`
+	// SkippedRanges is number of contiguous garbage runs skipped.
+	SkippedRanges int `json:"skipped_ranges" yaml:"skipped_ranges"`
+	// Salvaged is number of entries returned after recovery.
+	Salvaged int `json:"salvaged" yaml:"salvaged"`
+	// Dropped is number of plausible records dropped because payload lies beyond file end.
+	Dropped int `json:"dropped,omitempty" yaml:"dropped,omitempty"`
+	// Terminated reports whether entry table terminator record was found.
+	Terminated bool `json:"terminated" yaml:"terminated"`
+}
+
+// RecoveryReport returns entry table recovery report or nil when strict parse succeeded.
+func (r *Reader) RecoveryReport() *RecoveryReport {
+	if r == nil {
+		return nil
+	}
+
+	_ = r.LoadEntries()
+
+	return r.recovery
+}
+
+// parseEntryTable parses entry table and resolves offsets, falling back to recovery scan when enabled.
+func (r *Reader) parseEntryTable(ra io.ReaderAt, tableOffset int64, size int64, opts ReaderOptions) (int64, error) {
+	entriesEnd, err := r.parseEntriesBuffered(ra, tableOffset, size, opts.Limits, opts.InternPaths)
+	if err == nil {
+		storedOffsets := hasStoredOffsets(r.entries)
+		overlap := overlappingStoredOffset(r.entries)
+		var usedStored bool
+		usedStored, err = resolveEntryOffsets(r.entries, entriesEnd, size, opts.OffsetMode, opts.logger())
+		if err == nil && storedOffsets && !usedStored {
+			r.addIssue(ParseIssueStoredOffsetsIgnored, "",
+				fmt.Sprintf("non-zero stored offsets not used in %s mode", opts.OffsetMode))
+		}
+		if err == nil && overlap != "" && !usedStored {
+			r.addIssue(ParseIssueStoredOffsetsOverlap, overlap,
+				"stored offset points into earlier payload; sequential offsets misread shared payloads")
+			opts.logger().Warn("stored offsets overlap, entries may be misread; use stored offset mode",
+				"path", overlap, "offset_mode", opts.OffsetMode)
+		}
+	}
+	if err == nil {
+		return entriesEnd, nil
+	}
+	if !opts.RecoverMode || errors.Is(err, ErrLimitExceeded) {
+		return 0, encryptedTableError(ra, tableOffset, size, err)
+	}
+
+	r.entries = r.entries[:0]
+	report := &RecoveryReport{Cause: err, CauseText: err.Error()}
+	entriesEnd, err = r.recoverEntries(ra, tableOffset, size, opts.Limits, report)
+	if err != nil {
+		return 0, err
+	}
+
+	r.recovery = report
+	r.addIssue(ParseIssueTableRecovered, "", fmt.Sprintf("salvaged %d entries after: %s", report.Salvaged, report.CauseText))
+	opts.logger().Info("entry table recovered",
+		"cause", report.CauseText,
+		"salvaged", report.Salvaged,
+		"dropped", report.Dropped,
+		"skipped_bytes", report.SkippedBytes,
+		"terminated", report.Terminated)
+
+	return entriesEnd, nil
+}
+
+// recoverScanChunk is bytes read per ReadAt while scanning damaged entry table.
+const recoverScanChunk = 64 << 10
+
+// recoverMaxGarbageRun stops recovery scan after this many consecutive implausible bytes;
+// such run is payload section reached without terminator record, not table damage.
+const recoverMaxGarbageRun = 64 << 10
+
+// recoverEntries scans entry table byte-by-byte for plausible records and returns payload start.
+// Scan stops at terminator record, Limits.MaxIndexBytes, or long implausible run. Without
+// terminator, payload is assumed to end at file end (before SHA1 trailer) and start right
+// after salvaged payload sizes, but not before last salvaged record. Entries get sequential
+// offsets; records whose payload does not fit in file are dropped.
+func (r *Reader) recoverEntries(
+	ra io.ReaderAt,
+	tableOffset int64,
+	size int64,
+	limits ReaderLimits,
+	report *RecoveryReport,
+) (int64, error) {
+	scanEnd := size
+	if limits.MaxIndexBytes > 0 {
+		scanEnd = min(size, tableOffset+limits.MaxIndexBytes)
+	}
+
+	buf := make([]byte, recoverScanChunk+recoverRecordWindow)
+	var bufStart, bufLen int64
+	pos := tableOffset
+	recordsEnd := tableOffset
+	var garbageRun int64
+
+	for pos < scanEnd {
+		// Refill when buffered bytes may not hold whole record at pos.
+		if pos+recoverRecordWindow > bufStart+bufLen && bufStart+bufLen < size {
+			n, err := ra.ReadAt(buf, pos)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return 0, err
+			}
+			bufStart, bufLen = pos, int64(n)
+		}
+
+		window := buf[pos-bufStart : bufLen]
+		entry, recordLen, terminator, ok := decodeRecoverRecord(window, size-pos)
+		if ok && terminator {
+			report.Terminated = true
+			pos += int64(recordLen)
+			recordsEnd = pos
+			garbageRun = 0
+			break
+		}
+		if !ok {
+			if garbageRun == 0 {
+				report.SkippedRanges++
+			}
+
+			garbageRun++
+			report.SkippedBytes++
+			pos++
+			if garbageRun >= recoverMaxGarbageRun {
+				break
+			}
+			continue
+		}
+
+		garbageRun = 0
+		r.entries = append(r.entries, entry)
+		pos += int64(recordLen)
+		recordsEnd = pos
+		if err := limits.checkTable(len(r.entries), pos-tableOffset); err != nil {
+			return 0, err
+		}
+	}
+
+	dataStart := recordsEnd
+	if !report.Terminated {
+		// Trailing implausible run is payload, not skipped index bytes.
+		if garbageRun > 0 {
+			report.SkippedRanges--
+			report.SkippedBytes -= garbageRun
+		}
+		dataStart = recoverDataStart(ra, r.entries, recordsEnd, size)
+	}
+
+	current := dataStart
+	kept := r.entries[:0]
+	for _, entry := range r.entries {
+		if current+int64(entry.DataSize) > size {
+			report.Dropped++
+			continue
+		}
+
+		entry.Offset = uint32(current) //nolint:gosec // bounded by size check above
+		current += int64(entry.DataSize)
+		kept = append(kept, entry)
+	}
+
+	r.entries = kept
+	report.Salvaged = len(kept)
+
+	return dataStart, nil
+}
+
+// recoverDataStart estimates payload start of table without terminator: salvaged payloads
+// are placed to end at file end, before 0x00 + SHA1 trailer when present. Estimate never
+// precedes recordsEnd, end of last salvaged record.
+func recoverDataStart(ra io.ReaderAt, entries []EntryInfo, recordsEnd int64, size int64) int64 {
+	payloadEnd := size
+	if size-shaSize-1 >= recordsEnd {
+		var prefix [1]byte
+		if _, err := ra.ReadAt(prefix[:], size-shaSize-1); err == nil && prefix[0] == 0x00 {
+			payloadEnd = size - shaSize - 1
+		}
+	}
+
+	var total int64
+	for i := range entries {
+		total += int64(entries[i].DataSize)
+	}
+
+	return max(payloadEnd-total, recordsEnd)
+}
+
+// decodeRecoverRecord decodes one plausible entry record from buf start.
+// remaining bounds declared payload size; terminator reports all-zero table end record.
+func decodeRecoverRecord(buf []byte, remaining int64) (EntryInfo, int, bool, bool) {
+	nameLen := bytes.IndexByte(buf, 0)
+	if nameLen < 0 || len(buf) < nameLen+21 {
+		return EntryInfo{}, 0, false, false
+	}
+
+	fields := buf[nameLen+1 : nameLen+21]
+	mimeType := MimeType(binary.LittleEndian.Uint32(fields[0:4]))
+	originalSize := binary.LittleEndian.Uint32(fields[4:8])
+	offset := binary.LittleEndian.Uint32(fields[8:12])
+	timestamp := binary.LittleEndian.Uint32(fields[12:16])
+	dataSize := binary.LittleEndian.Uint32(fields[16:20])
+
+	if nameLen == 0 {
+		if mimeType == 0 && originalSize == 0 && offset == 0 && timestamp == 0 && dataSize == 0 {
+			return EntryInfo{}, nameLen + 21, true, true
+		}
+
+		return EntryInfo{}, 0, false, false
+	}
+
+	if !isPlausibleRecoverName(buf[:nameLen]) || int64(dataSize) > remaining {
+		return EntryInfo{}, 0, false, false
+	}
+
+	switch mimeType {
+	case MimeNil:
+		if originalSize != 0 && originalSize != dataSize {
+			return EntryInfo{}, 0, false, false
+		}
+	case MimeCompress, MimeEncoded:
+		if originalSize == 0 {
+			return EntryInfo{}, 0, false, false
+		}
+	default:
+		return EntryInfo{}, 0, false, false
+	}
+
+	return EntryInfo{
+		Path:         string(buf[:nameLen]),
+		DataSize:     dataSize,
+		OriginalSize: originalSize,
+		TimeStamp:    timestamp,
+		MimeType:     mimeType,
+		Reserved:     offset,
+	}, nameLen + 21, false, true
+}
+
+// isPlausibleRecoverName reports whether raw name bytes look like entry path (valid UTF-8, no C0 controls or DEL).
+func isPlausibleRecoverName(name []byte) bool {
+	for _, ch := range name {
+		if ch < 0x20 || ch == 0x7f {
+			return false
+		}
+	}
+
+	return utf8.Valid(name)
+}
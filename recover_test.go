@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecoverMode_SkipsGarbageBetweenRecords(t *testing.T) {
+	t.Parallel()
+
+	record := func(name string, size int) []byte {
+		var fields [20]byte
+		binary.LittleEndian.PutUint32(fields[16:20], uint32(size)) //nolint:gosec // test payloads are small
+		return append(append([]byte(name), 0), fields[:]...)
+	}
+
+	var buf bytes.Buffer
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(header[1:5], uint32(MimeHeader))
+	buf.Write(header)
+	buf.WriteByte(0)
+	buf.Write(record("a.txt", 5))
+	buf.Write([]byte{0xff, 0xff, 0x00})
+	buf.Write(bytes.Repeat([]byte{0xee}, 20))
+	buf.Write(record("b.txt", 5))
+	buf.Write(make([]byte, 21))
+	buf.WriteString("helloworld")
+
+	path := filepath.Join(t.TempDir(), "garbage.pbo")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected strict Open to fail")
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{RecoverMode: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	report := r.RecoveryReport()
+	if report == nil || !report.Terminated || report.Salvaged != 2 || report.SkippedRanges != 1 || report.SkippedBytes != 23 {
+		t.Fatalf("report=%+v", report)
+	}
+
+	for name, want := range map[string]string{"a.txt": "hello", "b.txt": "world"} {
+		got, err := r.ReadEntry(name)
+		if err != nil {
+			t.Fatalf("ReadEntry %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Fatalf("%s=%q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRecoverMode_DropsTruncatedPayload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "truncated.pbo")
+	err := createTestPBO(path, map[string][]byte{
+		"a.txt": []byte("first"),
+		"b.txt": bytes.Repeat([]byte("b"), 64),
+	}, PackOptions{})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if err := os.Truncate(path, info.Size()-21-32); err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+
+	entries, err := ListEntriesWithOptions(path, ReaderOptions{RecoverMode: true})
+	if err != nil {
+		t.Fatalf("ListEntriesWithOptions: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "a.txt" {
+		t.Fatalf("entries=%+v", entries)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{RecoverMode: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if report := r.RecoveryReport(); report == nil || report.Dropped != 1 || report.SkippedBytes != 0 {
+		t.Fatalf("report=%+v", report)
+	}
+}
+
+func TestRecoverMode_NoReportForValidArchive(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ok.pbo")
+	if err := createTestPBO(path, map[string][]byte{"a.txt": []byte("a")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{RecoverMode: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.RecoveryReport() != nil {
+		t.Fatalf("unexpected report %+v", r.RecoveryReport())
+	}
+}
+
+func TestRecoverMode_NoTerminator(t *testing.T) {
+	t.Parallel()
+
+	big := bytes.Repeat([]byte("payload scanned as garbage without terminator "), 8<<10)
+	files := map[string][]byte{"a.txt": []byte("first"), "b.bin": big, "c.txt": []byte("third")}
+	path := filepath.Join(t.TempDir(), "noterm.pbo")
+	if err := createTestPBO(path, files, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	clean, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	dataStart := clean.dataStart
+	_ = clean.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	copy(data[dataStart-21:dataStart], bytes.Repeat([]byte{0xff}, 21))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	r, err := OpenWithOptions(path, ReaderOptions{RecoverMode: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	report := r.RecoveryReport()
+	if report == nil || report.Terminated || report.Salvaged != 3 || report.Dropped != 0 {
+		t.Fatalf("report=%+v", report)
+	}
+	if report.SkippedBytes >= int64(len(big)) {
+		t.Fatalf("scan ran through payload: report=%+v", report)
+	}
+
+	for name, want := range files {
+		got, err := r.ReadEntry(name)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("ReadEntry %s: len=%d err=%v", name, len(got), err)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// DefaultRefScanExtensions lists entry extensions scanned by ScanReferences when
+// RefScanOptions.Extensions is empty.
+var DefaultRefScanExtensions = []string{"cpp", "hpp", "h", "inc", "c", "rvmat"}
+
+// rapSignature is leading signature of binarized (raP) config entries skipped by scanner.
+var rapSignature = []byte("\x00raP")
+
+var (
+	// refIncludeRe matches preprocessor include line; group 1 is angle-bracket target.
+	refIncludeRe = regexp.MustCompile(`^\s*#\s*include\s*(?:<([^>]+)>)?`)
+	// refClassRe matches config and Enforce class declarations with optional base.
+	refClassRe = regexp.MustCompile(`\bclass\s+([A-Za-z_]\w*)(?:\s*(?::|\bextends\b)\s*([A-Za-z_]\w*))?\s*(;)?`)
+)
+
+// RefKind identifies reference type of RefGraph.
+type RefKind string
+
+// Reference kinds collected by ScanReferences.
+const (
+	// RefInclude is preprocessor #include target.
+	RefInclude RefKind = "include"
+	// RefFile is file path in string literal ("\dz\data\x.paa").
+	RefFile RefKind = "file"
+	// RefBase is base class of class declaration.
+	RefBase RefKind = "base"
+)
+
+// RefScanOptions configures Reader.ScanReferences.
+type RefScanOptions struct {
+	// Entries selects scanned entries by ordered rules like ExtractMatching.
+	Entries []pathrules.Rule `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// Extensions lists scanned entry extensions; empty means DefaultRefScanExtensions.
+	Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	// MaxEntrySize skips entries whose decoded size exceeds this value.
+	MaxEntrySize int64 `json:"max_entry_size,omitempty" yaml:"max_entry_size,omitempty"`
+}
+
+// applyDefaults fills zero-value reference scan options.
+func (opts *RefScanOptions) applyDefaults() {
+	if len(opts.Extensions) == 0 {
+		opts.Extensions = DefaultRefScanExtensions
+	}
+	if opts.MaxEntrySize <= 0 {
+		opts.MaxEntrySize = DefaultSearchMaxEntrySize
+	}
+}
+
+// ClassDecl is one class declaration found by ScanReferences.
+type ClassDecl struct {
+	// Name is declared class name.
+	Name string `json:"name" yaml:"name"`
+	// Base is base class name; empty when class has no base.
+	Base string `json:"base,omitempty" yaml:"base,omitempty"`
+	// Path is entry path of declaring file.
+	Path string `json:"path" yaml:"path"`
+	// Line is 1-based line number.
+	Line int `json:"line" yaml:"line"`
+	// Forward marks "class Name;" declaration of class defined elsewhere.
+	Forward bool `json:"forward,omitempty" yaml:"forward,omitempty"`
+}
+
+// Reference is one include, file, or base class reference found by ScanReferences.
+type Reference struct {
+	// Kind is reference type.
+	Kind RefKind `json:"kind" yaml:"kind"`
+	// From is entry path of referencing file.
+	From string `json:"from" yaml:"from"`
+	// Target is referenced path or class name as written.
+	Target string `json:"target" yaml:"target"`
+	// Virtual is game virtual path of include and file targets; empty for RefBase.
+	// Relative includes resolve against referencing file.
+	Virtual string `json:"virtual,omitempty" yaml:"virtual,omitempty"`
+	// Line is 1-based line number.
+	Line int `json:"line" yaml:"line"`
+}
+
+// RefGraph is class and file cross-reference graph of one archive.
+type RefGraph struct {
+	// Prefix is normalized prefix header of scanned archive.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Classes lists class declarations in entry and line order.
+	Classes []ClassDecl `json:"classes,omitempty" yaml:"classes,omitempty"`
+	// References lists references in entry and line order.
+	References []Reference `json:"references,omitempty" yaml:"references,omitempty"`
+}
+
+// External returns references leaving the archive: include and file targets outside
+// its prefix and base classes without non-forward declaration in the graph.
+func (g *RefGraph) External() []Reference {
+	if g == nil {
+		return nil
+	}
+
+	defined := make(map[string]bool, len(g.Classes))
+	for _, class := range g.Classes {
+		if !class.Forward {
+			defined[strings.ToLower(class.Name)] = true
+		}
+	}
+
+	own := strings.ToLower(ResolveVirtualPath(g.Prefix, "")) + `\`
+	if g.Prefix == "" {
+		own = ""
+	}
+
+	var out []Reference
+	for _, ref := range g.References {
+		switch ref.Kind {
+		case RefBase:
+			if defined[strings.ToLower(ref.Target)] {
+				continue
+			}
+		default:
+			if own != "" && strings.HasPrefix(strings.ToLower(ref.Virtual), own) {
+				continue
+			}
+		}
+
+		out = append(out, ref)
+	}
+
+	return out
+}
+
+// Targets returns sorted unique targets of kind; virtual paths for include and file
+// references, class names for RefBase.
+func (g *RefGraph) Targets(kind RefKind) []string {
+	if g == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var out []string
+	for _, ref := range g.References {
+		if ref.Kind != kind {
+			continue
+		}
+
+		target := ref.Virtual
+		if kind == RefBase {
+			target = ref.Target
+		}
+
+		key := strings.ToLower(target)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		out = append(out, target)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// ScanReferences streams text config and script entries and collects class declarations,
+// includes, and file path literals into cross-reference graph. The scanner is lexical:
+// it strips comments and reads string literals but does not run preprocessor or parse
+// class bodies. Binarized (raP) entries and entries over MaxEntrySize are skipped.
+func (r *Reader) ScanReferences(ctx context.Context, opts RefScanOptions) (*RefGraph, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	opts.applyDefaults()
+	matcher, err := newEntryRulesMatcher(opts.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &RefGraph{Prefix: r.Prefix()}
+	for _, entry := range r.entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		target := searchTarget{r: r, path: entry.Path, entry: entry}
+		if !searchSelected(target, matcher, SearchOptions{Extensions: opts.Extensions, MaxEntrySize: opts.MaxEntrySize}) {
+			continue
+		}
+
+		if err := r.scanEntryReferences(graph, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return graph, nil
+}
+
+// scanEntryReferences streams one entry line by line into graph.
+func (r *Reader) scanEntryReferences(graph *RefGraph, entry EntryInfo) error {
+	rc, err := r.OpenEntryInfo(entry)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	br := bufio.NewReader(rc)
+	if head, _ := br.Peek(len(rapSignature)); bytes.Equal(head, rapSignature) {
+		return nil
+	}
+
+	dir := NormalizePath(entry.Path)
+	if i := strings.LastIndexByte(dir, '/'); i >= 0 {
+		dir = dir[:i]
+	} else {
+		dir = ""
+	}
+
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 0, 64*1024), int(filterOriginalSizeOrDataSize(entry))+1)
+
+	inComment := false
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		var (
+			code     []byte
+			literals []string
+		)
+		code, literals, inComment = stripRefLine(scanner.Bytes(), inComment)
+
+		if m := refIncludeRe.FindSubmatch(code); m != nil {
+			target := string(m[1])
+			if target == "" && len(literals) != 0 {
+				target = literals[0]
+			}
+			if target != "" {
+				graph.References = append(graph.References, Reference{
+					Kind:    RefInclude,
+					From:    entry.Path,
+					Target:  target,
+					Virtual: resolveIncludePath(graph.Prefix, dir, target),
+					Line:    lineNo,
+				})
+			}
+
+			continue
+		}
+
+		for _, m := range refClassRe.FindAllSubmatch(code, -1) {
+			class := ClassDecl{
+				Name:    string(m[1]),
+				Base:    string(m[2]),
+				Path:    entry.Path,
+				Line:    lineNo,
+				Forward: len(m[2]) == 0 && len(m[3]) != 0,
+			}
+			graph.Classes = append(graph.Classes, class)
+			if class.Base != "" {
+				graph.References = append(graph.References, Reference{
+					Kind:   RefBase,
+					From:   entry.Path,
+					Target: class.Base,
+					Line:   lineNo,
+				})
+			}
+		}
+
+		for _, literal := range literals {
+			if !looksLikeFileRef(literal) {
+				continue
+			}
+
+			graph.References = append(graph.References, Reference{
+				Kind:    RefFile,
+				From:    entry.Path,
+				Target:  literal,
+				Virtual: ResolveVirtualPath("", literal),
+				Line:    lineNo,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan entry %s: %w", entry.Path, err)
+	}
+
+	return nil
+}
+
+// stripRefLine removes comments from line, blanks string literal contents in returned code,
+// and returns literals separately. inComment carries open block comment across lines.
+// Doubled quotes ("") of config strings split into two literals, which keeps paths intact.
+func stripRefLine(line []byte, inComment bool) ([]byte, []string, bool) {
+	code := make([]byte, 0, len(line))
+	var literals []string
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case inComment:
+			if line[i] == '*' && i+1 < len(line) && line[i+1] == '/' {
+				inComment = false
+				i++
+			}
+
+		case line[i] == '/' && i+1 < len(line) && line[i+1] == '/':
+			return code, literals, false
+
+		case line[i] == '/' && i+1 < len(line) && line[i+1] == '*':
+			inComment = true
+			i++
+
+		case line[i] == '"':
+			end := bytes.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				end = len(line) - i - 1
+			}
+
+			literals = append(literals, string(line[i+1:i+1+end]))
+			code = append(code, '"', '"')
+			i += end + 1
+
+		default:
+			code = append(code, line[i])
+		}
+	}
+
+	return code, literals, inComment
+}
+
+// looksLikeFileRef reports whether string literal looks like file path: has path
+// separator and short alphanumeric extension, no whitespace edges, and is not URL.
+func looksLikeFileRef(literal string) bool {
+	if literal == "" || strings.TrimSpace(literal) != literal || strings.Contains(literal, "://") {
+		return false
+	}
+
+	sep := strings.LastIndexAny(literal, `/\`)
+	if sep < 0 {
+		return false
+	}
+
+	ext := signFileExtLower(literal)
+	if ext == "" || len(ext) > 5 {
+		return false
+	}
+	for _, c := range ext {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolveIncludePath returns game virtual path of include target; targets without
+// leading separator resolve against directory of including entry under prefix.
+func resolveIncludePath(prefix string, dir string, target string) string {
+	if strings.HasPrefix(target, `\`) || strings.HasPrefix(target, "/") {
+		return ResolveVirtualPath("", target)
+	}
+	if dir != "" {
+		target = dir + "/" + normalizePathForMatching(target)
+	}
+
+	return ResolveVirtualPath(prefix, target)
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReader_ScanReferences(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp": []byte(`#include "cfg\vehicles.hpp"
+#include <\dz\data\defines.hpp>
+class CfgPatches { class MyAddon { requiredAddons[] = {"DZ_Data"}; }; };
+class CfgVehicles {
+	class Inventory_Base;
+	class MyItem: Inventory_Base {
+		model = "\my\addon\data\item.p3d"; // "\ignored\comment.paa"
+		hiddenSelectionsTextures[] = {"dz\gear\camo.paa", "my\addon\data\item_co.paa"};
+		/* class Hidden: Base {}; "\my\block.paa"
+		*/ descriptionShort = "Not a path, really.";
+	};
+};
+`),
+		"cfg/vehicles.hpp": []byte("class MyOther: MyItem {};\n"),
+		"scripts/x.c":      []byte("modded class PlayerBase extends ManBase\n{\n\tstring icon = \"set:dayz_gui image:icon\";\n};\n"),
+		"config.bin":       []byte("\x00raP binary"),
+		"data/item.paa":    []byte("texture"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "my\\addon"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	g, err := r.ScanReferences(context.Background(), RefScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanReferences: %v", err)
+	}
+
+	var classes []string
+	for _, class := range g.Classes {
+		name := class.Name
+		if class.Base != "" {
+			name += ":" + class.Base
+		}
+		if class.Forward {
+			name += ";"
+		}
+		classes = append(classes, name)
+	}
+	wantClasses := []string{"MyOther:MyItem", "CfgPatches", "MyAddon", "CfgVehicles", "Inventory_Base;", "MyItem:Inventory_Base", "PlayerBase:ManBase"}
+	if !slices.Equal(classes, wantClasses) {
+		t.Fatalf("classes=%v", classes)
+	}
+
+	if got := g.Targets(RefInclude); !slices.Equal(got, []string{`\dz\data\defines.hpp`, `\my\addon\cfg\vehicles.hpp`}) {
+		t.Fatalf("includes=%v", got)
+	}
+	if got := g.Targets(RefFile); !slices.Equal(got, []string{`\dz\gear\camo.paa`, `\my\addon\data\item.p3d`, `\my\addon\data\item_co.paa`}) {
+		t.Fatalf("files=%v", got)
+	}
+	if got := g.Targets(RefBase); !slices.Equal(got, []string{"Inventory_Base", "ManBase", "MyItem"}) {
+		t.Fatalf("bases=%v", got)
+	}
+
+	var external []string
+	for _, ref := range g.External() {
+		target := ref.Virtual
+		if ref.Kind == RefBase {
+			target = ref.Target
+		}
+		external = append(external, string(ref.Kind)+"="+target)
+	}
+	wantExternal := []string{"include=\\dz\\data\\defines.hpp", "base=Inventory_Base", "file=\\dz\\gear\\camo.paa", "base=ManBase"}
+	if !slices.Equal(external, wantExternal) {
+		t.Fatalf("external=%v", external)
+	}
+
+	for _, ref := range g.References {
+		if ref.Target == `\my\addon\data\item.p3d` && (ref.From != "config.cpp" || ref.Line != 7) {
+			t.Fatalf("file ref=%+v", ref)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultHTTPBlockSize is default range request block size.
+	DefaultHTTPBlockSize = 64 * 1024
+	// DefaultHTTPCacheBlocks is default number of cached blocks (4 MiB with default block size).
+	DefaultHTTPCacheBlocks = 64
+)
+
+// HTTPReaderOptions configures HTTPReaderAt.
+type HTTPReaderOptions struct {
+	// Client is HTTP client used for range requests (http.DefaultClient when nil).
+	Client *http.Client `json:"-" yaml:"-"`
+	// Header is extra request header sent with every range request (auth, user agent).
+	Header http.Header `json:"-" yaml:"-"`
+	// BlockSize is range request granularity in bytes.
+	BlockSize int `json:"block_size,omitempty" yaml:"block_size,omitempty"`
+	// CacheBlocks is max number of blocks kept in LRU cache.
+	CacheBlocks int `json:"cache_blocks,omitempty" yaml:"cache_blocks,omitempty"`
+}
+
+// applyDefaults fills zero-value HTTP reader options.
+func (opts *HTTPReaderOptions) applyDefaults() {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.BlockSize <= 0 {
+		opts.BlockSize = DefaultHTTPBlockSize
+	}
+	if opts.CacheBlocks <= 0 {
+		opts.CacheBlocks = DefaultHTTPCacheBlocks
+	}
+}
+
+// HTTPReaderAt implements io.ReaderAt over HTTP range requests with LRU block cache.
+type HTTPReaderAt struct {
+	blocks map[int64]*list.Element
+	lru    *list.List
+	url    string
+	etag   string
+	size   int64
+	opts   HTTPReaderOptions
+	mu     sync.Mutex
+}
+
+// httpBlock is one cached block keyed by block index.
+type httpBlock struct {
+	data  []byte
+	index int64
+}
+
+// NewHTTPReaderAt probes url with first block range request and returns reader of remote object.
+// ctx bounds probe request only; ReadAt requests are bounded by Client timeouts, and
+// ReadAtContext bounds one read with its own context.
+func NewHTTPReaderAt(ctx context.Context, url string, opts HTTPReaderOptions) (*HTTPReaderAt, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	opts.applyDefaults()
+
+	h := &HTTPReaderAt{
+		blocks: make(map[int64]*list.Element, opts.CacheBlocks),
+		lru:    list.New(),
+		url:    url,
+		size:   -1,
+		opts:   opts,
+	}
+
+	data, err := h.fetch(ctx, 0, int64(opts.BlockSize)-1)
+	if err != nil {
+		return nil, err
+	}
+
+	h.store(0, data)
+	return h, nil
+}
+
+// OpenURL opens remote PBO over HTTP range requests and parses index/header structures.
+// Only index bytes and requested entry payloads are downloaded.
+func OpenURL(ctx context.Context, url string, opts ReaderOptions) (*Reader, error) {
+	ra, err := NewHTTPReaderAt(ctx, url, HTTPReaderOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReaderFromReaderAtWithOptions(ra, ra.Size(), opts)
+}
+
+// Size returns total remote object size in bytes.
+func (h *HTTPReaderAt) Size() int64 {
+	return h.size
+}
+
+// ReadAt reads len(p) bytes at off, fetching missing blocks in contiguous range requests.
+func (h *HTTPReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	return h.ReadAtContext(context.Background(), p, off)
+}
+
+// ReadAtContext is ReadAt with ctx bounding range requests made for this read.
+func (h *HTTPReaderAt) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrSizeOverflow, off)
+	}
+	if off >= h.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	end := min(off+int64(len(p)), h.size)
+	blockSize := int64(h.opts.BlockSize)
+	first := off / blockSize
+	last := (end - 1) / blockSize
+
+	n := 0
+	for index := first; index <= last; {
+		if data, ok := h.cached(index); ok {
+			n += copyRange(p[n:], data, index*blockSize, off, end)
+			index++
+			continue
+		}
+
+		runEnd := index
+		for runEnd < last {
+			if _, ok := h.cached(runEnd + 1); ok {
+				break
+			}
+			runEnd++
+		}
+
+		runStart := index * blockSize
+		fetched, err := h.fetch(ctx, runStart, min((runEnd+1)*blockSize, h.size)-1)
+		if err != nil {
+			return n, err
+		}
+
+		for i := index; i <= runEnd; i++ {
+			start := (i - index) * blockSize
+			h.store(i, fetched[start:min(start+blockSize, int64(len(fetched)))])
+		}
+
+		// Copy from fetched run itself: long reads and concurrent readers may already
+		// have evicted its blocks from cache.
+		n += copyRange(p[n:], fetched, runStart, off, end)
+		index = runEnd + 1
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// copyRange copies part of data starting at absolute dataStart that overlaps [off, end) into dst.
+func copyRange(dst []byte, data []byte, dataStart int64, off int64, end int64) int {
+	from := max(off, dataStart) - dataStart
+	to := min(end, dataStart+int64(len(data))) - dataStart
+	if to <= from {
+		return 0
+	}
+
+	return copy(dst, data[from:to])
+}
+
+// cached returns cached block and marks it recently used.
+func (h *HTTPReaderAt) cached(index int64) ([]byte, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	elem, ok := h.blocks[index]
+	if !ok {
+		return nil, false
+	}
+
+	h.lru.MoveToFront(elem)
+	return elem.Value.(*httpBlock).data, true
+}
+
+// store inserts block into cache and evicts least recently used blocks over limit.
+func (h *HTTPReaderAt) store(index int64, data []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if elem, ok := h.blocks[index]; ok {
+		elem.Value.(*httpBlock).data = data
+		h.lru.MoveToFront(elem)
+		return
+	}
+
+	h.blocks[index] = h.lru.PushFront(&httpBlock{index: index, data: data})
+	for h.lru.Len() > h.opts.CacheBlocks {
+		oldest := h.lru.Back()
+		h.lru.Remove(oldest)
+		delete(h.blocks, oldest.Value.(*httpBlock).index)
+	}
+}
+
+// fetch downloads inclusive byte range [start, end] and records object size and ETag on first call.
+func (h *HTTPReaderAt) fetch(ctx context.Context, start int64, end int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build range request: %w", err)
+	}
+
+	for key, values := range h.opts.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Range", "bytes="+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(end, 10))
+	// Weak validators never match If-Range (RFC 9110 13.1.5) and would turn every
+	// range request into full 200 response; they are compared on 206 responses instead.
+	if h.etag != "" && !strings.HasPrefix(h.etag, "W/") {
+		req.Header.Set("If-Range", h.etag)
+	}
+
+	resp, err := h.opts.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("range request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("%w: %s returned %s", ErrRangeNotSupported, h.url, resp.Status)
+	}
+
+	first, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, err
+	}
+	if first != start {
+		return nil, fmt.Errorf("%w: %s returned range at %d, requested %d", ErrRangeNotSupported, h.url, first, start)
+	}
+
+	etag := resp.Header.Get("ETag")
+	if h.size < 0 {
+		h.size = total
+		h.etag = etag
+	} else if total != h.size {
+		return nil, fmt.Errorf("%w: remote size changed from %d to %d", ErrRangeNotSupported, h.size, total)
+	} else if h.etag != "" && etag != "" && etag != h.etag {
+		return nil, fmt.Errorf("%w: remote ETag changed from %s to %s", ErrRangeNotSupported, h.etag, etag)
+	}
+
+	want := min(end, total-1) - start + 1
+	data := make([]byte, want)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, fmt.Errorf("read range body: %w", err)
+	}
+
+	return data, nil
+}
+
+// parseContentRange returns first byte offset and complete length from
+// "bytes start-end/total" header.
+func parseContentRange(value string) (int64, int64, error) {
+	spec, total, ok := strings.Cut(strings.TrimPrefix(value, "bytes "), "/")
+	first, _, rangeOK := strings.Cut(spec, "-")
+	if !ok || !rangeOK || !strings.HasPrefix(value, "bytes ") {
+		return 0, 0, fmt.Errorf("%w: malformed Content-Range %q", ErrRangeNotSupported, value)
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("%w: malformed Content-Range %q", ErrRangeNotSupported, value)
+	}
+
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, 0, fmt.Errorf("%w: unknown object size in Content-Range %q", ErrRangeNotSupported, value)
+	}
+
+	return start, size, nil
+}
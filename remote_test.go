@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// rangeTestStats counts requests and body bytes served by range test server.
+type rangeTestStats struct {
+	requests atomic.Int64
+	bytes    atomic.Int64
+}
+
+// countingResponseWriter counts response body bytes.
+type countingResponseWriter struct {
+	http.ResponseWriter
+	stats *rangeTestStats
+}
+
+// Write counts and forwards body bytes.
+func (w countingResponseWriter) Write(p []byte) (int, error) {
+	w.stats.bytes.Add(int64(len(p)))
+	return w.ResponseWriter.Write(p)
+}
+
+// newRangeTestServer serves data with range support and counts requests and sent bytes.
+func newRangeTestServer(t *testing.T, data []byte) (*httptest.Server, *rangeTestStats) {
+	t.Helper()
+
+	stats := &rangeTestStats{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stats.requests.Add(1)
+		http.ServeContent(countingResponseWriter{ResponseWriter: w, stats: stats}, req, "addon.pbo", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, stats
+}
+
+func TestOpenURLReadsEntryWithoutFullDownload(t *testing.T) {
+	t.Parallel()
+
+	big := bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+	path := filepath.Join(t.TempDir(), "remote.pbo")
+	err := createTestPBO(path, map[string][]byte{
+		"big.bin":    big,
+		"config.cpp": []byte("class CfgPatches {};"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "remote"}}})
+	if err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	srv, stats := newRangeTestServer(t, data)
+	r, err := OpenURL(context.Background(), srv.URL, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenURL: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if len(r.Entries()) != 2 || r.Headers()[0].Value != "remote" {
+		t.Fatalf("unexpected index: headers=%v entries=%v", r.Headers(), r.Entries())
+	}
+
+	got, err := r.ReadEntry("config.cpp")
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if string(got) != "class CfgPatches {};" {
+		t.Fatalf("config.cpp=%q", got)
+	}
+
+	if n := stats.bytes.Load(); n > int64(len(data))/4 {
+		t.Fatalf("index and small entry read downloaded %d of %d bytes", n, len(data))
+	}
+
+	got, err = r.ReadEntry("big.bin")
+	if err != nil {
+		t.Fatalf("ReadEntry big: %v", err)
+	}
+	if !bytes.Equal(got, big) {
+		t.Fatalf("big.bin content mismatch")
+	}
+}
+
+func TestHTTPReaderAtCacheAndBounds(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("abcdefgh", 100))
+	srv, stats := newRangeTestServer(t, data)
+
+	ra, err := NewHTTPReaderAt(context.Background(), srv.URL, HTTPReaderOptions{BlockSize: 64, CacheBlocks: 4})
+	if err != nil {
+		t.Fatalf("NewHTTPReaderAt: %v", err)
+	}
+	if ra.Size() != int64(len(data)) {
+		t.Fatalf("Size=%d, want %d", ra.Size(), len(data))
+	}
+
+	buf := make([]byte, 200)
+	if n, err := ra.ReadAt(buf, 30); err != nil || n != len(buf) {
+		t.Fatalf("ReadAt n=%d err=%v", n, err)
+	}
+	if !bytes.Equal(buf, data[30:230]) {
+		t.Fatalf("ReadAt content mismatch")
+	}
+
+	before := stats.requests.Load()
+	if _, err := ra.ReadAt(buf[:50], 100); err != nil {
+		t.Fatalf("cached ReadAt: %v", err)
+	}
+	if stats.requests.Load() != before {
+		t.Fatalf("cached read made extra request")
+	}
+
+	n, err := ra.ReadAt(buf, int64(len(data))-10)
+	if n != 10 || err == nil {
+		t.Fatalf("tail ReadAt n=%d err=%v, want 10 and EOF", n, err)
+	}
+	if !bytes.Equal(buf[:10], data[len(data)-10:]) {
+		t.Fatalf("tail content mismatch")
+	}
+}
+
+func TestHTTPReaderAtRequiresRangeSupport(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("full body"))
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := NewHTTPReaderAt(context.Background(), srv.URL, HTTPReaderOptions{})
+	if !errors.Is(err, ErrRangeNotSupported) {
+		t.Fatalf("expected ErrRangeNotSupported, got %v", err)
+	}
+}
+
+func TestHTTPReaderAtReadLargerThanCache(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("0123456789abcdef", 64))
+	srv, _ := newRangeTestServer(t, data)
+
+	ra, err := NewHTTPReaderAt(context.Background(), srv.URL, HTTPReaderOptions{BlockSize: 16, CacheBlocks: 2})
+	if err != nil {
+		t.Fatalf("NewHTTPReaderAt: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Go(func() {
+			buf := make([]byte, 300)
+			off := int64(worker * 37)
+			if n, err := ra.ReadAt(buf, off); err != nil || n != len(buf) {
+				t.Errorf("ReadAt off=%d n=%d err=%v", off, n, err)
+				return
+			}
+			if !bytes.Equal(buf, data[off:off+300]) {
+				t.Errorf("ReadAt off=%d content mismatch", off)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestHTTPReaderAtRejectsMisplacedRange(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("x", 256))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Always answer with first block regardless of requested range.
+		w.Header().Set("Content-Range", "bytes 0-63/256")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(data[:64])
+	}))
+	t.Cleanup(srv.Close)
+
+	ra, err := NewHTTPReaderAt(context.Background(), srv.URL, HTTPReaderOptions{BlockSize: 64})
+	if err != nil {
+		t.Fatalf("NewHTTPReaderAt: %v", err)
+	}
+
+	if _, err := ra.ReadAt(make([]byte, 10), 128); !errors.Is(err, ErrRangeNotSupported) {
+		t.Fatalf("misplaced range err = %v, want ErrRangeNotSupported", err)
+	}
+}
+
+func TestHTTPReaderAtWeakETag(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("0123456789abcdef", 64))
+	var etag atomic.Value
+	etag.Store(`W/"v1"`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// ServeContent follows RFC 9110: weak If-Range never matches and yields 200.
+		w.Header().Set("ETag", etag.Load().(string))
+		http.ServeContent(w, req, "addon.pbo", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+
+	ra, err := NewHTTPReaderAt(context.Background(), srv.URL, HTTPReaderOptions{BlockSize: 64, CacheBlocks: 2})
+	if err != nil {
+		t.Fatalf("NewHTTPReaderAt: %v", err)
+	}
+
+	buf := make([]byte, 100)
+	if n, err := ra.ReadAt(buf, 500); err != nil || n != len(buf) {
+		t.Fatalf("ReadAt n=%d err=%v", n, err)
+	}
+	if !bytes.Equal(buf, data[500:600]) {
+		t.Fatalf("ReadAt content mismatch")
+	}
+
+	etag.Store(`W/"v2"`)
+	if _, err := ra.ReadAt(buf, 800); !errors.Is(err, ErrRangeNotSupported) {
+		t.Fatalf("changed ETag err=%v, want ErrRangeNotSupported", err)
+	}
+}
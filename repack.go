@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// RepackResult contains Repack outcome.
+type RepackResult struct {
+	// PackResult is rewrite statistics.
+	PackResult *PackResult `json:"pack_result,omitempty" yaml:"pack_result,omitempty"`
+	// SizeBefore is archive file size before rewrite.
+	SizeBefore int64 `json:"size_before" yaml:"size_before"`
+	// SizeAfter is archive file size after rewrite.
+	SizeAfter int64 `json:"size_after" yaml:"size_after"`
+	// ReclaimedBytes is SizeBefore minus SizeAfter (gaps, junk tail, and table slack).
+	ReclaimedBytes int64 `json:"reclaimed_bytes" yaml:"reclaimed_bytes"`
+}
+
+// Repack rewrites archive at path in place with sequential payload layout and zero index offsets,
+// dropping gap bytes between stored-offset payloads. Payloads are copied as stored.
+// Empty opts.ReaderOptions.OffsetMode defaults to OffsetModeStoredCompat.
+func Repack(ctx context.Context, path string, opts EditOptions) (*RepackResult, error) {
+	if opts.ReaderOptions.OffsetMode == "" {
+		opts.ReaderOptions.OffsetMode = OffsetModeStoredCompat
+	}
+
+	before, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat archive: %w", err)
+	}
+
+	editor, err := OpenEditor(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := editor.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat repacked archive: %w", err)
+	}
+
+	return &RepackResult{
+		PackResult:     res,
+		SizeBefore:     before.Size(),
+		SizeAfter:      after.Size(),
+		ReclaimedBytes: before.Size() - after.Size(),
+	}, nil
+}
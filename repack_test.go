@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeGappedPBO writes archive with relative stored offsets and gap bytes between payloads.
+func writeGappedPBO(t *testing.T, gap int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(header[1:5], uint32(MimeHeader))
+	buf.Write(header)
+	buf.WriteString("prefix\x00gapped\x00\x00")
+
+	payloads := []struct {
+		name string
+		data string
+	}{{name: "a.txt", data: "alpha"}, {name: "b.txt", data: "bravo"}}
+
+	offset := uint32(0)
+	for _, p := range payloads {
+		buf.WriteString(p.name)
+		buf.WriteByte(0)
+
+		var fields [20]byte
+		binary.LittleEndian.PutUint32(fields[8:12], offset)
+		binary.LittleEndian.PutUint32(fields[16:20], uint32(len(p.data))) //nolint:gosec // test payloads are small
+		buf.Write(fields[:])
+		offset += uint32(len(p.data) + gap) //nolint:gosec // test payloads are small
+	}
+	buf.Write(make([]byte, 21))
+
+	for _, p := range payloads {
+		buf.WriteString(p.data)
+		buf.Write(bytes.Repeat([]byte{0xEE}, gap))
+	}
+
+	path := filepath.Join(t.TempDir(), "gapped.pbo")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write gapped pbo: %v", err)
+	}
+
+	return path
+}
+
+func TestRepackReclaimsGaps(t *testing.T) {
+	t.Parallel()
+
+	const gap = 256
+	path := writeGappedPBO(t, gap)
+
+	res, err := Repack(context.Background(), path, EditOptions{})
+	if err != nil {
+		t.Fatalf("Repack: %v", err)
+	}
+	if res.ReclaimedBytes <= 0 || res.SizeBefore-res.SizeAfter != res.ReclaimedBytes {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	// Repacked archive gains 21-byte SHA1 trailer.
+	if res.ReclaimedBytes != 2*gap-21 {
+		t.Fatalf("reclaimed %d bytes, want %d", res.ReclaimedBytes, 2*gap-21)
+	}
+
+	// Default sequential mode must read repacked payloads correctly.
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open repacked: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for name, want := range map[string]string{"a.txt": "alpha", "b.txt": "bravo"} {
+		got, err := r.ReadEntry(name)
+		if err != nil || string(got) != want {
+			t.Fatalf("ReadEntry %s=%q err=%v, want %q", name, got, err, want)
+		}
+	}
+	if r.Prefix() != "gapped" {
+		t.Fatalf("prefix=%q, want gapped", r.Prefix())
+	}
+}
@@ -93,7 +93,7 @@ func SanitizePath(pathValue string) (string, error) {
 		return "", err
 	}
 
-	if _, err := normalizeExtractEntryPath(sanitized); err != nil {
+	if _, err := NormalizeExtractPath(sanitized); err != nil {
 		return "", err
 	}
 
@@ -108,7 +108,7 @@ func sanitizeEntryInfoPaths(entries []EntryInfo) ([]EntryInfo, error) {
 
 	for i := range entries {
 		relativePath := entries[i].Path
-		normalizedPath, err := normalizeExtractEntryPath(entries[i].Path)
+		normalizedPath, err := NormalizeExtractPath(entries[i].Path)
 		if err == nil {
 			relativePath = normalizedPath
 		} else {
@@ -127,17 +127,26 @@ func sanitizeEntryInfoPaths(entries []EntryInfo) ([]EntryInfo, error) {
 			return nil, fmt.Errorf("sanitize path %s: %w", entries[i].Path, err)
 		}
 
-		if _, err := normalizeExtractEntryPath(sanitized); err != nil {
+		if _, err := NormalizeExtractPath(sanitized); err != nil {
 			return nil, fmt.Errorf("sanitize path %s: %w", entries[i].Path, err)
 		}
 
 		out[i] = entries[i]
 		out[i].Path = sanitized
+		keepOriginalPath(&out[i], entries[i].Path)
 	}
 
 	return out, nil
 }
 
+// keepOriginalPath records stored path of entry rewritten by sanitization. Path stored
+// before first rewrite is kept across control-char and name sanitize passes.
+func keepOriginalPath(entry *EntryInfo, stored string) {
+	if entry.OriginalPath == "" && entry.Path != stored {
+		entry.OriginalPath = stored
+	}
+}
+
 // sanitizeEntryInfoControlPaths rewrites control/format runes in entry paths.
 func sanitizeEntryInfoControlPaths(entries []EntryInfo) ([]EntryInfo, error) {
 	out := make([]EntryInfo, len(entries))
@@ -146,7 +155,7 @@ func sanitizeEntryInfoControlPaths(entries []EntryInfo) ([]EntryInfo, error) {
 
 	for i := range entries {
 		relativePath := entries[i].Path
-		normalizedPath, err := normalizeExtractEntryPath(entries[i].Path)
+		normalizedPath, err := NormalizeExtractPath(entries[i].Path)
 		if err == nil {
 			relativePath = normalizedPath
 		} else {
@@ -167,6 +176,7 @@ func sanitizeEntryInfoControlPaths(entries []EntryInfo) ([]EntryInfo, error) {
 
 		out[i] = entries[i]
 		out[i].Path = sanitized
+		keepOriginalPath(&out[i], entries[i].Path)
 	}
 
 	return out, nil
@@ -220,6 +220,46 @@ func TestOpenWithOptionsSanitizeNames(t *testing.T) {
 	}
 }
 
+func TestOpenWithOptionsSanitizeNamesKeepsOriginalPath(t *testing.T) {
+	t.Parallel()
+
+	path := createManualPBOWithNamedEntries(t, []manualEntry{
+		{name: "CON.txt", data: []byte("a")},
+		{name: "a:b.txt", data: []byte("b")},
+		{name: "a?b.txt", data: []byte("c")},
+		{name: "plain.txt", data: []byte("d")},
+	})
+
+	r, err := OpenWithOptions(path, ReaderOptions{SanitizeNames: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions sanitize: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	want := []string{"CON.txt", "a:b.txt", "a?b.txt", ""}
+	for i, entry := range entries {
+		if entry.OriginalPath != want[i] {
+			t.Fatalf("entries[%d] %s OriginalPath=%q, want %q", i, entry.Path, entry.OriginalPath, want[i])
+		}
+	}
+
+	for name, data := range map[string]string{
+		"a?b.txt":   "c",
+		"a_b~2.txt": "c",
+		"CON.txt":   "a",
+		"plain.txt": "d",
+	} {
+		got, err := r.ReadEntry(name)
+		if err != nil {
+			t.Fatalf("ReadEntry %q: %v", name, err)
+		}
+		if string(got) != data {
+			t.Fatalf("ReadEntry %q got %q, want %q", name, got, data)
+		}
+	}
+}
+
 func TestOpenWithOptionsSanitizeControlChars(t *testing.T) {
 	t.Parallel()
 
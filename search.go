@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// DefaultSearchMaxEntrySize is default decoded size limit of searched entries.
+const DefaultSearchMaxEntrySize = 16 << 20
+
+// searchBinaryProbeSize is number of leading content bytes checked for NUL to skip binary entries.
+const searchBinaryProbeSize = 8 * 1024
+
+// SearchOptions configures Reader.Search and BankSet.Search.
+type SearchOptions struct {
+	// Entries selects searched entries by ordered rules like ExtractMatching.
+	Entries []pathrules.Rule `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// Extensions limits search to entries with these extensions ("c", ".cpp"), case-insensitive.
+	Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	// MaxWorkers is number of parallel search workers (zero means GOMAXPROCS).
+	MaxWorkers int `json:"max_workers,omitempty" yaml:"max_workers,omitempty"`
+	// MaxMatches stops search after this many matches; zero means unlimited.
+	MaxMatches int `json:"max_matches,omitempty" yaml:"max_matches,omitempty"`
+	// MaxEntrySize skips entries whose decoded size exceeds this value.
+	MaxEntrySize int64 `json:"max_entry_size,omitempty" yaml:"max_entry_size,omitempty"`
+	// Literal matches pattern as plain text instead of regular expression.
+	Literal bool `json:"literal,omitempty" yaml:"literal,omitempty"`
+	// IgnoreCase matches case-insensitively.
+	IgnoreCase bool `json:"ignore_case,omitempty" yaml:"ignore_case,omitempty"`
+}
+
+// applyDefaults fills zero-value search options.
+func (opts *SearchOptions) applyDefaults() {
+	if opts.MaxWorkers <= 0 {
+		opts.MaxWorkers = runtime.GOMAXPROCS(0)
+	}
+	if opts.MaxEntrySize <= 0 {
+		opts.MaxEntrySize = DefaultSearchMaxEntrySize
+	}
+}
+
+// SearchMatch is one matching line of searched entry.
+type SearchMatch struct {
+	// Path is entry path; game virtual path for BankSet.Search.
+	Path string `json:"path" yaml:"path"`
+	// Archive is archive path for BankSet.Search; empty for Reader.Search.
+	Archive string `json:"archive,omitempty" yaml:"archive,omitempty"`
+	// Text is matching line without line terminator.
+	Text string `json:"text" yaml:"text"`
+	// Line is 1-based line number.
+	Line int `json:"line" yaml:"line"`
+	// Column is 1-based byte column of first match in line.
+	Column int `json:"column" yaml:"column"`
+}
+
+// searchTarget is one entry queued for search.
+type searchTarget struct {
+	r       *Reader
+	path    string
+	archive string
+	entry   EntryInfo
+}
+
+// Search greps decoded text entries for pattern in parallel and returns matching lines
+// in entry order. Binary entries (NUL in first 8 KiB) and entries over MaxEntrySize are skipped.
+func (r *Reader) Search(ctx context.Context, pattern string, opts SearchOptions) ([]SearchMatch, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+	if err := r.LoadEntries(); err != nil {
+		return nil, err
+	}
+
+	targets := make([]searchTarget, 0, len(r.entries))
+	for _, entry := range r.entries {
+		targets = append(targets, searchTarget{r: r, path: entry.Path, entry: entry})
+	}
+
+	return searchTargets(ctx, pattern, targets, opts)
+}
+
+// Search greps decoded text files of bank set like Reader.Search; matches use virtual
+// paths and are ordered by virtual path.
+func (b *BankSet) Search(ctx context.Context, pattern string, opts SearchOptions) ([]SearchMatch, error) {
+	if b == nil {
+		return nil, ErrNilReader
+	}
+
+	keys := b.sortedKeys()
+	targets := make([]searchTarget, 0, len(keys))
+	for _, key := range keys {
+		file := b.multi.index[key]
+		entry := b.bankEntry(file)
+		targets = append(targets, searchTarget{
+			r:       b.multi.readers[file.archive],
+			path:    entry.Path,
+			archive: entry.Archive,
+			entry:   entry.Entry,
+		})
+	}
+
+	return searchTargets(ctx, pattern, targets, opts)
+}
+
+// searchTargets filters targets by options and searches them with worker pool.
+func searchTargets(parent context.Context, pattern string, targets []searchTarget, opts SearchOptions) ([]SearchMatch, error) {
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	opts.applyDefaults()
+	if opts.Literal {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile search pattern: %w", err)
+	}
+
+	matcher, err := newEntryRulesMatcher(opts.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := targets[:0:0]
+	for _, target := range targets {
+		if searchSelected(target, matcher, opts) {
+			selected = append(selected, target)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var (
+		results  = make([][]SearchMatch, len(selected))
+		errs     = make([]error, len(selected))
+		found    atomic.Int64
+		jobs     = make(chan int)
+		wg       sync.WaitGroup
+		maxFound = int64(opts.MaxMatches)
+	)
+	for range min(opts.MaxWorkers, max(len(selected), 1)) {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i], errs[i] = searchTargetEntry(selected[i], re)
+				if errs[i] != nil {
+					cancel()
+				}
+				if maxFound > 0 && found.Add(int64(len(results[i]))) >= maxFound {
+					cancel()
+				}
+			}
+		})
+	}
+
+	// Targets are fed in order, so every target before the stop point is searched
+	// and truncating flattened results keeps output deterministic.
+feed:
+	for i := range selected {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+
+	var matches []SearchMatch
+	for i := range selected {
+		matches = append(matches, results[i]...)
+		if maxFound > 0 && len(matches) >= int(maxFound) {
+			return matches[:maxFound], nil
+		}
+	}
+
+	return matches, nil
+}
+
+// searchSelected reports whether target passes rule, extension, and size filters.
+func searchSelected(target searchTarget, matcher *pathrules.Matcher, opts SearchOptions) bool {
+	size := int64(filterOriginalSizeOrDataSize(target.entry))
+	if size == 0 || size > opts.MaxEntrySize {
+		return false
+	}
+
+	if len(opts.Extensions) != 0 && !entryExtListed(target.entry.Path, opts.Extensions) {
+		return false
+	}
+
+	if matcher == nil {
+		return true
+	}
+
+	candidate := NormalizePath(target.entry.Path)
+	return candidate != "" && matcher.Included(candidate, false)
+}
+
+// entryExtListed reports whether entry path extension is one of exts ("c", ".cpp"), case-insensitively.
+func entryExtListed(entryPath string, exts []string) bool {
+	ext := signFileExtLower(entryPath)
+	for _, want := range exts {
+		if strings.EqualFold(strings.TrimPrefix(want, "."), ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchTargetEntry reads one entry and returns its matching lines.
+func searchTargetEntry(target searchTarget, re *regexp.Regexp) ([]SearchMatch, error) {
+	rc, err := target.r.OpenEntryInfo(target.entry)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(rc)
+	closeErr := rc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read entry %s: %w", target.path, err)
+	}
+	if closeErr != nil {
+		return nil, fmt.Errorf("close entry %s: %w", target.path, closeErr)
+	}
+	if bytes.IndexByte(data[:min(len(data), searchBinaryProbeSize)], 0) >= 0 {
+		return nil, nil
+	}
+
+	var matches []SearchMatch
+	for lineNo := 1; len(data) > 0; lineNo++ {
+		line := data
+		if end := bytes.IndexByte(data, '\n'); end >= 0 {
+			line, data = data[:end], data[end+1:]
+		} else {
+			data = nil
+		}
+
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		if loc := re.FindIndex(line); loc != nil {
+			matches = append(matches, SearchMatch{
+				Path:    target.path,
+				Archive: target.archive,
+				Text:    string(line),
+				Line:    lineNo,
+				Column:  loc[0] + 1,
+			})
+		}
+	}
+
+	return matches, nil
+}
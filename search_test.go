@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestReader_Search(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "scripts.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"3_game/a.c":   []byte("class A {\r\n\tvoid Init();\r\n\tvoid INIT_B();\r\n}\n"),
+		"3_game/b.c":   []byte("// init here\nvoid Init() {}"),
+		"config.cpp":   []byte("class Init {};"),
+		"data/bin.paa": []byte("Init\x00binary"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	got, err := r.Search(context.Background(), `void Init\(`, SearchOptions{Extensions: []string{".c"}})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != 2 ||
+		got[0] != (SearchMatch{Path: `3_game\a.c`, Text: "\tvoid Init();", Line: 2, Column: 2}) ||
+		got[1] != (SearchMatch{Path: `3_game\b.c`, Text: "void Init() {}", Line: 2, Column: 1}) {
+		t.Fatalf("Search=%+v", got)
+	}
+
+	got, err = r.Search(context.Background(), "init", SearchOptions{IgnoreCase: true})
+	if err != nil {
+		t.Fatalf("Search ignore case: %v", err)
+	}
+	if len(got) != 5 {
+		t.Fatalf("Search ignore case=%+v", got)
+	}
+
+	got, err = r.Search(context.Background(), "Init(", SearchOptions{Literal: true, MaxMatches: 1, MaxWorkers: 1})
+	if err != nil {
+		t.Fatalf("Search literal: %v", err)
+	}
+	if len(got) != 1 || got[0].Path != `3_game\a.c` {
+		t.Fatalf("Search literal=%+v", got)
+	}
+
+	if _, err := r.Search(context.Background(), "(", SearchOptions{}); err == nil {
+		t.Fatal("Search invalid pattern: expected error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.Search(ctx, "Init", SearchOptions{}); err == nil {
+		t.Fatal("Search canceled: expected error")
+	}
+}
+
+func TestBankSet_Search(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "a_base.pbo")
+	if err := createTestPBO(basePath, map[string][]byte{
+		"x.c": []byte("old Marker"),
+		"y.c": []byte("base Marker"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "dz\\scripts"}}}); err != nil {
+		t.Fatalf("createTestPBO base: %v", err)
+	}
+
+	patchPath := filepath.Join(dir, "b_patch.pbo")
+	if err := createTestPBO(patchPath, map[string][]byte{
+		"x.c": []byte("new Marker"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "dz\\scripts"}}}); err != nil {
+		t.Fatalf("createTestPBO patch: %v", err)
+	}
+
+	b, err := OpenBankSetDir(dir, ReaderOptions{})
+	if err != nil {
+		t.Fatalf("OpenBankSetDir: %v", err)
+	}
+	defer func() { _ = b.Close() }()
+
+	got, err := b.Search(context.Background(), "Marker", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != 2 ||
+		got[0].Path != `\dz\scripts\x.c` || got[0].Archive != patchPath || got[0].Text != "new Marker" ||
+		got[1].Path != `\dz\scripts\y.c` || got[1].Archive != basePath {
+		t.Fatalf("Search=%+v", got)
+	}
+}
@@ -0,0 +1,384 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IndexSidecarExt is file extension of index sidecar written next to archive.
+const IndexSidecarExt = ".pboidx"
+
+const (
+	// indexSidecarMagic opens sidecar file.
+	indexSidecarMagic = "PBOIDX"
+	// indexSidecarVersion is sidecar format version.
+	indexSidecarVersion = 1
+	// indexSidecarFlagTrailer marks archive with SHA1 trailer.
+	indexSidecarFlagTrailer = 1 << 0
+	// indexSidecarFlagHashSet marks sidecar with signature hash set.
+	indexSidecarFlagHashSet = 1 << 1
+)
+
+// IndexSidecarOptions configures WriteIndexSidecar.
+type IndexSidecarOptions struct {
+	// HashGameType is game type of stored hash set.
+	HashGameType GameType `json:"hash_game_type,omitempty" yaml:"hash_game_type,omitempty"`
+	// HashVersion stores signature hash set of this version in sidecar; zero skips hash set.
+	HashVersion SignVersion `json:"hash_version,omitempty" yaml:"hash_version,omitempty"`
+}
+
+// IndexSidecar is archive index read from .pboidx sidecar.
+type IndexSidecar struct {
+	// ArchiveModTime is archive modification time sidecar is valid for.
+	ArchiveModTime time.Time `json:"archive_mod_time" yaml:"archive_mod_time"`
+	// HashSet is stored signature hash set; nil when sidecar has none.
+	HashSet *HashSet `json:"hash_set,omitempty" yaml:"hash_set,omitempty"`
+	// HashGameType is game type of HashSet.
+	HashGameType GameType `json:"hash_game_type,omitempty" yaml:"hash_game_type,omitempty"`
+	// Headers are archive header pairs.
+	Headers []HeaderPair `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Entries are parsed archive entries with resolved payload offsets.
+	Entries []EntryInfo `json:"entries,omitempty" yaml:"entries,omitempty"`
+	// ArchiveSize is archive size in bytes sidecar is valid for.
+	ArchiveSize int64 `json:"archive_size" yaml:"archive_size"`
+	// HashVersion is signature version of HashSet.
+	HashVersion SignVersion `json:"hash_version,omitempty" yaml:"hash_version,omitempty"`
+
+	// header is fixed 21-byte archive header block.
+	header []byte
+	// dataStart is absolute offset of first payload byte.
+	dataStart int64
+	// sha1Trailer is archive trailer hash when hasTrailer is set.
+	sha1Trailer [shaSize]byte
+	// hasTrailer reports whether archive ends with SHA1 trailer.
+	hasTrailer bool
+}
+
+// IndexSidecarPath returns sidecar path of archive: ".pbo" extension replaced by ".pboidx".
+func IndexSidecarPath(pboPath string) string {
+	if ext := filepath.Ext(pboPath); strings.EqualFold(ext, ".pbo") {
+		pboPath = strings.TrimSuffix(pboPath, ext)
+	}
+
+	return pboPath + IndexSidecarExt
+}
+
+// WriteIndexSidecar parses archive at pboPath and writes its header, entry table, and
+// optional signature hash set to IndexSidecarPath. Sidecar records archive size and
+// modification time; ReadIndexSidecar and OpenWithSidecar reject it once archive changes.
+func WriteIndexSidecar(pboPath string, opts IndexSidecarOptions) error {
+	r, err := Open(pboPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.decrypted {
+		return fmt.Errorf("%w: entry table belongs to decrypted view", ErrEncryptedArchive)
+	}
+
+	fi, err := r.file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	sc := &IndexSidecar{
+		ArchiveModTime: fi.ModTime(),
+		Headers:        r.Headers(),
+		Entries:        r.entries,
+		header:         r.header,
+		ArchiveSize:    r.size,
+		dataStart:      r.dataStart,
+		sha1Trailer:    r.sha1Trailer,
+		hasTrailer:     r.hasTrailer,
+	}
+
+	if opts.HashVersion != 0 {
+		spec, policy, err := signPolicyFor(opts.HashVersion, opts.HashGameType)
+		if err != nil {
+			return err
+		}
+
+		details, err := computeHashDetailsFromPackedParts(r.ra, r.size, r.hasTrailer, sc.Headers, r.entries, opts.HashVersion, spec, policy)
+		if err != nil {
+			return err
+		}
+
+		hs, err := details.HashSet()
+		if err != nil {
+			return err
+		}
+
+		sc.HashSet = &hs
+		sc.HashVersion = opts.HashVersion
+		sc.HashGameType = opts.HashGameType
+	}
+
+	sidecarPath := IndexSidecarPath(pboPath)
+	tmpPath := sidecarPath + ".tmp"
+	if err := os.WriteFile(tmpPath, sc.encode(), 0o644); err != nil { //nolint:gosec // sidecar is public like archive
+		return fmt.Errorf("write index sidecar: %w", err)
+	}
+	if err := os.Rename(tmpPath, sidecarPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename index sidecar: %w", err)
+	}
+
+	return nil
+}
+
+// ReadIndexSidecar reads sidecar of archive at pboPath and checks it against archive
+// size and modification time. It fails with ErrStaleIndexSidecar when archive changed
+// and ErrInvalidIndexSidecar when sidecar is malformed.
+func ReadIndexSidecar(pboPath string) (*IndexSidecar, error) {
+	fi, err := os.Stat(pboPath)
+	if err != nil {
+		return nil, fmt.Errorf("stat: %w", err)
+	}
+
+	return readIndexSidecar(pboPath, fi)
+}
+
+// OpenWithSidecar opens PBO file like Open, taking header and entry table from valid
+// sidecar instead of parsing archive. Missing, stale, or malformed sidecar falls back to Open.
+func OpenWithSidecar(pboPath string) (*Reader, error) {
+	f, err := os.Open(pboPath)
+	if err != nil {
+		return nil, fmt.Errorf("open PBO: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("stat: %w", err)
+	}
+
+	sc, err := readIndexSidecar(pboPath, fi)
+	if err != nil {
+		r, err := NewReaderFromReaderAtWithOptions(f, fi.Size(), ReaderOptions{})
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+
+		r.file = f
+		return r, nil
+	}
+
+	r := &Reader{
+		ra:            f,
+		file:          f,
+		header:        sc.header,
+		headers:       make([]headerPair, len(sc.Headers)),
+		entries:       sc.Entries,
+		size:          sc.ArchiveSize,
+		dataStart:     sc.dataStart,
+		decompressSem: newDecompressSem(0),
+		sha1Trailer:   sc.sha1Trailer,
+		hasTrailer:    sc.hasTrailer,
+	}
+	for i, h := range sc.Headers {
+		r.headers[i] = headerPair(h)
+	}
+
+	return r, nil
+}
+
+// readIndexSidecar reads sidecar of archive with file info fi.
+func readIndexSidecar(pboPath string, fi os.FileInfo) (*IndexSidecar, error) {
+	data, err := os.ReadFile(IndexSidecarPath(pboPath))
+	if err != nil {
+		return nil, fmt.Errorf("read index sidecar: %w", err)
+	}
+
+	sc, err := decodeIndexSidecar(data)
+	if err != nil {
+		return nil, err
+	}
+	if sc.ArchiveSize != fi.Size() || !sc.ArchiveModTime.Equal(fi.ModTime()) {
+		return nil, fmt.Errorf("%w: archive size or modification time changed", ErrStaleIndexSidecar)
+	}
+
+	return sc, nil
+}
+
+// encode serializes sidecar: magic, version, flags, archive identity, header block,
+// trailer, header pairs, entries, and optional hash set.
+func (sc *IndexSidecar) encode() []byte {
+	var flags byte
+	if sc.hasTrailer {
+		flags |= indexSidecarFlagTrailer
+	}
+	if sc.HashSet != nil {
+		flags |= indexSidecarFlagHashSet
+	}
+
+	buf := make([]byte, 0, 128+len(sc.Entries)*64)
+	buf = append(buf, indexSidecarMagic...)
+	buf = append(buf, indexSidecarVersion, flags)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(sc.ArchiveSize))               //nolint:gosec // size is non-negative
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(sc.ArchiveModTime.UnixNano())) //nolint:gosec // round-trips through int64
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(sc.dataStart))                 //nolint:gosec // offset is non-negative
+	buf = append(buf, sc.header...)
+	buf = append(buf, sc.sha1Trailer[:]...)
+
+	buf = binary.AppendUvarint(buf, uint64(len(sc.Headers)))
+	for _, h := range sc.Headers {
+		buf = appendSidecarString(buf, h.Key)
+		buf = appendSidecarString(buf, h.Value)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(sc.Entries)))
+	for i := range sc.Entries {
+		e := &sc.Entries[i]
+		buf = appendSidecarString(buf, e.Path)
+		for _, v := range [...]uint32{e.Offset, e.DataSize, e.OriginalSize, e.TimeStamp, uint32(e.MimeType), e.Reserved} {
+			buf = binary.LittleEndian.AppendUint32(buf, v)
+		}
+	}
+
+	if sc.HashSet != nil {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(sc.HashVersion))
+		buf = appendSidecarString(buf, string(sc.HashGameType))
+		buf = append(buf, sc.HashSet.Hash1[:]...)
+		buf = append(buf, sc.HashSet.Hash2[:]...)
+		buf = append(buf, sc.HashSet.Hash3[:]...)
+	}
+
+	return buf
+}
+
+// appendSidecarString appends uvarint length-prefixed string.
+func appendSidecarString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// sidecarDecoder reads sidecar fields and keeps first error.
+type sidecarDecoder struct {
+	err  error
+	data []byte
+}
+
+// bytes returns next n bytes.
+func (d *sidecarDecoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.err = fmt.Errorf("%w: truncated", ErrInvalidIndexSidecar)
+		return nil
+	}
+
+	out := d.data[:n]
+	d.data = d.data[n:]
+	return out
+}
+
+// uint32 returns next little-endian uint32.
+func (d *sidecarDecoder) uint32() uint32 {
+	if b := d.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+
+	return 0
+}
+
+// int64 returns next little-endian int64.
+func (d *sidecarDecoder) int64() int64 {
+	if b := d.bytes(8); b != nil {
+		return int64(binary.LittleEndian.Uint64(b)) //nolint:gosec // written from int64
+	}
+
+	return 0
+}
+
+// count returns next uvarint bounded by remaining bytes, each item taking at least minSize.
+func (d *sidecarDecoder) count(minSize int) int {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 || v > uint64(len(d.data)-n)/uint64(minSize) {
+		d.err = fmt.Errorf("%w: bad length", ErrInvalidIndexSidecar)
+		return 0
+	}
+
+	d.data = d.data[n:]
+	return int(v) //nolint:gosec // bounded by data length
+}
+
+// string returns next length-prefixed string.
+func (d *sidecarDecoder) string() string {
+	return string(d.bytes(d.count(1)))
+}
+
+// decodeIndexSidecar parses sidecar bytes written by encode.
+func decodeIndexSidecar(data []byte) (*IndexSidecar, error) {
+	d := &sidecarDecoder{data: data}
+	if magic := d.bytes(len(indexSidecarMagic)); string(magic) != indexSidecarMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidIndexSidecar)
+	}
+
+	head := d.bytes(2)
+	if d.err != nil || head[0] != indexSidecarVersion {
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidIndexSidecar)
+	}
+	flags := head[1]
+
+	sc := &IndexSidecar{
+		ArchiveSize:    d.int64(),
+		ArchiveModTime: time.Unix(0, d.int64()),
+		dataStart:      d.int64(),
+		header:         d.bytes(headerSize),
+		hasTrailer:     flags&indexSidecarFlagTrailer != 0,
+	}
+	copy(sc.sha1Trailer[:], d.bytes(shaSize))
+	sc.header = append([]byte(nil), sc.header...)
+
+	sc.Headers = make([]HeaderPair, d.count(2))
+	for i := range sc.Headers {
+		sc.Headers[i] = HeaderPair{Key: d.string(), Value: d.string()}
+	}
+
+	sc.Entries = make([]EntryInfo, d.count(25))
+	for i := range sc.Entries {
+		e := &sc.Entries[i]
+		e.Path = d.string()
+		e.Offset = d.uint32()
+		e.DataSize = d.uint32()
+		e.OriginalSize = d.uint32()
+		e.TimeStamp = d.uint32()
+		e.MimeType = MimeType(d.uint32())
+		e.Reserved = d.uint32()
+	}
+
+	if flags&indexSidecarFlagHashSet != 0 {
+		sc.HashVersion = SignVersion(d.uint32())
+		sc.HashGameType = GameType(d.string())
+
+		var hs HashSet
+		copy(hs.Hash1[:], d.bytes(len(hs.Hash1)))
+		copy(hs.Hash2[:], d.bytes(len(hs.Hash2)))
+		copy(hs.Hash3[:], d.bytes(len(hs.Hash3)))
+		sc.HashSet = &hs
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.data) != 0 {
+		return nil, fmt.Errorf("%w: trailing bytes", ErrInvalidIndexSidecar)
+	}
+
+	return sc, nil
+}
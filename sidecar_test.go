@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestIndexSidecar_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	pboPath := filepath.Join(dir, "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp": []byte("class CfgPatches {};"),
+		"data/a.paa": []byte("texture"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "addon"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	if got := IndexSidecarPath(pboPath); got != filepath.Join(dir, "addon.pboidx") {
+		t.Fatalf("IndexSidecarPath=%q", got)
+	}
+	if _, err := ReadIndexSidecar(pboPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("ReadIndexSidecar missing err=%v", err)
+	}
+
+	if err := WriteIndexSidecar(pboPath, IndexSidecarOptions{HashVersion: SignVersionV3, HashGameType: GameTypeDayZ}); err != nil {
+		t.Fatalf("WriteIndexSidecar: %v", err)
+	}
+
+	want, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = want.Close() }()
+
+	sc, err := ReadIndexSidecar(pboPath)
+	if err != nil {
+		t.Fatalf("ReadIndexSidecar: %v", err)
+	}
+	hs, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+	if sc.HashSet == nil || *sc.HashSet != hs || sc.HashVersion != SignVersionV3 || sc.HashGameType != GameTypeDayZ {
+		t.Fatalf("hash set=%v version=%d game=%q", sc.HashSet, sc.HashVersion, sc.HashGameType)
+	}
+	if !slices.Equal(sc.Entries, want.Entries()) || !slices.Equal(sc.Headers, want.Headers()) {
+		t.Fatalf("sidecar entries=%+v headers=%+v", sc.Entries, sc.Headers)
+	}
+
+	r, err := OpenWithSidecar(pboPath)
+	if err != nil {
+		t.Fatalf("OpenWithSidecar: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.Prefix() != "addon" || r.dataStart != want.dataStart || !r.hasTrailer {
+		t.Fatalf("prefix=%q dataStart=%d trailer=%v", r.Prefix(), r.dataStart, r.hasTrailer)
+	}
+	if data, err := r.ReadEntry("data/a.paa"); err != nil || string(data) != "texture" {
+		t.Fatalf("ReadEntry=%q err=%v", data, err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(pboPath, later, later); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if _, err := ReadIndexSidecar(pboPath); !errors.Is(err, ErrStaleIndexSidecar) {
+		t.Fatalf("ReadIndexSidecar stale err=%v", err)
+	}
+
+	stale, err := OpenWithSidecar(pboPath)
+	if err != nil {
+		t.Fatalf("OpenWithSidecar stale: %v", err)
+	}
+	defer func() { _ = stale.Close() }()
+
+	if stale.EntryCount() != 2 {
+		t.Fatalf("stale fallback EntryCount=%d", stale.EntryCount())
+	}
+}
+
+func TestIndexSidecar_RejectsMalformed(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+	if err := WriteIndexSidecar(pboPath, IndexSidecarOptions{}); err != nil {
+		t.Fatalf("WriteIndexSidecar: %v", err)
+	}
+
+	data, err := os.ReadFile(IndexSidecarPath(pboPath))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	for _, bad := range [][]byte{data[:len(data)-3], append(slices.Clone(data), 0), append([]byte("XXXXXX"), data[6:]...)} {
+		if _, err := decodeIndexSidecar(bad); !errors.Is(err, ErrInvalidIndexSidecar) {
+			t.Fatalf("decode malformed err=%v", err)
+		}
+	}
+
+	writeTestFile(t, IndexSidecarPath(pboPath), data[:len(data)-3])
+	r, err := OpenWithSidecar(pboPath)
+	if err != nil {
+		t.Fatalf("OpenWithSidecar malformed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if data, err := r.ReadEntry("a.txt"); err != nil || string(data) != "alpha" {
+		t.Fatalf("ReadEntry=%q err=%v", data, err)
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// defaultHashSetsPattern selects archives when ComputeHashSets pattern is empty.
+const defaultHashSetsPattern = "**/*.pbo"
+
+// ComputeHashSets calculates hash sets of every file under dir whose slash-separated
+// relative path matches pattern (pathrules glob, case-insensitive; empty means "**/*.pbo").
+// Archives are hashed by workers goroutines (zero means GOMAXPROCS). Result is keyed by
+// file path (dir joined with relative path). First failure or ctx cancellation stops
+// remaining work and is returned.
+func ComputeHashSets(
+	ctx context.Context,
+	dir string,
+	pattern string,
+	version SignVersion,
+	gameType GameType,
+	workers int,
+) (map[string]HashSet, error) {
+	_, policy, err := signPolicyFor(version, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeHashSets(ctx, dir, pattern, version, policy, workers)
+}
+
+// ComputeHashSetsWithPolicy is ComputeHashSets selecting filehash entries by policy
+// instead of built-in game extension lists.
+func ComputeHashSetsWithPolicy(
+	ctx context.Context,
+	dir string,
+	pattern string,
+	version SignVersion,
+	policy SignPolicy,
+	workers int,
+) (map[string]HashSet, error) {
+	if _, err := validateSignPolicyArgs(version, policy); err != nil {
+		return nil, err
+	}
+
+	return computeHashSets(ctx, dir, pattern, version, policy, workers)
+}
+
+// computeHashSets hashes matching archives under dir with worker pool.
+func computeHashSets(
+	ctx context.Context,
+	dir string,
+	pattern string,
+	version SignVersion,
+	policy SignPolicy,
+	workers int,
+) (map[string]HashSet, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	paths, err := findHashSetArchives(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, max(len(paths), 1))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan string)
+	results := make(map[string]HashSet, len(paths))
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	for range workers {
+		wg.Go(func() {
+			for path := range jobs {
+				hs, err := ComputeHashSetWithPolicy(path, version, policy)
+				if err != nil {
+					fail(fmt.Errorf("hash %s: %w", path, err))
+					continue
+				}
+
+				mu.Lock()
+				results[path] = hs
+				mu.Unlock()
+			}
+		})
+	}
+
+dispatch:
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- path:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// findHashSetArchives walks dir and returns regular files matching pattern in walk order.
+func findHashSetArchives(dir string, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = defaultHashSetsPattern
+	}
+
+	matcher, err := newEntryRulesMatcher([]pathrules.Rule{{Action: pathrules.ActionInclude, Pattern: pattern}})
+	if err != nil {
+		return nil, err
+	}
+	if matcher == nil {
+		return nil, fmt.Errorf("%w: empty pattern", ErrInvalidEntryPath)
+	}
+
+	var paths []string
+	walkErr := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return fmt.Errorf("resolve relative path %s: %w", filePath, err)
+		}
+
+		if matcher.Included(NormalizePath(filepath.ToSlash(relPath)), false) {
+			paths = append(paths, filePath)
+		}
+
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("walk %s: %w", dir, walkErr)
+	}
+
+	return paths, nil
+}
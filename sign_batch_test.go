@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeHashSets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	addons := filepath.Join(dir, "@mod", "addons")
+	if err := os.MkdirAll(addons, 0o750); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	paths := []string{filepath.Join(addons, "a.pbo"), filepath.Join(addons, "B.PBO")}
+	for i, p := range paths {
+		if err := createTestPBO(p, map[string][]byte{
+			"config.cpp": []byte("class CfgPatches {};"),
+			"script.c":   []byte{byte('0' + i)},
+		}, PackOptions{}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "@mod", "readme.txt"), []byte("not archive"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ctx := context.Background()
+	got, err := ComputeHashSets(ctx, dir, "", SignVersionV3, GameTypeDayZ, 2)
+	if err != nil {
+		t.Fatalf("ComputeHashSets: %v", err)
+	}
+	if len(got) != len(paths) {
+		t.Fatalf("results=%d, want %d", len(got), len(paths))
+	}
+	for _, p := range paths {
+		want, err := ComputeHashSet(p, SignVersionV3, GameTypeDayZ)
+		if err != nil {
+			t.Fatalf("ComputeHashSet: %v", err)
+		}
+		if got[p] != want {
+			t.Fatalf("%s hash set mismatch", p)
+		}
+	}
+
+	only, err := ComputeHashSets(ctx, dir, "**/a.pbo", SignVersionV3, GameTypeDayZ, 0)
+	if err != nil || len(only) != 1 {
+		t.Fatalf("pattern results=%d err=%v, want 1", len(only), err)
+	}
+
+	if err := os.WriteFile(filepath.Join(addons, "broken.pbo"), []byte("garbage"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := ComputeHashSets(ctx, dir, "", SignVersionV3, GameTypeDayZ, 2); !errors.Is(err, ErrInvalidHeader) {
+		t.Fatalf("broken archive err=%v, want ErrInvalidHeader", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := ComputeHashSets(canceled, dir, "**/a.pbo", SignVersionV3, GameTypeDayZ, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled err=%v, want context.Canceled", err)
+	}
+}
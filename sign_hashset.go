@@ -5,7 +5,8 @@
 package pbo
 
 import (
-	"crypto/sha1" //nolint:gosec // Signature format requires SHA1.
+	"crypto"
+	_ "crypto/sha1" //nolint:gosec // Signature format requires SHA1.
 	"fmt"
 	"io"
 	"sort"
@@ -14,44 +15,118 @@ import (
 
 const signHashCopyBufferSize = 32 * 1024
 
+// HashDetails is signature hash set with intermediate digests, useful for comparing
+// results against other signing tools. Digest length depends on version hash algorithm.
+type HashDetails struct {
+	// Prefix is "prefix" header value mixed into hash2 and hash3.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// FileHashEntries lists entry paths hashed into FileHash in hashing order.
+	FileHashEntries []string `json:"file_hash_entries,omitempty" yaml:"file_hash_entries,omitempty"`
+	// Hash1 is digest of full PBO data (without trailer).
+	Hash1 []byte `json:"hash1" yaml:"hash1"`
+	// NameHash is digest of sorted normalized entry names.
+	NameHash []byte `json:"name_hash" yaml:"name_hash"`
+	// FileHash is digest of packed payloads selected by filehash policy.
+	FileHash []byte `json:"file_hash" yaml:"file_hash"`
+	// Hash2 is composed from hash1, name hash, and prefix rules.
+	Hash2 []byte `json:"hash2" yaml:"hash2"`
+	// Hash3 is composed from file hash, name hash, and prefix rules.
+	Hash3 []byte `json:"hash3" yaml:"hash3"`
+	// Version is signature hash policy version.
+	Version SignVersion `json:"version" yaml:"version"`
+}
+
+// HashSet converts details to fixed-size HashSet; fails for non-SHA1 versions.
+func (d *HashDetails) HashSet() (HashSet, error) {
+	var hs HashSet
+	if d == nil {
+		return hs, ErrNilReader
+	}
+
+	for _, v := range [][]byte{d.Hash1, d.Hash2, d.Hash3} {
+		if len(v) != len(hs.Hash1) {
+			return hs, fmt.Errorf("%w: version %d digest is %d bytes", ErrInvalidSHA1DigestLength, d.Version, len(v))
+		}
+	}
+
+	copy(hs.Hash1[:], d.Hash1)
+	copy(hs.Hash2[:], d.Hash2)
+	copy(hs.Hash3[:], d.Hash3)
+
+	return hs, nil
+}
+
 // ComputeHashSet calculates hash1/hash2/hash3 for a PBO.
 func ComputeHashSet(path string, version SignVersion, gameType GameType) (HashSet, error) {
-	var hs HashSet
+	details, err := ComputeHashDetails(path, version, gameType)
+	if err != nil {
+		return HashSet{}, err
+	}
 
-	if err := validateSignHashArgs(version, gameType); err != nil {
-		return hs, err
+	return details.HashSet()
+}
+
+// ComputeHashSetWithPolicy calculates hash1/hash2/hash3 for a PBO selecting filehash
+// entries by policy instead of built-in game extension lists.
+func ComputeHashSetWithPolicy(path string, version SignVersion, policy SignPolicy) (HashSet, error) {
+	details, err := ComputeHashDetailsWithPolicy(path, version, policy)
+	if err != nil {
+		return HashSet{}, err
 	}
 
+	return details.HashSet()
+}
+
+// ComputeHashDetails calculates hash set and intermediate digests for a PBO.
+// Unlike ComputeHashSet it supports registered versions with any digest size.
+func ComputeHashDetails(path string, version SignVersion, gameType GameType) (*HashDetails, error) {
+	spec, policy, err := signPolicyFor(version, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeHashDetailsFromFile(path, version, spec, policy)
+}
+
+// ComputeHashDetailsWithPolicy is ComputeHashDetails selecting filehash entries by policy.
+func ComputeHashDetailsWithPolicy(path string, version SignVersion, policy SignPolicy) (*HashDetails, error) {
+	spec, err := validateSignPolicyArgs(version, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeHashDetailsFromFile(path, version, spec, policy)
+}
+
+// computeHashDetailsFromFile opens archive and calculates hash details.
+func computeHashDetailsFromFile(path string, version SignVersion, spec SignVersionSpec, policy SignPolicy) (*HashDetails, error) {
 	r, err := Open(path)
 	if err != nil {
-		return hs, err
+		return nil, err
 	}
 	defer func() { _ = r.Close() }()
 
-	return computeHashSetFromReader(r, version, gameType)
+	return computeHashDetailsFromPackedParts(r.ra, r.size, r.hasTrailer, r.Headers(), r.entries, version, spec, policy)
 }
 
 // validateSignHashArgs validates hash/signing options.
 func validateSignHashArgs(version SignVersion, gameType GameType) error {
-	if version != SignVersionV2 && version != SignVersionV3 {
-		return fmt.Errorf("%w: got %d", ErrUnsupportedSignVersion, version)
-	}
+	_, _, err := signPolicyFor(version, gameType)
+	return err
+}
 
-	gameType = normalizeGameType(gameType)
-	if version == SignVersionV3 && gameType != GameTypeArma && gameType != GameTypeDayZ {
-		return fmt.Errorf("%w: %q", ErrUnsupportedGameTypeV3, gameType)
+// validateSignPolicyArgs validates hash options with custom filehash policy.
+func validateSignPolicyArgs(version SignVersion, policy SignPolicy) (SignVersionSpec, error) {
+	spec, err := lookupSignVersion(version)
+	if err != nil {
+		return spec, err
 	}
 
-	return nil
-}
-
-// computeHashSetFromReader calculates hash1/hash2/hash3 from parsed reader.
-func computeHashSetFromReader(r *Reader, version SignVersion, gameType GameType) (HashSet, error) {
-	if r == nil {
-		return HashSet{}, ErrNilReader
+	if policy == nil {
+		return spec, ErrNilSignPolicy
 	}
 
-	return computeHashSetFromPackedParts(r.ra, r.size, r.hasTrailer, r.Headers(), r.entries, version, gameType)
+	return spec, nil
 }
 
 // computeHashSetFromPackedParts calculates hash set from packed metadata and ReaderAt.
@@ -62,34 +137,57 @@ func computeHashSetFromPackedParts(
 	headers []HeaderPair,
 	entries []EntryInfo,
 	version SignVersion,
-	gameType GameType,
+	policy SignPolicy,
 ) (HashSet, error) {
-	var hs HashSet
+	spec, err := lookupSignVersion(version)
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	details, err := computeHashDetailsFromPackedParts(ra, size, hasTrailer, headers, entries, version, spec, policy)
+	if err != nil {
+		return HashSet{}, err
+	}
+
+	return details.HashSet()
+}
+
+// computeHashDetailsFromPackedParts calculates hash details from packed metadata and ReaderAt.
+func computeHashDetailsFromPackedParts(
+	ra io.ReaderAt,
+	size int64,
+	hasTrailer bool,
+	headers []HeaderPair,
+	entries []EntryInfo,
+	version SignVersion,
+	spec SignVersionSpec,
+	policy SignPolicy,
+) (*HashDetails, error) {
 	if ra == nil {
-		return hs, ErrNilReader
+		return nil, ErrNilReader
 	}
 
-	prefix := pboPrefixFromHeaders(headers)
+	d := &HashDetails{
+		Prefix:  pboPrefixFromHeaders(headers),
+		Version: version,
+	}
 
-	hash1, err := computeSignHash1(ra, size, hasTrailer)
+	var err error
+	d.Hash1, err = computeSignHash1(spec.Hash, ra, size, hasTrailer)
 	if err != nil {
-		return hs, fmt.Errorf("hash1: %w", err)
+		return nil, fmt.Errorf("hash1: %w", err)
 	}
 
-	nameHash := computeSignNameHash(entries)
-	fileHash, err := computeSignFileHashFromReaderAt(ra, entries, version, gameType)
+	d.NameHash = computeSignNameHash(spec.Hash, entries)
+	d.FileHash, d.FileHashEntries, err = computeSignFileHashFromReaderAt(spec, ra, entries, policy)
 	if err != nil {
-		return hs, fmt.Errorf("filehash: %w", err)
+		return nil, fmt.Errorf("filehash: %w", err)
 	}
 
-	hash2 := computeSignHash2(hash1, nameHash, prefix)
-	hash3 := computeSignHash3(fileHash, nameHash, prefix)
-
-	copy(hs.Hash1[:], hash1)
-	copy(hs.Hash2[:], hash2)
-	copy(hs.Hash3[:], hash3)
+	d.Hash2 = computeSignHash2(spec.Hash, d.Hash1, d.NameHash, d.Prefix)
+	d.Hash3 = computeSignHash3(spec.Hash, d.FileHash, d.NameHash, d.Prefix)
 
-	return hs, nil
+	return d, nil
 }
 
 // pboPrefixFromHeaders extracts the "prefix" header value from PBO metadata.
@@ -103,13 +201,13 @@ func pboPrefixFromHeaders(headers []HeaderPair) string {
 }
 
 // computeSignHash1 hashes full PBO content excluding optional 21-byte trailer.
-func computeSignHash1(ra io.ReaderAt, size int64, hasTrailer bool) ([]byte, error) {
+func computeSignHash1(alg crypto.Hash, ra io.ReaderAt, size int64, hasTrailer bool) ([]byte, error) {
 	toRead := size
 	if hasTrailer && size >= 21 {
 		toRead = size - 21
 	}
 
-	h := sha1.New() //nolint:gosec // Signature format requires SHA1.
+	h := alg.New()
 	if _, err := io.Copy(h, io.NewSectionReader(ra, 0, toRead)); err != nil {
 		return nil, err
 	}
@@ -130,8 +228,8 @@ func writeSignPrefix(h io.Writer, prefix string) {
 }
 
 // computeSignHash2 builds hash2 from hash1, namehash, and optional prefix.
-func computeSignHash2(hash1 []byte, nameHash []byte, prefix string) []byte {
-	h := sha1.New() //nolint:gosec // Signature format requires SHA1.
+func computeSignHash2(alg crypto.Hash, hash1 []byte, nameHash []byte, prefix string) []byte {
+	h := alg.New()
 	_, _ = h.Write(hash1)
 	_, _ = h.Write(nameHash)
 	writeSignPrefix(h, prefix)
@@ -139,16 +237,16 @@ func computeSignHash2(hash1 []byte, nameHash []byte, prefix string) []byte {
 }
 
 // computeSignHash3 builds hash3 from filehash, namehash, and optional prefix.
-func computeSignHash3(fileHash []byte, nameHash []byte, prefix string) []byte {
-	h := sha1.New() //nolint:gosec // Signature format requires SHA1.
+func computeSignHash3(alg crypto.Hash, fileHash []byte, nameHash []byte, prefix string) []byte {
+	h := alg.New()
 	_, _ = h.Write(fileHash)
 	_, _ = h.Write(nameHash)
 	writeSignPrefix(h, prefix)
 	return h.Sum(nil)
 }
 
-// computeSignNameHash builds deterministic SHA1 over normalized entry names.
-func computeSignNameHash(entries []EntryInfo) []byte {
+// computeSignNameHash builds deterministic digest over normalized entry names.
+func computeSignNameHash(alg crypto.Hash, entries []EntryInfo) []byte {
 	names := make([]string, 0, len(entries))
 	for _, e := range entries {
 		if e.Path == "" || e.DataSize == 0 {
@@ -159,7 +257,7 @@ func computeSignNameHash(entries []EntryInfo) []byte {
 	}
 
 	sort.Strings(names)
-	h := sha1.New() //nolint:gosec // Signature format requires SHA1.
+	h := alg.New()
 
 	prev := ""
 	for _, n := range names {
@@ -204,33 +302,28 @@ func normalizeSignEntryName(path string) string {
 	return string(b)
 }
 
-// computeSignFileHashFromReaderAt builds deterministic SHA1 over selected packed payload bytes.
+// computeSignFileHashFromReaderAt builds deterministic digest over selected packed payload bytes.
 func computeSignFileHashFromReaderAt(
+	spec SignVersionSpec,
 	ra io.ReaderAt,
 	entries []EntryInfo,
-	version SignVersion,
-	gameType GameType,
-) ([]byte, error) {
+	policy SignPolicy,
+) ([]byte, []string, error) {
 	if ra == nil {
-		return nil, ErrNilReader
+		return nil, nil, ErrNilReader
 	}
 
-	h := sha1.New() //nolint:gosec // Signature format requires SHA1.
+	h := spec.Hash.New()
 	var copyBufArr [signHashCopyBufferSize]byte
 	copyBuf := copyBufArr[:]
 
-	gameType = normalizeGameType(gameType)
-	hashedAny := false
+	var hashed []string
 	for _, e := range entries {
 		if e.Path == "" || e.DataSize == 0 {
 			continue
 		}
 
-		ok, err := shouldHashFileForSign(version, gameType, e.Path)
-		if err != nil {
-			return nil, err
-		}
-		if !ok {
+		if !policy.HashFile(e.Path) {
 			continue
 		}
 
@@ -246,7 +339,7 @@ func computeSignFileHashFromReaderAt(
 			n, readErr := ra.ReadAt(copyBuf[:chunk], offset)
 			if n > 0 {
 				if _, writeErr := h.Write(copyBuf[:n]); writeErr != nil {
-					return nil, fmt.Errorf("hash packed %s: %w", e.Path, writeErr)
+					return nil, nil, fmt.Errorf("hash packed %s: %w", e.Path, writeErr)
 				}
 
 				offset += int64(n)
@@ -258,50 +351,30 @@ func computeSignFileHashFromReaderAt(
 					break
 				}
 
-				return nil, fmt.Errorf("read packed %s: %w", e.Path, readErr)
+				return nil, nil, fmt.Errorf("read packed %s: %w", e.Path, readErr)
 			}
 			if n == 0 {
-				return nil, fmt.Errorf("read packed %s: %w", e.Path, io.ErrNoProgress)
+				return nil, nil, fmt.Errorf("read packed %s: %w", e.Path, io.ErrNoProgress)
 			}
 		}
 
-		hashedAny = true
+		hashed = append(hashed, e.Path)
 	}
-	if !hashedAny {
-		if version == SignVersionV2 {
-			_, _ = h.Write([]byte("nothing"))
-		} else {
-			_, _ = h.Write([]byte("gnihton"))
-		}
+	if len(hashed) == 0 {
+		_, _ = h.Write([]byte(spec.EmptyMarker))
 	}
 
-	return h.Sum(nil), nil
+	return h.Sum(nil), hashed, nil
 }
 
-// shouldHashFileForSign applies file-extension policy for v2/v3 signatures.
+// shouldHashFileForSign applies built-in file-extension policy for v2/v3 signatures.
 func shouldHashFileForSign(version SignVersion, gameType GameType, filename string) (bool, error) {
-	ext := signFileExtLower(filename)
-	gameType = normalizeGameType(gameType)
-
-	switch version {
-	case SignVersionV2:
-		return !isV2SignExcludedExt(ext), nil
-
-	case SignVersionV3:
-		switch gameType {
-		case GameTypeDayZ:
-			return isDayZV3SignAllowedExt(ext), nil
-
-		case GameTypeArma:
-			return isArmaV3SignAllowedExt(ext), nil
-
-		default:
-			return false, fmt.Errorf("%w: %q", ErrUnsupportedGameTypeV3, gameType)
-		}
-
-	default:
-		return false, fmt.Errorf("%w: %d", ErrUnsupportedSignVersion, version)
+	_, policy, err := signPolicyFor(version, gameType)
+	if err != nil {
+		return false, err
 	}
+
+	return policy.HashFile(filename), nil
 }
 
 // signFileExtLower extracts lower-cased ASCII extension from a path-like filename.
@@ -315,36 +388,6 @@ func signFileExtLower(filename string) string {
 	return asciiLower(filename[dot+1:])
 }
 
-// isV2SignExcludedExt reports whether extension is excluded from v2 filehash.
-func isV2SignExcludedExt(ext string) bool {
-	switch ext {
-	case "paa", "jpg", "p3d", "tga", "rvmat", "lip", "ogg", "wss", "png", "rtm", "pac", "fxy", "wrp":
-		return true
-	default:
-		return false
-	}
-}
-
-// isDayZV3SignAllowedExt reports whether extension is included in DayZ v3 filehash.
-func isDayZV3SignAllowedExt(ext string) bool {
-	switch ext {
-	case "bikb", "c", "ext", "hpp", "cfg", "h", "inc":
-		return true
-	default:
-		return false
-	}
-}
-
-// isArmaV3SignAllowedExt reports whether extension is included in Arma v3 filehash.
-func isArmaV3SignAllowedExt(ext string) bool {
-	switch ext {
-	case "sqf", "inc", "bikb", "ext", "fsm", "sqm", "hpp", "cfg", "sqs", "h", "cpp":
-		return true
-	default:
-		return false
-	}
-}
-
 // asciiLower converts only ASCII A-Z to a-z and leaves all other bytes untouched.
 func asciiLower(s string) string {
 	for i := 0; i < len(s); i++ {
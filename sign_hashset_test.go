@@ -3,6 +3,7 @@ package pbo
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/sha1" //nolint:gosec // Test mirrors format SHA1 hashing pipeline.
 	"io"
 	"os"
@@ -37,14 +38,14 @@ func TestComputeHashSet_FileHashUsesPackedBytes(t *testing.T) {
 		t.Fatalf("ComputeHashSet(%s): %v", pboPath, err)
 	}
 
-	nameHash := computeSignNameHash(entries)
+	nameHash := computeSignNameHash(crypto.SHA1, entries)
 	prefix := pboPrefixFromHeaders(r.Headers())
 	fileHashPacked, err := computePackedFileHashForTest(r, entries, SignVersionV3, GameTypeDayZ)
 	if err != nil {
 		t.Fatalf("computePackedFileHashForTest(): %v", err)
 	}
 
-	wantHash3 := computeSignHash3(fileHashPacked, nameHash, prefix)
+	wantHash3 := computeSignHash3(crypto.SHA1, fileHashPacked, nameHash, prefix)
 	if !bytes.Equal(hs.Hash3[:], wantHash3) {
 		t.Fatalf("hash3 mismatch:\n got  %x\n want %x", hs.Hash3, wantHash3)
 	}
@@ -54,7 +55,7 @@ func TestComputeHashSet_FileHashUsesPackedBytes(t *testing.T) {
 		t.Fatalf("computeDecompressedFileHashForTest(): %v", err)
 	}
 
-	legacyHash3 := computeSignHash3(fileHashDecompressed, nameHash, prefix)
+	legacyHash3 := computeSignHash3(crypto.SHA1, fileHashDecompressed, nameHash, prefix)
 	if bytes.Equal(hs.Hash3[:], legacyHash3) {
 		t.Fatalf("hash3 must not match decompressed-content legacy logic: %x", legacyHash3)
 	}
@@ -108,14 +109,14 @@ func TestComputeHashSet_FileHashUsesStoredEntryOrder(t *testing.T) {
 
 	entries := r.Entries()
 	prefix := pboPrefixFromHeaders(r.Headers())
-	nameHash := computeSignNameHash(entries)
+	nameHash := computeSignNameHash(crypto.SHA1, entries)
 
 	fileHashStoredOrder, err := computePackedFileHashForTestUsingOrder(f, entries, SignVersionV3, GameTypeDayZ, false)
 	if err != nil {
 		t.Fatalf("stored-order filehash: %v", err)
 	}
 
-	wantHash3 := computeSignHash3(fileHashStoredOrder, nameHash, prefix)
+	wantHash3 := computeSignHash3(crypto.SHA1, fileHashStoredOrder, nameHash, prefix)
 	if !bytes.Equal(hs.Hash3[:], wantHash3) {
 		t.Fatalf("hash3 mismatch for stored order:\n got  %x\n want %x", hs.Hash3, wantHash3)
 	}
@@ -125,7 +126,7 @@ func TestComputeHashSet_FileHashUsesStoredEntryOrder(t *testing.T) {
 		t.Fatalf("lower-order filehash: %v", err)
 	}
 
-	legacyHash3 := computeSignHash3(fileHashLowerOrder, nameHash, prefix)
+	legacyHash3 := computeSignHash3(crypto.SHA1, fileHashLowerOrder, nameHash, prefix)
 	if bytes.Equal(hs.Hash3[:], legacyHash3) {
 		t.Fatalf("hash3 must not match lower-case sorted filehash order: %x", legacyHash3)
 	}
@@ -143,7 +144,7 @@ func TestComputeSignNameHash_DeduplicatesNormalizedNames(t *testing.T) {
 		{Path: "", DataSize: 10},
 	}
 
-	got := computeSignNameHash(entries)
+	got := computeSignNameHash(crypto.SHA1, entries)
 
 	wantNames := []string{
 		"config.bin",
@@ -170,7 +171,7 @@ func TestComputeSignNameHash_UsesASCIILowercase(t *testing.T) {
 		{Path: "scripts/ä.c", DataSize: 8},
 	}
 
-	got := computeSignNameHash(entries)
+	got := computeSignNameHash(crypto.SHA1, entries)
 
 	wantNames := []string{
 		"scripts\\Ä.c",
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"strings"
+)
+
+// SignComponent names one value of signature hash chain.
+type SignComponent string
+
+// Signature hash chain components reported by MismatchReport.
+const (
+	// SignComponentHash1 is digest of full archive bytes.
+	SignComponentHash1 SignComponent = "hash1"
+	// SignComponentHash2 is digest of hash1, namehash, and prefix.
+	SignComponentHash2 SignComponent = "hash2"
+	// SignComponentHash3 is digest of filehash, namehash, and prefix.
+	SignComponentHash3 SignComponent = "hash3"
+	// SignComponentNameHash is digest of sorted normalized entry names.
+	SignComponentNameHash SignComponent = "namehash"
+	// SignComponentFileHash is digest of payloads selected by filehash policy.
+	SignComponentFileHash SignComponent = "filehash"
+	// SignComponentPrefix is "prefix" header mixed into hash2 and hash3.
+	SignComponentPrefix SignComponent = "prefix"
+)
+
+// MismatchReport explains which parts of signature hash chain differ between
+// archive and expected (signed) hash set.
+type MismatchReport struct {
+	// Details holds archive hash set and intermediates, including FileHashEntries.
+	Details *HashDetails `json:"details" yaml:"details"`
+	// GameType is game type of filehash policy used for archive.
+	GameType GameType `json:"game_type,omitempty" yaml:"game_type,omitempty"`
+	// SignedPrefix is prefix that reproduces expected hash2 when it differs from archive prefix.
+	SignedPrefix string `json:"signed_prefix,omitempty" yaml:"signed_prefix,omitempty"`
+	// Mismatched lists hash1/hash2/hash3 differing from expected values.
+	Mismatched []SignComponent `json:"mismatched,omitempty" yaml:"mismatched,omitempty"`
+	// Causes lists components identified as mismatch sources.
+	Causes []SignComponent `json:"causes,omitempty" yaml:"causes,omitempty"`
+	// Notes are human-readable explanations in Causes order.
+	Notes []string `json:"notes,omitempty" yaml:"notes,omitempty"`
+	// Expected is signed hash set archive was compared with.
+	Expected HashSet `json:"expected" yaml:"expected"`
+	// Actual is archive hash set.
+	Actual HashSet `json:"actual" yaml:"actual"`
+}
+
+// OK reports whether archive hash set equals expected.
+func (r *MismatchReport) OK() bool {
+	return r != nil && len(r.Mismatched) == 0
+}
+
+// ExplainHashMismatch compares archive hashes with expected hash set and isolates
+// differing components. Signed hash1 is reused to check namehash and prefix
+// independently of archive byte changes.
+func ExplainHashMismatch(pboPath string, expected HashSet, version SignVersion, gameType GameType) (*MismatchReport, error) {
+	spec, err := lookupSignVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Hash != crypto.SHA1 {
+		return nil, fmt.Errorf("%w: version %d does not use SHA1", ErrUnsupportedSignVersion, version)
+	}
+
+	d, err := ComputeHashDetails(pboPath, version, gameType)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := d.HashSet()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MismatchReport{
+		Details:  d,
+		GameType: normalizeGameType(gameType),
+		Expected: expected,
+		Actual:   actual,
+	}
+	for _, c := range [...]struct {
+		name           SignComponent
+		actual, signed [20]byte
+	}{
+		{SignComponentHash1, actual.Hash1, expected.Hash1},
+		{SignComponentHash2, actual.Hash2, expected.Hash2},
+		{SignComponentHash3, actual.Hash3, expected.Hash3},
+	} {
+		if c.actual != c.signed {
+			r.Mismatched = append(r.Mismatched, c.name)
+		}
+	}
+	if r.OK() {
+		return r, nil
+	}
+
+	if actual.Hash1 != expected.Hash1 {
+		r.addCause(SignComponentHash1, "archive bytes differ from signed archive: content, headers, entry order or compression changed")
+	}
+
+	prefix, namesOK := matchSignPrefix(expected.Hash1[:], d.NameHash, d.Prefix, expected.Hash2[:])
+	switch {
+	case !namesOK:
+		r.addCause(SignComponentNameHash, "entry name set differs from signed archive: entries were added, removed, or renamed")
+		prefix = d.Prefix
+
+	case prefix != d.Prefix:
+		r.SignedPrefix = prefix
+		r.addCause(SignComponentPrefix, fmt.Sprintf("signed with prefix %q, archive prefix is %q", prefix, d.Prefix))
+	}
+
+	if actual.Hash3 == expected.Hash3 || (namesOK && bytes.Equal(computeSignHash3(crypto.SHA1, d.FileHash, d.NameHash, prefix), expected.Hash3[:])) {
+		return r, nil
+	}
+
+	if !namesOK {
+		// hash3 cannot separate filehash from namehash change.
+		return r, nil
+	}
+
+	note := fmt.Sprintf("payload of %d filehash entries differs from signed archive", len(d.FileHashEntries))
+	if alt, ok := matchSignFilePolicy(pboPath, version, r.GameType, d.NameHash, prefix, expected.Hash3[:]); ok {
+		note = fmt.Sprintf("filehash matches %s policy: signed for different game type", alt)
+	}
+	r.addCause(SignComponentFileHash, note)
+
+	return r, nil
+}
+
+// ExplainBisignMismatch recovers signed hashes from .bisign with its embedded key
+// and explains differences with archive. v3 filehash is computed for gameType.
+func ExplainBisignMismatch(pboPath string, bisignPath string, gameType GameType) (*MismatchReport, error) {
+	sig, err := ReadBisign(bisignPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected HashSet
+	for i, dst := range [3]*[20]byte{&expected.Hash1, &expected.Hash2, &expected.Hash3} {
+		hash, err := sig.Key.RecoverHash([][]byte{sig.Sig1, sig.Sig2, sig.Sig3}[i])
+		if err != nil {
+			return nil, fmt.Errorf("sig%d: %w", i+1, err)
+		}
+		copy(dst[:], hash)
+	}
+
+	return ExplainHashMismatch(pboPath, expected, sig.Version, gameType)
+}
+
+// addCause records cause component with explanation.
+func (r *MismatchReport) addCause(component SignComponent, note string) {
+	r.Causes = append(r.Causes, component)
+	r.Notes = append(r.Notes, string(component)+": "+note)
+}
+
+// matchSignPrefix finds prefix variant reproducing signed hash2 from signed hash1 and
+// archive namehash. ok is false when no variant matches (namehash differs).
+func matchSignPrefix(signedHash1 []byte, nameHash []byte, prefix string, signedHash2 []byte) (string, bool) {
+	variants := []string{
+		prefix,
+		"",
+		strings.ReplaceAll(prefix, "/", `\`),
+		strings.Trim(strings.ReplaceAll(prefix, "/", `\`), `\`),
+		strings.ToLower(prefix),
+	}
+
+	for _, v := range variants {
+		if bytes.Equal(computeSignHash2(crypto.SHA1, signedHash1, nameHash, v), signedHash2) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// matchSignFilePolicy checks built-in game policies of version other than gameType
+// against signed hash3 and returns matching game type.
+func matchSignFilePolicy(
+	pboPath string,
+	version SignVersion,
+	gameType GameType,
+	nameHash []byte,
+	prefix string,
+	signedHash3 []byte,
+) (GameType, bool) {
+	if version != SignVersionV3 {
+		return "", false
+	}
+
+	for _, alt := range []GameType{GameTypeDayZ, GameTypeArma} {
+		if alt == gameType {
+			continue
+		}
+
+		d, err := ComputeHashDetails(pboPath, version, alt)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(computeSignHash3(crypto.SHA1, d.FileHash, nameHash, prefix), signedHash3) {
+			return alt, true
+		}
+	}
+
+	return "", false
+}
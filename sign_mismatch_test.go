@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExplainHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": []byte("void main() {}"),
+		"data/tex.paa":   []byte("texture"),
+	}
+	build := func(name string, prefix string, edit func(map[string][]byte)) string {
+		t.Helper()
+
+		in := make(map[string][]byte, len(files)+1)
+		for k, v := range files {
+			in[k] = v
+		}
+		if edit != nil {
+			edit(in)
+		}
+
+		path := filepath.Join(t.TempDir(), name)
+		if err := createTestPBO(path, in, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: prefix}}}); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+
+		return path
+	}
+
+	signedPath := build("signed.pbo", "mod", nil)
+	signed, err := ComputeHashSet(signedPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected HashSet
+		causes   []SignComponent
+	}{
+		{name: "same", path: signedPath, expected: signed},
+		{
+			name: "script changed", expected: signed,
+			path:   build("script.pbo", "mod", func(m map[string][]byte) { m["scripts/main.c"] = []byte("changed") }),
+			causes: []SignComponent{SignComponentHash1, SignComponentFileHash},
+		},
+		{
+			name: "texture changed", expected: signed,
+			path:   build("texture.pbo", "mod", func(m map[string][]byte) { m["data/tex.paa"] = []byte("other") }),
+			causes: []SignComponent{SignComponentHash1},
+		},
+		{
+			name: "prefix case", expected: signed,
+			path:   build("prefix.pbo", "MOD", nil),
+			causes: []SignComponent{SignComponentHash1, SignComponentPrefix},
+		},
+		{
+			name: "entry added", expected: signed,
+			path:   build("added.pbo", "mod", func(m map[string][]byte) { m["extra.txt"] = []byte("x") }),
+			causes: []SignComponent{SignComponentHash1, SignComponentNameHash},
+		},
+	}
+
+	for _, tt := range tests {
+		report, err := ExplainHashMismatch(tt.path, tt.expected, SignVersionV3, GameTypeDayZ)
+		if err != nil {
+			t.Fatalf("%s: ExplainHashMismatch: %v", tt.name, err)
+		}
+		if report.OK() != (len(tt.causes) == 0) || !slices.Equal(report.Causes, tt.causes) {
+			t.Fatalf("%s: causes=%v notes=%q, want %v", tt.name, report.Causes, report.Notes, tt.causes)
+		}
+		if len(report.Notes) != len(report.Causes) {
+			t.Fatalf("%s: notes=%d causes=%d", tt.name, len(report.Notes), len(report.Causes))
+		}
+	}
+
+	arma, err := ComputeHashSet(signedPath, SignVersionV3, GameTypeArma)
+	if err != nil {
+		t.Fatalf("ComputeHashSet(arma): %v", err)
+	}
+	report, err := ExplainHashMismatch(signedPath, arma, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ExplainHashMismatch(arma): %v", err)
+	}
+	if !slices.Equal(report.Mismatched, []SignComponent{SignComponentHash3}) ||
+		!slices.Equal(report.Causes, []SignComponent{SignComponentFileHash}) {
+		t.Fatalf("game type mismatch=%v causes=%v notes=%q", report.Mismatched, report.Causes, report.Notes)
+	}
+	if report.SignedPrefix != "" || len(report.Details.FileHashEntries) != 1 {
+		t.Fatalf("SignedPrefix=%q FileHashEntries=%v", report.SignedPrefix, report.Details.FileHashEntries)
+	}
+}
+
+func TestExplainBisignMismatch(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "addon.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"scripts/main.c": []byte("void main() {}")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	hs, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+
+	bisignPath := pboPath + ".author.bisign"
+	writeTestFile(t, bisignPath, newTestBiSigner(t, "author").bisign(t, SignVersionV3, hs))
+
+	if err := createTestPBO(pboPath, map[string][]byte{"scripts/main.c": []byte("void main() { x(); }")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	report, err := ExplainBisignMismatch(pboPath, bisignPath, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ExplainBisignMismatch: %v", err)
+	}
+	if report.Expected != hs || !slices.Contains(report.Causes, SignComponentFileHash) {
+		t.Fatalf("expected recovered=%v causes=%v", report.Expected == hs, report.Causes)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SignPolicy selects archive entries whose packed payload contributes to signature filehash.
+type SignPolicy interface {
+	// HashFile reports whether entry path is included in filehash.
+	HashFile(path string) bool
+}
+
+// SignExtensionPolicy is extension-based SignPolicy. Extensions are ASCII case-insensitive
+// and may have leading dot. Non-empty Allow includes only listed extensions; Deny always excludes.
+type SignExtensionPolicy struct {
+	// Allow lists included extensions; empty includes every extension not denied.
+	Allow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	// Deny lists excluded extensions.
+	Deny []string `json:"deny,omitempty" yaml:"deny,omitempty"`
+}
+
+// Built-in extension lists of game signature tooling.
+var (
+	signV2DeniedExts      = []string{"paa", "jpg", "p3d", "tga", "rvmat", "lip", "ogg", "wss", "png", "rtm", "pac", "fxy", "wrp"}
+	signDayZV3AllowedExts = []string{"bikb", "c", "ext", "hpp", "cfg", "h", "inc"}
+	signArmaV3AllowedExts = []string{"sqf", "inc", "bikb", "ext", "fsm", "sqm", "hpp", "cfg", "sqs", "h", "cpp"}
+)
+
+var _ SignPolicy = SignExtensionPolicy{}
+
+// HashFile reports whether path extension passes Allow and Deny lists.
+func (p SignExtensionPolicy) HashFile(path string) bool {
+	ext := signFileExtLower(path)
+	if len(p.Allow) > 0 && !signExtListed(p.Allow, ext) {
+		return false
+	}
+
+	return !signExtListed(p.Deny, ext)
+}
+
+// DefaultSignPolicy returns copy of built-in extension policy for version and game type,
+// suitable as a base for custom lists.
+func DefaultSignPolicy(version SignVersion, gameType GameType) (SignExtensionPolicy, error) {
+	policy, err := builtinSignPolicy(version, gameType)
+	if err != nil {
+		return SignExtensionPolicy{}, err
+	}
+
+	return SignExtensionPolicy{
+		Allow: slices.Clone(policy.Allow),
+		Deny:  slices.Clone(policy.Deny),
+	}, nil
+}
+
+// builtinSignPolicy returns built-in policy sharing package lists; callers must not modify it.
+func builtinSignPolicy(version SignVersion, gameType GameType) (SignExtensionPolicy, error) {
+	switch version {
+	case SignVersionV2:
+		return SignExtensionPolicy{Deny: signV2DeniedExts}, nil
+
+	case SignVersionV3:
+		switch normalizeGameType(gameType) {
+		case GameTypeDayZ:
+			return SignExtensionPolicy{Allow: signDayZV3AllowedExts}, nil
+
+		case GameTypeArma:
+			return SignExtensionPolicy{Allow: signArmaV3AllowedExts}, nil
+
+		default:
+			return SignExtensionPolicy{}, fmt.Errorf("%w: %q", ErrUnsupportedGameTypeV3, gameType)
+		}
+
+	default:
+		return SignExtensionPolicy{}, fmt.Errorf("%w: %d", ErrUnsupportedSignVersion, version)
+	}
+}
+
+// signExtListed reports whether lower-cased ext is present in list.
+func signExtListed(list []string, ext string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimPrefix(item, "."), ext) {
+			return true
+		}
+	}
+
+	return false
+}
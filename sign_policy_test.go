@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignExtensionPolicy_HashFile(t *testing.T) {
+	t.Parallel()
+
+	policy := SignExtensionPolicy{Allow: []string{".C", "layout"}, Deny: []string{"layout"}}
+	tests := map[string]bool{
+		"scripts/mission.c":  true,
+		`gui\main.LAYOUT`:    false,
+		"config.cpp":         false,
+		"scripts.dir/noext":  false,
+		"scripts/Mission.C":  true,
+		"data/texture.paa":   false,
+		"scripts.c/readme":   false,
+		"scripts/./module.c": true,
+	}
+	for path, want := range tests {
+		if got := policy.HashFile(path); got != want {
+			t.Fatalf("HashFile(%q)=%t, want %t", path, got, want)
+		}
+	}
+
+	if !(SignExtensionPolicy{Deny: []string{"paa"}}).HashFile("noext") {
+		t.Fatal("deny-only policy excluded file without extension")
+	}
+}
+
+func TestComputeHashSetWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "policy.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":       []byte("class CfgPatches {};"),
+		"scripts/main.c":   []byte("void main() {}"),
+		"scripts/custom.x": []byte("custom script"),
+	}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	builtin, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+
+	policy, err := DefaultSignPolicy(SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("DefaultSignPolicy: %v", err)
+	}
+	same, err := ComputeHashSetWithPolicy(pboPath, SignVersionV3, policy)
+	if err != nil {
+		t.Fatalf("ComputeHashSetWithPolicy(default): %v", err)
+	}
+	if same != builtin {
+		t.Fatal("default policy hash set differs from ComputeHashSet")
+	}
+
+	policy.Allow = append(policy.Allow, "x")
+	custom, err := ComputeHashSetWithPolicy(pboPath, SignVersionV3, policy)
+	if err != nil {
+		t.Fatalf("ComputeHashSetWithPolicy(custom): %v", err)
+	}
+	if custom.Hash3 == builtin.Hash3 || custom.Hash1 != builtin.Hash1 {
+		t.Fatal("custom extension did not change only filehash-derived hash3")
+	}
+
+	again, err := DefaultSignPolicy(SignVersionV3, GameTypeDayZ)
+	if err != nil || len(again.Allow) != len(policy.Allow)-1 {
+		t.Fatalf("DefaultSignPolicy shares built-in list: allow=%v err=%v", again.Allow, err)
+	}
+
+	if _, err := ComputeHashSetWithPolicy(pboPath, SignVersionV3, nil); !errors.Is(err, ErrNilSignPolicy) {
+		t.Fatalf("nil policy err=%v, want ErrNilSignPolicy", err)
+	}
+	if _, err := DefaultSignPolicy(SignVersionV3, GameType("unknown")); !errors.Is(err, ErrUnsupportedGameTypeV3) {
+		t.Fatalf("unknown game err=%v, want ErrUnsupportedGameTypeV3", err)
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"crypto"
+	"fmt"
+	"sync"
+)
+
+var (
+	// signVersionsMu guards signVersions registry.
+	signVersionsMu sync.RWMutex
+	// signVersions maps custom signature versions to registered specs.
+	signVersions = make(map[SignVersion]SignVersionSpec)
+)
+
+// SignVersionSpec describes signature hash policy version: digest algorithm used for
+// hash1, namehash, filehash, hash2 and hash3, and per-game filehash entry policy.
+type SignVersionSpec struct {
+	// Policy returns filehash entry policy for game type.
+	Policy func(gameType GameType) (SignPolicy, error) `json:"-" yaml:"-"`
+	// EmptyMarker is written to filehash when policy selects no entries.
+	EmptyMarker string `json:"empty_marker,omitempty" yaml:"empty_marker,omitempty"`
+	// Hash is digest algorithm; it must be linked into binary.
+	Hash crypto.Hash `json:"hash" yaml:"hash"`
+}
+
+// RegisterSignVersion registers process-wide signature hash policy version.
+// Built-in v2 and v3 cannot be overridden.
+func RegisterSignVersion(version SignVersion, spec SignVersionSpec) error {
+	if version == SignVersionV2 || version == SignVersionV3 {
+		return fmt.Errorf("%w: version %d is reserved", ErrInvalidSignVersionSpec, version)
+	}
+
+	if spec.Policy == nil {
+		return fmt.Errorf("%w: policy is nil", ErrInvalidSignVersionSpec)
+	}
+
+	if !spec.Hash.Available() {
+		return fmt.Errorf("%w: hash %v is not available", ErrInvalidSignVersionSpec, spec.Hash)
+	}
+
+	signVersionsMu.Lock()
+	signVersions[version] = spec
+	signVersionsMu.Unlock()
+
+	return nil
+}
+
+// UnregisterSignVersion removes custom signature hash policy version.
+func UnregisterSignVersion(version SignVersion) {
+	signVersionsMu.Lock()
+	delete(signVersions, version)
+	signVersionsMu.Unlock()
+}
+
+// lookupSignVersion returns spec of built-in or registered signature version.
+func lookupSignVersion(version SignVersion) (SignVersionSpec, error) {
+	switch version {
+	case SignVersionV2:
+		return SignVersionSpec{Policy: builtinSignPolicyFunc(version), EmptyMarker: "nothing", Hash: crypto.SHA1}, nil
+
+	case SignVersionV3:
+		return SignVersionSpec{Policy: builtinSignPolicyFunc(version), EmptyMarker: "gnihton", Hash: crypto.SHA1}, nil
+	}
+
+	signVersionsMu.RLock()
+	spec, ok := signVersions[version]
+	signVersionsMu.RUnlock()
+	if !ok {
+		return SignVersionSpec{}, fmt.Errorf("%w: got %d", ErrUnsupportedSignVersion, version)
+	}
+
+	return spec, nil
+}
+
+// builtinSignPolicyFunc adapts built-in extension policy of version to SignVersionSpec.Policy.
+func builtinSignPolicyFunc(version SignVersion) func(GameType) (SignPolicy, error) {
+	return func(gameType GameType) (SignPolicy, error) {
+		return builtinSignPolicy(version, gameType)
+	}
+}
+
+// signPolicyFor resolves filehash entry policy of version for game type.
+func signPolicyFor(version SignVersion, gameType GameType) (SignVersionSpec, SignPolicy, error) {
+	spec, err := lookupSignVersion(version)
+	if err != nil {
+		return spec, nil, err
+	}
+
+	policy, err := spec.Policy(gameType)
+	if err != nil {
+		return spec, nil, err
+	}
+	if policy == nil {
+		return spec, nil, ErrNilSignPolicy
+	}
+
+	return spec, policy, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeHashDetails_MatchesHashSet(t *testing.T) {
+	t.Parallel()
+
+	pboPath := filepath.Join(t.TempDir(), "details.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": []byte("void main() {}"),
+		"data/tex.paa":   []byte("texture"),
+	}, PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "mod"}}}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	hs, err := ComputeHashSet(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashSet: %v", err)
+	}
+
+	d, err := ComputeHashDetails(pboPath, SignVersionV3, GameTypeDayZ)
+	if err != nil {
+		t.Fatalf("ComputeHashDetails: %v", err)
+	}
+	if got, err := d.HashSet(); err != nil || got != hs {
+		t.Fatalf("details HashSet mismatch err=%v", err)
+	}
+	if d.Prefix != "mod" || len(d.NameHash) != 20 || len(d.FileHash) != 20 {
+		t.Fatalf("details prefix=%q namehash=%d filehash=%d", d.Prefix, len(d.NameHash), len(d.FileHash))
+	}
+	if len(d.FileHashEntries) != 1 || d.FileHashEntries[0] != `scripts\main.c` {
+		t.Fatalf("FileHashEntries=%v", d.FileHashEntries)
+	}
+	if want := computeSignHash2(crypto.SHA1, d.Hash1, d.NameHash, d.Prefix); !bytes.Equal(d.Hash2, want) {
+		t.Fatal("hash2 is not derived from reported intermediates")
+	}
+}
+
+func TestRegisterSignVersion(t *testing.T) {
+	t.Parallel()
+
+	const v4 SignVersion = 104
+	err := RegisterSignVersion(v4, SignVersionSpec{
+		Policy: func(GameType) (SignPolicy, error) {
+			return SignExtensionPolicy{Allow: []string{"c"}}, nil
+		},
+		EmptyMarker: "empty",
+		Hash:        crypto.SHA256,
+	})
+	if err != nil {
+		t.Fatalf("RegisterSignVersion: %v", err)
+	}
+	t.Cleanup(func() { UnregisterSignVersion(v4) })
+
+	pboPath := filepath.Join(t.TempDir(), "v4.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"main.c": []byte("void main() {}")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	d, err := ComputeHashDetails(pboPath, v4, GameTypeAny)
+	if err != nil {
+		t.Fatalf("ComputeHashDetails(v4): %v", err)
+	}
+	for name, v := range map[string][]byte{"hash1": d.Hash1, "hash2": d.Hash2, "hash3": d.Hash3, "filehash": d.FileHash} {
+		if len(v) != 32 {
+			t.Fatalf("%s length=%d, want 32", name, len(v))
+		}
+	}
+
+	if _, err := ComputeHashSet(pboPath, v4, GameTypeAny); !errors.Is(err, ErrInvalidSHA1DigestLength) {
+		t.Fatalf("ComputeHashSet(v4) err=%v, want ErrInvalidSHA1DigestLength", err)
+	}
+
+	if err := RegisterSignVersion(SignVersionV3, SignVersionSpec{
+		Policy: func(GameType) (SignPolicy, error) { return SignExtensionPolicy{}, nil },
+		Hash:   crypto.SHA256,
+	}); !errors.Is(err, ErrInvalidSignVersionSpec) {
+		t.Fatalf("override v3 err=%v, want ErrInvalidSignVersionSpec", err)
+	}
+	if err := RegisterSignVersion(105, SignVersionSpec{Hash: crypto.SHA256}); !errors.Is(err, ErrInvalidSignVersionSpec) {
+		t.Fatalf("nil policy err=%v, want ErrInvalidSignVersionSpec", err)
+	}
+	if _, err := ComputeHashDetails(pboPath, 105, GameTypeAny); !errors.Is(err, ErrUnsupportedSignVersion) {
+		t.Fatalf("unregistered err=%v, want ErrUnsupportedSignVersion", err)
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// splitEntryOverhead is fixed index record size per entry (name terminator + 5 uint32 fields).
+const splitEntryOverhead = 1 + 20
+
+// SplitVolume describes one archive written by PackSplit.
+type SplitVolume struct {
+	// Result is pack statistics for this volume.
+	Result *PackResult `json:"result,omitempty" yaml:"result,omitempty"`
+	// Path is written volume file path.
+	Path string `json:"path" yaml:"path"`
+	// Entries lists normalized entry paths stored in this volume.
+	Entries []string `json:"entries" yaml:"entries"`
+}
+
+// PackSplit packs inputs into several PBO volumes no larger than maxSize bytes each.
+// Inputs are ordered per opts.Order (by normalized path by default) and assigned to volumes
+// in that order, so equal inputs always produce equal volumes. outPattern is fmt pattern with one integer verb
+// for 1-based volume number (for example "data_%02d.pbo"). Non-positive maxSize means 4 GiB.
+// Volume budget uses Input.SizeHint as upper bound of stored payload size; inputs without
+// SizeHint are read once up front to measure them. Volume that still ends up larger than
+// maxSize (for example, understated SizeHint) is removed and fails with ErrSizeOverflow.
+func PackSplit(
+	ctx context.Context,
+	outPattern string,
+	inputs []Input,
+	opts PackOptions,
+	maxSize int64,
+) ([]SplitVolume, error) {
+	if len(inputs) == 0 {
+		return nil, ErrEmptyInputs
+	}
+
+	if !strings.Contains(outPattern, "%") {
+		return nil, fmt.Errorf("output pattern %q has no volume number verb", outPattern)
+	}
+
+	if maxSize <= 0 || maxSize > maxPBOData {
+		maxSize = maxPBOData
+	}
+
+	sorted, err := normalizePackInputs(inputs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := measureUnknownSizes(ctx, sorted, opts); err != nil {
+		return nil, err
+	}
+
+	groups, err := splitInputGroups(sorted, splitBaseOverhead(opts.Headers), maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]SplitVolume, 0, len(groups))
+	for i, group := range groups {
+		outPath := fmt.Sprintf(outPattern, i+1)
+		res, err := PackFile(ctx, outPath, group, opts)
+		if err != nil {
+			return volumes, fmt.Errorf("pack volume %s: %w", outPath, err)
+		}
+
+		info, err := os.Stat(outPath)
+		if err != nil {
+			return volumes, fmt.Errorf("stat volume %s: %w", outPath, err)
+		}
+		if info.Size() > maxSize {
+			_ = os.Remove(outPath)
+			return volumes, fmt.Errorf("%w: volume %s is %d bytes, limit %d", ErrSizeOverflow, outPath, info.Size(), maxSize)
+		}
+
+		paths := make([]string, len(group))
+		for j := range group {
+			paths[j] = group[j].Path
+		}
+
+		volumes = append(volumes, SplitVolume{Path: outPath, Result: res, Entries: paths})
+	}
+
+	return volumes, nil
+}
+
+// measureUnknownSizes reads inputs without positive SizeHint to set it to actual
+// stream size, so volume budget does not count them as empty.
+func measureUnknownSizes(ctx context.Context, inputs []Input, opts PackOptions) error {
+	for i := range inputs {
+		if inputs[i].SizeHint > 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rc, err := openInputReader(ctx, inputs[i], opts.PerEntryTimeout, opts.OpenRetry)
+		if err != nil {
+			return err
+		}
+
+		n, err := io.Copy(io.Discard, rc)
+		_ = rc.Close()
+		if err != nil {
+			return fmt.Errorf("measure input %s: %w", inputs[i].Path, err)
+		}
+
+		inputs[i].SizeHint = n
+	}
+
+	return nil
+}
+
+// splitInputGroups assigns sorted inputs to consecutive volumes within maxSize budget.
+func splitInputGroups(sorted []Input, baseOverhead int64, maxSize int64) ([][]Input, error) {
+	groups := make([][]Input, 0, 1)
+	var current []Input
+	used := baseOverhead
+
+	for _, input := range sorted {
+		size := max(input.SizeHint, 0) + int64(len(input.Path)) + splitEntryOverhead
+		if baseOverhead+size > maxSize {
+			return nil, fmt.Errorf("%w: entry %s needs %d bytes, volume limit %d", ErrSizeOverflow, input.Path, size, maxSize)
+		}
+
+		if len(current) > 0 && used+size > maxSize {
+			groups = append(groups, current)
+			current = nil
+			used = baseOverhead
+		}
+
+		current = append(current, input)
+		used += size
+	}
+
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	return groups, nil
+}
+
+// splitBaseOverhead estimates per-volume bytes outside entry records:
+// header block, index terminator record and SHA1 trailer.
+func splitBaseOverhead(headers []HeaderPair) int64 {
+	size := int64(splitEntryOverhead + 1) // product entry with empty name and header terminator
+	for _, h := range headers {
+		size += int64(len(h.Key)) + 1 + int64(len(h.Value)) + 1
+	}
+
+	size += splitEntryOverhead // index terminator record
+	size += 1 + 20             // trailer marker and SHA1 digest
+
+	return size
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPackSplit_DeterministicVolumes(t *testing.T) {
+	t.Parallel()
+
+	files := make(map[string][]byte)
+	for i := range 6 {
+		files[fmt.Sprintf("data/f%d.bin", i)] = bytes.Repeat([]byte{byte('a' + i)}, 400)
+	}
+
+	dir := t.TempDir()
+	pattern := filepath.Join(dir, "vol_%02d.pbo")
+	opts := PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "mod"}}}
+	const maxSize = 1024
+
+	volumes, err := PackSplit(context.Background(), pattern, streamTestInputs(files), opts, maxSize)
+	if err != nil {
+		t.Fatalf("PackSplit: %v", err)
+	}
+	if len(volumes) != 3 {
+		t.Fatalf("volumes=%d, want 3", len(volumes))
+	}
+
+	seen := 0
+	for i, vol := range volumes {
+		if vol.Path != fmt.Sprintf(pattern, i+1) {
+			t.Fatalf("volume[%d] path=%s", i, vol.Path)
+		}
+
+		info, err := os.Stat(vol.Path)
+		if err != nil {
+			t.Fatalf("stat volume: %v", err)
+		}
+		if info.Size() > maxSize {
+			t.Fatalf("volume %s size %d exceeds %d", vol.Path, info.Size(), maxSize)
+		}
+
+		entries, err := ListEntries(vol.Path)
+		if err != nil {
+			t.Fatalf("ListEntries: %v", err)
+		}
+		if len(entries) != len(vol.Entries) || vol.Result.WrittenEntries != len(entries) {
+			t.Fatalf("volume %s entries=%d, reported %v", vol.Path, len(entries), vol.Entries)
+		}
+
+		for j, entry := range entries {
+			want := fmt.Sprintf(`data\f%d.bin`, seen+j)
+			if entry.Path != want {
+				t.Fatalf("volume %s entry[%d]=%s, want %s", vol.Path, j, entry.Path, want)
+			}
+		}
+		seen += len(entries)
+	}
+
+	if seen != len(files) {
+		t.Fatalf("total entries=%d, want %d", seen, len(files))
+	}
+}
+
+func TestPackSplit_EntryExceedsVolume(t *testing.T) {
+	t.Parallel()
+
+	inputs := streamTestInputs(map[string][]byte{"big.bin": bytes.Repeat([]byte("x"), 2048)})
+	pattern := filepath.Join(t.TempDir(), "vol_%d.pbo")
+
+	_, err := PackSplit(context.Background(), pattern, inputs, PackOptions{}, 1024)
+	if !errors.Is(err, ErrSizeOverflow) {
+		t.Fatalf("expected ErrSizeOverflow, got %v", err)
+	}
+}
+
+func TestPackSplit_UnknownAndUnderstatedSizes(t *testing.T) {
+	t.Parallel()
+
+	files := make(map[string][]byte)
+	for i := range 6 {
+		files[fmt.Sprintf("data/f%d.bin", i)] = bytes.Repeat([]byte{byte('a' + i)}, 400)
+	}
+
+	unknown := streamTestInputs(files)
+	for i := range unknown {
+		unknown[i].SizeHint = 0
+	}
+
+	const maxSize = 1024
+	pattern := filepath.Join(t.TempDir(), "vol_%02d.pbo")
+	volumes, err := PackSplit(context.Background(), pattern, unknown, PackOptions{}, maxSize)
+	if err != nil {
+		t.Fatalf("PackSplit unknown sizes: %v", err)
+	}
+	if len(volumes) != 3 {
+		t.Fatalf("volumes=%d, want 3", len(volumes))
+	}
+
+	understated := streamTestInputs(map[string][]byte{"big.bin": bytes.Repeat([]byte("x"), 2048)})
+	understated[0].SizeHint = 1
+	pattern = filepath.Join(t.TempDir(), "vol_%d.pbo")
+	if _, err := PackSplit(context.Background(), pattern, understated, PackOptions{}, maxSize); !errors.Is(err, ErrSizeOverflow) {
+		t.Fatalf("understated size err=%v, want ErrSizeOverflow", err)
+	}
+	if _, err := os.Stat(fmt.Sprintf(pattern, 1)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("oversized volume left on disk: %v", err)
+	}
+}
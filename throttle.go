@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// byteRateLimiter is token bucket limiting payload bytes per second shared by one job.
+type byteRateLimiter struct {
+	// last is time of previous refill.
+	last time.Time
+	// now returns current time (replaceable in tests).
+	now func() time.Time
+	// sleep waits for duration or context cancellation (replaceable in tests).
+	sleep func(ctx context.Context, d time.Duration) error
+	// rate is refill speed in bytes per second.
+	rate float64
+	// burst is bucket capacity in bytes (one tenth of a second of traffic, at least 1).
+	burst float64
+	// tokens is currently available byte budget.
+	tokens float64
+	// mu guards bucket state.
+	mu sync.Mutex
+}
+
+// newByteRateLimiter returns limiter for bytesPerSecond or nil when limit is disabled.
+func newByteRateLimiter(bytesPerSecond int64) *byteRateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	rate := float64(bytesPerSecond)
+	burst := max(rate/10, 1)
+
+	return &byteRateLimiter{
+		now:    time.Now,
+		sleep:  sleepContext,
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// chunk returns max bytes one read may request to keep throttling smooth.
+func (l *byteRateLimiter) chunk(n int) int {
+	return min(n, max(int(l.burst), 1))
+}
+
+// wait blocks until n bytes of budget are consumed.
+func (l *byteRateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	return l.sleep(ctx, delay)
+}
+
+// throttledReader limits read throughput with shared byteRateLimiter.
+type throttledReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *byteRateLimiter
+}
+
+// throttleReader wraps r with limiter; nil limiter returns r unchanged.
+func throttleReader(ctx context.Context, r io.Reader, limiter *byteRateLimiter) io.Reader {
+	if limiter == nil {
+		return r
+	}
+
+	return &throttledReader{ctx: ctx, r: r, limiter: limiter}
+}
+
+// Read reads at most one burst chunk and waits for its byte budget.
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	n, err := t.r.Read(p[:t.limiter.chunk(len(p))])
+	if n > 0 {
+		if waitErr := t.limiter.wait(t.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err
+}
+
+// throttledWriter limits write throughput with shared byteRateLimiter.
+type throttledWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	limiter *byteRateLimiter
+}
+
+// throttleWriter wraps w with limiter; nil limiter returns w unchanged.
+func throttleWriter(ctx context.Context, w io.Writer, limiter *byteRateLimiter) io.Writer {
+	if limiter == nil {
+		return w
+	}
+
+	return &throttledWriter{ctx: ctx, w: w, limiter: limiter}
+}
+
+// Write writes p in burst-sized chunks, waiting for byte budget after each chunk.
+func (t *throttledWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p[:t.limiter.chunk(len(p))]
+		n, err := t.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		if err := t.limiter.wait(t.ctx, n); err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
+
+// sleepContext sleeps for d or returns context error when ctx is canceled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
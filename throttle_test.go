@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestByteRateLimiter_TokenBucket(t *testing.T) {
+	t.Parallel()
+
+	clock := time.Unix(1700000000, 0)
+	var slept []time.Duration
+	limiter := newByteRateLimiter(1000)
+	limiter.now = func() time.Time { return clock }
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		clock = clock.Add(d)
+		return nil
+	}
+
+	if limiter.chunk(4096) != 100 {
+		t.Fatalf("chunk=%d, want burst 100", limiter.chunk(4096))
+	}
+
+	ctx := context.Background()
+	if err := limiter.wait(ctx, 100); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if err := limiter.wait(ctx, 50); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if err := limiter.wait(ctx, 100); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	want := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond}
+	if len(slept) != len(want) || slept[0] != want[0] || slept[1] != want[1] {
+		t.Fatalf("slept=%v, want %v", slept, want)
+	}
+
+	if newByteRateLimiter(0) != nil {
+		t.Fatal("zero rate must disable limiter")
+	}
+}
+
+func TestThrottle_PackAndExtractRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("throttled "), 600)
+	pboPath := filepath.Join(t.TempDir(), "throttled.pbo")
+
+	started := time.Now()
+	_, err := PackFile(context.Background(), pboPath, streamTestInputs(map[string][]byte{"a.txt": payload}), PackOptions{
+		BytesPerSecond: 30000,
+	})
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	outDir := t.TempDir()
+	if err := r.Extract(context.Background(), outDir, ExtractOptions{BytesPerSecond: 30000}); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	// 6000 bytes at 30000 B/s with 3000 B burst needs at least ~100ms per direction.
+	if elapsed := time.Since(started); elapsed < 150*time.Millisecond {
+		t.Fatalf("elapsed=%s, throttle not applied", elapsed)
+	}
+
+	got, err := os.ReadFile(filepath.Join(outDir, "a.txt"))
+	if err != nil {
+		t.Fatalf("read extracted file: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatal("payload mismatch after throttled roundtrip")
+	}
+}
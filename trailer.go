@@ -72,6 +72,50 @@ func writeSHA1Trailer(path string) error {
 	return f.Sync()
 }
 
+// VerifySHA1Trailer verifies that file at path ends with 0x00 + SHA1 trailer
+// matching all preceding content and returns stored digest.
+func VerifySHA1Trailer(path string) ([20]byte, error) {
+	var stored [20]byte
+
+	f, err := os.Open(path)
+	if err != nil {
+		return stored, fmt.Errorf("open: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return stored, fmt.Errorf("seek end: %w", err)
+	}
+
+	if size < 21 {
+		return stored, ErrTrailerTooShort
+	}
+
+	tail := make([]byte, 21)
+	if _, err := f.ReadAt(tail, size-21); err != nil {
+		return stored, fmt.Errorf("read trailer: %w", err)
+	}
+	if tail[0] != 0x00 {
+		return stored, ErrInvalidTrailerPrefix
+	}
+
+	computed, err := hashFilePrefixSHA1(f, size-21)
+	if err != nil {
+		return stored, fmt.Errorf("hash content: %w", err)
+	}
+	if len(computed) != shaSize {
+		return stored, fmt.Errorf("%w: %d", ErrInvalidSHA1DigestLength, len(computed))
+	}
+
+	copy(stored[:], tail[1:21])
+	if !bytes.Equal(stored[:], computed) {
+		return stored, ErrTrailerHashMismatch
+	}
+
+	return stored, nil
+}
+
 // hashFilePrefixSHA1 calculates SHA1 over first n bytes of file.
 func hashFilePrefixSHA1(f *os.File, n int64) ([]byte, error) {
 	if _, err := f.Seek(0, io.SeekStart); err != nil {
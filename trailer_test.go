@@ -3,9 +3,12 @@ package pbo
 import (
 	"bytes"
 	"crypto/sha1" //nolint:gosec // Trailer format requires SHA1.
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"testing"
 )
 
 // checkSHA1TrailerForTest verifies that the file ends with a valid SHA1 trailer and that
@@ -55,3 +58,51 @@ func checkSHA1TrailerForTest(path string) ([20]byte, error) {
 
 	return stored, nil
 }
+
+func TestVerifySHA1Trailer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ok.pbo")
+	if err := createTestPBO(path, map[string][]byte{"a.txt": []byte("alpha")}, PackOptions{}); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	want, err := checkSHA1TrailerForTest(path)
+	if err != nil {
+		t.Fatalf("checkSHA1TrailerForTest: %v", err)
+	}
+	got, err := VerifySHA1Trailer(path)
+	if err != nil || got != want {
+		t.Fatalf("VerifySHA1Trailer=%x err=%v, want %x", got, err, want)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)-30] ^= 0xff
+	badPrefix := bytes.Clone(data)
+	badPrefix[len(badPrefix)-21] = 1
+
+	cases := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "tampered", data: tampered, want: ErrTrailerHashMismatch},
+		{name: "prefix", data: badPrefix, want: ErrInvalidTrailerPrefix},
+		{name: "short", data: data[:20], want: ErrTrailerTooShort},
+	}
+	for _, tc := range cases {
+		casePath := filepath.Join(dir, tc.name+".pbo")
+		if err := os.WriteFile(casePath, tc.data, 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if _, err := VerifySHA1Trailer(casePath); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: VerifySHA1Trailer err=%v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// UnicodeForm selects Unicode normalization form applied to entry paths.
+type UnicodeForm string
+
+// Unicode normalization forms for ReaderOptions.UnicodeForm and PackOptions.UnicodeForm.
+const (
+	// UnicodeFormNone keeps entry paths as stored (default).
+	UnicodeFormNone UnicodeForm = ""
+	// UnicodeNFC composes characters ("é" as one rune), the form Windows and Linux tools produce.
+	UnicodeNFC UnicodeForm = "nfc"
+	// UnicodeNFD decomposes characters ("e" plus combining accent), the form macOS file names use.
+	UnicodeNFD UnicodeForm = "nfd"
+)
+
+// NormalizeUnicodePath returns path in Unicode normalization form; UnicodeFormNone
+// returns path unchanged. Unknown forms fail with ErrInvalidUnicodeForm.
+func NormalizeUnicodePath(path string, form UnicodeForm) (string, error) {
+	switch form {
+	case UnicodeFormNone:
+		return path, nil
+	case UnicodeNFC:
+		return norm.NFC.String(path), nil
+	case UnicodeNFD:
+		return norm.NFD.String(path), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidUnicodeForm, form)
+	}
+}
+
+// normalizeEntryUnicode rewrites entry paths in place to form, keeping stored
+// names of changed entries in OriginalPath.
+func normalizeEntryUnicode(entries []EntryInfo, form UnicodeForm) error {
+	if form == UnicodeFormNone {
+		return nil
+	}
+
+	for i := range entries {
+		normalized, err := NormalizeUnicodePath(entries[i].Path, form)
+		if err != nil {
+			return err
+		}
+
+		stored := entries[i].Path
+		entries[i].Path = normalized
+		keepOriginalPath(&entries[i], stored)
+	}
+
+	return nil
+}
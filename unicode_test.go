@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// nfdCafe is "café" with decomposed accent as written by macOS tools.
+	nfdCafe = "cafe\u0301"
+	// nfcCafe is "café" with precomposed accent.
+	nfcCafe = "caf\u00e9"
+)
+
+func TestNormalizeUnicodePath(t *testing.T) {
+	t.Parallel()
+
+	if got, err := NormalizeUnicodePath(nfdCafe, UnicodeNFC); err != nil || got != nfcCafe {
+		t.Fatalf("NFC = %q, %v; want %q", got, err, nfcCafe)
+	}
+	if got, err := NormalizeUnicodePath(nfcCafe, UnicodeNFD); err != nil || got != nfdCafe {
+		t.Fatalf("NFD = %q, %v; want %q", got, err, nfdCafe)
+	}
+	if got, err := NormalizeUnicodePath(nfdCafe, UnicodeFormNone); err != nil || got != nfdCafe {
+		t.Fatalf("none = %q, %v; want unchanged", got, err)
+	}
+	if _, err := NormalizeUnicodePath(nfdCafe, "nfkc"); !errors.Is(err, ErrInvalidUnicodeForm) {
+		t.Fatalf("unknown form err = %v, want ErrInvalidUnicodeForm", err)
+	}
+}
+
+func TestOpenWithOptions_UnicodeForm(t *testing.T) {
+	t.Parallel()
+
+	path := createManualPBOWithNamedEntries(t, []manualEntry{
+		{name: `data\` + nfdCafe + `.txt`, data: []byte("A")},
+		{name: `data\plain.txt`, data: []byte("B")},
+	})
+
+	r, err := OpenWithOptions(path, ReaderOptions{UnicodeForm: UnicodeNFC})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if entries[0].Path != `data\`+nfcCafe+`.txt` || entries[0].OriginalPath != `data\`+nfdCafe+`.txt` {
+		t.Fatalf("entries[0] = %q (original %q), want NFC name", entries[0].Path, entries[0].OriginalPath)
+	}
+	if entries[1].OriginalPath != "" {
+		t.Fatalf("unchanged entry OriginalPath = %q, want empty", entries[1].OriginalPath)
+	}
+
+	data, err := r.ReadEntry(`data\` + nfcCafe + `.txt`)
+	if err != nil || string(data) != "A" {
+		t.Fatalf("ReadEntry normalized = %q, %v", data, err)
+	}
+
+	if _, err := ListEntriesWithOptions(path, ReaderOptions{UnicodeForm: "bad"}); !errors.Is(err, ErrInvalidUnicodeForm) {
+		t.Fatalf("ListEntriesWithOptions bad form err = %v, want ErrInvalidUnicodeForm", err)
+	}
+}
+
+func TestPackFile_UnicodeFormDuplicates(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "out.pbo")
+	files := map[string][]byte{
+		nfdCafe + ".txt": []byte("A"),
+		nfcCafe + ".txt": []byte("B"),
+	}
+
+	if err := createTestPBO(out, files, PackOptions{}); err != nil {
+		t.Fatalf("pack without normalization: %v", err)
+	}
+
+	err := createTestPBO(out, files, PackOptions{UnicodeForm: UnicodeNFC})
+	if !errors.Is(err, ErrDuplicateEntryPath) {
+		t.Fatalf("pack NFC err = %v, want ErrDuplicateEntryPath", err)
+	}
+}
@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultWatchPollInterval is source tree scan interval when WatchOptions.PollInterval is zero.
+	defaultWatchPollInterval = 500 * time.Millisecond
+	// defaultWatchDebounce is quiet period before rebuild when WatchOptions.Debounce is zero.
+	defaultWatchDebounce = 300 * time.Millisecond
+)
+
+// WatchOptions configures Watcher.
+type WatchOptions struct {
+	// OnCommit is called after each successful rebuild.
+	OnCommit func(event WatchEvent) `json:"-" yaml:"-"`
+	// OnError receives rebuild errors during Run; watching continues after them.
+	// Nil makes Run return on first error.
+	OnError func(err error) `json:"-" yaml:"-"`
+	// EditOptions configure incremental commits; PackOptions are also used for full builds.
+	EditOptions EditOptions `json:"edit_options,omitzero" yaml:"edit_options,omitzero"`
+	// PollInterval is source tree scan interval (zero means 500ms).
+	PollInterval time.Duration `json:"poll_interval,omitempty" yaml:"poll_interval,omitempty"`
+	// Debounce is time source tree must stay unchanged before rebuild (zero means 300ms).
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+}
+
+// WatchEvent describes one Watcher rebuild.
+type WatchEvent struct {
+	// Result is pack statistics of rewritten archive.
+	Result *PackResult `json:"result,omitempty" yaml:"result,omitempty"`
+	// Added lists archive paths of new source files.
+	Added []string `json:"added,omitempty" yaml:"added,omitempty"`
+	// Changed lists archive paths of modified source files.
+	Changed []string `json:"changed,omitempty" yaml:"changed,omitempty"`
+	// Removed lists archive paths of deleted source files.
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// Duration is rebuild wall time.
+	Duration time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	// Full reports full PackDir build instead of incremental Editor commit.
+	Full bool `json:"full,omitempty" yaml:"full,omitempty"`
+}
+
+// Watcher keeps target archive in sync with source directory for development rebuilds.
+// Source tree is polled; changed files are committed into existing archive via Editor.
+// Watcher methods must not be called concurrently.
+type Watcher struct {
+	committed map[string]Input
+	srcDir    string
+	target    string
+	opts      WatchOptions
+}
+
+// NewWatcher creates Watcher packing srcDir into target archive path.
+func NewWatcher(srcDir string, target string, opts WatchOptions) (*Watcher, error) {
+	if target == "" {
+		return nil, ErrInvalidEntryPath
+	}
+
+	fi, err := os.Stat(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("stat source dir: %w", err)
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("source %s is not a directory", srcDir)
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWatchPollInterval
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultWatchDebounce
+	}
+
+	return &Watcher{srcDir: srcDir, target: target, opts: opts}, nil
+}
+
+// Sync scans source directory once and rebuilds target when it differs from last build.
+// First call, or a call after target was removed, packs whole tree. Returns nil event when
+// nothing changed.
+func (w *Watcher) Sync(ctx context.Context) (*WatchEvent, error) {
+	snap, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	return w.commit(ctx, snap)
+}
+
+// Run builds target and rebuilds it after source changes until ctx is canceled.
+func (w *Watcher) Run(ctx context.Context) error {
+	if _, err := w.Sync(ctx); err != nil && !w.reportError(err) {
+		return err
+	}
+
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	var last map[string]Input
+	var changedAt time.Time
+	failed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		snap, err := w.scan()
+		if err != nil {
+			if !w.reportError(err) {
+				return err
+			}
+
+			continue
+		}
+
+		if last == nil || !sameWatchSnapshot(snap, last) {
+			last, changedAt, failed = snap, time.Now(), false
+			continue
+		}
+		if failed || time.Since(changedAt) < w.opts.Debounce {
+			continue
+		}
+
+		if _, err := w.commit(ctx, snap); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !w.reportError(err) {
+				return err
+			}
+
+			failed = true
+		}
+	}
+}
+
+// reportError passes error to OnError and reports whether watching continues.
+func (w *Watcher) reportError(err error) bool {
+	if w.opts.OnError == nil {
+		return false
+	}
+
+	w.opts.OnError(err)
+	return true
+}
+
+// scan collects current source tree inputs keyed by archive path.
+func (w *Watcher) scan() (map[string]Input, error) {
+	inputs, err := InputsFromDir(w.srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := make(map[string]Input, len(inputs))
+	for _, in := range inputs {
+		snap[in.Path] = in
+	}
+
+	return snap, nil
+}
+
+// commit writes snapshot into target, fully or incrementally, and records it as committed.
+func (w *Watcher) commit(ctx context.Context, snap map[string]Input) (*WatchEvent, error) {
+	start := time.Now()
+
+	if _, err := os.Stat(w.target); errors.Is(err, os.ErrNotExist) {
+		w.committed = nil
+	}
+
+	if w.committed == nil {
+		return w.commitFull(ctx, snap, start)
+	}
+
+	event := &WatchEvent{}
+	var added, changed []Input
+	for p, in := range snap {
+		prev, ok := w.committed[p]
+		switch {
+		case !ok:
+			added = append(added, in)
+			event.Added = append(event.Added, p)
+		case prev.SizeHint != in.SizeHint || !prev.ModTime.Equal(in.ModTime):
+			changed = append(changed, in)
+			event.Changed = append(event.Changed, p)
+		}
+	}
+	for p := range w.committed {
+		if _, ok := snap[p]; !ok {
+			event.Removed = append(event.Removed, p)
+		}
+	}
+	if len(added)+len(changed)+len(event.Removed) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(event.Added)
+	sort.Strings(event.Changed)
+	sort.Strings(event.Removed)
+
+	editor, err := OpenEditor(w.target, w.opts.EditOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err := editor.Delete(event.Removed...); err != nil {
+		return nil, err
+	}
+	if err := editor.Replace(changed...); err != nil {
+		return nil, err
+	}
+	if err := editor.Add(added...); err != nil {
+		return nil, err
+	}
+
+	res, err := editor.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commit %s: %w", w.target, err)
+	}
+
+	w.committed = snap
+	event.Result = res
+	event.Duration = time.Since(start)
+	w.notify(event)
+
+	return event, nil
+}
+
+// commitFull packs whole snapshot into target.
+func (w *Watcher) commitFull(ctx context.Context, snap map[string]Input, start time.Time) (*WatchEvent, error) {
+	inputs := make([]Input, 0, len(snap))
+	event := &WatchEvent{Full: true}
+	for p, in := range snap {
+		inputs = append(inputs, in)
+		event.Added = append(event.Added, p)
+	}
+	sort.Strings(event.Added)
+
+	if dir := filepath.Dir(w.target); dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("create target dir: %w", err)
+		}
+	}
+
+	res, err := PackFile(ctx, w.target, inputs, w.opts.EditOptions.PackOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	w.committed = snap
+	event.Result = res
+	event.Duration = time.Since(start)
+	w.notify(event)
+
+	return event, nil
+}
+
+// notify calls OnCommit when set.
+func (w *Watcher) notify(event *WatchEvent) {
+	if w.opts.OnCommit != nil {
+		w.opts.OnCommit(*event)
+	}
+}
+
+// sameWatchSnapshot reports whether two scans have equal paths, sizes, and mtimes.
+func sameWatchSnapshot(a map[string]Input, b map[string]Input) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for p, in := range a {
+		other, ok := b[p]
+		if !ok || other.SizeHint != in.SizeHint || !other.ModTime.Equal(in.ModTime) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Sync(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	target := filepath.Join(t.TempDir(), "out", "addon.pbo")
+	writeWatchFile(t, src, "config.cpp", "class CfgPatches {};")
+	writeWatchFile(t, src, "scripts/old.c", "void Old();")
+
+	w, err := NewWatcher(src, target, WatchOptions{})
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+
+	event, err := w.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("initial Sync: %v", err)
+	}
+	if event == nil || !event.Full || len(event.Added) != 2 {
+		t.Fatalf("initial event=%+v, want full build of 2 files", event)
+	}
+
+	if event, err := w.Sync(context.Background()); err != nil || event != nil {
+		t.Fatalf("unchanged Sync event=%+v err=%v, want nil", event, err)
+	}
+
+	writeWatchFile(t, src, "config.cpp", "class CfgPatches { class Mod {}; };")
+	writeWatchFile(t, src, "scripts/new.c", "void New();")
+	if err := os.Remove(filepath.Join(src, "scripts", "old.c")); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	event, err = w.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("incremental Sync: %v", err)
+	}
+	if event == nil || event.Full ||
+		!slices.Equal(event.Added, []string{"scripts/new.c"}) ||
+		!slices.Equal(event.Changed, []string{"config.cpp"}) ||
+		!slices.Equal(event.Removed, []string{"scripts/old.c"}) {
+		t.Fatalf("incremental event=%+v", event)
+	}
+
+	r, err := Open(target)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if got, err := r.ReadEntry("config.cpp"); err != nil || string(got) != "class CfgPatches { class Mod {}; };" {
+		t.Fatalf("config.cpp=%q err=%v", got, err)
+	}
+	if _, err := r.ReadEntry("scripts/old.c"); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("removed entry err=%v, want ErrEntryNotFound", err)
+	}
+	if _, err := r.ReadEntry("scripts/new.c"); err != nil {
+		t.Fatalf("added entry: %v", err)
+	}
+	if _, ok := r.SHA1Trailer(); !ok {
+		t.Fatal("incremental commit must keep SHA1 trailer")
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	target := filepath.Join(t.TempDir(), "addon.pbo")
+	writeWatchFile(t, src, "config.cpp", "v1")
+
+	events := make(chan WatchEvent, 4)
+	w, err := NewWatcher(src, target, WatchOptions{
+		PollInterval: 5 * time.Millisecond,
+		Debounce:     10 * time.Millisecond,
+		OnCommit:     func(e WatchEvent) { events <- e },
+	})
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	if e := waitWatchEvent(t, events); !e.Full {
+		t.Fatalf("first event=%+v, want full build", e)
+	}
+
+	writeWatchFile(t, src, "data.txt", "payload")
+	if e := waitWatchEvent(t, events); !slices.Equal(e.Added, []string{"data.txt"}) {
+		t.Fatalf("second event=%+v, want data.txt added", e)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+func TestNewWatcher_InvalidSource(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := NewWatcher(file, filepath.Join(t.TempDir(), "a.pbo"), WatchOptions{}); err == nil {
+		t.Fatal("expected error for non-directory source")
+	}
+}
+
+// writeWatchFile writes file under dir and moves its mtime forward to make change visible.
+func writeWatchFile(t *testing.T, dir string, rel string, content string) {
+	t.Helper()
+
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	mtime := time.Now().Add(time.Duration(len(content)) * time.Second)
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+// waitWatchEvent waits for next watcher commit event.
+func waitWatchEvent(t *testing.T, events <-chan WatchEvent) WatchEvent {
+	t.Helper()
+
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watcher commit")
+		return WatchEvent{}
+	}
+}
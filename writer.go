@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"sort"
@@ -42,17 +43,34 @@ const (
 type writtenEntry struct {
 	path                 string
 	dataSize             uint32
+	offset               uint32
 	originalSize         uint32
 	mime                 MimeType
 	timestamp            uint32
 	compressionCandidate bool
+	// skipReason explains why compression candidate was stored raw.
+	skipReason string
 }
 
+// skipReasonNotSmaller is skip reason for candidates whose compressed form was not smaller.
+const skipReasonNotSmaller = "compressed size not smaller than raw"
+
 // rewriteEntry describes one payload source for archive rewrite core.
 type rewriteEntry struct {
 	input  *Input
 	source *EntryInfo
-	path   string
+	// sourceReader overrides shared rewrite source for entries copied from other archives.
+	sourceReader *Reader
+	path         string
+}
+
+// sourceOf returns reader holding source entry of item: own sourceReader or shared src.
+func (item rewriteEntry) sourceOf(src *Reader) *Reader {
+	if item.sourceReader != nil {
+		return item.sourceReader
+	}
+
+	return src
 }
 
 // rewriteArchiveResult contains rewrite core result and written metadata.
@@ -63,7 +81,7 @@ type rewriteArchiveResult struct {
 }
 
 // Pack writes a PBO to out from the given inputs.
-// Inputs are sorted by path for deterministic output.
+// Inputs are sorted by path for deterministic output unless opts.Order says otherwise.
 func Pack(ctx context.Context, out io.WriteSeeker, inputs []Input, opts PackOptions) (*PackResult, error) {
 	if len(inputs) == 0 {
 		return nil, ErrEmptyInputs
@@ -71,7 +89,7 @@ func Pack(ctx context.Context, out io.WriteSeeker, inputs []Input, opts PackOpti
 
 	opts.applyDefaults()
 
-	rewritePlan, err := preparePackRewritePlan(inputs)
+	rewritePlan, err := preparePackRewritePlan(inputs, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +151,8 @@ func PackAndHash(
 		return nil, hs, ErrReaderAtRequired
 	}
 
-	if err := validateSignHashArgs(signVersion, gameType); err != nil {
+	_, policy, err := signPolicyFor(signVersion, gameType)
+	if err != nil {
 		return nil, hs, err
 	}
 
@@ -142,7 +161,7 @@ func PackAndHash(
 	}
 
 	opts.applyDefaults()
-	rewritePlan, err := preparePackRewritePlan(inputs)
+	rewritePlan, err := preparePackRewritePlan(inputs, opts)
 	if err != nil {
 		return nil, hs, err
 	}
@@ -164,7 +183,7 @@ func PackAndHash(
 		details.headers,
 		details.entries,
 		signVersion,
-		gameType,
+		policy,
 	)
 	if err != nil {
 		return nil, hs, err
@@ -240,25 +259,10 @@ func acquirePackCopyBuffer() ([]byte, func()) {
 	}
 }
 
-// preparePackRewritePlan normalizes and sorts pack inputs for deterministic rewrite pass.
-func preparePackRewritePlan(inputs []Input) ([]rewriteEntry, error) {
-	sorted := make([]Input, len(inputs))
-	copy(sorted, inputs)
-
-	for i := range sorted {
-		normalizedPath, err := normalizeArchiveEntryPath(sorted[i].Path)
-		if err != nil {
-			return nil, err
-		}
-
-		sorted[i].Path = normalizedPath
-	}
-
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Path < sorted[j].Path
-	})
-
-	if err := validateUniqueEntryPaths(sorted); err != nil {
+// preparePackRewritePlan normalizes and orders pack inputs for rewrite pass.
+func preparePackRewritePlan(inputs []Input, opts PackOptions) ([]rewriteEntry, error) {
+	sorted, err := normalizePackInputs(inputs, opts)
+	if err != nil {
 		return nil, err
 	}
 
@@ -284,18 +288,51 @@ func preparePackRewritePlan(inputs []Input) ([]rewriteEntry, error) {
 	return rewritePlan, nil
 }
 
-// openInputReader opens source stream for one input.
-func openInputReader(in Input) (io.ReadCloser, error) {
-	if in.Open == nil {
-		return nil, fmt.Errorf("input %s: Open is nil", in.Path)
+// normalizePackInputs returns copy of inputs with canonical paths ordered per opts.Order and checked for duplicates.
+func normalizePackInputs(inputs []Input, opts PackOptions) ([]Input, error) {
+	sorted := make([]Input, len(inputs))
+	copy(sorted, inputs)
+
+	for i := range sorted {
+		unicodePath, err := NormalizeUnicodePath(sorted[i].Path, opts.UnicodeForm)
+		if err != nil {
+			return nil, err
+		}
+
+		normalizedPath, err := NormalizeEntryPath(unicodePath)
+		if err != nil {
+			return nil, err
+		}
+
+		sorted[i].Path = normalizedPath
 	}
 
-	rc, err := in.Open()
-	if err != nil {
-		return nil, fmt.Errorf("open input %s: %w", in.Path, err)
+	switch opts.Order {
+	case "", PackOrderPath:
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Path < sorted[j].Path
+		})
+	case PackOrderPreserveInput:
+	case PackOrderCustom:
+		if opts.OrderLess == nil {
+			return nil, fmt.Errorf("%w: custom order requires OrderLess", ErrInvalidPackOrder)
+		}
+
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return opts.OrderLess(sorted[i], sorted[j])
+		})
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPackOrder, opts.Order)
 	}
 
-	return rc, nil
+	if err := validateUniqueEntryPaths(sorted, opts.PathCaseSensitivity); err != nil {
+		return nil, err
+	}
+	if err := validateInputModTimes(sorted, opts); err != nil {
+		return nil, err
+	}
+
+	return sorted, nil
 }
 
 // writeInputPayload writes one entry payload according to precomputed compression plan.
@@ -319,7 +356,7 @@ func writeInputPayload(
 func rewriteArchive(
 	ctx context.Context,
 	out io.WriteSeeker,
-	src io.ReaderAt,
+	src *Reader,
 	rewritePlan []rewriteEntry,
 	opts PackOptions,
 ) (*PackResult, error) {
@@ -335,7 +372,7 @@ func rewriteArchive(
 func rewriteArchiveDetailed(
 	ctx context.Context,
 	out io.WriteSeeker,
-	src io.ReaderAt,
+	src *Reader,
 	rewritePlan []rewriteEntry,
 	opts PackOptions,
 ) (*rewriteArchiveResult, error) {
@@ -351,49 +388,54 @@ func rewriteArchiveDetailed(
 
 	opts.applyDefaults()
 
-	compressMatcher, err := newCompressMatcher(opts.Compress, opts.CompressMatcherOptions)
-	if err != nil {
-		return nil, fmt.Errorf("compile compress rules: %w", err)
+	if err := validatePayloadAlignment(opts.PayloadAlignment); err != nil {
+		return nil, err
 	}
 
-	w, releaseWriter := acquirePackWriter(out, opts.WriterBufferSize)
-	defer releaseWriter()
-
-	header := make([]byte, headerSize)
-	binary.LittleEndian.PutUint32(header[1:5], uint32(MimeHeader))
-	if _, err := w.Write(header); err != nil {
-		return nil, fmt.Errorf("write header: %w", err)
+	compressMatcher, err := opts.compileCompressRules()
+	if err != nil {
+		return nil, err
 	}
 
-	writtenHeaders := make([]HeaderPair, 0, len(opts.Headers))
-	for _, h := range opts.Headers {
-		key := h.Key
-		value := h.Value
-		if strings.EqualFold(strings.TrimSpace(key), "prefix") {
-			value = NormalizePrefixHeader(value)
-		}
-
-		writtenHeaders = append(writtenHeaders, HeaderPair{Key: key, Value: value})
+	hasher, err := newEntryHasher(opts.EntryHash)
+	if err != nil {
+		return nil, err
+	}
 
-		if _, err := w.WriteString(key); err != nil {
-			return nil, fmt.Errorf("write header key: %w", err)
-		}
+	storedNames, err := encodeEntryNames(rewritePlan, opts.NameEncoding)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := w.WriteByte(0); err != nil {
-			return nil, fmt.Errorf("write header key terminator: %w", err)
+	var (
+		verifier  *packVerifier
+		verifyOut io.ReaderAt
+	)
+	if opts.VerifyAfterWrite {
+		ra, ok := out.(io.ReaderAt)
+		if !ok {
+			return nil, fmt.Errorf("%w: VerifyAfterWrite", ErrReaderAtRequired)
 		}
+		verifier = &packVerifier{sums: make([]packVerifySum, 0, len(rewritePlan))}
+		verifyOut = ra
+	}
 
-		if _, err := w.WriteString(value); err != nil {
-			return nil, fmt.Errorf("write header value: %w", err)
+	headers := opts.Headers
+	if opts.PayloadAlignment > 1 {
+		start, err := out.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("seek before headers: %w", err)
 		}
 
-		if err := w.WriteByte(0); err != nil {
-			return nil, fmt.Errorf("write header value terminator: %w", err)
-		}
+		headers = alignHeaderSection(headers, storedNames, start, opts.PayloadAlignment)
 	}
 
-	if err := w.WriteByte(0); err != nil {
-		return nil, fmt.Errorf("write header terminator: %w", err)
+	w, releaseWriter := opts.acquireWriter(out)
+	defer releaseWriter()
+
+	writtenHeaders, err := writeHeaderSection(w, headers, opts.AllowDuplicateHeaders)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := w.Flush(); err != nil {
@@ -406,8 +448,8 @@ func rewriteArchiveDetailed(
 	}
 
 	var placeholder [20]byte
-	for _, item := range rewritePlan {
-		if _, err := w.WriteString(item.path); err != nil {
+	for _, name := range storedNames {
+		if _, err := w.WriteString(name); err != nil {
 			return nil, fmt.Errorf("write entry path: %w", err)
 		}
 
@@ -444,20 +486,56 @@ func rewriteArchiveDetailed(
 	written := make([]writtenEntry, 0, len(rewritePlan))
 	entries := make([]EntryInfo, 0, len(rewritePlan))
 	currentOffset := uint32(dataStart) //nolint:gosec // checked above against maxPBOData
+
 	var (
 		rawBytes                  int64
 		compressedBytes           int64
 		compressedEntries         int
 		skippedCompressionEntries int
+		digests                   []EntryDigest
 	)
+	extStats := make(extensionStatsCollector)
+	if hasher != nil {
+		digests = make([]EntryDigest, 0, len(rewritePlan))
+	}
 
 	copyBuf, releaseCopyBuffer := acquirePackCopyBuffer()
 	defer releaseCopyBuffer()
 
-	appendWrittenEntry := func(path string, record writtenEntry) {
+	var (
+		sharedWith   []int
+		dedupEntries int
+		dedupBytes   int64
+	)
+	if opts.DedupEqualPayloads {
+		sharedWith, err = planPayloadDedup(ctx, src, rewritePlan, copyBuf, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pipeline := startCompressPipeline(ctx, rewritePlan, sharedWith, opts, compressMatcher)
+	defer pipeline.stop()
+
+	var progressDone, progressTotal EntryStats
+	if opts.OnProgress != nil {
+		progressTotal = packProgressTotal(rewritePlan)
+	}
+
+	// BytesPerSecond throttles payload writes only; header and index are small.
+	payloadDst := throttleWriter(ctx, w, newByteRateLimiter(opts.BytesPerSecond))
+
+	// shared records reuse payload already written at record.offset.
+	appendWrittenEntry := func(path string, record writtenEntry, digest string, shared bool) {
+		if shared {
+			record.path = path
+		} else {
+			record.offset = currentOffset
+		}
+		record.timestamp = opts.entryTimestamp(record.timestamp)
 		entryInfo := EntryInfo{
 			Path:         path,
-			Offset:       currentOffset,
+			Offset:       record.offset,
 			DataSize:     record.dataSize,
 			OriginalSize: record.originalSize,
 			TimeStamp:    record.timestamp,
@@ -466,66 +544,143 @@ func rewriteArchiveDetailed(
 
 		written = append(written, record)
 		entries = append(entries, entryInfo)
+		extStats.add(path, record)
 
-		if record.mime == MimeCompress {
+		switch {
+		case shared:
+			dedupEntries++
+			dedupBytes += int64(record.dataSize)
+		case record.mime == MimeCompress:
 			compressedEntries++
 			compressedBytes += int64(record.dataSize)
-		} else {
+		default:
 			rawBytes += int64(record.dataSize)
 		}
 
-		if record.compressionCandidate && record.mime != MimeCompress {
+		if !shared && record.compressionCandidate && record.mime != MimeCompress {
 			skippedCompressionEntries++
+			opts.logger().Debug("compression skipped", "path", path, "reason", record.skipReason)
+		}
+
+		if hasher != nil {
+			digests = append(digests, EntryDigest{Path: path, Digest: digest})
 		}
 
 		if opts.OnEntryDone != nil {
 			opts.OnEntryDone(PackEntryProgress{
 				Path:                 path,
-				Offset:               currentOffset,
+				Offset:               record.offset,
 				DataSize:             record.dataSize,
 				OriginalSize:         record.originalSize,
 				MimeType:             record.mime,
 				CompressionCandidate: record.compressionCandidate,
 				Compressed:           record.mime == MimeCompress,
+				Digest:               digest,
+				ModTime:              uint32ToTime(record.timestamp),
 			})
 		}
 
-		currentOffset += record.dataSize
+		if opts.OnProgress != nil {
+			progressDone.Entries++
+			progressDone.Bytes += writtenEntryContentSize(record)
+			opts.OnProgress(progressDone, progressTotal)
+		}
+
+		if !shared {
+			currentOffset += record.dataSize
+		}
 	}
 
-	for _, item := range rewritePlan {
+	for i, item := range rewritePlan {
 		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
 
+		if sharedWith != nil && sharedWith[i] >= 0 {
+			primary := sharedWith[i]
+			var digest string
+			if hasher != nil {
+				digest = digests[primary].Digest
+			}
+			if verifier != nil {
+				verifier.addShared(primary)
+			}
+
+			appendWrittenEntry(item.path, written[primary], digest, true)
+			continue
+		}
+
+		if opts.AlignEntries {
+			currentOffset, err = writeAlignmentPadding(w, currentOffset, opts.PayloadAlignment)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if item.source != nil {
-			if src == nil {
+			itemSrc := item.sourceOf(src)
+			if itemSrc == nil || itemSrc.ra == nil {
 				return nil, ErrNilReader
 			}
 
-			record, err := writeSourcePackedPayload(w, src, item.path, *item.source, currentOffset, copyBuf)
+			record, err := writeSourcePackedPayload(payloadDst, itemSrc.ra, item.path, *item.source, currentOffset, copyBuf)
 			if err != nil {
 				return nil, err
 			}
 
-			appendWrittenEntry(item.path, record)
+			var digest string
+			if hasher != nil {
+				digest, err = hashStoredEntry(hasher, itemSrc, *item.source)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if verifier != nil {
+				if err := verifier.addSource(itemSrc.ra, *item.source, copyBuf); err != nil {
+					return nil, err
+				}
+			}
+
+			appendWrittenEntry(item.path, record, digest, false)
 
 			continue
 		}
 
-		record, err := writeRewriteInputPayload(
-			w,
-			item,
-			opts,
-			compressMatcher,
-			currentOffset,
-			copyBuf,
-		)
+		var verifySum hash.Hash32
+		if verifier != nil && item.input != nil {
+			item, verifySum = verifier.wrapInput(item)
+		}
+
+		cand, pipelined, err := pipeline.result(i)
+		if err != nil {
+			return nil, err
+		}
+
+		var record writtenEntry
+		if pipelined {
+			record, err = writePipelineCandidate(payloadDst, *item.input, cand, hasher, verifySum, currentOffset)
+		} else {
+			record, err = writeRewriteInputPayload(
+				ctx,
+				payloadDst,
+				item,
+				opts,
+				compressMatcher,
+				hasher,
+				currentOffset,
+				copyBuf,
+			)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		appendWrittenEntry(item.path, record)
+		if verifier != nil {
+			verifier.addInput(item.input, verifySum)
+		}
+
+		appendWrittenEntry(item.path, record, hexDigest(hasher), false)
 	}
 
 	if err := w.Flush(); err != nil {
@@ -534,19 +689,15 @@ func rewriteArchiveDetailed(
 
 	pos := entriesStart
 	var entryFields [20]byte
-	for i, item := range rewritePlan {
-		pos += int64(len(item.path) + 1)
+	for i := range rewritePlan {
+		pos += int64(len(storedNames[i]) + 1)
 		if _, err := out.Seek(pos, io.SeekStart); err != nil {
 			return nil, fmt.Errorf("seek to entry %d: %w", i, err)
 		}
 
-		record := written[i]
-		binary.LittleEndian.PutUint32(entryFields[0:4], uint32(record.mime))
-		binary.LittleEndian.PutUint32(entryFields[4:8], record.originalSize)
-		// Common tooling emits zero in index offset and derives offsets sequentially.
-		binary.LittleEndian.PutUint32(entryFields[8:12], 0)
-		binary.LittleEndian.PutUint32(entryFields[12:16], record.timestamp)
-		binary.LittleEndian.PutUint32(entryFields[16:20], record.dataSize)
+		if err := opts.encodeEntryFields(&entryFields, written[i], dataStart); err != nil {
+			return nil, err
+		}
 		if _, err := out.Write(entryFields[:]); err != nil {
 			return nil, fmt.Errorf("patch entry %d: %w", i, err)
 		}
@@ -554,6 +705,12 @@ func rewriteArchiveDetailed(
 		pos += 20
 	}
 
+	if verifier != nil {
+		if err := verifier.verify(verifyOut, entries, copyBuf); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := applySealedTransformToWriteSeeker(out, opts.SealedKey); err != nil {
 		return nil, err
 	}
@@ -567,6 +724,10 @@ func rewriteArchiveDetailed(
 			CompressedBytes:           compressedBytes,
 			CompressedEntries:         compressedEntries,
 			SkippedCompressionEntries: skippedCompressionEntries,
+			DedupEntries:              dedupEntries,
+			DedupBytes:                dedupBytes,
+			EntryDigests:              digests,
+			Extensions:                extStats.result(),
 			Duration:                  time.Since(startedAt),
 		},
 		entries: entries,
@@ -574,12 +735,117 @@ func rewriteArchiveDetailed(
 	}, nil
 }
 
+// packProgressTotal returns planned entry count and known original content bytes.
+func packProgressTotal(rewritePlan []rewriteEntry) EntryStats {
+	total := EntryStats{Entries: len(rewritePlan)}
+	for _, item := range rewritePlan {
+		switch {
+		case item.source != nil:
+			total.Bytes += int64(filterOriginalSizeOrDataSize(*item.source))
+		case item.input != nil && item.input.OriginalSize != 0:
+			total.Bytes += int64(item.input.OriginalSize)
+		case item.input != nil:
+			total.Bytes += max(item.input.SizeHint, 0)
+		}
+	}
+
+	return total
+}
+
+// writtenEntryContentSize returns original content size of one written entry.
+func writtenEntryContentSize(record writtenEntry) int64 {
+	if record.mime == MimeCompress {
+		return int64(record.originalSize)
+	}
+
+	return int64(record.dataSize)
+}
+
+// writeHeaderSection writes fixed header block and key-value header pairs with terminator.
+// It returns headers exactly as written (with normalized prefix value).
+func writeHeaderSection(w *bufio.Writer, headers []HeaderPair, allowDuplicates bool) ([]HeaderPair, error) {
+	if !allowDuplicates {
+		if err := validateUniqueHeaderKeys(headers); err != nil {
+			return nil, err
+		}
+	}
+
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(header[1:5], uint32(MimeHeader))
+	if _, err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+
+	writtenHeaders := make([]HeaderPair, 0, len(headers))
+	for _, h := range headers {
+		key := h.Key
+		value := writtenHeaderValue(h)
+
+		writtenHeaders = append(writtenHeaders, HeaderPair{Key: key, Value: value})
+
+		if _, err := w.WriteString(key); err != nil {
+			return nil, fmt.Errorf("write header key: %w", err)
+		}
+
+		if err := w.WriteByte(0); err != nil {
+			return nil, fmt.Errorf("write header key terminator: %w", err)
+		}
+
+		if _, err := w.WriteString(value); err != nil {
+			return nil, fmt.Errorf("write header value: %w", err)
+		}
+
+		if err := w.WriteByte(0); err != nil {
+			return nil, fmt.Errorf("write header value terminator: %w", err)
+		}
+	}
+
+	if err := w.WriteByte(0); err != nil {
+		return nil, fmt.Errorf("write header terminator: %w", err)
+	}
+
+	return writtenHeaders, nil
+}
+
+// encodeEntryFields encodes one written entry record into 20-byte index field block
+// and applies EntryFields override. dataStart is payload section start of archive.
+func (opts *PackOptions) encodeEntryFields(dst *[20]byte, record writtenEntry, dataStart int64) error {
+	binary.LittleEndian.PutUint32(dst[0:4], uint32(record.mime))
+	binary.LittleEndian.PutUint32(dst[4:8], record.originalSize)
+	// Common tooling emits zero in index offset and derives offsets sequentially;
+	// deduplicated archives need stored offsets for shared payloads.
+	var offset uint32
+	if opts.storedOffsets() {
+		offset = record.offset
+		if opts.RelativeStoredOffsets {
+			offset -= uint32(dataStart) //nolint:gosec // payloads start at or after dataStart
+		}
+	}
+	binary.LittleEndian.PutUint32(dst[8:12], offset)
+	binary.LittleEndian.PutUint32(dst[12:16], record.timestamp)
+	binary.LittleEndian.PutUint32(dst[16:20], record.dataSize)
+
+	if opts.EntryFields == nil {
+		return nil
+	}
+
+	fields := opts.EntryFields(record.path, *dst)
+	if binary.LittleEndian.Uint32(fields[16:20]) != record.dataSize {
+		return fmt.Errorf("%w: %s: data size field changed", ErrInvalidEntryFields, record.path)
+	}
+
+	*dst = fields
+	return nil
+}
+
 // writeRewriteInputPayload opens and writes one input-backed rewrite item.
 func writeRewriteInputPayload(
+	ctx context.Context,
 	dst io.Writer,
 	item rewriteEntry,
 	opts PackOptions,
 	matcher *compressMatcher,
+	hasher hash.Hash,
 	currentOffset uint32,
 	copyBuf []byte,
 ) (writtenEntry, error) {
@@ -589,14 +855,27 @@ func writeRewriteInputPayload(
 
 	useCompression := shouldUseCompressionForInput(opts, matcher, *item.input)
 
-	rc, err := openInputReader(*item.input)
+	rc, err := openInputReader(ctx, *item.input, opts.PerEntryTimeout, opts.OpenRetry)
 	if err != nil {
 		return writtenEntry{}, err
 	}
 
+	if item.input.OriginalSize != 0 {
+		record, writeErr := writePreCompressedPayload(dst, hashingReader(hasher, rc), *item.input, currentOffset, copyBuf)
+		closeErr := rc.Close()
+		if writeErr != nil {
+			return writtenEntry{}, writeErr
+		}
+		if closeErr != nil {
+			return writtenEntry{}, fmt.Errorf("close input %s: %w", item.input.Path, closeErr)
+		}
+
+		return record, nil
+	}
+
 	record, writeErr := writeInputPayload(
 		dst,
-		rc,
+		hashingReader(hasher, rc),
 		*item.input,
 		opts,
 		useCompression,
@@ -618,10 +897,14 @@ func writeRewriteInputPayload(
 
 // shouldUseCompressionForInput reports whether input should enter compression candidate path.
 func shouldUseCompressionForInput(opts PackOptions, matcher *compressMatcher, in Input) bool {
-	if matcher == nil {
+	if matcher == nil || in.OriginalSize != 0 {
 		return false
 	}
 
+	if shouldUseSpoolCompressPath(opts, in.SizeHint, int64(^uint32(0))) {
+		return matcher.Match(in.Path)
+	}
+
 	if shouldSkipCompressBySizeHint(opts, in.SizeHint) {
 		return false
 	}
@@ -657,13 +940,111 @@ func writeCompressedCandidatePayload(
 	copyBuf []byte,
 ) (writtenEntry, error) {
 	maxEntrySize := int64(^uint32(0)) - int64(currentOffset)
+	if shouldUseSpoolCompressPath(opts, in.SizeHint, maxEntrySize) {
+		return writeCompressedCandidatePayloadSpooled(dst, src, in, opts, currentOffset, copyBuf, maxEntrySize)
+	}
+
 	if !shouldUseInMemoryCompressPath(opts, in.SizeHint, maxEntrySize) {
-		return writeUncompressedPayload(dst, src, in, currentOffset, copyBuf)
+		record, err := writeUncompressedPayload(dst, src, in, currentOffset, copyBuf)
+		record.skipReason = "size unknown or above max compress size"
+		return record, err
 	}
 
 	return writeCompressedCandidatePayloadInMemory(dst, src, in, opts, currentOffset, copyBuf, maxEntrySize)
 }
 
+// shouldUseSpoolCompressPath reports whether known-size candidate above in-memory limit is spooled.
+func shouldUseSpoolCompressPath(opts PackOptions, sizeHint int64, maxEntrySize int64) bool {
+	if opts.SpoolCompressSize == 0 || sizeHint <= int64(opts.MaxCompressSize) {
+		return false
+	}
+	if sizeHint > int64(opts.SpoolCompressSize) || sizeHint > maxEntrySize {
+		return false
+	}
+
+	_, ok := opts.Compressor.(StreamCompressor)
+	return ok
+}
+
+// writeCompressedCandidatePayloadSpooled compresses large candidate into temp file while
+// keeping raw copy in second temp file, then writes the smaller form.
+func writeCompressedCandidatePayloadSpooled(
+	dst io.Writer,
+	src io.Reader,
+	in Input,
+	opts PackOptions,
+	currentOffset uint32,
+	copyBuf []byte,
+	maxEntrySize int64,
+) (writtenEntry, error) {
+	compressor, ok := opts.Compressor.(StreamCompressor)
+	if !ok {
+		record, err := writeUncompressedPayload(dst, src, in, currentOffset, copyBuf)
+		record.skipReason = "compressor does not support streaming"
+		return record, err
+	}
+
+	rawSpool, err := os.CreateTemp(opts.SpoolDir, "pbo-raw-*")
+	if err != nil {
+		return writtenEntry{}, fmt.Errorf("create spool for %s: %w", in.Path, err)
+	}
+	defer removeSpoolFile(rawSpool)
+
+	packedSpool, err := os.CreateTemp(opts.SpoolDir, "pbo-packed-*")
+	if err != nil {
+		return writtenEntry{}, fmt.Errorf("create spool for %s: %w", in.Path, err)
+	}
+	defer removeSpoolFile(packedSpool)
+
+	limited := io.LimitReader(src, maxEntrySize+1)
+	rawSize, packedSize, err := compressor.CompressStream(packedSpool, io.TeeReader(limited, rawSpool))
+	if err != nil {
+		return writtenEntry{}, fmt.Errorf("compress %s: %w", in.Path, err)
+	}
+
+	originalSize, err := checkedDataSize(in.Path, rawSize, currentOffset)
+	if err != nil {
+		return writtenEntry{}, err
+	}
+
+	record := writtenEntry{
+		path:      in.Path,
+		dataSize:  originalSize,
+		mime:      MimeNil,
+		timestamp: timeToUint32(in.ModTime),
+	}
+
+	spool, size := rawSpool, rawSize
+	if packedSize < rawSize {
+		record.dataSize = uint32(packedSize) //nolint:gosec // packedSize < rawSize, bounded above
+		record.originalSize = originalSize
+		record.mime = MimeCompress
+		spool, size = packedSpool, packedSize
+	} else {
+		record.skipReason = skipReasonNotSmaller
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return writtenEntry{}, fmt.Errorf("rewind spool for %s: %w", in.Path, err)
+	}
+
+	written, err := copyPayloadBounded(dst, spool, size, copyBuf)
+	if err != nil {
+		return writtenEntry{}, fmt.Errorf("write payload %s: %w", in.Path, err)
+	}
+	if written != size {
+		return writtenEntry{}, fmt.Errorf("write payload %s: short spool read (%d/%d)", in.Path, written, size)
+	}
+
+	return record, nil
+}
+
+// removeSpoolFile closes and deletes one spool temp file.
+func removeSpoolFile(f *os.File) {
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
+
 // writeUncompressedPayload streams payload directly into destination and records MimeNil metadata.
 func writeUncompressedPayload(
 	dst io.Writer,
@@ -691,6 +1072,25 @@ func writeUncompressedPayload(
 	}, nil
 }
 
+// writePreCompressedPayload streams already compressed input payload and records MimeCompress metadata.
+func writePreCompressedPayload(
+	dst io.Writer,
+	src io.Reader,
+	in Input,
+	currentOffset uint32,
+	copyBuf []byte,
+) (writtenEntry, error) {
+	record, err := writeUncompressedPayload(dst, src, in, currentOffset, copyBuf)
+	if err != nil {
+		return writtenEntry{}, err
+	}
+
+	record.mime = MimeCompress
+	record.originalSize = in.OriginalSize
+
+	return record, nil
+}
+
 // shouldUseInMemoryCompressPath reports whether compression candidate can use fast in-memory path.
 func shouldUseInMemoryCompressPath(opts PackOptions, sizeHint int64, maxEntrySize int64) bool {
 	if sizeHint <= 0 {
@@ -716,51 +1116,83 @@ func writeCompressedCandidatePayloadInMemory(
 	copyBuf []byte,
 	maxEntrySize int64,
 ) (writtenEntry, error) {
-	raw, err := readPayloadBounded(src, maxEntrySize, in.SizeHint, int64(opts.MaxCompressSize), copyBuf)
-	if err != nil {
-		return writtenEntry{}, fmt.Errorf("stream input %s: %w", in.Path, err)
-	}
-
-	originalSize, err := checkedDataSize(in.Path, int64(len(raw)), currentOffset)
+	cand, err := compressCandidateInMemory(src, in, opts, maxEntrySize, copyBuf)
 	if err != nil {
 		return writtenEntry{}, err
 	}
 
-	record := writtenEntry{
-		path:      in.Path,
-		dataSize:  originalSize,
-		mime:      MimeNil,
-		timestamp: timeToUint32(in.ModTime),
+	return writeCompressedCandidate(dst, in, cand, currentOffset)
+}
+
+// compressedCandidate is in-memory compression result of one candidate input.
+type compressedCandidate struct {
+	raw []byte
+	// compressed is nil when raw form is stored.
+	compressed []byte
+	skipReason string
+}
+
+// compressCandidateInMemory reads candidate into memory and compresses it when size allows.
+func compressCandidateInMemory(
+	src io.Reader,
+	in Input,
+	opts PackOptions,
+	maxEntrySize int64,
+	copyBuf []byte,
+) (compressedCandidate, error) {
+	raw, err := readPayloadBounded(src, maxEntrySize, in.SizeHint, int64(opts.MaxCompressSize), copyBuf)
+	if err != nil {
+		return compressedCandidate{}, fmt.Errorf("stream input %s: %w", in.Path, err)
 	}
-	if !shouldCompressBySize(opts, originalSize) {
-		if _, err := dst.Write(raw); err != nil {
-			return writtenEntry{}, fmt.Errorf("write payload %s: %w", in.Path, err)
-		}
 
-		return record, nil
+	cand := compressedCandidate{raw: raw}
+	if !shouldCompressBySize(opts, uint32(len(raw))) { //nolint:gosec // read is bounded by maxEntrySize
+		cand.skipReason = "size outside compress size range"
+		return cand, nil
 	}
 
-	compressed, err := compressLZSS(raw)
+	compressed, err := opts.Compressor.Compress(raw)
 	if err != nil {
-		return writtenEntry{}, fmt.Errorf("compress %s: %w", in.Path, err)
+		return compressedCandidate{}, fmt.Errorf("compress %s: %w", in.Path, err)
 	}
 	if len(compressed) >= len(raw) {
-		if _, err := dst.Write(raw); err != nil {
-			return writtenEntry{}, fmt.Errorf("write payload %s: %w", in.Path, err)
-		}
-
-		return record, nil
+		cand.skipReason = skipReasonNotSmaller
+		return cand, nil
 	}
 
-	dataSize, err := checkedDataSize(in.Path, int64(len(compressed)), currentOffset)
+	cand.compressed = compressed
+	return cand, nil
+}
+
+// writeCompressedCandidate writes smaller form of in-memory candidate and returns its record.
+func writeCompressedCandidate(dst io.Writer, in Input, cand compressedCandidate, currentOffset uint32) (writtenEntry, error) {
+	originalSize, err := checkedDataSize(in.Path, int64(len(cand.raw)), currentOffset)
 	if err != nil {
 		return writtenEntry{}, err
 	}
 
-	record.dataSize = dataSize
-	record.originalSize = originalSize
-	record.mime = MimeCompress
-	if _, err := dst.Write(compressed); err != nil {
+	record := writtenEntry{
+		path:       in.Path,
+		dataSize:   originalSize,
+		mime:       MimeNil,
+		timestamp:  timeToUint32(in.ModTime),
+		skipReason: cand.skipReason,
+	}
+
+	payload := cand.raw
+	if cand.compressed != nil {
+		dataSize, err := checkedDataSize(in.Path, int64(len(cand.compressed)), currentOffset)
+		if err != nil {
+			return writtenEntry{}, err
+		}
+
+		record.dataSize = dataSize
+		record.originalSize = originalSize
+		record.mime = MimeCompress
+		payload = cand.compressed
+	}
+
+	if _, err := dst.Write(payload); err != nil {
 		return writtenEntry{}, fmt.Errorf("write payload %s: %w", in.Path, err)
 	}
 
@@ -902,11 +1334,28 @@ func checkedDataSize(path string, size int64, currentOffset uint32) (uint32, err
 	return uint32(size), nil
 }
 
-// validateUniqueEntryPaths ensures there are no duplicate logical entry paths.
-func validateUniqueEntryPaths(inputs []Input) error {
+// validateUniqueHeaderKeys ensures header keys do not repeat (case-insensitive).
+func validateUniqueHeaderKeys(headers []HeaderPair) error {
+	for i := 1; i < len(headers); i++ {
+		for j := range i {
+			if strings.EqualFold(headers[i].Key, headers[j].Key) {
+				return fmt.Errorf("%w: %q", ErrDuplicateHeaderKey, headers[i].Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateUniqueEntryPaths ensures there are no duplicate logical entry paths under pathCase.
+func validateUniqueEntryPaths(inputs []Input, pathCase PathCaseSensitivity) error {
+	if err := validatePathCaseSensitivity(pathCase); err != nil {
+		return err
+	}
+
 	seen := make(map[string]string, len(inputs))
 	for _, in := range inputs {
-		key := strings.ToLower(in.Path)
+		key := editorPathKey(in.Path, pathCase)
 		if existing, ok := seen[key]; ok {
 			return fmt.Errorf("%w: %q conflicts with %q", ErrDuplicateEntryPath, in.Path, existing)
 		}
@@ -917,6 +1366,131 @@ func validateUniqueEntryPaths(inputs []Input) error {
 	return nil
 }
 
+// maxPayloadAlignment bounds PackOptions.PayloadAlignment.
+const maxPayloadAlignment = 1 << 20
+
+// validateInputModTimes rejects inputs with zero ModTime when opts.RequireModTime is set
+// and no timestamp override applies.
+func validateInputModTimes(inputs []Input, opts PackOptions) error {
+	if !opts.RequireModTime || opts.ZeroTimestamps || !opts.SourceDateEpoch.IsZero() {
+		return nil
+	}
+
+	for _, in := range inputs {
+		if in.ModTime.IsZero() {
+			return fmt.Errorf("%w: %s", ErrMissingModTime, in.Path)
+		}
+	}
+
+	return nil
+}
+
+// validatePayloadAlignment rejects negative and oversized payload alignment.
+func validatePayloadAlignment(alignment int) error {
+	if alignment < 0 || alignment > maxPayloadAlignment {
+		return fmt.Errorf("%w: %d (want 0..%d)", ErrInvalidPayloadAlignment, alignment, maxPayloadAlignment)
+	}
+
+	return nil
+}
+
+// writeAlignmentPadding writes zero bytes to w until offset is multiple of alignment
+// and returns padded offset.
+func writeAlignmentPadding(w io.Writer, offset uint32, alignment int) (uint32, error) {
+	if alignment <= 1 {
+		return offset, nil
+	}
+
+	pad := (uint64(alignment) - uint64(offset)%uint64(alignment)) % uint64(alignment)
+	if uint64(offset)+pad > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("%w: payload alignment padding at offset %d", ErrSizeOverflow, offset)
+	}
+
+	var zeros [512]byte
+	for remaining := pad; remaining > 0; {
+		n := min(remaining, uint64(len(zeros)))
+		if _, err := w.Write(zeros[:n]); err != nil {
+			return 0, fmt.Errorf("write alignment padding: %w", err)
+		}
+		remaining -= n
+	}
+
+	return offset + uint32(pad), nil //nolint:gosec // bounded by overflow check above
+}
+
+// alignPaddingHeaderKey is header key of filler pair that aligns payload section start.
+const alignPaddingHeaderKey = "pbo_align_padding"
+
+// alignHeaderSection returns headers with filler pair appended so header section and
+// entry table written at start end on multiple of alignment. Padding stays inside
+// header block, so sequential payload offsets of readers ignoring stored offsets hold.
+// Filler pairs of earlier aligned packs are replaced.
+func alignHeaderSection(headers []HeaderPair, storedNames []string, start int64, alignment int) []HeaderPair {
+	out := make([]HeaderPair, 0, len(headers)+1)
+	size := start + headerSize + 1 // fixed header record and header terminator
+	for _, h := range headers {
+		if h.Key == alignPaddingHeaderKey {
+			continue
+		}
+
+		out = append(out, h)
+		size += int64(len(h.Key)) + int64(len(writtenHeaderValue(h))) + 2
+	}
+	for _, name := range storedNames {
+		size += int64(len(name)) + 21
+	}
+	size += 21 // entry table terminator record
+
+	align := int64(alignment)
+	minPad := int64(len(alignPaddingHeaderKey)) + 2
+	pad := (align - size%align) % align
+	for pad < minPad {
+		pad += align
+	}
+
+	return append(out, HeaderPair{
+		Key:   alignPaddingHeaderKey,
+		Value: strings.Repeat("0", int(pad-minPad)),
+	})
+}
+
+// writtenHeaderValue returns header value as written, with prefix normalized.
+func writtenHeaderValue(h HeaderPair) string {
+	if strings.EqualFold(strings.TrimSpace(h.Key), "prefix") {
+		return NormalizePrefixHeader(h.Value)
+	}
+
+	return h.Value
+}
+
+// storedOffsets reports whether index offset fields carry payload offsets.
+// AlignEntries padding between payloads breaks sequential offsets, so it needs them.
+func (opts *PackOptions) storedOffsets() bool {
+	return opts.WriteStoredOffsets || opts.DedupEqualPayloads || (opts.AlignEntries && opts.PayloadAlignment > 1)
+}
+
+// validatePathCaseSensitivity rejects unknown path case policies.
+func validatePathCaseSensitivity(pathCase PathCaseSensitivity) error {
+	switch pathCase {
+	case "", PathCaseInsensitive, PathCaseSensitive:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidPathCaseSensitivity, pathCase)
+	}
+}
+
+// entryTimestamp applies ZeroTimestamps and SourceDateEpoch overrides to entry timestamp.
+func (opts *PackOptions) entryTimestamp(timestamp uint32) uint32 {
+	switch {
+	case opts.ZeroTimestamps:
+		return 0
+	case !opts.SourceDateEpoch.IsZero():
+		return timeToUint32(opts.SourceDateEpoch)
+	default:
+		return timestamp
+	}
+}
+
 // timeToUint32 converts time to uint32 Unix timestamp with bounds clamping.
 func timeToUint32(t time.Time) uint32 {
 	u := t.Unix()
@@ -930,3 +1504,12 @@ func timeToUint32(t time.Time) uint32 {
 
 	return uint32(u)
 }
+
+// uint32ToTime converts Unix timestamp to UTC time; zero timestamp yields zero time.
+func uint32ToTime(timestamp uint32) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(timestamp), 0).UTC()
+}
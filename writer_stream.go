@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// PackStreamMode controls how PackToWriter resolves entry table before payload bytes.
+type PackStreamMode string
+
+// Stream pack modes for non-seekable writers.
+const (
+	// PackStreamModeAuto uses size-hint mode when all inputs qualify and falls back to buffered mode otherwise.
+	PackStreamModeAuto PackStreamMode = "auto"
+	// PackStreamModeSizeHint writes entry table from input SizeHint values and streams payload raw.
+	// Every input must have positive SizeHint and compression is not applied.
+	PackStreamModeSizeHint PackStreamMode = "size_hint"
+	// PackStreamModeBuffered packs the whole archive in memory and copies it to writer afterwards.
+	PackStreamModeBuffered PackStreamMode = "buffered"
+)
+
+// PackToWriter writes a PBO to non-seekable out (HTTP response body, pipe, socket).
+// Entry table resolution follows PackOptions.StreamMode. SHA1 trailer is not appended.
+func PackToWriter(ctx context.Context, out io.Writer, inputs []Input, opts PackOptions) (*PackResult, error) {
+	if out == nil {
+		return nil, ErrNilWriter
+	}
+
+	if len(inputs) == 0 {
+		return nil, ErrEmptyInputs
+	}
+
+	opts.applyDefaults()
+
+	rewritePlan, err := preparePackRewritePlan(inputs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := opts.StreamMode
+	if mode == PackStreamModeAuto {
+		mode = PackStreamModeBuffered
+		eligible, err := canPackWithSizeHints(rewritePlan, opts)
+		if err != nil {
+			return nil, err
+		}
+		if eligible {
+			mode = PackStreamModeSizeHint
+		}
+	}
+
+	switch mode {
+	case PackStreamModeSizeHint:
+		if opts.SealedKey != nil {
+			return nil, fmt.Errorf("%w: sealed mode requires buffered stream mode", ErrWriterAtRequired)
+		}
+		if opts.VerifyAfterWrite {
+			return nil, fmt.Errorf("%w: VerifyAfterWrite requires buffered stream mode", ErrReaderAtRequired)
+		}
+		if opts.DedupEqualPayloads {
+			return nil, fmt.Errorf("%w: DedupEqualPayloads requires buffered stream mode", ErrWriterAtRequired)
+		}
+		if opts.PayloadAlignment > 1 {
+			return nil, fmt.Errorf("%w: PayloadAlignment requires buffered stream mode", ErrWriterAtRequired)
+		}
+
+		return packSizeHintStream(ctx, out, rewritePlan, opts)
+	case PackStreamModeBuffered:
+		return packBufferedStream(ctx, out, rewritePlan, opts)
+	default:
+		return nil, fmt.Errorf("unknown pack stream mode %q", mode)
+	}
+}
+
+// canPackWithSizeHints reports whether plan can be written in size-hint mode without losing compression.
+func canPackWithSizeHints(rewritePlan []rewriteEntry, opts PackOptions) (bool, error) {
+	if opts.SealedKey != nil || opts.VerifyAfterWrite || opts.DedupEqualPayloads || opts.PayloadAlignment > 1 {
+		return false, nil
+	}
+
+	compressMatcher, err := opts.compileCompressRules()
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range rewritePlan {
+		if item.input == nil || item.input.SizeHint <= 0 {
+			return false, nil
+		}
+		if shouldUseCompressionForInput(opts, compressMatcher, *item.input) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// packSizeHintStream writes header, final entry table from size hints, and raw payload in one forward pass.
+func packSizeHintStream(
+	ctx context.Context,
+	out io.Writer,
+	rewritePlan []rewriteEntry,
+	opts PackOptions,
+) (*PackResult, error) {
+	startedAt := time.Now()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	records := make([]writtenEntry, len(rewritePlan))
+	var total int64
+	for i, item := range rewritePlan {
+		if item.input == nil || item.input.SizeHint <= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrSizeHintRequired, item.path)
+		}
+
+		total += item.input.SizeHint
+		if total > maxPBOData {
+			return nil, fmt.Errorf("%w: estimated data %d exceeds 4 GiB", ErrSizeOverflow, total)
+		}
+
+		records[i] = writtenEntry{
+			path:      item.path,
+			dataSize:  uint32(item.input.SizeHint), //nolint:gosec // bounded by maxPBOData check above
+			mime:      MimeNil,
+			timestamp: opts.entryTimestamp(timeToUint32(item.input.ModTime)),
+		}
+		if item.input.OriginalSize != 0 {
+			records[i].mime = MimeCompress
+			records[i].originalSize = item.input.OriginalSize
+		}
+	}
+
+	cw := &countingWriter{w: out}
+	w, releaseWriter := opts.acquireWriter(cw)
+	defer releaseWriter()
+
+	if _, err := writeHeaderSection(w, opts.Headers, opts.AllowDuplicateHeaders); err != nil {
+		return nil, err
+	}
+
+	storedNames, err := encodeEntryNames(rewritePlan, opts.NameEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	entriesStart := int64(w.Buffered()) + cw.n
+	// Entry table size is known from paths, so payload offsets are final before table write.
+	dataStart := entriesStart + 21
+	for _, name := range storedNames {
+		dataStart += int64(len(name) + 21)
+	}
+	if dataStart+total > maxPBOData {
+		return nil, fmt.Errorf("%w: data start offset %d", ErrSizeOverflow, dataStart)
+	}
+
+	nextOffset := uint32(dataStart) //nolint:gosec // checked above against maxPBOData
+	for i := range records {
+		records[i].offset = nextOffset
+		nextOffset += records[i].dataSize
+	}
+
+	var entryFields [20]byte
+	for i, name := range storedNames {
+		if _, err := w.WriteString(name); err != nil {
+			return nil, fmt.Errorf("write entry path: %w", err)
+		}
+
+		if err := w.WriteByte(0); err != nil {
+			return nil, fmt.Errorf("write entry path terminator: %w", err)
+		}
+
+		if err := opts.encodeEntryFields(&entryFields, records[i], dataStart); err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(entryFields[:]); err != nil {
+			return nil, fmt.Errorf("write entry %d: %w", i, err)
+		}
+	}
+
+	var terminator [21]byte
+	if _, err := w.Write(terminator[:]); err != nil {
+		return nil, fmt.Errorf("write entries terminator: %w", err)
+	}
+
+	hasher, err := newEntryHasher(opts.EntryHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		digests           []EntryDigest
+		compressedBytes   int64
+		compressedEntries int
+	)
+	if hasher != nil {
+		digests = make([]EntryDigest, 0, len(rewritePlan))
+	}
+
+	copyBuf, releaseCopyBuffer := acquirePackCopyBuffer()
+	defer releaseCopyBuffer()
+
+	var progressDone, progressTotal EntryStats
+	if opts.OnProgress != nil {
+		progressTotal = packProgressTotal(rewritePlan)
+	}
+
+	payloadDst := throttleWriter(ctx, w, newByteRateLimiter(opts.BytesPerSecond))
+	currentOffset := uint32(dataStart) //nolint:gosec // checked above against maxPBOData
+	extStats := make(extensionStatsCollector)
+	for i, item := range rewritePlan {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rc, err := openInputReader(ctx, *item.input, opts.PerEntryTimeout, opts.OpenRetry)
+		if err != nil {
+			return nil, err
+		}
+
+		record, writeErr := writeUncompressedPayload(payloadDst, hashingReader(hasher, rc), *item.input, currentOffset, copyBuf)
+		closeErr := rc.Close()
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		if closeErr != nil {
+			return nil, fmt.Errorf("close input %s: %w", item.path, closeErr)
+		}
+		if record.dataSize != records[i].dataSize {
+			return nil, fmt.Errorf(
+				"%w: %s wrote %d bytes, hint %d",
+				ErrSizeHintMismatch, item.path, record.dataSize, records[i].dataSize,
+			)
+		}
+
+		digest := hexDigest(hasher)
+		if hasher != nil {
+			digests = append(digests, EntryDigest{Path: item.path, Digest: digest})
+		}
+
+		if opts.OnEntryDone != nil {
+			opts.OnEntryDone(PackEntryProgress{
+				Path:         item.path,
+				Offset:       currentOffset,
+				DataSize:     record.dataSize,
+				OriginalSize: records[i].originalSize,
+				MimeType:     records[i].mime,
+				Compressed:   records[i].mime == MimeCompress,
+				Digest:       digest,
+				ModTime:      uint32ToTime(records[i].timestamp),
+			})
+		}
+
+		if records[i].mime == MimeCompress {
+			compressedEntries++
+			compressedBytes += int64(record.dataSize)
+		}
+		extStats.add(item.path, records[i])
+
+		if opts.OnProgress != nil {
+			progressDone.Entries++
+			progressDone.Bytes += writtenEntryContentSize(records[i])
+			opts.OnProgress(progressDone, progressTotal)
+		}
+
+		currentOffset += record.dataSize
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("flush payloads: %w", err)
+	}
+
+	return &PackResult{
+		WrittenEntries:    len(rewritePlan),
+		DataSize:          total,
+		IndexSize:         dataStart - entriesStart,
+		RawBytes:          total - compressedBytes,
+		CompressedBytes:   compressedBytes,
+		CompressedEntries: compressedEntries,
+		EntryDigests:      digests,
+		Extensions:        extStats.result(),
+		Duration:          time.Since(startedAt),
+	}, nil
+}
+
+// packBufferedStream packs archive into memory buffer and copies final bytes to out.
+func packBufferedStream(
+	ctx context.Context,
+	out io.Writer,
+	rewritePlan []rewriteEntry,
+	opts PackOptions,
+) (*PackResult, error) {
+	buf := &memoryBuffer{}
+	res, err := rewriteArchive(ctx, buf, nil, rewritePlan, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := out.Write(buf.data); err != nil {
+		return nil, fmt.Errorf("write buffered archive: %w", err)
+	}
+
+	return res, nil
+}
+
+// countingWriter counts bytes passed to underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to underlying writer and counts accepted bytes.
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// memoryBuffer is an in-memory io.WriteSeeker with ReaderAt/WriterAt support.
+type memoryBuffer struct {
+	data []byte
+	off  int64
+}
+
+// Write writes p at current offset and grows buffer as needed.
+func (m *memoryBuffer) Write(p []byte) (int, error) {
+	n, err := m.WriteAt(p, m.off)
+	m.off += int64(n)
+	return n, err
+}
+
+// WriteAt writes p at absolute offset and grows buffer as needed.
+func (m *memoryBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrSizeOverflow
+	}
+
+	end := off + int64(len(p))
+	if end > int64(len(m.data)) {
+		if end > int64(cap(m.data)) {
+			next := make([]byte, end, max(end, int64(cap(m.data))*2))
+			copy(next, m.data)
+			m.data = next
+		} else {
+			m.data = m.data[:end]
+		}
+	}
+
+	copy(m.data[off:end], p)
+	return len(p), nil
+}
+
+// ReadAt reads bytes at absolute offset.
+func (m *memoryBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrSizeOverflow
+	}
+	if off >= int64(len(m.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, m.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Seek sets offset for next Write.
+func (m *memoryBuffer) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = m.off + offset
+	case io.SeekEnd:
+		next = int64(len(m.data)) + offset
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+
+	if next < 0 {
+		return 0, ErrSizeOverflow
+	}
+
+	m.off = next
+	return next, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestPackToWriter_SizeHintMatchesPack(t *testing.T) {
+	t.Parallel()
+
+	inputs := streamTestInputs(map[string][]byte{
+		"config.cpp":     []byte("class CfgPatches {};"),
+		"scripts/main.c": bytes.Repeat([]byte("void main() {}\n"), 64),
+	})
+	opts := PackOptions{Headers: []HeaderPair{{Key: "prefix", Value: "my/addon"}}}
+
+	var seekable memoryBuffer
+	if _, err := Pack(context.Background(), &seekable, inputs, opts); err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	var streamed bytes.Buffer
+	opts.StreamMode = PackStreamModeSizeHint
+	res, err := PackToWriter(context.Background(), &streamed, inputs, opts)
+	if err != nil {
+		t.Fatalf("PackToWriter: %v", err)
+	}
+
+	if !bytes.Equal(streamed.Bytes(), seekable.data) {
+		t.Fatal("size-hint stream output differs from Pack output")
+	}
+	if res.WrittenEntries != 2 {
+		t.Fatalf("WrittenEntries=%d, want 2", res.WrittenEntries)
+	}
+
+	r, err := NewReaderFromBytes(streamed.Bytes(), ReaderOptions{})
+	if err != nil {
+		t.Fatalf("parse streamed archive: %v", err)
+	}
+	data, err := r.ReadEntry("scripts/main.c")
+	if err != nil {
+		t.Fatalf("ReadEntry: %v", err)
+	}
+	if !bytes.Equal(data, bytes.Repeat([]byte("void main() {}\n"), 64)) {
+		t.Fatal("streamed payload mismatch")
+	}
+}
+
+func TestPackToWriter_AutoFallsBackToBufferedForCompression(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("compress me "), 512)
+	inputs := streamTestInputs(map[string][]byte{"data/a.txt": payload})
+	opts := PackOptions{Compress: includeRules("*.txt")}
+
+	var seekable memoryBuffer
+	if _, err := Pack(context.Background(), &seekable, inputs, opts); err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	var streamed bytes.Buffer
+	res, err := PackToWriter(context.Background(), &streamed, inputs, opts)
+	if err != nil {
+		t.Fatalf("PackToWriter: %v", err)
+	}
+	if res.CompressedEntries != 1 {
+		t.Fatalf("CompressedEntries=%d, want 1", res.CompressedEntries)
+	}
+	if !bytes.Equal(streamed.Bytes(), seekable.data) {
+		t.Fatal("buffered stream output differs from Pack output")
+	}
+}
+
+func TestPackToWriter_SizeHintErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing hint", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := streamTestInputs(map[string][]byte{"a.txt": []byte("abc")})
+		inputs[0].SizeHint = 0
+
+		_, err := PackToWriter(context.Background(), io.Discard, inputs, PackOptions{StreamMode: PackStreamModeSizeHint})
+		if !errors.Is(err, ErrSizeHintRequired) {
+			t.Fatalf("expected ErrSizeHintRequired, got %v", err)
+		}
+	})
+
+	t.Run("short stream", func(t *testing.T) {
+		t.Parallel()
+
+		inputs := streamTestInputs(map[string][]byte{"a.txt": []byte("abc")})
+		inputs[0].SizeHint = 10
+
+		_, err := PackToWriter(context.Background(), io.Discard, inputs, PackOptions{StreamMode: PackStreamModeSizeHint})
+		if !errors.Is(err, ErrSizeHintMismatch) {
+			t.Fatalf("expected ErrSizeHintMismatch, got %v", err)
+		}
+	})
+}
+
+func streamTestInputs(files map[string][]byte) []Input {
+	inputs := make([]Input, 0, len(files))
+	for filePath, payload := range files {
+		localPayload := append([]byte(nil), payload...)
+		inputs = append(inputs, Input{
+			Path:     filePath,
+			ModTime:  time.Unix(1700000000, 0),
+			SizeHint: int64(len(localPayload)),
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(localPayload)), nil
+			},
+		})
+	}
+
+	return inputs
+}
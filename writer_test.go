@@ -7,11 +7,17 @@ package pbo
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/woozymasta/pathrules"
 )
@@ -207,6 +213,451 @@ func TestPack_RejectsDuplicateEntryPathsCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestPack_PathCaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	opts := PackOptions{PathCaseSensitivity: PathCaseSensitive}
+	pboPath := filepath.Join(t.TempDir(), "case.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{
+		"data/a.txt": []byte("lower"),
+		"data/A.txt": []byte("upper"),
+	}, opts); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	editor, err := OpenEditor(pboPath, EditOptions{})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if _, err := editor.Commit(context.Background()); !errors.Is(err, ErrDuplicateEntryPath) {
+		t.Fatalf("case-insensitive commit err=%v, want ErrDuplicateEntryPath", err)
+	}
+
+	editor, err = OpenEditor(pboPath, EditOptions{PackOptions: opts})
+	if err != nil {
+		t.Fatalf("OpenEditor: %v", err)
+	}
+	if err := editor.Replace(sessionInput("data/a.txt", "changed")); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if _, err := editor.Commit(context.Background()); err != nil {
+		t.Fatalf("case-sensitive commit: %v", err)
+	}
+
+	for path, want := range map[string]string{`data\a.txt`: "changed", `data\A.txt`: "upper"} {
+		got, err := readEntryFromFile(pboPath, path)
+		if err != nil || string(got) != want {
+			t.Fatalf("%s=%q err=%v, want %q", path, got, err, want)
+		}
+	}
+
+	if _, err := OpenEditor(pboPath, EditOptions{PackOptions: PackOptions{PathCaseSensitivity: "mixed"}}); !errors.Is(err, ErrInvalidPathCaseSensitivity) {
+		t.Fatalf("unknown policy err=%v, want ErrInvalidPathCaseSensitivity", err)
+	}
+}
+
+func TestPack_EntryFields(t *testing.T) {
+	t.Parallel()
+
+	opts := PackOptions{
+		EntryFields: func(path string, fields [20]byte) [20]byte {
+			if path == "meta.txt" {
+				binary.LittleEndian.PutUint32(fields[8:12], 0xdeadbeef)
+				binary.LittleEndian.PutUint32(fields[12:16], 42)
+			}
+			return fields
+		},
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "fields.pbo")
+	if err := createTestPBO(pboPath, map[string][]byte{"meta.txt": []byte("meta"), "plain.txt": []byte("plain")}, opts); err != nil {
+		t.Fatalf("createTestPBO: %v", err)
+	}
+
+	r, err := Open(pboPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := r.Entries()
+	if entries[0].Reserved != 0xdeadbeef || entries[0].TimeStamp != 42 || entries[1].Reserved != 0 {
+		t.Fatalf("entries=%+v", entries)
+	}
+	if raw := entries[0].RawFields(); binary.LittleEndian.Uint32(raw[8:12]) != 0xdeadbeef ||
+		binary.LittleEndian.Uint32(raw[16:20]) != entries[0].DataSize {
+		t.Fatalf("RawFields=%x", raw)
+	}
+	if data, err := r.ReadEntry("meta.txt"); err != nil || string(data) != "meta" {
+		t.Fatalf("ReadEntry=%q err=%v", data, err)
+	}
+
+	opts.EntryFields = func(_ string, fields [20]byte) [20]byte {
+		fields[16]++
+		return fields
+	}
+	if err := createTestPBO(pboPath, map[string][]byte{"a.txt": []byte("a")}, opts); !errors.Is(err, ErrInvalidEntryFields) {
+		t.Fatalf("data size change err=%v, want ErrInvalidEntryFields", err)
+	}
+}
+
+func TestPack_WriteStoredOffsets(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{"a.txt": []byte("first"), "b.txt": []byte("second payload")}
+	for _, relative := range []bool{false, true} {
+		pboPath := filepath.Join(t.TempDir(), "offsets.pbo")
+		opts := PackOptions{WriteStoredOffsets: true, RelativeStoredOffsets: relative}
+		if err := createTestPBO(pboPath, files, opts); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+
+		r, err := OpenWithOptions(pboPath, ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+		if err != nil {
+			t.Fatalf("OpenWithOptions(relative=%v): %v", relative, err)
+		}
+
+		entries := r.Entries()
+		dataStart := entries[0].Offset
+		for _, entry := range entries {
+			want := entry.Offset
+			if relative {
+				want -= dataStart
+			}
+			if entry.Reserved != want {
+				t.Fatalf("relative=%v %s: stored=%d, want %d", relative, entry.Path, entry.Reserved, want)
+			}
+		}
+		if data, err := r.ReadEntry("b.txt"); err != nil || string(data) != "second payload" {
+			t.Fatalf("ReadEntry=%q err=%v", data, err)
+		}
+		_ = r.Close()
+	}
+
+	var buf bytes.Buffer
+	inputs := []Input{{Path: "x.txt", SizeHint: 3, Open: func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("xyz")), nil
+	}}}
+	if _, err := PackToWriter(context.Background(), &buf, inputs, PackOptions{
+		StreamMode:         PackStreamModeSizeHint,
+		WriteStoredOffsets: true,
+	}); err != nil {
+		t.Fatalf("PackToWriter: %v", err)
+	}
+
+	r, err := NewReaderFromBytes(buf.Bytes(), ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+	if err != nil {
+		t.Fatalf("NewReaderFromBytes: %v", err)
+	}
+	if entry := r.Entries()[0]; entry.Reserved == 0 || entry.Reserved != entry.Offset {
+		t.Fatalf("stream entry=%+v", entry)
+	}
+}
+
+func TestPack_CompressWorkersMatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	files := make(map[string][]byte, 32)
+	for i := range 32 {
+		files[fmt.Sprintf("scripts/file_%02d.c", i)] = bytes.Repeat([]byte(fmt.Sprintf("void f%d() {}\n", i)), 100+i*10)
+	}
+	files["tiny.c"] = []byte("x")
+	files["raw.bin"] = bytes.Repeat([]byte{1}, 2048)
+
+	pack := func(workers int) ([]byte, *PackResult) {
+		t.Helper()
+
+		inputs := make([]Input, 0, len(files))
+		for path, data := range files {
+			inputs = append(inputs, Input{
+				Path:     path,
+				SizeHint: int64(len(data)),
+				Open:     func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil },
+			})
+		}
+
+		pboPath := filepath.Join(t.TempDir(), "out.pbo")
+		res, err := PackFile(context.Background(), pboPath, inputs, PackOptions{
+			Compress:         includeRules("*.c"),
+			ZeroTimestamps:   true,
+			EntryHash:        crypto.SHA256,
+			VerifyAfterWrite: true,
+			CompressWorkers:  workers,
+		})
+		if err != nil {
+			t.Fatalf("PackFile(workers=%d): %v", workers, err)
+		}
+
+		data, err := os.ReadFile(pboPath)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+
+		return data, res
+	}
+
+	sequential, seqRes := pack(0)
+	parallel, parRes := pack(4)
+	if !bytes.Equal(sequential, parallel) {
+		t.Fatalf("parallel pack output differs from sequential")
+	}
+	if parRes.CompressedEntries != seqRes.CompressedEntries || parRes.CompressedEntries == 0 ||
+		!slices.Equal(parRes.EntryDigests, seqRes.EntryDigests) {
+		t.Fatalf("parallel result=%+v, sequential=%+v", parRes, seqRes)
+	}
+}
+
+func TestPack_PayloadAlignment(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{"a.txt": []byte("first"), "b.txt": []byte("second"), "c.txt": []byte("third")}
+	for _, alignEntries := range []bool{false, true} {
+		pboPath := filepath.Join(t.TempDir(), "aligned.pbo")
+		opts := PackOptions{PayloadAlignment: 4096, AlignEntries: alignEntries}
+		if err := createTestPBO(pboPath, files, opts); err != nil {
+			t.Fatalf("createTestPBO: %v", err)
+		}
+
+		r, err := OpenWithOptions(pboPath, ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+		if err != nil {
+			t.Fatalf("OpenWithOptions: %v", err)
+		}
+
+		for i, entry := range r.Entries() {
+			aligned := entry.Offset%4096 == 0
+			if (i == 0 || alignEntries) != aligned {
+				t.Fatalf("alignEntries=%v %s: offset %d", alignEntries, entry.Path, entry.Offset)
+			}
+			if data, err := r.ReadEntry(entry.Path); err != nil || !bytes.Equal(data, files[entry.Path]) {
+				t.Fatalf("ReadEntry(%s)=%q err=%v", entry.Path, data, err)
+			}
+		}
+		_ = r.Close()
+
+		if alignEntries {
+			continue
+		}
+
+		// Start padding lives in header block, so default sequential readers agree.
+		r, err = Open(pboPath)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		entries := r.Entries()
+		if len(entries) != len(files) || entries[0].Offset%4096 != 0 {
+			t.Fatalf("default reader entries=%+v", entries)
+		}
+		for _, entry := range entries {
+			if data, err := r.ReadEntry(entry.Path); err != nil || !bytes.Equal(data, files[entry.Path]) {
+				t.Fatalf("default ReadEntry(%s)=%q err=%v", entry.Path, data, err)
+			}
+		}
+		_ = r.Close()
+	}
+
+	err := createTestPBO(filepath.Join(t.TempDir(), "bad.pbo"), files, PackOptions{PayloadAlignment: -1})
+	if !errors.Is(err, ErrInvalidPayloadAlignment) {
+		t.Fatalf("negative alignment err=%v, want ErrInvalidPayloadAlignment", err)
+	}
+}
+
+func TestPack_DedupEqualPayloads(t *testing.T) {
+	t.Parallel()
+
+	shared := bytes.Repeat([]byte("shared texture payload "), 64)
+	inputs := []Input{
+		{Path: "a.paa", Open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(shared)), nil }},
+		{Path: "b.txt", Open: func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("unique")), nil }},
+		{Path: "c.paa", Open: func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(shared)), nil }},
+	}
+
+	pboPath := filepath.Join(t.TempDir(), "dedup.pbo")
+	res, err := PackFile(context.Background(), pboPath, inputs, PackOptions{
+		Compress:           includeRules("*.paa"),
+		DedupEqualPayloads: true,
+		VerifyAfterWrite:   true,
+	})
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if res.WrittenEntries != 3 || res.DedupEntries != 1 || res.DedupBytes == 0 {
+		t.Fatalf("result=%+v", res)
+	}
+
+	r, err := OpenWithOptions(pboPath, ReaderOptions{OffsetMode: OffsetModeStoredStrict})
+	if err != nil {
+		t.Fatalf("OpenWithOptions: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	a, c := findEntry(r.Entries(), "a.paa"), findEntry(r.Entries(), "c.paa")
+	if a == nil || c == nil || a.Offset != c.Offset || a.DataSize != c.DataSize {
+		t.Fatalf("entries=%+v", r.Entries())
+	}
+	for _, name := range []string{"a.paa", "c.paa"} {
+		if data, err := r.ReadEntry(name); err != nil || !bytes.Equal(data, shared) {
+			t.Fatalf("ReadEntry(%s) err=%v", name, err)
+		}
+	}
+	if data, err := r.ReadEntry("b.txt"); err != nil || string(data) != "unique" {
+		t.Fatalf("ReadEntry(b.txt)=%q err=%v", data, err)
+	}
+}
+
+func TestPack_EntryOrder(t *testing.T) {
+	t.Parallel()
+
+	openFn := func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte("ok"))), nil
+	}
+	inputs := []Input{
+		{Path: "z/last.txt", Open: openFn},
+		{Path: "a/first.txt", Open: openFn},
+		{Path: "config.cpp", Open: openFn},
+	}
+
+	packOrder := func(opts PackOptions) []string {
+		t.Helper()
+
+		outPath := filepath.Join(t.TempDir(), "out.pbo")
+		if _, err := PackFile(context.Background(), outPath, inputs, opts); err != nil {
+			t.Fatalf("PackFile order=%q: %v", opts.Order, err)
+		}
+
+		entries, err := ListEntries(outPath)
+		if err != nil {
+			t.Fatalf("ListEntries: %v", err)
+		}
+
+		paths := make([]string, len(entries))
+		for i := range entries {
+			paths[i] = entries[i].Path
+		}
+
+		return paths
+	}
+
+	tests := []struct {
+		opts PackOptions
+		want []string
+	}{
+		{opts: PackOptions{}, want: []string{`a\first.txt`, `config.cpp`, `z\last.txt`}},
+		{opts: PackOptions{Order: PackOrderPreserveInput}, want: []string{`z\last.txt`, `a\first.txt`, `config.cpp`}},
+		{opts: PackOptions{Order: PackOrderCustom, OrderLess: func(a Input, b Input) bool {
+			return a.Path > b.Path
+		}}, want: []string{`z\last.txt`, `config.cpp`, `a\first.txt`}},
+	}
+
+	for _, tc := range tests {
+		got := packOrder(tc.opts)
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("order=%q entries=%v, want %v", tc.opts.Order, got, tc.want)
+		}
+	}
+
+	_, err := Pack(context.Background(), nil, inputs, PackOptions{Order: PackOrderCustom})
+	if !errors.Is(err, ErrInvalidPackOrder) {
+		t.Fatalf("expected ErrInvalidPackOrder, got %v", err)
+	}
+}
+
+func TestPack_ReproducibleTimestamps(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	packAt := func(name string, modTime time.Time, opts PackOptions) []byte {
+		t.Helper()
+
+		inputs := streamTestInputs(map[string][]byte{
+			"config.cpp":  []byte("class CfgPatches {};"),
+			"scripts/a.c": bytes.Repeat([]byte("void a();\n"), 100),
+		})
+		for i := range inputs {
+			inputs[i].ModTime = modTime
+		}
+
+		outPath := filepath.Join(dir, name)
+		opts.Compress = includeRules("*.c")
+		if _, err := PackFile(context.Background(), outPath, inputs, opts); err != nil {
+			t.Fatalf("PackFile %s: %v", name, err)
+		}
+
+		data, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return data
+	}
+
+	epoch := time.Unix(1600000000, 0)
+	first := packAt("a.pbo", time.Unix(1700000000, 0), PackOptions{SourceDateEpoch: epoch})
+	second := packAt("b.pbo", time.Unix(1800000000, 0), PackOptions{SourceDateEpoch: epoch})
+	if !bytes.Equal(first, second) {
+		t.Fatalf("SourceDateEpoch packs differ")
+	}
+
+	entries, err := ListEntries(filepath.Join(dir, "a.pbo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.TimeStamp != 1600000000 {
+			t.Fatalf("entry %s timestamp=%d, want epoch", entry.Path, entry.TimeStamp)
+		}
+	}
+
+	zeroA := packAt("z1.pbo", time.Unix(1700000000, 0), PackOptions{ZeroTimestamps: true, SourceDateEpoch: epoch})
+	zeroB := packAt("z2.pbo", time.Unix(1800000000, 0), PackOptions{ZeroTimestamps: true})
+	if !bytes.Equal(zeroA, zeroB) {
+		t.Fatalf("ZeroTimestamps packs differ")
+	}
+}
+
+func TestPack_RequireModTimeAndProgressModTime(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string][]byte{"config.cpp": []byte("class CfgPatches {};"), "data/a.txt": []byte("alpha")}
+
+	inputs := streamTestInputs(files)
+	inputs[1].ModTime = time.Time{}
+	if _, err := PackFile(context.Background(), filepath.Join(dir, "fail.pbo"), inputs, PackOptions{RequireModTime: true}); !errors.Is(err, ErrMissingModTime) {
+		t.Fatalf("PackFile zero ModTime err=%v", err)
+	}
+
+	epoch := time.Unix(1600000000, 0)
+	if _, err := PackFile(context.Background(), filepath.Join(dir, "epoch.pbo"), inputs, PackOptions{
+		RequireModTime:  true,
+		SourceDateEpoch: epoch,
+	}); err != nil {
+		t.Fatalf("PackFile SourceDateEpoch: %v", err)
+	}
+
+	var progress []PackEntryProgress
+	outPath := filepath.Join(dir, "ok.pbo")
+	if _, err := PackFile(context.Background(), outPath, streamTestInputs(files), PackOptions{
+		RequireModTime: true,
+		OnEntryDone:    func(p PackEntryProgress) { progress = append(progress, p) },
+	}); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+	if len(progress) != 2 || !progress[0].ModTime.Equal(time.Unix(1700000000, 0)) || progress[0].ModTime.Location() != time.UTC {
+		t.Fatalf("progress=%+v", progress)
+	}
+
+	entries, err := ListEntries(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entries[0].Time(); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("EntryInfo.Time=%v", got)
+	}
+	if got := (&EntryInfo{}).Time(); !got.IsZero() {
+		t.Fatalf("zero EntryInfo.Time=%v", got)
+	}
+}
+
 func TestPack_RejectsInvalidNormalizedEntryPath(t *testing.T) {
 	t.Parallel()
 
@@ -529,3 +980,86 @@ func TestPack_TelemetryAndOnEntryDone(t *testing.T) {
 		t.Fatalf("compressed callbacks=%d, want 1", compressedCount)
 	}
 }
+
+func TestPack_OnProgressReportsTotals(t *testing.T) {
+	t.Parallel()
+
+	files := map[string][]byte{
+		"a.txt": bytes.Repeat([]byte("a"), 2048),
+		"b.bin": []byte("bbbb"),
+		"c.bin": []byte("cc"),
+	}
+
+	var events []EntryStats
+	var lastTotal EntryStats
+	opts := PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+		OnProgress: func(done EntryStats, total EntryStats) {
+			events = append(events, done)
+			lastTotal = total
+		},
+	}
+
+	outPath := filepath.Join(t.TempDir(), "progress.pbo")
+	if _, err := PackFile(context.Background(), outPath, streamTestInputs(files), opts); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	want := EntryStats{Entries: 3, Bytes: 2048 + 4 + 2}
+	if lastTotal != want {
+		t.Fatalf("total=%+v, want %+v", lastTotal, want)
+	}
+	if len(events) != 3 || events[2] != want {
+		t.Fatalf("events=%+v", events)
+	}
+	for i := 1; i < len(events); i++ {
+		if events[i].Bytes <= events[i-1].Bytes || events[i].Entries != i+1 {
+			t.Fatalf("non-monotonic events=%+v", events)
+		}
+	}
+}
+
+func TestPack_ExtensionStats(t *testing.T) {
+	t.Parallel()
+
+	res, err := PackFile(context.Background(), filepath.Join(t.TempDir(), "out.pbo"), streamTestInputs(map[string][]byte{
+		"a.txt":        bytes.Repeat([]byte("x"), 4096),
+		"b.TXT":        []byte("xyz"),
+		"c.bin":        []byte("raw"),
+		"dir.d/noext":  []byte("plain"),
+		"scripts/z.c":  []byte("void z();"),
+		"scripts/y.c":  []byte("void y();"),
+		"config.cpp":   []byte("class CfgPatches {};"),
+		"data/img.paa": []byte("img"),
+	}), PackOptions{
+		Compress:        includeRules("*.txt"),
+		MinCompressSize: 1,
+	})
+	if err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	byExt := make(map[string]ExtensionStats, len(res.Extensions))
+	for i, stats := range res.Extensions {
+		if i > 0 && res.Extensions[i-1].Extension >= stats.Extension {
+			t.Fatalf("extensions not sorted: %+v", res.Extensions)
+		}
+
+		byExt[stats.Extension] = stats
+	}
+
+	txt := byExt[".txt"]
+	if txt.Entries != 2 || txt.CompressedEntries != 1 || txt.SkippedNotSmaller != 1 {
+		t.Fatalf(".txt stats=%+v", txt)
+	}
+	if txt.OriginalBytes != 4096+3 || txt.PackedBytes >= txt.OriginalBytes || txt.Ratio <= 0 || txt.Ratio >= 1 {
+		t.Fatalf(".txt sizes=%+v", txt)
+	}
+	if c := byExt[".c"]; c.Entries != 2 || c.Ratio != 1 || c.CompressedEntries != 0 {
+		t.Fatalf(".c stats=%+v", c)
+	}
+	if none, ok := byExt[""]; !ok || none.Entries != 1 {
+		t.Fatalf("no-extension stats=%+v present=%v", none, ok)
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"crypto"
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// XXH64 selects 64-bit xxHash (seed 0) for PackOptions.EntryHash and Reader.HashEntry.
+// It is not registered in crypto package; only this package accepts it.
+// Digest is 8 bytes, big-endian like canonical xxHash output.
+const XXH64 crypto.Hash = 1 << 8
+
+// xxHash64 primes.
+const (
+	xxhPrime1 uint64 = 0x9E3779B185EBCA87
+	xxhPrime2 uint64 = 0xC2B2AE3D27D4EB4F
+	xxhPrime3 uint64 = 0x165667B19E3779F9
+	xxhPrime4 uint64 = 0x85EBCA77C2B2AE63
+	xxhPrime5 uint64 = 0x27D4EB2F165667C5
+)
+
+// xxh64 is streaming xxHash64 state with zero seed.
+type xxh64 struct {
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int
+}
+
+// newXXH64 returns xxHash64 hasher with zero seed.
+func newXXH64() hash.Hash64 {
+	h := &xxh64{}
+	h.Reset()
+
+	return h
+}
+
+// Reset restores initial state.
+func (h *xxh64) Reset() {
+	p1, p2 := xxhPrime1, xxhPrime2 // variables: initial lanes wrap around uint64
+	h.v = [4]uint64{p1 + p2, p2, 0, -p1}
+	h.total = 0
+	h.n = 0
+}
+
+// Size returns digest size in bytes.
+func (h *xxh64) Size() int { return 8 }
+
+// BlockSize returns stripe size in bytes.
+func (h *xxh64) BlockSize() int { return 32 }
+
+// Write absorbs p; it never fails.
+func (h *xxh64) Write(p []byte) (int, error) {
+	written := len(p)
+	h.total += uint64(written)
+
+	if h.n > 0 {
+		c := copy(h.buf[h.n:], p)
+		h.n += c
+		p = p[c:]
+		if h.n < len(h.buf) {
+			return written, nil
+		}
+
+		h.stripe(h.buf[:])
+		h.n = 0
+	}
+
+	for len(p) >= 32 {
+		h.stripe(p[:32])
+		p = p[32:]
+	}
+	h.n = copy(h.buf[:], p)
+
+	return written, nil
+}
+
+// stripe mixes one 32-byte stripe into accumulators.
+func (h *xxh64) stripe(p []byte) {
+	for i := range h.v {
+		h.v[i] = xxhRound(h.v[i], binary.LittleEndian.Uint64(p[i*8:]))
+	}
+}
+
+// Sum64 returns digest of data written so far.
+func (h *xxh64) Sum64() uint64 {
+	var acc uint64
+	if h.total >= 32 {
+		acc = bits.RotateLeft64(h.v[0], 1) + bits.RotateLeft64(h.v[1], 7) +
+			bits.RotateLeft64(h.v[2], 12) + bits.RotateLeft64(h.v[3], 18)
+		for _, v := range h.v {
+			acc ^= xxhRound(0, v)
+			acc = acc*xxhPrime1 + xxhPrime4
+		}
+	} else {
+		acc = xxhPrime5
+	}
+	acc += h.total
+
+	p := h.buf[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		acc ^= xxhRound(0, binary.LittleEndian.Uint64(p))
+		acc = bits.RotateLeft64(acc, 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(p) >= 4 {
+		acc ^= uint64(binary.LittleEndian.Uint32(p)) * xxhPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxhPrime2 + xxhPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		acc ^= uint64(b) * xxhPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxhPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxhPrime2
+	acc ^= acc >> 29
+	acc *= xxhPrime3
+	acc ^= acc >> 32
+
+	return acc
+}
+
+// Sum appends big-endian digest to b.
+func (h *xxh64) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, h.Sum64())
+}
+
+// xxhRound mixes one 8-byte lane into accumulator.
+func xxhRound(acc uint64, lane uint64) uint64 {
+	acc += lane * xxhPrime2
+	acc = bits.RotateLeft64(acc, 31)
+
+	return acc * xxhPrime1
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxZipComment is max archive comment length allowed by zip format.
+const maxZipComment = 0xffff
+
+// ZipOptions configures ConvertToZip.
+type ZipOptions struct {
+	// ReaderOptions configure source archive parsing.
+	ReaderOptions ReaderOptions `json:"reader_options,omitzero" yaml:"reader_options,omitzero"`
+	// Store writes entries without deflate compression.
+	Store bool `json:"store,omitempty" yaml:"store,omitempty"`
+}
+
+// ConvertToZip writes all entries of PBO at pboPath into zip archive at zipPath.
+// Entry paths use forward slashes, entry timestamps become zip modification times,
+// and PBO headers are stored as "key=value" lines in zip archive comment; headers
+// that cannot round-trip through such lines fail with ErrInvalidHeaderPair.
+func ConvertToZip(ctx context.Context, pboPath string, zipPath string, opts ZipOptions) error {
+	r, err := OpenWithOptions(pboPath, opts.ReaderOptions)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	comment, err := encodeZipHeaders(r.Headers())
+	if err != nil {
+		return err
+	}
+	if len(comment) > maxZipComment {
+		return fmt.Errorf("%w: headers take %d bytes of zip comment", ErrSizeOverflow, len(comment))
+	}
+
+	f, err := os.OpenFile(zipPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create zip file: %w", err)
+	}
+
+	if err := writePBOAsZip(ctx, r, f, comment, opts); err != nil {
+		_ = f.Close()
+		_ = os.Remove(zipPath)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(zipPath)
+		return fmt.Errorf("close zip file: %w", err)
+	}
+
+	return nil
+}
+
+// ConvertFromZip packs regular files of zip archive at zipPath into PBO at pboPath with SHA1 trailer.
+// Zip modification times become entry timestamps. Empty opts.Headers are restored
+// from "key=value" lines of zip archive comment.
+func ConvertFromZip(ctx context.Context, zipPath string, pboPath string, opts PackOptions) (*PackResult, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	if len(opts.Headers) == 0 {
+		opts.Headers = decodeZipHeaders(zr.Comment)
+		opts.AllowDuplicateHeaders = true
+	}
+
+	inputs := make([]Input, 0, len(zr.File))
+	for _, file := range zr.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		if file.UncompressedSize64 > maxPBOData {
+			return nil, fmt.Errorf("%w: zip entry %s", ErrSizeOverflow, file.Name)
+		}
+
+		inputs = append(inputs, Input{
+			Path:     file.Name,
+			ModTime:  file.Modified,
+			SizeHint: int64(file.UncompressedSize64), //nolint:gosec // bounded by maxPBOData check above
+			Open:     file.Open,
+		})
+	}
+
+	return PackFile(ctx, pboPath, inputs, opts)
+}
+
+// writePBOAsZip streams reader entries into zip writer over out.
+func writePBOAsZip(ctx context.Context, r *Reader, out io.Writer, comment string, opts ZipOptions) error {
+	zw := zip.NewWriter(out)
+	if err := zw.SetComment(comment); err != nil {
+		return fmt.Errorf("set zip comment: %w", err)
+	}
+
+	method := zip.Deflate
+	if opts.Store {
+		method = zip.Store
+	}
+
+	err := r.ExtractTo(ctx, ExtractSinkFunc(func(_ context.Context, entry EntryInfo, content io.Reader) error {
+		header := &zip.FileHeader{Name: entry.Path, Method: method, Modified: uint32ToTime(entry.TimeStamp)}
+
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(w, content)
+		return err
+	}), ExtractOptions{MaxWorkers: 1, RawNames: true})
+	if err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("finish zip: %w", err)
+	}
+
+	return nil
+}
+
+// encodeZipHeaders formats headers as "key=value" lines. Empty keys, keys with '=',
+// and line breaks in keys or values are rejected since they would corrupt lines.
+func encodeZipHeaders(headers []HeaderPair) (string, error) {
+	var sb strings.Builder
+	for _, h := range headers {
+		if h.Key == "" || strings.ContainsAny(h.Key, "=\r\n") || strings.ContainsAny(h.Value, "\r\n") {
+			return "", fmt.Errorf("%w: header %q cannot be stored in zip comment", ErrInvalidHeaderPair, h.Key)
+		}
+
+		sb.WriteString(h.Key)
+		sb.WriteByte('=')
+		sb.WriteString(h.Value)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String(), nil
+}
+
+// decodeZipHeaders parses "key=value" lines and ignores lines without separator.
+func decodeZipHeaders(comment string) []HeaderPair {
+	var headers []HeaderPair
+	for line := range strings.Lines(comment) {
+		key, value, ok := strings.Cut(strings.TrimRight(line, "\r\n"), "=")
+		if !ok || key == "" {
+			continue
+		}
+
+		headers = append(headers, HeaderPair{Key: key, Value: value})
+	}
+
+	return headers
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pbo
+
+package pbo
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertZipRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"config.cpp":       []byte("class CfgPatches {};"),
+		"scripts/init.sqf": bytes.Repeat([]byte("hint 1;\n"), 400),
+	}
+	srcPath := filepath.Join(dir, "src.pbo")
+	if _, err := PackFile(context.Background(), srcPath, streamTestInputs(files), PackOptions{
+		Headers:  []HeaderPair{{Key: "prefix", Value: `my\addon`}, {Key: "version", Value: "3"}},
+		Compress: includeRules("*.sqf"),
+	}); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	zipPath := filepath.Join(dir, "out.zip")
+	if err := ConvertToZip(context.Background(), srcPath, zipPath, ZipOptions{}); err != nil {
+		t.Fatalf("ConvertToZip: %v", err)
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	if zr.Comment != "prefix=my\\addon\nversion=3\n" {
+		t.Fatalf("zip comment=%q", zr.Comment)
+	}
+	for _, f := range zr.File {
+		if f.Modified.Unix() != 1700000000 {
+			t.Fatalf("%s modified=%v, want entry timestamp", f.Name, f.Modified)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		if !bytes.Equal(data, files[f.Name]) {
+			t.Fatalf("zip entry %q content mismatch", f.Name)
+		}
+	}
+	if len(zr.File) != len(files) {
+		t.Fatalf("zip has %d files, want %d", len(zr.File), len(files))
+	}
+	_ = zr.Close()
+
+	backPath := filepath.Join(dir, "back.pbo")
+	if _, err := ConvertFromZip(context.Background(), zipPath, backPath, PackOptions{Compress: includeRules("*.sqf")}); err != nil {
+		t.Fatalf("ConvertFromZip: %v", err)
+	}
+
+	r, err := Open(backPath)
+	if err != nil {
+		t.Fatalf("open converted: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	headers := r.Headers()
+	if len(headers) != 2 || headers[0].Value != `my\addon` || headers[1].Key != "version" {
+		t.Fatalf("headers=%v", headers)
+	}
+
+	for name, want := range files {
+		got, err := r.ReadEntry(name)
+		if err != nil {
+			t.Fatalf("ReadEntry %s: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("entry %s content mismatch", name)
+		}
+
+		entry := findEntry(r.Entries(), strings.ReplaceAll(name, "/", `\`))
+		if entry == nil || entry.TimeStamp != 1700000000 {
+			t.Fatalf("entry %s timestamp not preserved: %+v", name, entry)
+		}
+	}
+}
+
+func TestConvertToZipRejectsMultilineHeader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.pbo")
+	if _, err := PackFile(context.Background(), srcPath, streamTestInputs(map[string][]byte{
+		"config.cpp": []byte("class CfgPatches {};"),
+	}), PackOptions{
+		Headers: []HeaderPair{{Key: "prefix", Value: "my_addon\nversion=9"}},
+	}); err != nil {
+		t.Fatalf("PackFile: %v", err)
+	}
+
+	zipPath := filepath.Join(dir, "out.zip")
+	err := ConvertToZip(context.Background(), srcPath, zipPath, ZipOptions{})
+	if !errors.Is(err, ErrInvalidHeaderPair) {
+		t.Fatalf("ConvertToZip err=%v, want ErrInvalidHeaderPair", err)
+	}
+	if _, statErr := os.Stat(zipPath); !os.IsNotExist(statErr) {
+		t.Fatalf("zip file created on rejected headers: %v", statErr)
+	}
+}